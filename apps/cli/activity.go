@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+func runActivity(cmd *cobra.Command, args []string) error {
+	if err := checkOutput(output); err != nil {
+		return err
+	}
+
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	page, err := client.GetActivity(api.ActivityOptions{
+		Type:   activityType,
+		Actor:  activityActor,
+		Limit:  activityLimit,
+		Cursor: activityCursor,
+	})
+	if err != nil {
+		return err
+	}
+
+	if dataOutput(output) {
+		if page.Entries == nil {
+			page.Entries = []api.ActivityEntry{}
+		}
+		return printData(os.Stdout, page)
+	}
+
+	printActivityTable(page)
+	return nil
+}
+
+func printActivityTable(page *api.ActivityPage) {
+	if len(page.Entries) == 0 {
+		fmt.Println("No activity recorded.")
+		return
+	}
+
+	fmt.Printf("%-19s %-16s %-20s %s\n", "TIME", "TYPE", "ACTOR", "TARGET")
+	fmt.Println("--------------------------------------------------------------")
+
+	for _, entry := range page.Entries {
+		when := time.Unix(entry.Timestamp, 0).Format("2006-01-02 15:04:05")
+		fmt.Printf("%-19s %-16s %-20s %s\n", when, entry.Type, dashIfEmpty(entry.Actor), dashIfEmpty(entry.Target))
+	}
+
+	if page.NextCursor != "" {
+		fmt.Printf("\nMore entries: buntime activity --cursor %s\n", page.NextCursor)
+	}
+}
+
+func dashIfEmpty(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"time"
+
+	"github.com/buntime/cli/internal/db"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const issuesURL = "https://github.com/zommehq/buntime/issues"
+
+// crashReport is a panic captured anywhere in the TUI together with the
+// stack of the goroutine that raised it
+type crashReport struct {
+	value interface{}
+	stack []byte
+}
+
+// commandPanicMsg carries a panic from a command goroutine back to the
+// event loop so it can be handled on the main goroutine
+type commandPanicMsg struct {
+	report *crashReport
+}
+
+// crashGuard wraps the root model so panics in Update, View and commands all
+// surface as a *crashReport panic on the main goroutine, where runProgram
+// can restore the terminal before reporting them
+type crashGuard struct {
+	model tea.Model
+}
+
+func (g crashGuard) Init() tea.Cmd {
+	return guardCmd(g.model.Init())
+}
+
+func (g crashGuard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(commandPanicMsg); ok {
+		panic(msg.report)
+	}
+	// Commands inside a batch run on their own goroutines
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		for i, cmd := range batch {
+			batch[i] = guardCmd(cmd)
+		}
+	}
+
+	defer capturePanic()
+	model, cmd := g.model.Update(msg)
+	g.model = model
+	return g, guardCmd(cmd)
+}
+
+func (g crashGuard) View() string {
+	defer capturePanic()
+	return g.model.View()
+}
+
+// capturePanic records the stack where the panic happened; by the time the
+// panic reaches runProgram the original stack is gone
+func capturePanic() {
+	if r := recover(); r != nil {
+		if report, ok := r.(*crashReport); ok {
+			panic(report)
+		}
+		panic(&crashReport{value: r, stack: debug.Stack()})
+	}
+}
+
+func guardCmd(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() (msg tea.Msg) {
+		defer func() {
+			if r := recover(); r != nil {
+				msg = commandPanicMsg{report: &crashReport{value: r, stack: debug.Stack()}}
+			}
+		}()
+		msg = cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			for i, inner := range batch {
+				batch[i] = guardCmd(inner)
+			}
+		}
+		return msg
+	}
+}
+
+// runProgram runs the TUI and turns a panic into a readable crash report
+// instead of leaving the terminal in the alternate screen
+func runProgram(p *tea.Program) (err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		report, ok := r.(*crashReport)
+		if !ok {
+			report = &crashReport{value: r, stack: debug.Stack()}
+		}
+
+		p.ReleaseTerminal()
+		err = reportCrash(report)
+	}()
+
+	_, err = p.Run()
+	return err
+}
+
+func reportCrash(report *crashReport) error {
+	fmt.Fprintf(os.Stderr, "buntime crashed: %v\n\n%s\n", report.value, report.stack)
+	if logOutput != nil {
+		log.Printf("panic: %v\n%s", report.value, report.stack)
+	}
+
+	if path, err := writeCrashLog(report); err == nil {
+		fmt.Fprintf(os.Stderr, "Crash log written to %s\n", path)
+	}
+	fmt.Fprintf(os.Stderr, "Please file an issue at %s and include the output above (buntime %s).\n", issuesURL, version)
+
+	return fmt.Errorf("unexpected error: %v", report.value)
+}
+
+// writeCrashLog appends the report to crash.log next to the config database
+func writeCrashLog(report *crashReport) (string, error) {
+	dbPath, err := db.Path()
+	if err != nil {
+		return "", err
+	}
+	if dbPath == db.MemoryDir {
+		return "", fmt.Errorf("no config directory for an in-memory config")
+	}
+
+	path := filepath.Join(filepath.Dir(dbPath), "crash.log")
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	_, err = fmt.Fprintf(file, "=== %s buntime %s\npanic: %v\n\n%s\n",
+		time.Now().Format(time.RFC3339), version, report.value, report.stack)
+	return path, err
+}
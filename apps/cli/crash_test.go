@@ -0,0 +1,79 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type panicModel struct{}
+
+type explodeMsg struct{}
+
+func (panicModel) Init() tea.Cmd {
+	return func() tea.Msg { return explodeMsg{} }
+}
+
+func (m panicModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if _, ok := msg.(explodeMsg); ok {
+		panic("boom")
+	}
+	return m, nil
+}
+
+func (panicModel) View() string { return "" }
+
+type panicCmdModel struct{ panicModel }
+
+func (panicCmdModel) Init() tea.Cmd {
+	return tea.Batch(func() tea.Msg { panic("cmd boom") })
+}
+
+func runGuarded(t *testing.T, model tea.Model) (error, string) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("BUNTIME_CONFIG_DIR", dir)
+
+	stderr := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+	defer func() { os.Stderr = stderr }()
+
+	p := tea.NewProgram(crashGuard{model: model}, tea.WithInput(nil), tea.WithOutput(io.Discard), tea.WithoutCatchPanics())
+	err := runProgram(p)
+
+	w.Close()
+	out, _ := io.ReadAll(r)
+
+	if _, statErr := os.Stat(filepath.Join(dir, "crash.log")); statErr != nil {
+		t.Errorf("expected crash.log to be written: %v", statErr)
+	}
+	return err, string(out)
+}
+
+func TestRunProgramReportsUpdatePanic(t *testing.T) {
+	err, out := runGuarded(t, panicModel{})
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("expected crash error, got %v", err)
+	}
+	if !strings.Contains(out, "buntime crashed: boom") || !strings.Contains(out, issuesURL) {
+		t.Fatalf("expected crash report on stderr, got %q", out)
+	}
+	if !strings.Contains(out, "panicModel.Update") {
+		t.Fatalf("expected stack to point at the panicking Update, got %q", out)
+	}
+}
+
+func TestRunProgramReportsCommandPanic(t *testing.T) {
+	err, out := runGuarded(t, panicCmdModel{})
+	if err == nil || !strings.Contains(err.Error(), "cmd boom") {
+		t.Fatalf("expected crash error, got %v", err)
+	}
+	if !strings.Contains(out, "buntime crashed: cmd boom") {
+		t.Fatalf("expected crash report on stderr, got %q", out)
+	}
+}
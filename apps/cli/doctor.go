@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/tui/styles"
+	"github.com/spf13/cobra"
+)
+
+// doctorCheck is one line of the doctor report
+type doctorCheck struct {
+	name   string
+	ok     bool
+	detail string
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	if serverURL == "" {
+		return fmt.Errorf("server URL required. Use --url flag")
+	}
+
+	client := newClient()
+	checks := []doctorCheck{connectionCheck(client), clockCheck(client)}
+
+	failed := 0
+	for _, check := range checks {
+		mark := styles.TextSuccess.Render("✓")
+		if !check.ok {
+			mark = styles.TextError.Render("✗")
+			failed++
+		}
+		fmt.Printf("%s %-11s %s\n", mark, check.name, check.detail)
+	}
+
+	if failed > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d of %d checks failed", failed, len(checks))
+	}
+	return nil
+}
+
+func connectionCheck(client *api.Client) doctorCheck {
+	check := doctorCheck{name: "Connection"}
+	if err := client.Ping(); err != nil {
+		check.detail = err.Error()
+		return check
+	}
+	check.ok = true
+	check.detail = "connected to " + serverURL
+	return check
+}
+
+// clockCheck compares the local clock with the server's Date header. It runs
+// after connectionCheck, which made the request the skew is read from.
+func clockCheck(client *api.Client) doctorCheck {
+	check := doctorCheck{name: "Clock"}
+	skew, ok := client.ClockSkew()
+	switch {
+	case !ok:
+		// Nothing to compare against, which isn't a problem in itself
+		check.ok = true
+		check.detail = "not checked, the server sent no Date header"
+	case client.ClockSkewed():
+		check.detail = fmt.Sprintf("%s (more than %s). Key expiry shown by the CLI uses the server's clock, but fix the local clock: TLS checks also depend on it.",
+			api.DescribeClockSkew(skew), api.ClockSkewThreshold)
+	default:
+		check.ok = true
+		check.detail = "in sync with the server (" + api.DescribeClockSkew(skew) + ")"
+	}
+	return check
+}
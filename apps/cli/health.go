@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+// Exit statuses of `buntime health`
+const (
+	exitUnhealthy   = 1 // The server answered but isn't healthy
+	exitUnreachable = 2 // The server couldn't be reached at all
+)
+
+// healthWaitInterval is how often --wait checks the server again
+const healthWaitInterval = time.Second
+
+func runHealth(cmd *cobra.Command, args []string) error {
+	if err := checkOutput(output); err != nil {
+		return err
+	}
+	if serverURL == "" {
+		return fmt.Errorf("server URL required. Use --url flag")
+	}
+	cmd.SilenceUsage = true
+
+	client := newClient()
+	health, err := waitForHealth(client, healthWait, healthWaitInterval)
+	if err != nil {
+		// An HTTP error status still means the server is up
+		if apiErr, ok := err.(*api.APIError); ok && apiErr.Status != 0 {
+			return &exitError{code: exitUnhealthy, err: err}
+		}
+		return &exitError{code: exitUnreachable, err: err}
+	}
+
+	if dataOutput(output) {
+		if err := printData(os.Stdout, health); err != nil {
+			return err
+		}
+	} else {
+		printHealth(os.Stdout, health)
+	}
+
+	if !health.OK {
+		// Already reported above
+		return &exitError{code: exitUnhealthy}
+	}
+	return nil
+}
+
+// waitForHealth checks the server's health until it is OK or wait has
+// passed, and returns the last result. A zero wait checks once.
+func waitForHealth(client *api.Client, wait, interval time.Duration) (*api.HealthInfo, error) {
+	deadline := time.Now().Add(wait)
+	for {
+		health, err := client.GetHealth()
+		if (err == nil && health.OK) || !time.Now().Before(deadline) {
+			return health, err
+		}
+		time.Sleep(min(interval, time.Until(deadline)))
+	}
+}
+
+func printHealth(w io.Writer, health *api.HealthInfo) {
+	state := "healthy"
+	if !health.OK {
+		state = "unhealthy"
+	}
+	fmt.Fprintf(w, "%s: status %s, version %s\n", state, dashIfEmpty(health.Status), dashIfEmpty(health.Version))
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/buntime/cli/internal/api"
+)
+
+func TestWaitForHealthPollsUntilHealthy(t *testing.T) {
+	var checks atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/.well-known/buntime" {
+			http.NotFound(w, r)
+			return
+		}
+		if checks.Add(1) < 3 {
+			w.Write([]byte(`{"ok":false,"status":"starting","version":"1.2.0"}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true,"status":"ok","version":"1.2.0"}`))
+	}))
+	defer server.Close()
+
+	client := api.New(server.URL, "", false)
+
+	health, err := waitForHealth(client, 0, time.Millisecond)
+	if err != nil || health.OK {
+		t.Fatalf("expected a single unhealthy check without --wait, got %+v, %v", health, err)
+	}
+
+	health, err = waitForHealth(client, 5*time.Second, time.Millisecond)
+	if err != nil || !health.OK || checks.Load() != 3 {
+		t.Fatalf("expected to wait for the healthy check, got %+v, %v after %d checks", health, err, checks.Load())
+	}
+}
+
+func TestPrintHealth(t *testing.T) {
+	var b strings.Builder
+	printHealth(&b, &api.HealthInfo{OK: false, Status: "degraded"})
+	if got, want := b.String(), "unhealthy: status degraded, version -\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
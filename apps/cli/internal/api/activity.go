@@ -0,0 +1,78 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ActivityEntry is one event in the server's activity log, such as an
+// install, a removal or a key being created
+type ActivityEntry struct {
+	ID        string `json:"id"`
+	Timestamp int64  `json:"timestamp"` // Unix seconds
+	Type      string `json:"type"`      // e.g. "app.install", "key.revoke"
+	Actor     string `json:"actor"`     // Key name or provenance of whoever did it
+	Target    string `json:"target"`    // e.g. "my-app@1.2.0"
+	Message   string `json:"message,omitempty"`
+}
+
+// ActivityOptions filters and pages the activity log. Empty fields are not
+// sent.
+type ActivityOptions struct {
+	Type   string
+	Actor  string
+	Limit  int
+	Cursor string // NextCursor of the previous page
+}
+
+// ActivityPage is a page of the activity log, newest first. NextCursor is
+// empty on the last page.
+type ActivityPage struct {
+	Entries    []ActivityEntry `json:"entries"`
+	NextCursor string          `json:"nextCursor,omitempty"`
+}
+
+// GetActivity lists the server's activity log. Servers that don't record one
+// report ErrorTypeUnsupported.
+func (c *Client) GetActivity(opts ActivityOptions) (*ActivityPage, error) {
+	query := url.Values{}
+	if opts.Type != "" {
+		query.Set("type", opts.Type)
+	}
+	if opts.Actor != "" {
+		query.Set("actor", opts.Actor)
+	}
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.Cursor != "" {
+		query.Set("cursor", opts.Cursor)
+	}
+
+	path := "/activity"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	resp, err := c.doAPIRequest("GET", path, nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		resp.Body.Close()
+		return nil, &APIError{
+			Type:    ErrorTypeUnsupported,
+			Message: "this server does not record an activity log",
+			Status:  resp.StatusCode,
+		}
+	}
+
+	var page ActivityPage
+	if err := c.handleResponse(resp, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
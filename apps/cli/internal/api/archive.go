@@ -0,0 +1,245 @@
+package api
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// PackageInfo is the name/version pair the runtime reads from an archive
+type PackageInfo struct {
+	Name    string
+	Version string
+	// Type is "app" or "plugin" when the contents make it clear, "" otherwise
+	Type string
+}
+
+var (
+	manifestFiles = []string{"manifest.yaml", "manifest.yml"}
+	// pluginFiles are the entry files the runtime's plugin loader falls back
+	// to when the manifest has no pluginEntry
+	pluginFiles = []string{"plugin.ts", "plugin.js"}
+)
+
+// ReadArchivePackage reads the package name and version from a .zip, .tgz or
+// .tar.gz archive, or from a package directory, the same way the runtime does
+// on upload: manifest.yaml is preferred, package.json is the fallback and the
+// version defaults to "latest".
+func ReadArchivePackage(filePath string) (*PackageInfo, error) {
+	wanted := append(append(append([]string{}, manifestFiles...), pluginFiles...), "package.json")
+	files, err := readArchiveRootFiles(filePath, wanted)
+	if err != nil {
+		return nil, err
+	}
+
+	var info PackageInfo
+	var manifest map[string]string
+	for _, name := range manifestFiles {
+		if content, ok := files[name]; ok {
+			manifest = parseManifestYAML(content)
+			info.Name, info.Version = manifest["name"], manifest["version"]
+			break
+		}
+	}
+	info.Type = detectPackageType(manifest, files)
+
+	if content, ok := files["package.json"]; ok {
+		var pkg struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		}
+		if err := json.Unmarshal(content, &pkg); err == nil {
+			if info.Name == "" {
+				info.Name = pkg.Name
+			}
+			if info.Version == "" {
+				info.Version = pkg.Version
+			}
+		}
+	}
+
+	if info.Name == "" {
+		return nil, fmt.Errorf("package name not found: set manifest.yaml name or package.json name")
+	}
+	if info.Version == "" {
+		info.Version = "latest"
+	}
+
+	return &info, nil
+}
+
+// detectPackageType tells plugins from apps. Plugins need a manifest and are
+// recognized by the keys only the plugin loader reads (pluginEntry, base) or
+// a root plugin entry file; an archive without a manifest can only be an app.
+func detectPackageType(manifest map[string]string, files map[string][]byte) string {
+	if manifest == nil {
+		return "app"
+	}
+	if _, ok := manifest["pluginEntry"]; ok {
+		return "plugin"
+	}
+	if _, ok := manifest["base"]; ok {
+		return "plugin"
+	}
+	for _, name := range pluginFiles {
+		if _, ok := files[name]; ok {
+			return "plugin"
+		}
+	}
+	if _, ok := manifest["entrypoint"]; ok {
+		return "app"
+	}
+	return ""
+}
+
+// readArchiveRootFiles returns the contents of the wanted files found at the
+// archive root. Tarballs have their first path component stripped (npm pack
+// convention) and zips may wrap everything in a "package/" directory.
+// Directories are read as they would be zipped.
+func readArchiveRootFiles(filePath string, wanted []string) (map[string][]byte, error) {
+	if info, err := os.Stat(filePath); err == nil && info.IsDir() {
+		return readDirRootFiles(filePath, wanted)
+	}
+
+	lower := strings.ToLower(filePath)
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		return readZipRootFiles(filePath, wanted)
+	case strings.HasSuffix(lower, ".tgz"), strings.HasSuffix(lower, ".tar.gz"):
+		return readTarballRootFiles(filePath, wanted)
+	default:
+		return nil, fmt.Errorf("unsupported archive type: %s", path.Base(filePath))
+	}
+}
+
+func readDirRootFiles(dir string, wanted []string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	for _, name := range wanted {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		files[name] = content
+	}
+	return files, nil
+}
+
+func readZipRootFiles(filePath string, wanted []string) (map[string][]byte, error) {
+	reader, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer reader.Close()
+
+	files := make(map[string][]byte)
+	for _, f := range reader.File {
+		name := strings.TrimPrefix(f.Name, "package/")
+		if !containsString(wanted, name) {
+			continue
+		}
+		// Root-level files win over files inside package/
+		if _, seen := files[name]; seen && name != f.Name {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+		}
+		files[name] = content
+	}
+
+	return files, nil
+}
+
+func readTarballRootFiles(filePath string, wanted []string) (map[string][]byte, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer gz.Close()
+
+	files := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		// Equivalent of tar --strip-components=1
+		parts := strings.SplitN(strings.TrimPrefix(header.Name, "./"), "/", 2)
+		if len(parts) != 2 || !containsString(wanted, parts[1]) {
+			continue
+		}
+
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", header.Name, err)
+		}
+		files[parts[1]] = content
+	}
+
+	return files, nil
+}
+
+// parseManifestYAML extracts the top-level keys and their scalar values from
+// a manifest without pulling in a full YAML parser. Keys holding a nested
+// block map to "".
+func parseManifestYAML(content []byte) map[string]string {
+	fields := make(map[string]string)
+	for _, line := range strings.Split(string(content), "\n") {
+		if line == "" || line[0] == ' ' || line[0] == '\t' || line[0] == '#' {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		if idx := strings.Index(value, " #"); idx >= 0 {
+			value = value[:idx]
+		}
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+
+		fields[strings.TrimSpace(key)] = value
+	}
+	return fields
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
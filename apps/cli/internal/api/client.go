@@ -2,8 +2,10 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -12,6 +14,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -20,20 +24,47 @@ const defaultAPIPath = "/api"
 type Client struct {
 	baseURL    string
 	apiPath    string
+	discoverMu sync.Mutex // Guards apiPath and discovered for concurrent requests
 	discovered bool
 	token      string
 	insecure   bool
+	provenance *Provenance
+	clockMu    sync.Mutex // Guards clock, updated by concurrent requests
+	clock      clock
 	httpClient *http.Client
+	ctxMu      sync.Mutex // Guards ctx, swapped while requests are running
+	ctx        context.Context
+
+	timeout          time.Duration // Per request, including reading the body
+	uploadTimeout    time.Duration // For archive uploads; 0 uses timeout
+	retryAttempts    int           // Tries of a GET that hits a network error
+	retryDelay       time.Duration // Wait before the first retry, doubled after each
+	uploadAttempts   int           // Tries of an upload that can't reach the server
+	uploadRetryDelay time.Duration // Wait before the first upload retry, doubled after each
+
+	// gzipRequests is set by discovery when the server decodes gzip request
+	// bodies. Atomic because requests read it while Discover holds discoverMu.
+	gzipRequests atomic.Bool
+
+	// inFlight counts requests waiting for the server; lastAnswer is when it
+	// last answered, in Unix nanoseconds
+	inFlight   atomic.Int32
+	lastAnswer atomic.Int64
 }
 
 type ErrorType string
 
 const (
 	ErrorTypeAuthRequired      ErrorType = "auth_required"
+	ErrorTypeCanceled          ErrorType = "canceled"
+	ErrorTypeForbidden         ErrorType = "forbidden" // The key is valid but lacks a permission
 	ErrorTypeConnectionRefused ErrorType = "connection_refused"
 	ErrorTypeNetworkError      ErrorType = "network_error"
 	ErrorTypeServerError       ErrorType = "server_error"
 	ErrorTypeTLSError          ErrorType = "tls_error"
+	ErrorTypeUnhealthy         ErrorType = "unhealthy"
+	ErrorTypeVersionExists     ErrorType = "version_exists"
+	ErrorTypeUnsupported       ErrorType = "unsupported"
 	ErrorTypeUnknown           ErrorType = "unknown"
 )
 
@@ -41,35 +72,162 @@ type APIError struct {
 	Type    ErrorType
 	Message string
 	Status  int
+	Code    string // Error code the server sent, e.g. "INVALID_FILE_TYPE"
+	Err     error  // Underlying transport error, if any
 }
 
 func (e *APIError) Error() string {
 	return e.Message
 }
 
-func New(baseURL string, token string, insecure bool) *Client {
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// Request timeouts. Pings only check that the server answers, so they give
+// up sooner than other requests, and archive uploads are legitimately slow
+// over poor links, so they get longer.
+const (
+	DefaultTimeout       = 30 * time.Second
+	PingTimeout          = 5 * time.Second
+	DefaultUploadTimeout = 10 * time.Minute
+)
+
+// GETs that fail with a network error are retried with exponential backoff
+// by default: after 200ms, then 400ms
+const (
+	DefaultRetryAttempts = 3
+	DefaultRetryDelay    = 200 * time.Millisecond
+)
+
+// DefaultUploadRetryDelay is the wait before retrying an upload. Uploads are
+// only retried when enabled with WithUploadRetry.
+const DefaultUploadRetryDelay = time.Second
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithTimeout sets how long a request may take, including reading its
+// response. Pings use the shorter of this and PingTimeout.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		if d > 0 {
+			c.timeout = d
+		}
+	}
+}
+
+// WithUploadTimeout sets how long an archive upload may take, for large
+// archives over slow links. Uploads use the longer of the request timeout and
+// DefaultUploadTimeout by default.
+func WithUploadTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		if d > 0 {
+			c.uploadTimeout = d
+		}
+	}
+}
+
+// WithRetry sets how many times a GET is tried when it hits a network error,
+// waiting base before the first retry and doubling the wait after each. Only
+// GETs are retried here; uploads have WithUploadRetry, and deletes and key
+// creation never are. A maxAttempts of 1 turns retries off.
+func WithRetry(maxAttempts int, base time.Duration) Option {
+	return func(c *Client) {
+		c.retryAttempts = max(1, maxAttempts)
+		c.retryDelay = base
+	}
+}
+
+// WithUploadRetry sets how many times an archive upload is tried when the
+// server can't be reached, waiting base before the first retry and doubling
+// the wait after each. Failures the server answered, such as 4xx errors, are
+// never retried. Uploads are tried once by default.
+func WithUploadRetry(maxAttempts int, base time.Duration) Option {
+	return func(c *Client) {
+		c.uploadAttempts = max(1, maxAttempts)
+		c.uploadRetryDelay = base
+	}
+}
+
+// RetryFunc is told that an upload failed with err and is about to be tried
+// again, as attempt of maxAttempts
+type RetryFunc func(attempt, maxAttempts int, err error)
+
+func New(baseURL string, token string, insecure bool, opts ...Option) *Client {
 	transport := &http.Transport{
 		TLSClientConfig: &tls.Config{
 			InsecureSkipVerify: insecure,
 		},
 	}
 
-	return &Client{
+	c := &Client{
 		baseURL:  strings.TrimRight(baseURL, "/"),
 		apiPath:  defaultAPIPath,
 		token:    token,
 		insecure: insecure,
+		// Timeouts are applied per request through the context, so uploads
+		// and pings can use their own
 		httpClient: &http.Client{
 			Transport: transport,
-			Timeout:   30 * time.Second,
 		},
+		timeout:        DefaultTimeout,
+		retryAttempts:  DefaultRetryAttempts,
+		retryDelay:     DefaultRetryDelay,
+		uploadAttempts: 1,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// pingTimeout is the timeout of reachability checks
+func (c *Client) pingTimeout() time.Duration {
+	return min(PingTimeout, c.timeout)
+}
+
+// uploadTimeoutOrDefault is the timeout of archive uploads
+func (c *Client) uploadTimeoutOrDefault() time.Duration {
+	if c.uploadTimeout > 0 {
+		return c.uploadTimeout
+	}
+	return max(c.timeout, DefaultUploadTimeout)
+}
+
+// cancelOnClose releases a request's timeout once its body has been read
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
 }
 
 func (c *Client) SetToken(token string) {
 	c.token = token
 }
 
+// SetContext sets the context later requests are made with. Cancelling it
+// aborts the requests in flight with an ErrorTypeCanceled error.
+func (c *Client) SetContext(ctx context.Context) {
+	c.ctxMu.Lock()
+	defer c.ctxMu.Unlock()
+	c.ctx = ctx
+}
+
+func (c *Client) context() context.Context {
+	c.ctxMu.Lock()
+	defer c.ctxMu.Unlock()
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
 func normalizeAPIPath(path string) string {
 	if path == "" || path == "/" {
 		return defaultAPIPath
@@ -88,12 +246,24 @@ func joinPath(prefix, path string) string {
 }
 
 func (c *Client) Discover() error {
+	return c.discoverCtx(c.context())
+}
+
+func (c *Client) discoverCtx(ctx context.Context) error {
+	c.discoverMu.Lock()
+	defer c.discoverMu.Unlock()
+
 	if c.discovered {
 		return nil
 	}
 
-	resp, err := c.doRequest("GET", "/.well-known/buntime", nil, "")
+	resp, err := c.doRequestCtx(ctx, "GET", "/.well-known/buntime", nil, "")
 	if err != nil {
+		// A canceled discovery says nothing about the server; try again on
+		// the next request instead of settling on the default path
+		if errors.Is(err, context.Canceled) {
+			return err
+		}
 		c.discovered = true
 		return nil
 	}
@@ -101,10 +271,14 @@ func (c *Client) Discover() error {
 
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 		var config struct {
-			API string `json:"api"`
+			API              string   `json:"api"`
+			RequestEncodings []string `json:"requestEncodings"`
 		}
-		if err := json.NewDecoder(resp.Body).Decode(&config); err == nil && config.API != "" {
-			c.apiPath = normalizeAPIPath(config.API)
+		if err := json.NewDecoder(resp.Body).Decode(&config); err == nil {
+			if config.API != "" {
+				c.apiPath = normalizeAPIPath(config.API)
+			}
+			c.gzipRequests.Store(acceptsGzip(config.RequestEncodings))
 		}
 	}
 
@@ -122,12 +296,91 @@ func isStateChangingMethod(method string) bool {
 }
 
 func (c *Client) doRequest(method, path string, body io.Reader, contentType string) (*http.Response, error) {
+	return c.doRequestCtx(c.context(), method, path, body, contentType)
+}
+
+// doRequestCtx is doRequest with a context of its own, for callers that
+// cancel a request independently of the client's context. GETs that hit a
+// transient network error are retried.
+func (c *Client) doRequestCtx(ctx context.Context, method, path string, body io.Reader, contentType string) (*http.Response, error) {
+	attempts := 1
+	if method == "GET" {
+		attempts = c.retryAttempts
+	}
+
+	delay := c.retryDelay
+	for attempt := 1; ; attempt++ {
+		resp, err := c.sendRequest(ctx, method, path, body, contentType)
+		if err == nil || attempt >= attempts || !retryable(err) {
+			return resp, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// uploadRetryable reports whether an upload failed before the server could
+// answer it, so sending it again may succeed
+func uploadRetryable(err error) bool {
+	apiErr, ok := err.(*APIError)
+	return ok && (apiErr.Type == ErrorTypeNetworkError || apiErr.Type == ErrorTypeConnectionRefused)
+}
+
+// retryable reports whether a failed request may succeed if sent again.
+// Refused connections, auth and TLS errors won't change on their own.
+func retryable(err error) bool {
+	apiErr, ok := err.(*APIError)
+	return ok && apiErr.Type == ErrorTypeNetworkError
+}
+
+// sendRequest makes a single attempt at a request
+func (c *Client) sendRequest(ctx context.Context, method, path string, body io.Reader, contentType string) (*http.Response, error) {
+	// Callers with a deadline of their own, like pings and uploads, keep it
+	cancel := context.CancelFunc(func() {})
+	if _, ok := ctx.Deadline(); !ok {
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+	}
+
+	req, err := c.newRequest(ctx, method, path, body, contentType)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	sent := time.Now()
+	resp, err := c.do(req)
+	if err != nil {
+		cancel()
+		return nil, c.classifyError(err)
+	}
+	c.recordServerTime(resp, sent, time.Now())
+
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// newRequest builds a request with the auth and CSRF headers every call needs
+func (c *Client) newRequest(ctx context.Context, method, path string, body io.Reader, contentType string) (*http.Request, error) {
 	url := c.baseURL + path
 
-	req, err := http.NewRequest(method, url, body)
+	body, encoding := c.gzipBody(body, contentType)
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, err
 	}
+	if encoding != "" {
+		req.Header.Set("Content-Encoding", encoding)
+	}
+	// Streamed bodies of a known length keep a Content-Length instead of
+	// being sent chunked
+	if sized, ok := body.(interface{ Size() int64 }); ok && req.ContentLength == 0 {
+		req.ContentLength = sized.Size()
+	}
 
 	// Use API key for authentication (bypasses CSRF and other auth)
 	if c.token != "" {
@@ -140,24 +393,40 @@ func (c *Client) doRequest(method, path string, body io.Reader, contentType stri
 
 	if isStateChangingMethod(method) {
 		req.Header.Set("Origin", c.baseURL)
+		c.setProvenanceHeaders(req)
 	}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, c.classifyError(err)
-	}
-
-	return resp, nil
+	return req, nil
 }
 
 func (c *Client) doAPIRequest(method, path string, body io.Reader, contentType string) (*http.Response, error) {
-	if err := c.Discover(); err != nil {
+	return c.doAPIRequestCtx(c.context(), method, path, body, contentType)
+}
+
+func (c *Client) doAPIRequestCtx(ctx context.Context, method, path string, body io.Reader, contentType string) (*http.Response, error) {
+	if err := c.discoverCtx(ctx); err != nil {
 		return nil, err
 	}
-	return c.doRequest(method, joinPath(c.apiPath, path), body, contentType)
+	return c.doRequestCtx(ctx, method, joinPath(c.apiPath, path), body, contentType)
 }
 
 func (c *Client) classifyError(err error) *APIError {
+	if errors.Is(err, context.Canceled) {
+		return &APIError{
+			Type:    ErrorTypeCanceled,
+			Message: "Request canceled",
+			Err:     err,
+		}
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return &APIError{
+			Type:    ErrorTypeNetworkError,
+			Message: "Network error: the server did not respond in time",
+			Err:     err,
+		}
+	}
+
 	errStr := err.Error()
 
 	// Check for TLS errors
@@ -206,37 +475,74 @@ func containsAny(s string, substrs ...string) bool {
 func (c *Client) handleResponse(resp *http.Response, v interface{}) error {
 	defer resp.Body.Close()
 
-	if resp.StatusCode == 401 {
+	if resp.StatusCode < 400 {
+		if v != nil {
+			return json.NewDecoder(resp.Body).Decode(v)
+		}
+		return nil
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	code, message := parseErrorBody(body)
+
+	switch {
+	case resp.StatusCode == 401:
 		return &APIError{
 			Type:    ErrorTypeAuthRequired,
 			Message: "Authentication required",
 			Status:  401,
+			Code:    code,
 		}
-	}
 
-	if resp.StatusCode >= 500 {
-		body, _ := io.ReadAll(resp.Body)
+	// 403 without AUTH_REQUIRED means the key was accepted but isn't allowed
+	// to do this, so a more privileged key may succeed
+	case resp.StatusCode == 403:
+		if code == "AUTH_REQUIRED" {
+			return &APIError{
+				Type:    ErrorTypeAuthRequired,
+				Message: "Authentication required",
+				Status:  403,
+				Code:    code,
+			}
+		}
 		return &APIError{
-			Type:    ErrorTypeServerError,
-			Message: fmt.Sprintf("Server error (%d): %s", resp.StatusCode, string(body)),
-			Status:  resp.StatusCode,
+			Type:    ErrorTypeForbidden,
+			Message: fmt.Sprintf("Permission denied (403): %s", message),
+			Status:  403,
+			Code:    code,
 		}
-	}
 
-	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
+	case resp.StatusCode >= 500:
 		return &APIError{
-			Type:    ErrorTypeUnknown,
-			Message: fmt.Sprintf("Request failed (%d): %s", resp.StatusCode, string(body)),
+			Type:    ErrorTypeServerError,
+			Message: fmt.Sprintf("Server error (%d): %s", resp.StatusCode, message),
 			Status:  resp.StatusCode,
+			Code:    code,
 		}
 	}
 
-	if v != nil {
-		return json.NewDecoder(resp.Body).Decode(v)
+	return &APIError{
+		Type:    ErrorTypeUnknown,
+		Message: fmt.Sprintf("Request failed (%d): %s", resp.StatusCode, message),
+		Status:  resp.StatusCode,
+		Code:    code,
 	}
+}
 
-	return nil
+// errorEnvelope is the body the runtime sends with a failed request
+type errorEnvelope struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// parseErrorBody reads the code and message of a failed request. Bodies that
+// aren't an error envelope, such as proxy error pages, are the message as is.
+func parseErrorBody(body []byte) (code, message string) {
+	var envelope errorEnvelope
+	if json.Unmarshal(body, &envelope) == nil && envelope.Message != "" {
+		return envelope.Code, envelope.Message
+	}
+	return envelope.Code, strings.TrimSpace(string(body))
 }
 
 // Health API
@@ -248,7 +554,12 @@ type HealthInfo struct {
 }
 
 func (c *Client) GetHealth() (*HealthInfo, error) {
-	resp, err := c.doAPIRequest("GET", "/health", nil, "")
+	return c.GetHealthCtx(c.context())
+}
+
+// GetHealthCtx is GetHealth with a context that can cancel it
+func (c *Client) GetHealthCtx(ctx context.Context) (*HealthInfo, error) {
+	resp, err := c.doAPIRequestCtx(ctx, "GET", "/health", nil, "")
 	if err != nil {
 		return nil, err
 	}
@@ -261,11 +572,53 @@ func (c *Client) GetHealth() (*HealthInfo, error) {
 	return &health, nil
 }
 
+// Default post-install verification window
+const (
+	DefaultVerifyWindow   = 5 * time.Second
+	DefaultVerifyInterval = time.Second
+)
+
+// VerifyHealth polls the health endpoint for the given window and returns
+// the first failed check. Used after installs and plugin toggles to catch
+// changes that take the runtime down right away.
+func (c *Client) VerifyHealth(window, interval time.Duration) error {
+	deadline := time.Now().Add(window)
+	for {
+		health, err := c.GetHealth()
+		if err != nil {
+			return &APIError{
+				Type:    ErrorTypeUnhealthy,
+				Message: "Health check failed: " + err.Error(),
+			}
+		}
+		if !health.OK {
+			return &APIError{
+				Type:    ErrorTypeUnhealthy,
+				Message: fmt.Sprintf("Server reported unhealthy status %q", health.Status),
+			}
+		}
+
+		if !time.Now().Add(interval).Before(deadline) {
+			return nil
+		}
+		time.Sleep(interval)
+	}
+}
+
 // Ping checks if server is reachable and if auth is required
 // Calls a protected endpoint to verify both connectivity and authentication
 func (c *Client) Ping() error {
+	return c.PingCtx(c.context())
+}
+
+// PingCtx is Ping with a context that can cancel it, e.g. when the user
+// gives up on connecting
+func (c *Client) PingCtx(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.pingTimeout())
+	defer cancel()
+
 	// Call a protected endpoint to check auth status
-	resp, err := c.doAPIRequest("GET", "/plugins", nil, "")
+	resp, err := c.doAPIRequestCtx(ctx, "GET", "/plugins", nil, "")
 	if err != nil {
 		return err
 	}
@@ -283,22 +636,13 @@ func (c *Client) Ping() error {
 	// 403 might mean auth required (no key) or permission denied (invalid key)
 	// Check the response body for the error code
 	if resp.StatusCode == 403 {
-		var errResp struct {
-			Code string `json:"code"`
-		}
-		if err := json.NewDecoder(resp.Body).Decode(&errResp); err == nil {
-			if errResp.Code == "AUTH_REQUIRED" {
-				return &APIError{
-					Type:    ErrorTypeAuthRequired,
-					Message: "Authentication required",
-					Status:  403,
-				}
-			}
-		}
+		body, _ := io.ReadAll(resp.Body)
+		code, _ := parseErrorBody(body)
 		return &APIError{
 			Type:    ErrorTypeAuthRequired,
 			Message: "Authentication required",
 			Status:  403,
+			Code:    code,
 		}
 	}
 
@@ -308,7 +652,16 @@ func (c *Client) Ping() error {
 
 // IsReachable checks if the server is reachable (any HTTP response = reachable)
 func (c *Client) IsReachable() bool {
-	resp, err := c.doAPIRequest("GET", "/health", nil, "")
+	return c.IsReachableCtx(c.context())
+}
+
+// IsReachableCtx is IsReachable with a context that can cancel it. A
+// cancelled check reports the server as unreachable.
+func (c *Client) IsReachableCtx(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, c.pingTimeout())
+	defer cancel()
+
+	resp, err := c.doAPIRequestCtx(ctx, "GET", "/health", nil, "")
 	if err != nil {
 		return false
 	}
@@ -326,42 +679,135 @@ type PluginInfo struct {
 	Enabled  bool     `json:"enabled"`
 	Path     string   `json:"path"`
 	Versions []string `json:"versions"`
+	// ActiveVersion is the version that runs while the plugin is enabled, or
+	// the one that ran last for a disabled plugin. Empty when the server
+	// doesn't report it.
+	ActiveVersion string `json:"activeVersion,omitempty"`
+	// Provenance is set when the server recorded who installed the plugin
+	Provenance *Provenance `json:"provenance,omitempty"`
+	// Labels is nil when the server does not include labels in the list
+	Labels Labels `json:"labels,omitempty"`
 }
 
 func (c *Client) ListPlugins() ([]PluginInfo, error) {
-	resp, err := c.doAPIRequest("GET", "/plugins", nil, "")
+	plugins, _, err := c.ListPluginsPage(ListOptions{})
+	return plugins, err
+}
+
+// ListPluginsPage fetches one page of plugins, or all of them for zero
+// options
+func (c *Client) ListPluginsPage(opts ListOptions) ([]PluginInfo, *PageInfo, error) {
+	plugins, page, err := listPageOf[PluginInfo](c, "/plugins", "plugins", opts)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	for i := range plugins {
+		plugins[i].normalize()
+	}
+
+	return plugins, page, nil
+}
+
+// normalize fills in what older servers leave out: a loaded plugin (one with
+// a path) is enabled, and a plugin without versions runs "latest"
+func (p *PluginInfo) normalize() {
+	if p.Path != "" {
+		p.Enabled = true
 	}
+	if len(p.Versions) == 0 {
+		p.Versions = []string{"latest"}
+	}
+}
 
-	var plugins []PluginInfo
-	if err := c.handleResponse(resp, &plugins); err != nil {
+// PluginDetail is everything the server knows about one plugin
+type PluginDetail struct {
+	PluginInfo
+	Description  string   `json:"description,omitempty"`
+	Author       string   `json:"author,omitempty"`
+	Homepage     string   `json:"homepage,omitempty"`
+	Dependencies []string `json:"dependencies,omitempty"`
+	// Manifest and ConfigSchema are passed through as sent, nil when the
+	// server leaves them out
+	Manifest     json.RawMessage `json:"manifest,omitempty"`
+	ConfigSchema json.RawMessage `json:"configSchema,omitempty"`
+}
+
+// GetPlugin fetches the full metadata of one plugin. Servers that only list
+// plugins report ErrorTypeUnsupported.
+func (c *Client) GetPlugin(id int) (*PluginDetail, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("plugin details are not supported by this runtime API")
+	}
+	resp, err := c.doAPIRequest("GET", fmt.Sprintf("/plugins/%d", id), nil, "")
+	if err != nil {
 		return nil, err
 	}
 
-	for i := range plugins {
-		if plugins[i].Path != "" {
-			plugins[i].Enabled = true
-		}
-		if len(plugins[i].Versions) == 0 {
-			plugins[i].Versions = []string{"latest"}
+	// 404 is left to handleResponse: it means there is no such plugin
+	switch resp.StatusCode {
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		resp.Body.Close()
+		return nil, &APIError{
+			Type:    ErrorTypeUnsupported,
+			Message: "this server does not expose plugin details",
+			Status:  resp.StatusCode,
 		}
 	}
 
-	return plugins, nil
+	var detail PluginDetail
+	if err := c.handleResponse(resp, &detail); err != nil {
+		return nil, err
+	}
+	detail.normalize()
+	return &detail, nil
 }
 
 func (c *Client) EnablePlugin(id int) error {
+	return c.EnablePluginVersion(id, "")
+}
+
+// EnablePluginVersion enables a plugin running the given version. An empty
+// version leaves the choice to the server.
+func (c *Client) EnablePluginVersion(id int, version string) error {
 	if id == 0 {
 		return fmt.Errorf("plugin enable is not supported by this runtime API")
 	}
-	resp, err := c.doAPIRequest("PUT", fmt.Sprintf("/plugins/%d/enable", id), nil, "")
+
+	var body io.Reader
+	contentType := ""
+	if version != "" {
+		data, err := json.Marshal(map[string]string{"version": version})
+		if err != nil {
+			return fmt.Errorf("failed to marshal input: %w", err)
+		}
+		body = bytes.NewReader(data)
+		contentType = "application/json"
+	}
+
+	resp, err := c.doAPIRequest("PUT", fmt.Sprintf("/plugins/%d/enable", id), body, contentType)
 	if err != nil {
 		return err
 	}
 	return c.handleResponse(resp, nil)
 }
 
+// DefaultEnableVersion is the version enabling a plugin should pick: the one
+// that ran last when the server reports it, otherwise the newest
+func (p *PluginInfo) DefaultEnableVersion() string {
+	if p.ActiveVersion != "" {
+		for _, version := range p.Versions {
+			if version == p.ActiveVersion {
+				return version
+			}
+		}
+	}
+	if len(p.Versions) > 0 {
+		return p.Versions[0]
+	}
+	return ""
+}
+
 func (c *Client) DisablePlugin(id int) error {
 	if id == 0 {
 		return fmt.Errorf("plugin disable is not supported by this runtime API")
@@ -396,10 +842,40 @@ type InstallResult struct {
 	Name    string `json:"name"`
 	Path    string `json:"path"`
 	Version string `json:"version"`
+	// Warnings are problems the server accepted the install despite, such as
+	// deprecated manifest fields or an oversized bundle
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// InstallOptions controls how an archive is installed
+type InstallOptions struct {
+	// Force overwrites a version that is already installed. Without it the
+	// install is refused with an ErrorTypeVersionExists error.
+	Force bool
 }
 
 func (c *Client) InstallPlugin(filePath string) (*InstallResult, error) {
-	result, err := c.uploadAPIFile("/plugins/upload", filePath)
+	return c.InstallPluginWithOptions(filePath, InstallOptions{})
+}
+
+func (c *Client) InstallPluginWithOptions(filePath string, opts InstallOptions) (*InstallResult, error) {
+	return c.installPlugin(c.context(), filePath, opts, nil)
+}
+
+// InstallPluginCtx installs a plugin archive, streaming it from disk.
+// onProgress, when not nil, is told how much has been sent; canceling ctx
+// aborts the upload with ErrorTypeCanceled.
+func (c *Client) InstallPluginCtx(ctx context.Context, filePath string, onProgress ProgressFunc) (*InstallResult, error) {
+	return c.installPlugin(ctx, filePath, InstallOptions{}, onProgress)
+}
+
+func (c *Client) installPlugin(ctx context.Context, filePath string, opts InstallOptions, onProgress ProgressFunc) (*InstallResult, error) {
+	if !opts.Force {
+		if err := c.checkPluginVersionFree(filePath); err != nil {
+			return nil, err
+		}
+	}
+	result, err := c.uploadAPIFileCtx(ctx, installEndpoint("/plugins/upload", opts), filePath, onProgress, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -423,20 +899,57 @@ type AppInfo struct {
 	Name     string   `json:"name"`
 	Path     string   `json:"path"`
 	Versions []string `json:"versions"`
+	// Enabled is false while the server keeps the app installed but doesn't
+	// serve it
+	Enabled bool `json:"enabled"`
+	// ActiveVersion is the version the server is serving. Empty when the
+	// server doesn't report it.
+	ActiveVersion string `json:"activeVersion,omitempty"`
+	// Provenance is set when the server recorded who installed the app
+	Provenance *Provenance `json:"provenance,omitempty"`
+	// Labels is nil when the server does not include labels in the list
+	Labels Labels `json:"labels,omitempty"`
+	// InstalledAt maps each version to when it was installed. Nil when the
+	// server doesn't report it.
+	InstalledAt map[string]time.Time `json:"installedAt,omitempty"`
 }
 
-func (c *Client) ListApps() ([]AppInfo, error) {
-	resp, err := c.doAPIRequest("GET", "/apps", nil, "")
-	if err != nil {
-		return nil, err
+// UnmarshalJSON treats an app as enabled when the server doesn't say, since
+// servers without app toggles serve every installed app
+func (a *AppInfo) UnmarshalJSON(data []byte) error {
+	type plain AppInfo
+	app := plain{Enabled: true}
+	if err := json.Unmarshal(data, &app); err != nil {
+		return err
 	}
+	*a = AppInfo(app)
+	return nil
+}
 
-	var apps []AppInfo
-	if err := c.handleResponse(resp, &apps); err != nil {
-		return nil, err
+// CurrentVersion is the version the app runs: the active one when the server
+// reports it, otherwise the newest
+func (a *AppInfo) CurrentVersion() string {
+	if a.ActiveVersion != "" {
+		for _, version := range a.Versions {
+			if version == a.ActiveVersion {
+				return version
+			}
+		}
 	}
+	if len(a.Versions) > 0 {
+		return a.Versions[0]
+	}
+	return ""
+}
 
-	return apps, nil
+func (c *Client) ListApps() ([]AppInfo, error) {
+	apps, _, err := c.ListAppsPage(ListOptions{})
+	return apps, err
+}
+
+// ListAppsPage fetches one page of apps, or all of them for zero options
+func (c *Client) ListAppsPage(opts ListOptions) ([]AppInfo, *PageInfo, error) {
+	return listPageOf[AppInfo](c, "/apps", "apps", opts)
 }
 
 func (c *Client) RemoveApp(name, version string) error {
@@ -449,6 +962,67 @@ func (c *Client) RemoveApp(name, version string) error {
 	return c.handleResponse(resp, nil)
 }
 
+// ActivateAppVersion makes an installed version of an app the one the server
+// runs, such as an older version after a bad deploy, with
+// PUT /apps/{scope}/{name}/{version}/activate. Servers without the route,
+// which answer 404, report ErrorTypeUnsupported.
+func (c *Client) ActivateAppVersion(name, version string) error {
+	scope, pkgName := parsePackageName(name)
+	path := "/apps/" + scope + "/" + pkgName + "/" + version + "/activate"
+	resp, err := c.doAPIRequest("PUT", path, nil, "")
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		resp.Body.Close()
+		return &APIError{
+			Type:    ErrorTypeUnsupported,
+			Message: "this server does not support switching app versions",
+			Status:  resp.StatusCode,
+		}
+	}
+
+	return c.handleResponse(resp, nil)
+}
+
+// EnableApp makes the server serve an installed app again
+func (c *Client) EnableApp(name string) error {
+	return c.setAppEnabled(name, true)
+}
+
+// DisableApp stops serving an app but keeps it installed
+func (c *Client) DisableApp(name string) error {
+	return c.setAppEnabled(name, false)
+}
+
+// setAppEnabled enables or disables an app. Servers without app toggles
+// report ErrorTypeUnsupported.
+func (c *Client) setAppEnabled(name string, enable bool) error {
+	action := "disable"
+	if enable {
+		action = "enable"
+	}
+	scope, pkgName := parsePackageName(name)
+	resp, err := c.doAPIRequest("PUT", "/apps/"+scope+"/"+pkgName+"/"+action, nil, "")
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		resp.Body.Close()
+		return &APIError{
+			Type:    ErrorTypeUnsupported,
+			Message: "this server does not support enabling and disabling apps",
+			Status:  resp.StatusCode,
+		}
+	}
+
+	return c.handleResponse(resp, nil)
+}
+
 // parsePackageName splits a package name into scope and name
 // "@scope/name" -> ("@scope", "name")
 // "name" -> ("_", "name")
@@ -465,7 +1039,100 @@ func parsePackageName(fullName string) (scope, name string) {
 }
 
 func (c *Client) InstallApp(filePath string) (*InstallResult, error) {
-	return c.uploadAPIFile("/apps/upload", filePath)
+	return c.InstallAppWithOptions(filePath, InstallOptions{})
+}
+
+func (c *Client) InstallAppWithOptions(filePath string, opts InstallOptions) (*InstallResult, error) {
+	return c.installApp(c.context(), filePath, opts, nil)
+}
+
+// InstallAppCtx installs an app archive, streaming it from disk. onProgress,
+// when not nil, is told how much has been sent; canceling ctx aborts the
+// upload with ErrorTypeCanceled.
+func (c *Client) InstallAppCtx(ctx context.Context, filePath string, onProgress ProgressFunc) (*InstallResult, error) {
+	return c.installApp(ctx, filePath, InstallOptions{}, onProgress)
+}
+
+func (c *Client) installApp(ctx context.Context, filePath string, opts InstallOptions, onProgress ProgressFunc) (*InstallResult, error) {
+	if !opts.Force {
+		if err := c.checkAppVersionFree(filePath); err != nil {
+			return nil, err
+		}
+	}
+	return c.uploadAPIFileCtx(ctx, installEndpoint("/apps/upload", opts), filePath, onProgress, nil)
+}
+
+func installEndpoint(endpoint string, opts InstallOptions) string {
+	if opts.Force {
+		return endpoint + "?overwrite=true"
+	}
+	return endpoint
+}
+
+// checkAppVersionFree refuses the install when the archive's version is
+// already installed. Archives that cannot be inspected are left for the
+// server to validate.
+func (c *Client) checkAppVersionFree(filePath string) error {
+	pkg, err := ReadArchivePackage(filePath)
+	if err != nil {
+		return nil
+	}
+
+	apps, err := c.ListApps()
+	if err != nil {
+		return err
+	}
+
+	for _, app := range apps {
+		if app.Name == pkg.Name && containsString(app.Versions, pkg.Version) {
+			return versionExistsError("app", pkg)
+		}
+	}
+	return nil
+}
+
+func (c *Client) checkPluginVersionFree(filePath string) error {
+	pkg, err := ReadArchivePackage(filePath)
+	if err != nil {
+		return nil
+	}
+
+	plugins, err := c.ListPlugins()
+	if err != nil {
+		return err
+	}
+
+	for _, plugin := range plugins {
+		if plugin.Name == pkg.Name && containsString(plugin.Versions, pkg.Version) {
+			return versionExistsError("plugin", pkg)
+		}
+	}
+	return nil
+}
+
+func versionExistsError(itemType string, pkg *PackageInfo) *APIError {
+	return &APIError{
+		Type:    ErrorTypeVersionExists,
+		Message: fmt.Sprintf("VERSION_EXISTS: %s %s@%s is already installed", itemType, pkg.Name, pkg.Version),
+		Status:  409,
+	}
+}
+
+// versionConflict reports a 409 from an install upload as the version being
+// installed already. Other requests answer 409 for other conflicts, such as
+// a key name in use.
+func versionConflict(err error) error {
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.Status != http.StatusConflict {
+		return err
+	}
+	message := strings.TrimPrefix(apiErr.Message, "Request failed (409): ")
+	return &APIError{
+		Type:    ErrorTypeVersionExists,
+		Message: "Version already exists (409): " + message,
+		Status:  apiErr.Status,
+		Code:    apiErr.Code,
+	}
 }
 
 // Keys API
@@ -512,6 +1179,9 @@ type ApiKeyInfo struct {
 type KeyMetaInfo struct {
 	Roles       []KeyRole    `json:"roles"`
 	Permissions []Permission `json:"permissions"`
+	// Longest lifetime the server allows for new keys, e.g. "90d". Empty
+	// when keys may never expire.
+	MaxExpiration string `json:"maxExpiration,omitempty"`
 }
 
 type CreateKeyInput struct {
@@ -522,6 +1192,22 @@ type CreateKeyInput struct {
 	Permissions []Permission `json:"permissions,omitempty"`
 }
 
+// UpdateKeyInput changes an existing key. Nil fields are left as they are.
+type UpdateKeyInput struct {
+	Name        *string       `json:"name,omitempty"`
+	Description *string       `json:"description,omitempty"`
+	Role        *KeyRole      `json:"role,omitempty"`
+	Permissions *[]Permission `json:"permissions,omitempty"`
+	ExpiresAt   *int64        `json:"expiresAt,omitempty"` // Unix seconds
+}
+
+// Empty reports whether the input changes nothing
+func (in UpdateKeyInput) Empty() bool {
+	return in.Name == nil && in.Description == nil && in.Role == nil && in.Permissions == nil && in.ExpiresAt == nil
+}
+
+// CreateKeyResult is a key with its secret, which the server returns only
+// when the key is created or rotated
 type CreateKeyResult struct {
 	ID        int     `json:"id"`
 	Name      string  `json:"name"`
@@ -531,27 +1217,34 @@ type CreateKeyResult struct {
 }
 
 func (c *Client) ListKeys() ([]ApiKeyInfo, error) {
-	resp, err := c.doAPIRequest("GET", "/keys", nil, "")
-	if err != nil {
-		return nil, err
-	}
-
-	var result struct {
-		Keys []ApiKeyInfo `json:"keys"`
-	}
-	if err := c.handleResponse(resp, &result); err != nil {
-		return nil, err
-	}
+	keys, _, err := c.ListKeysPage(ListOptions{})
+	return keys, err
+}
 
-	return result.Keys, nil
+// ListKeysPage fetches one page of API keys, or all of them for zero options
+func (c *Client) ListKeysPage(opts ListOptions) ([]ApiKeyInfo, *PageInfo, error) {
+	return listPageOf[ApiKeyInfo](c, "/keys", "keys", opts)
 }
 
+// GetKeyMeta lists the roles, permissions and maximum lifetime the server
+// accepts for new keys.
+// Servers that predate the endpoint report ErrorTypeUnsupported.
 func (c *Client) GetKeyMeta() (*KeyMetaInfo, error) {
 	resp, err := c.doAPIRequest("GET", "/keys/meta", nil, "")
 	if err != nil {
 		return nil, err
 	}
 
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		resp.Body.Close()
+		return nil, &APIError{
+			Type:    ErrorTypeUnsupported,
+			Message: "key roles and permissions are not listed by this server",
+			Status:  resp.StatusCode,
+		}
+	}
+
 	var meta KeyMetaInfo
 	if err := c.handleResponse(resp, &meta); err != nil {
 		return nil, err
@@ -571,6 +1264,32 @@ func (c *Client) CreateKey(input CreateKeyInput) (*CreateKeyResult, error) {
 		return nil, err
 	}
 
+	return c.decodeKeyResult(resp)
+}
+
+// RotateKey issues a new secret and prefix for a key, keeping its name, role
+// and permissions. The server invalidates the old secret in the same step.
+// Servers that can't rotate keys report ErrorTypeUnsupported.
+func (c *Client) RotateKey(id int) (*CreateKeyResult, error) {
+	resp, err := c.doAPIRequest("POST", fmt.Sprintf("/keys/%d/rotate", id), nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		resp.Body.Close()
+		return nil, &APIError{
+			Type:    ErrorTypeUnsupported,
+			Message: "this server does not support rotating keys",
+			Status:  resp.StatusCode,
+		}
+	}
+
+	return c.decodeKeyResult(resp)
+}
+
+func (c *Client) decodeKeyResult(resp *http.Response) (*CreateKeyResult, error) {
 	var result struct {
 		Success bool            `json:"success"`
 		Data    CreateKeyResult `json:"data"`
@@ -582,6 +1301,33 @@ func (c *Client) CreateKey(input CreateKeyInput) (*CreateKeyResult, error) {
 	return &result.Data, nil
 }
 
+// UpdateKey renames a key or changes its role, permissions or expiration
+// without replacing its secret. Servers that can't edit keys report
+// ErrorTypeUnsupported.
+func (c *Client) UpdateKey(id int, input UpdateKeyInput) error {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("failed to marshal input: %w", err)
+	}
+
+	resp, err := c.doAPIRequest("PATCH", fmt.Sprintf("/keys/%d", id), bytes.NewReader(body), "application/json")
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		resp.Body.Close()
+		return &APIError{
+			Type:    ErrorTypeUnsupported,
+			Message: "this server does not support editing keys",
+			Status:  resp.StatusCode,
+		}
+	}
+
+	return c.handleResponse(resp, nil)
+}
+
 func (c *Client) RevokeKey(id int) error {
 	resp, err := c.doAPIRequest("DELETE", fmt.Sprintf("/keys/%d", id), nil, "")
 	if err != nil {
@@ -592,43 +1338,93 @@ func (c *Client) RevokeKey(id int) error {
 
 // File upload helper
 func (c *Client) uploadAPIFile(endpoint, filePath string) (*InstallResult, error) {
-	if err := c.Discover(); err != nil {
+	return c.uploadAPIFileCtx(c.context(), endpoint, filePath, nil, nil)
+}
+
+// uploadAPIFileCtx uploads filePath to an API endpoint. Uploads that can't
+// reach the server are tried again as set by WithUploadRetry, telling
+// onRetry, when set, before each new attempt.
+func (c *Client) uploadAPIFileCtx(ctx context.Context, endpoint, filePath string, onProgress ProgressFunc, onRetry RetryFunc) (*InstallResult, error) {
+	if err := c.discoverCtx(ctx); err != nil {
 		return nil, err
 	}
-	return c.uploadFile(joinPath(c.apiPath, endpoint), filePath)
+
+	delay := c.uploadRetryDelay
+	for attempt := 1; ; attempt++ {
+		result, err := c.uploadAPIFileOnce(ctx, endpoint, filePath, onProgress)
+		if err == nil || attempt >= c.uploadAttempts || !uploadRetryable(err) || ctx.Err() != nil {
+			return result, err
+		}
+		if onRetry != nil {
+			onRetry(attempt+1, c.uploadAttempts, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, c.classifyError(ctx.Err())
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
 }
 
-func (c *Client) uploadFile(endpoint, filePath string) (*InstallResult, error) {
+// uploadAPIFileOnce makes a single upload attempt, with its own timeout
+func (c *Client) uploadAPIFileOnce(ctx context.Context, endpoint, filePath string, onProgress ProgressFunc) (*InstallResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.uploadTimeoutOrDefault())
+	defer cancel()
+	return c.uploadFile(ctx, joinPath(c.apiPath, endpoint), filePath, onProgress)
+}
+
+// uploadFile posts filePath as a multipart form, reporting the bytes sent to
+// onProgress when it isn't nil. The form is written into a pipe as the
+// request reads it, so the archive is streamed from disk rather than held in
+// memory. Cancelling ctx aborts the upload.
+func (c *Client) uploadFile(ctx context.Context, endpoint, filePath string, onProgress ProgressFunc) (*InstallResult, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
 	defer file.Close()
 
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-
-	part, err := writer.CreateFormFile("file", filepath.Base(filePath))
+	info, err := file.Stat()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create form file: %w", err)
+		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
 
-	if _, err := io.Copy(part, file); err != nil {
-		return nil, fmt.Errorf("failed to copy file: %w", err)
+	if err := ctx.Err(); err != nil {
+		return nil, c.classifyError(err)
 	}
 
-	if err := writer.Close(); err != nil {
-		return nil, fmt.Errorf("failed to close writer: %w", err)
+	pr, pw := io.Pipe()
+	// Stops the writer below when the request ends without reading it all
+	defer pr.Close()
+
+	writer := multipart.NewWriter(pw)
+	size, err := multipartSize(writer.Boundary(), filepath.Base(filePath), info.Size())
+	if err != nil {
+		return nil, err
 	}
 
-	resp, err := c.doRequest("POST", endpoint, body, writer.FormDataContentType())
+	go func() {
+		part, err := writer.CreateFormFile("file", filepath.Base(filePath))
+		if err == nil {
+			_, err = io.Copy(part, file)
+		}
+		if err == nil {
+			err = writer.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	body := withProgress(sizedReader{Reader: pr, size: size}, size, 0, size, onProgress)
+	resp, err := c.doRequestCtx(ctx, "POST", endpoint, body, writer.FormDataContentType())
 	if err != nil {
 		return nil, err
 	}
 
 	var raw json.RawMessage
 	if err := c.handleResponse(resp, &raw); err != nil {
-		return nil, err
+		return nil, versionConflict(err)
 	}
 
 	result, err := parseInstallResult(raw)
@@ -646,8 +1442,10 @@ func parseInstallResult(raw json.RawMessage) (*InstallResult, error) {
 	}
 
 	var wrapped struct {
-		Data struct {
-			App struct {
+		Warnings []string `json:"warnings"`
+		Data     struct {
+			Warnings []string `json:"warnings"`
+			App      struct {
 				InstalledAt string `json:"installedAt"`
 				Name        string `json:"name"`
 				Version     string `json:"version"`
@@ -663,18 +1461,23 @@ func parseInstallResult(raw json.RawMessage) (*InstallResult, error) {
 		return nil, err
 	}
 
+	// Warnings may sit next to the data or inside it
+	warnings := append(wrapped.Warnings, wrapped.Data.Warnings...)
+
 	if wrapped.Data.App.Name != "" {
 		return &InstallResult{
-			Name:    wrapped.Data.App.Name,
-			Path:    wrapped.Data.App.InstalledAt,
-			Version: wrapped.Data.App.Version,
+			Name:     wrapped.Data.App.Name,
+			Path:     wrapped.Data.App.InstalledAt,
+			Version:  wrapped.Data.App.Version,
+			Warnings: warnings,
 		}, nil
 	}
 	if wrapped.Data.Plugin.Name != "" {
 		return &InstallResult{
-			Name:    wrapped.Data.Plugin.Name,
-			Path:    wrapped.Data.Plugin.InstalledAt,
-			Version: wrapped.Data.Plugin.Version,
+			Name:     wrapped.Data.Plugin.Name,
+			Path:     wrapped.Data.Plugin.InstalledAt,
+			Version:  wrapped.Data.Plugin.Version,
+			Warnings: warnings,
 		}, nil
 	}
 
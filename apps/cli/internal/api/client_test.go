@@ -1,12 +1,20 @@
 package api
 
 import (
+	"archive/zip"
+	"compress/gzip"
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 type roundTripFunc func(*http.Request) (*http.Response, error)
@@ -165,3 +173,1695 @@ func TestInstallPluginUsesDiscoveredAPIPathOriginAndReload(t *testing.T) {
 		t.Fatal("expected reload request")
 	}
 }
+
+func writeTestZip(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	archive, err := os.CreateTemp(t.TempDir(), "package-*.zip")
+	if err != nil {
+		t.Fatalf("CreateTemp() error = %v", err)
+	}
+	writer := zip.NewWriter(archive)
+	for name, content := range files {
+		w, err := writer.Create(name)
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	return archive.Name()
+}
+
+func TestReadArchivePackageDetectsType(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		files map[string]string
+		want  string
+	}{
+		"app manifest":      {map[string]string{"manifest.yaml": "name: my-app\nentrypoint: index.ts\n"}, "app"},
+		"package.json only": {map[string]string{"package.json": `{"name":"my-app"}`}, "app"},
+		"plugin entry":      {map[string]string{"manifest.yaml": "name: my-plugin\npluginEntry: dist/plugin.js\n"}, "plugin"},
+		"plugin base":       {map[string]string{"manifest.yaml": "name: my-plugin\nbase: \"/my\"\n"}, "plugin"},
+		"plugin file":       {map[string]string{"manifest.yaml": "name: my-plugin\n", "plugin.ts": ""}, "plugin"},
+		"unknown":           {map[string]string{"manifest.yaml": "name: something\n"}, ""},
+	}
+
+	for name, tt := range tests {
+		pkg, err := ReadArchivePackage(writeTestZip(t, tt.files))
+		if err != nil {
+			t.Fatalf("%s: ReadArchivePackage() error = %v", name, err)
+		}
+		if pkg.Type != tt.want {
+			t.Errorf("%s: Type = %q, want %q", name, pkg.Type, tt.want)
+		}
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "manifest.yaml"), []byte("name: my-plugin\npluginEntry: dist/plugin.js\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	pkg, err := ReadArchivePackage(dir)
+	if err != nil || pkg.Name != "my-plugin" || pkg.Type != "plugin" {
+		t.Fatalf("ReadArchivePackage(dir) = %+v, %v", pkg, err)
+	}
+}
+
+func TestInstallAppRefusesExistingVersionWithoutForce(t *testing.T) {
+	t.Parallel()
+
+	var uploadSeen bool
+
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/api/apps":
+			return testResponse(http.StatusOK, `[{"name":"my-app","path":"/data/apps/my-app","versions":["1.0.0"]}]`), nil
+		case "/api/apps/upload":
+			uploadSeen = true
+			return testResponse(http.StatusOK, `{"name":"my-app","version":"1.0.0","path":"/data/apps/my-app/1.0.0"}`), nil
+		default:
+			return testResponse(http.StatusNotFound, ""), nil
+		}
+	})
+
+	archive := writeTestZip(t, map[string]string{
+		"manifest.yaml": "name: my-app\nversion: \"1.0.0\" # pinned\nentrypoint: index.ts\n",
+	})
+
+	_, err := client.InstallApp(archive)
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.Type != ErrorTypeVersionExists {
+		t.Fatalf("expected version exists error, got %v", err)
+	}
+	if uploadSeen {
+		t.Fatal("expected upload to be skipped")
+	}
+}
+
+func TestInstallAppWithForceRequestsOverwrite(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/api/apps":
+			t.Fatal("expected version check to be skipped with force")
+		case "/api/apps/upload":
+			if got := r.URL.Query().Get("overwrite"); got != "true" {
+				t.Fatalf("expected overwrite=true, got %q", got)
+			}
+			return testResponse(http.StatusOK, `{"name":"my-app","version":"1.0.0","path":"/data/apps/my-app/1.0.0"}`), nil
+		}
+		return testResponse(http.StatusNotFound, ""), nil
+	})
+
+	archive := writeTestZip(t, map[string]string{
+		"package/package.json": `{"name":"my-app","version":"1.0.0"}`,
+	})
+
+	result, err := client.InstallAppWithOptions(archive, InstallOptions{Force: true})
+	if err != nil {
+		t.Fatalf("InstallAppWithOptions() error = %v", err)
+	}
+	if result.Version != "1.0.0" {
+		t.Fatalf("unexpected install result: %#v", result)
+	}
+}
+
+func TestInstallAppReportsAServerConflictAsVersionExists(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/api/apps":
+			return testResponse(http.StatusOK, `[]`), nil
+		case "/api/apps/upload":
+			return testResponse(http.StatusConflict, `{"code":"VERSION_EXISTS","message":"1.0.0 is installed"}`), nil
+		}
+		return testResponse(http.StatusNotFound, ""), nil
+	})
+
+	archive := writeTestZip(t, map[string]string{
+		"package/package.json": `{"name":"my-app","version":"1.0.0"}`,
+	})
+
+	_, err := client.InstallApp(archive)
+	if apiErr, ok := err.(*APIError); !ok || apiErr.Type != ErrorTypeVersionExists || apiErr.Message != "Version already exists (409): 1.0.0 is installed" {
+		t.Fatalf("expected a version exists error, got %#v", err)
+	}
+}
+
+func TestVerifyHealthReportsUnhealthyServer(t *testing.T) {
+	t.Parallel()
+
+	checks := 0
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path != "/api/health" {
+			return testResponse(http.StatusNotFound, ""), nil
+		}
+		checks++
+		if checks == 1 {
+			return testResponse(http.StatusOK, `{"ok":true,"status":"healthy"}`), nil
+		}
+		return testResponse(http.StatusOK, `{"ok":false,"status":"degraded"}`), nil
+	})
+
+	err := client.VerifyHealth(time.Second, time.Millisecond)
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.Type != ErrorTypeUnhealthy {
+		t.Fatalf("expected unhealthy error, got %v", err)
+	}
+	if checks != 2 {
+		t.Fatalf("expected verification to stop at the first failure, got %d checks", checks)
+	}
+}
+
+func TestInstallResumableResumesFromAcknowledgedOffset(t *testing.T) {
+	t.Parallel()
+
+	archive := writeTestZip(t, map[string]string{"package.json": `{"name":"big-app","version":"2.0.0"}`})
+	content, err := os.ReadFile(archive)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+
+	var received []byte
+	failedOnce := false
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch {
+		case r.URL.Path == "/api/apps" && r.Method == http.MethodGet:
+			return testResponse(http.StatusOK, `[]`), nil
+		case r.URL.Path == "/api/apps/upload/sessions" && r.Method == http.MethodPost:
+			return testResponse(http.StatusCreated, `{"id":"s1","offset":0}`), nil
+		case r.URL.Path == "/api/apps/upload/sessions/s1" && r.Method == http.MethodGet:
+			return testResponse(http.StatusOK, fmt.Sprintf(`{"id":"s1","offset":%d}`, len(received))), nil
+		case r.URL.Path == "/api/apps/upload/sessions/s1" && r.Method == http.MethodPut:
+			chunk, _ := io.ReadAll(r.Body)
+			// Accept half of the second chunk, then drop the connection
+			if len(received) > 0 && !failedOnce {
+				failedOnce = true
+				received = append(received, chunk[:len(chunk)/2]...)
+				return nil, fmt.Errorf("connection reset by peer")
+			}
+			received = append(received, chunk...)
+			return testResponse(http.StatusOK, fmt.Sprintf(`{"id":"s1","offset":%d}`, len(received))), nil
+		case r.URL.Path == "/api/apps/upload/sessions/s1/complete":
+			return testResponse(http.StatusOK, `{"success":true,"data":{"app":{"installedAt":"/data/apps/big-app/2.0.0","name":"big-app","version":"2.0.0"}}}`), nil
+		default:
+			return testResponse(http.StatusNotFound, ""), nil
+		}
+	})
+
+	var lastSent, lastTotal int64
+	var retries []string
+	result, err := client.InstallResumable("app", archive, ResumableOptions{
+		ChunkSize:  64,
+		OnProgress: func(sent, total int64) { lastSent, lastTotal = sent, total },
+		OnRetry: func(attempt, maxAttempts int, _ error) {
+			retries = append(retries, fmt.Sprintf("%d of %d", attempt, maxAttempts))
+		},
+	})
+	if err != nil {
+		t.Fatalf("InstallResumable() error = %v", err)
+	}
+	if result.Name != "big-app" || result.Version != "2.0.0" {
+		t.Fatalf("unexpected install result: %#v", result)
+	}
+	if lastTotal != int64(len(content)) || lastSent != lastTotal {
+		t.Fatalf("last progress %d/%d, want the whole %d-byte archive", lastSent, lastTotal, len(content))
+	}
+	if !failedOnce || strings.Join(retries, ", ") != "1 of 3" {
+		t.Fatalf("expected a failed chunk to be resumed as retry 1 of 3, got %v", retries)
+	}
+	if string(received) != string(content) {
+		t.Fatalf("server received %d bytes, expected the %d-byte archive intact", len(received), len(content))
+	}
+}
+
+func TestInstallResumableDoesNotResumeRejectedChunks(t *testing.T) {
+	t.Parallel()
+
+	archive := writeTestZip(t, map[string]string{"package.json": `{"name":"big-app","version":"2.0.0"}`})
+	chunks := 0
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch {
+		case r.URL.Path == "/api/apps" && r.Method == http.MethodGet:
+			return testResponse(http.StatusOK, `[]`), nil
+		case r.URL.Path == "/api/apps/upload/sessions" && r.Method == http.MethodPost:
+			return testResponse(http.StatusCreated, `{"id":"s1","offset":0}`), nil
+		case r.URL.Path == "/api/apps/upload/sessions/s1" && r.Method == http.MethodPut:
+			chunks++
+			return testResponse(http.StatusRequestEntityTooLarge, `{"message":"chunk too large"}`), nil
+		default:
+			return testResponse(http.StatusNotFound, ""), nil
+		}
+	})
+
+	_, err := client.InstallResumable("app", archive, ResumableOptions{
+		ChunkSize: 64,
+		OnRetry:   func(int, int, error) { t.Error("expected no retry") },
+	})
+	if apiErr, ok := err.(*APIError); !ok || apiErr.Status != http.StatusRequestEntityTooLarge || chunks != 1 {
+		t.Fatalf("expected the 413 after one chunk, got %v after %d chunks", err, chunks)
+	}
+}
+
+func TestInstallResumableFallsBackWithoutSessionSupport(t *testing.T) {
+	t.Parallel()
+
+	archive := writeTestZip(t, map[string]string{"package.json": `{"name":"big-app","version":"2.0.0"}`})
+
+	var uploadSeen bool
+	var contentLength int64
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/api/apps":
+			return testResponse(http.StatusOK, `[]`), nil
+		case "/api/apps/upload":
+			uploadSeen = true
+			contentLength = r.ContentLength
+			io.Copy(io.Discard, r.Body)
+			return testResponse(http.StatusOK, `{"success":true,"data":{"app":{"installedAt":"/data/apps/big-app/2.0.0","name":"big-app","version":"2.0.0"}}}`), nil
+		default:
+			return testResponse(http.StatusNotFound, ""), nil
+		}
+	})
+
+	var calls int
+	var lastSent, lastTotal int64
+	opts := ResumableOptions{
+		ChunkSize:  64,
+		OnProgress: func(sent, total int64) { calls, lastSent, lastTotal = calls+1, sent, total },
+	}
+	if _, err := client.InstallResumable("app", archive, opts); err != nil {
+		t.Fatalf("InstallResumable() error = %v", err)
+	}
+	if !uploadSeen {
+		t.Fatal("expected whole-file upload fallback")
+	}
+	if calls == 0 || lastSent != lastTotal || lastTotal != contentLength {
+		t.Fatalf("progress %d/%d after %d calls, want the whole %d-byte body", lastSent, lastTotal, calls, contentLength)
+	}
+}
+
+func TestProvenanceHeadersOnlyOnStateChangingRequests(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		tool, installedBy := r.Header.Get("X-Buntime-Client"), r.Header.Get("X-Buntime-Installed-By")
+		switch r.Method {
+		case http.MethodGet:
+			if tool != "" || installedBy != "" {
+				t.Fatalf("expected no provenance on %s %s", r.Method, r.URL.Path)
+			}
+			return testResponse(http.StatusOK, `[]`), nil
+		default:
+			if tool != "buntime-cli/9.9.9" || installedBy != "alice@build-01" {
+				t.Fatalf("unexpected provenance headers %q / %q", tool, installedBy)
+			}
+			return testResponse(http.StatusOK, `{}`), nil
+		}
+	})
+	client.SetProvenance(&Provenance{Client: "buntime-cli/9.9.9", InstalledBy: "alice@build-01"})
+
+	if _, err := client.ListApps(); err != nil {
+		t.Fatalf("ListApps() error = %v", err)
+	}
+	if err := client.RemoveApp("my-app", "1.0.0"); err != nil {
+		t.Fatalf("RemoveApp() error = %v", err)
+	}
+}
+
+func TestSelectorMatchesLabels(t *testing.T) {
+	t.Parallel()
+
+	labels := Labels{"env": "prod", "tier": "web"}
+	tests := []struct {
+		selector string
+		want     bool
+	}{
+		{"", true},
+		{"env=prod", true},
+		{"env==prod", true},
+		{"env=staging", false},
+		{"env!=staging", true},
+		{"env!=prod", false},
+		{"tier", true},
+		{"owner", false},
+		{"!owner", true},
+		{"!tier", false},
+		{"env=prod, tier=web", true},
+		{"env=prod,tier=api", false},
+		{"owner!=ops", true},
+	}
+
+	for _, tt := range tests {
+		sel, err := ParseSelector(tt.selector)
+		if err != nil {
+			t.Fatalf("ParseSelector(%q) error = %v", tt.selector, err)
+		}
+		if got := sel.Matches(labels); got != tt.want {
+			t.Errorf("%q.Matches(%v) = %v, want %v", tt.selector, labels, got, tt.want)
+		}
+	}
+
+	for _, invalid := range []string{"env=prod,", "=prod", "!", "my key=x"} {
+		if _, err := ParseSelector(invalid); err == nil {
+			t.Errorf("ParseSelector(%q) expected an error", invalid)
+		}
+	}
+}
+
+func TestListAppsMatchingFiltersOnListedLabels(t *testing.T) {
+	t.Parallel()
+
+	listed := `[
+		{"name":"@acme/shop","versions":["1.0.0"],"labels":{"env":"prod"}},
+		{"name":"blog","versions":["2.0.0"],"labels":{"env":"staging"}},
+		{"name":"docs","versions":["1.0.0"]}
+	]`
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/.well-known/buntime":
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		case "/api/apps":
+			return testResponse(http.StatusOK, listed), nil
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			return testResponse(http.StatusNotFound, ""), nil
+		}
+	})
+
+	sel, err := ParseSelector("env=prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	apps, err := client.ListAppsMatching(sel)
+	if err != nil {
+		t.Fatalf("ListAppsMatching() error = %v", err)
+	}
+	if len(apps) != 1 || apps[0].Name != "@acme/shop" {
+		t.Fatalf("expected only @acme/shop, got %#v", apps)
+	}
+
+	// A list without any labels can't be filtered
+	listed = `[{"name":"blog","versions":["2.0.0"]}]`
+	_, err = client.ListAppsMatching(sel)
+	if apiErr, ok := err.(*APIError); !ok || apiErr.Type != ErrorTypeUnsupported || apiErr.Message != "selector not supported by this server" {
+		t.Fatalf("expected the selector to be unsupported, got %v", err)
+	}
+}
+
+func TestGetLabelsReportsUnsupportedServer(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/.well-known/buntime":
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		default:
+			return testResponse(http.StatusNotFound, "404 Not Found"), nil
+		}
+	})
+
+	_, err := client.GetLabels("plugin", "auth")
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.Type != ErrorTypeUnsupported {
+		t.Fatalf("expected an unsupported error, got %v", err)
+	}
+}
+
+func TestClockSkewFromDateHeader(t *testing.T) {
+	t.Parallel()
+
+	// The server's clock is an hour behind the local one
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		resp := testResponse(http.StatusOK, `[]`)
+		resp.Header.Set("Date", time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat))
+		return resp, nil
+	})
+
+	if _, ok := client.ClockSkew(); ok {
+		t.Fatal("expected the skew to be unknown before any request")
+	}
+	if _, err := client.ListPlugins(); err != nil {
+		t.Fatalf("ListPlugins() error = %v", err)
+	}
+
+	skew, ok := client.ClockSkew()
+	if !ok || (skew-time.Hour).Abs() > 2*time.Second {
+		t.Fatalf("expected a skew of about 1h, got %v (known %v)", skew, ok)
+	}
+	if !client.ClockSkewed() {
+		t.Fatal("expected a 1h skew to be over the threshold")
+	}
+	if drift := time.Until(client.ServerNow().Add(time.Hour)).Abs(); drift > 2*time.Second {
+		t.Fatalf("ServerNow() is off by %v", drift)
+	}
+}
+
+func TestGetKeyMetaReportsUnsupportedServer(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/.well-known/buntime":
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		default:
+			return testResponse(http.StatusNotFound, "404 Not Found"), nil
+		}
+	})
+
+	_, err := client.GetKeyMeta()
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.Type != ErrorTypeUnsupported {
+		t.Fatalf("expected an unsupported error, got %v", err)
+	}
+}
+
+func TestUpdateKeySendsOnlyTheChangedFields(t *testing.T) {
+	t.Parallel()
+
+	var method, path, body string
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/.well-known/buntime":
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		default:
+			data, _ := io.ReadAll(r.Body)
+			method, path, body = r.Method, r.URL.Path, string(data)
+			return testResponse(http.StatusOK, `{"success":true}`), nil
+		}
+	})
+
+	name := "deploy"
+	if err := client.UpdateKey(7, UpdateKeyInput{Name: &name}); err != nil {
+		t.Fatalf("UpdateKey() error = %v", err)
+	}
+	if method != "PATCH" || path != "/api/keys/7" {
+		t.Fatalf("got %s %s", method, path)
+	}
+	if body != `{"name":"deploy"}` {
+		t.Fatalf("body = %s", body)
+	}
+}
+
+func TestUpdateKeyReportsValidationErrors(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/.well-known/buntime":
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		default:
+			return testResponse(http.StatusBadRequest, `{"success":false,"code":"VALIDATION_ERROR","message":"Name is already taken"}`), nil
+		}
+	})
+
+	name := "deploy"
+	err := client.UpdateKey(7, UpdateKeyInput{Name: &name})
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.Code != "VALIDATION_ERROR" || !strings.Contains(apiErr.Message, "Name is already taken") {
+		t.Fatalf("expected the server's validation error, got %v", err)
+	}
+}
+
+func TestRotateKeyReturnsTheNewSecret(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch {
+		case r.URL.Path == "/.well-known/buntime":
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		case r.Method == http.MethodPost && r.URL.Path == "/api/keys/7/rotate":
+			return testResponse(http.StatusOK, `{"success":true,"data":{"id":7,"name":"ci","key":"btk_new","keyPrefix":"btk_ne","role":"editor"}}`), nil
+		}
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		return testResponse(http.StatusNotFound, "404 Not Found"), nil
+	})
+
+	result, err := client.RotateKey(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Key != "btk_new" || result.Name != "ci" || result.Role != KeyRoleEditor {
+		t.Fatalf("unexpected result %+v", result)
+	}
+}
+
+func TestActivateAppVersionPutsTheVersion(t *testing.T) {
+	t.Parallel()
+
+	var paths []string
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/.well-known/buntime" {
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		}
+		if r.Method != http.MethodPut {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		paths = append(paths, r.URL.Path)
+		return testResponse(http.StatusOK, `{"success":true}`), nil
+	})
+
+	if err := client.ActivateAppVersion("@acme/shop", "1.2.0"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.ActivateAppVersion("blog", "0.9.1"); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"/api/apps/@acme/shop/1.2.0/activate", "/api/apps/_/blog/0.9.1/activate"}
+	if strings.Join(paths, " ") != strings.Join(want, " ") {
+		t.Fatalf("paths = %v, want %v", paths, want)
+	}
+}
+
+func TestActivateAppVersionReportsUnsupportedServers(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/.well-known/buntime" {
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		}
+		return testResponse(http.StatusMethodNotAllowed, "Method Not Allowed"), nil
+	})
+
+	err := client.ActivateAppVersion("blog", "0.9.1")
+	if apiErr, ok := err.(*APIError); !ok || apiErr.Type != ErrorTypeUnsupported {
+		t.Fatalf("expected an unsupported error, got %v", err)
+	}
+}
+
+func TestRollbackKeepsWhatWasInstalledBefore(t *testing.T) {
+	t.Parallel()
+
+	var requests []string
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/.well-known/buntime":
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		case "/api/apps":
+			return testResponse(http.StatusOK, `[{"name":"shop","versions":["1.1.0","1.0.0"],"activeVersion":"1.0.0"}]`), nil
+		case "/api/plugins":
+			return testResponse(http.StatusOK, `[{"name":"auth","versions":["2.0.0"]}]`), nil
+		}
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		return testResponse(http.StatusOK, `{"success":true}`), nil
+	})
+
+	undo, err := client.PrepareRollback("app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Rollback(undo, &InstallResult{Name: "shop", Version: "1.2.0"}); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"DELETE /api/apps/_/shop/1.2.0", "PUT /api/apps/_/shop/1.0.0/activate"}
+	if strings.Join(requests, ", ") != strings.Join(want, ", ") {
+		t.Fatalf("requests = %v, want %v", requests, want)
+	}
+
+	// Servers without the activate route fall back on their own once the new
+	// version is gone
+	notFound := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch {
+		case r.URL.Path == "/.well-known/buntime":
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		case r.URL.Path == "/api/apps":
+			return testResponse(http.StatusOK, `[{"name":"shop","versions":["1.0.0"],"activeVersion":"1.0.0"}]`), nil
+		case strings.HasSuffix(r.URL.Path, "/activate"):
+			return testResponse(http.StatusNotFound, "404 Not Found"), nil
+		}
+		return testResponse(http.StatusOK, `{"success":true}`), nil
+	})
+	notFoundUndo, err := notFound.PrepareRollback("app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := notFound.Rollback(notFoundUndo, &InstallResult{Name: "shop", Version: "1.1.0"}); err != nil {
+		t.Fatalf("expected the rollback to succeed without the activate route, got %v", err)
+	}
+
+	// An overwritten version is gone for good
+	requests = nil
+	if err := client.Rollback(undo, &InstallResult{Name: "shop", Version: "1.1.0"}); err == nil || len(requests) > 0 {
+		t.Fatalf("expected the rollback of a replaced version to be refused, got %v after %v", err, requests)
+	}
+
+	// Removing a plugin takes every version with it
+	undo, err = client.PrepareRollback("plugin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Rollback(undo, &InstallResult{Name: "auth", Version: "2.1.0"}); err == nil || len(requests) > 0 {
+		t.Fatalf("expected the rollback of an upgraded plugin to be refused, got %v after %v", err, requests)
+	}
+	if err := client.Rollback(undo, &InstallResult{Name: "cache", Version: "1.0.0"}); err != nil {
+		t.Fatal(err)
+	}
+	want = []string{"DELETE /api/plugins/cache", "POST /api/plugins/reload"}
+	if strings.Join(requests, ", ") != strings.Join(want, ", ") {
+		t.Fatalf("requests = %v, want %v", requests, want)
+	}
+}
+
+func TestToggleAppUsesTheAppPath(t *testing.T) {
+	t.Parallel()
+
+	var requests []string
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/.well-known/buntime" {
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		}
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		return testResponse(http.StatusOK, `{"success":true}`), nil
+	})
+
+	if err := client.DisableApp("@acme/shop"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.EnableApp("blog"); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"PUT /api/apps/@acme/shop/disable", "PUT /api/apps/_/blog/enable"}
+	if strings.Join(requests, ", ") != strings.Join(want, ", ") {
+		t.Fatalf("requests = %v, want %v", requests, want)
+	}
+}
+
+func TestAppsWithoutEnabledAreEnabled(t *testing.T) {
+	t.Parallel()
+
+	var apps []AppInfo
+	if err := json.Unmarshal([]byte(`[{"name":"shop"},{"name":"blog","enabled":false}]`), &apps); err != nil {
+		t.Fatal(err)
+	}
+	if !apps[0].Enabled || apps[1].Enabled {
+		t.Fatalf("expected only blog disabled, got %+v", apps)
+	}
+}
+
+func TestAppCurrentVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		app  AppInfo
+		want string
+	}{
+		{AppInfo{Versions: []string{"2.0.0", "1.0.0"}, ActiveVersion: "1.0.0"}, "1.0.0"},
+		{AppInfo{Versions: []string{"2.0.0", "1.0.0"}}, "2.0.0"},
+		{AppInfo{Versions: []string{"2.0.0"}, ActiveVersion: "3.0.0"}, "2.0.0"},
+		{AppInfo{}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.app.CurrentVersion(); got != tt.want {
+			t.Errorf("CurrentVersion() of %+v = %q, want %q", tt.app, got, tt.want)
+		}
+	}
+}
+
+func TestLargeJSONBodiesAreGzippedWhenTheServerAcceptsIt(t *testing.T) {
+	t.Parallel()
+
+	description := strings.Repeat("deploy key for the nightly pipeline ", 500)
+
+	for _, advertised := range []bool{true, false} {
+		discovery := `{"api":"/api"}`
+		if advertised {
+			discovery = `{"api":"/api","requestEncodings":["gzip"]}`
+		}
+
+		client := newTestClient(func(r *http.Request) (*http.Response, error) {
+			switch r.URL.Path {
+			case "/.well-known/buntime":
+				return testResponse(http.StatusOK, discovery), nil
+			case "/api/keys":
+				body := io.Reader(r.Body)
+				gzipped := r.Header.Get("Content-Encoding") == "gzip"
+				if gzipped != advertised {
+					t.Errorf("advertised %v: Content-Encoding = %q", advertised, r.Header.Get("Content-Encoding"))
+				}
+				if gzipped {
+					zr, err := gzip.NewReader(r.Body)
+					if err != nil {
+						t.Fatalf("gzip.NewReader() error = %v", err)
+					}
+					body = zr
+				}
+				var input CreateKeyInput
+				if err := json.NewDecoder(body).Decode(&input); err != nil || input.Description != description {
+					t.Errorf("advertised %v: body did not round-trip (%v)", advertised, err)
+				}
+				return testResponse(http.StatusOK, `{"id":1,"name":"ci"}`), nil
+			default:
+				return testResponse(http.StatusNotFound, "404 Not Found"), nil
+			}
+		})
+
+		if _, err := client.CreateKey(CreateKeyInput{Name: "ci", Role: KeyRoleEditor, Description: description}); err != nil {
+			t.Fatalf("advertised %v: CreateKey() error = %v", advertised, err)
+		}
+	}
+}
+
+func TestGetActivitySendsFiltersAndCursor(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/.well-known/buntime":
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		case "/api/activity":
+			query := r.URL.Query()
+			if query.Get("type") != "app.install" || query.Get("actor") != "ci" || query.Get("limit") != "10" || query.Get("cursor") != "abc" {
+				t.Errorf("unexpected query %q", r.URL.RawQuery)
+			}
+			return testResponse(http.StatusOK, `{"entries":[{"id":"1","timestamp":1700000000,"type":"app.install","actor":"ci","target":"my-app@1.0.0"}],"nextCursor":"def"}`), nil
+		default:
+			return testResponse(http.StatusNotFound, "404 Not Found"), nil
+		}
+	})
+
+	page, err := client.GetActivity(ActivityOptions{Type: "app.install", Actor: "ci", Limit: 10, Cursor: "abc"})
+	if err != nil {
+		t.Fatalf("GetActivity() error = %v", err)
+	}
+	if len(page.Entries) != 1 || page.Entries[0].Target != "my-app@1.0.0" || page.NextCursor != "def" {
+		t.Fatalf("unexpected page %+v", page)
+	}
+}
+
+func TestGetActivityReportsUnsupportedServer(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/.well-known/buntime":
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		default:
+			return testResponse(http.StatusNotFound, "404 Not Found"), nil
+		}
+	})
+
+	_, err := client.GetActivity(ActivityOptions{})
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.Type != ErrorTypeUnsupported {
+		t.Fatalf("expected an unsupported error, got %v", err)
+	}
+}
+
+func TestCheckExpirationAgainstServerMaximum(t *testing.T) {
+	t.Parallel()
+
+	meta := &KeyMetaInfo{MaxExpiration: "90d"}
+	maxDays := meta.MaxExpirationDays()
+	if maxDays != 90 {
+		t.Fatalf("MaxExpirationDays() = %d, want 90", maxDays)
+	}
+
+	for expiresIn, ok := range map[string]bool{"30d": true, "90d": true, "3m": true, "91d": false, "1y": false, "never": false} {
+		if err := CheckExpiration(expiresIn, maxDays); (err == nil) != ok {
+			t.Errorf("CheckExpiration(%q) error = %v, want ok %v", expiresIn, err, ok)
+		}
+	}
+	if err := CheckExpiration("never", 0); err != nil {
+		t.Errorf("expected no limit without a maximum, got %v", err)
+	}
+
+	if got, err := NormalizeExpiration("1y 2m 15d"); err != nil || got != "440d" {
+		t.Errorf("NormalizeExpiration() = %q, %v, want 440d", got, err)
+	}
+}
+
+func TestEnablePluginVersionSendsTheChosenVersion(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]string
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/.well-known/buntime":
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		case "/api/plugins/7/enable":
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+			return testResponse(http.StatusOK, `{"success":true}`), nil
+		default:
+			return testResponse(http.StatusNotFound, "404 Not Found"), nil
+		}
+	})
+
+	plugin := &PluginInfo{ID: 7, Versions: []string{"1.2.0", "1.1.0"}, ActiveVersion: "1.1.0"}
+	if err := client.EnablePluginVersion(plugin.ID, plugin.DefaultEnableVersion()); err != nil {
+		t.Fatalf("EnablePluginVersion() error = %v", err)
+	}
+	if body["version"] != "1.1.0" {
+		t.Fatalf("expected the last active version to be sent, got %v", body)
+	}
+
+	plugin.ActiveVersion = ""
+	if got := plugin.DefaultEnableVersion(); got != "1.2.0" {
+		t.Fatalf("expected the newest version without a last active one, got %q", got)
+	}
+}
+
+func TestListFollowsCursorsAndFallsBackToOffsets(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/.well-known/buntime":
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		case "/api/keys":
+			// Cursor pagination
+			switch query.Get("cursor") {
+			case "":
+				return testResponse(http.StatusOK, `{"keys":[{"id":1,"name":"a"}],"nextCursor":"c2"}`), nil
+			case "c2":
+				return testResponse(http.StatusOK, `{"keys":[{"id":2,"name":"b"}],"nextCursor":null}`), nil
+			}
+		case "/api/apps":
+			// Offset pagination only
+			switch query.Get("offset") {
+			case "":
+				return testResponse(http.StatusOK, `{"apps":[{"name":"a"},{"name":"b"}],"total":3}`), nil
+			case "2":
+				return testResponse(http.StatusOK, `{"apps":[{"name":"c"}],"total":3}`), nil
+			}
+		case "/api/plugins":
+			// No pagination
+			return testResponse(http.StatusOK, `[{"name":"p"}]`), nil
+		}
+		t.Errorf("unexpected request %s", r.URL)
+		return testResponse(http.StatusNotFound, "404 Not Found"), nil
+	})
+
+	keys, err := client.ListKeys()
+	if err != nil || len(keys) != 2 || keys[1].Name != "b" {
+		t.Fatalf("ListKeys() = %v, %v; want both cursor pages", keys, err)
+	}
+	apps, err := client.ListApps()
+	if err != nil || len(apps) != 3 || apps[2].Name != "c" {
+		t.Fatalf("ListApps() = %v, %v; want both offset pages", apps, err)
+	}
+	plugins, err := client.ListPlugins()
+	if err != nil || len(plugins) != 1 {
+		t.Fatalf("ListPlugins() = %v, %v; want the single page", plugins, err)
+	}
+}
+
+func TestListRejectsRepeatedCursors(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/.well-known/buntime" {
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		}
+		return testResponse(http.StatusOK, `{"keys":[{"id":1}],"nextCursor":"same"}`), nil
+	})
+
+	if _, err := client.ListKeys(); err == nil {
+		t.Fatal("expected a cursor loop to fail instead of fetching forever")
+	}
+}
+
+func TestListPageSendsLimitAndReportsTheNextPage(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/.well-known/buntime":
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		case "/api/apps":
+			if query.Get("limit") != "2" {
+				t.Errorf("expected limit=2, got %s", r.URL.RawQuery)
+			}
+			switch query.Get("offset") {
+			case "":
+				return testResponse(http.StatusOK, `{"apps":[{"name":"a"},{"name":"b"}],"total":3}`), nil
+			case "2":
+				return testResponse(http.StatusOK, `{"apps":[{"name":"c"}],"total":3}`), nil
+			}
+		case "/api/keys":
+			if query.Get("cursor") == "" {
+				return testResponse(http.StatusOK, `{"keys":[{"id":1}],"nextCursor":"c2"}`), nil
+			}
+		case "/api/workers":
+			// Pages by offset without telling the total
+			switch query.Get("offset") {
+			case "":
+				return testResponse(http.StatusOK, `{"items":[{"name":"a"},{"name":"b"}]}`), nil
+			case "2":
+				return testResponse(http.StatusOK, `{"items":[{"name":"c"}]}`), nil
+			}
+		case "/api/plugins":
+			// Ignores the limit and sends everything
+			return testResponse(http.StatusOK, `[{"name":"p"},{"name":"q"},{"name":"r"}]`), nil
+		}
+		t.Errorf("unexpected request %s", r.URL)
+		return testResponse(http.StatusNotFound, "404 Not Found"), nil
+	})
+
+	apps, page, err := client.ListAppsPage(ListOptions{Limit: 2})
+	if err != nil || len(apps) != 2 {
+		t.Fatalf("ListAppsPage() = %v, %v; want the first two apps", apps, err)
+	}
+	if page.Total != 3 || !page.HasMore() || page.Next(2) != (ListOptions{Limit: 2, Offset: 2}) {
+		t.Fatalf("unexpected first page %+v", page)
+	}
+	apps, page, err = client.ListAppsPage(page.Next(2))
+	if err != nil || len(apps) != 1 || apps[0].Name != "c" || page.HasMore() {
+		t.Fatalf("ListAppsPage() = %v, %+v, %v; want the last app", apps, page, err)
+	}
+
+	_, page, err = client.ListKeysPage(ListOptions{Limit: 1})
+	if err != nil || page.Next(1) != (ListOptions{Limit: 1, Cursor: "c2"}) || page.Total != -1 {
+		t.Fatalf("ListKeysPage() = %+v, %v; want the cursor of the next page", page, err)
+	}
+
+	first, page, err := listPageOf[AppInfo](client, "/workers", "items", ListOptions{Limit: 2})
+	if err != nil || len(first) != 2 || page.Next(2) != (ListOptions{Limit: 2, Offset: 2}) {
+		t.Fatalf("listPageOf() = %v, %+v, %v; want a next page after a full one", first, page, err)
+	}
+	last, page, err := listPageOf[AppInfo](client, "/workers", "items", page.Next(2))
+	if err != nil || len(last) != 1 || page.HasMore() || page.Total != 3 {
+		t.Fatalf("listPageOf() = %v, %+v, %v; want the short page to be the last", last, page, err)
+	}
+
+	plugins, page, err := client.ListPluginsPage(ListOptions{Limit: 2})
+	if err != nil || len(plugins) != 3 || page.HasMore() || page.Total != 3 {
+		t.Fatalf("ListPluginsPage() = %v, %+v, %v; want the whole list in one page", plugins, page, err)
+	}
+}
+
+func TestForbiddenIsDistinctFromAuthRequired(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/.well-known/buntime":
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		case "/api/keys":
+			return testResponse(http.StatusForbidden, `{"code":"AUTH_REQUIRED"}`), nil
+		default:
+			return testResponse(http.StatusForbidden, `{"code":"FORBIDDEN","error":"missing keys:write"}`), nil
+		}
+	})
+
+	err := client.RevokeKey(7)
+	if apiErr, ok := err.(*APIError); !ok || apiErr.Type != ErrorTypeForbidden {
+		t.Fatalf("expected a permission error, got %v", err)
+	}
+	_, err = client.ListKeys()
+	if apiErr, ok := err.(*APIError); !ok || apiErr.Type != ErrorTypeAuthRequired {
+		t.Fatalf("expected AUTH_REQUIRED to mean authentication, got %v", err)
+	}
+}
+
+func TestParseInstallResultKeepsWarnings(t *testing.T) {
+	t.Parallel()
+
+	flat, err := parseInstallResult([]byte(`{"name":"my-app","version":"1.0.0","warnings":["bundle is 12 MB"]}`))
+	if err != nil || len(flat.Warnings) != 1 || flat.Warnings[0] != "bundle is 12 MB" {
+		t.Fatalf("flat result = %+v, %v; want its warning", flat, err)
+	}
+
+	wrapped, err := parseInstallResult([]byte(`{"warnings":["manifest field \"entry\" is deprecated"],"data":{"warnings":["bundle is 12 MB"],"plugin":{"name":"auth","version":"2.0.0"}}}`))
+	if err != nil || len(wrapped.Warnings) != 2 {
+		t.Fatalf("wrapped result = %+v, %v; want both warnings", wrapped, err)
+	}
+
+	clean, err := parseInstallResult([]byte(`{"data":{"app":{"name":"my-app","version":"1.0.0"}}}`))
+	if err != nil || len(clean.Warnings) != 0 {
+		t.Fatalf("clean result = %+v, %v; want no warnings", clean, err)
+	}
+}
+
+func TestListWorkersAndRestart(t *testing.T) {
+	t.Parallel()
+
+	var restarted string
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch {
+		case r.URL.Path == "/.well-known/buntime":
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		case r.Method == http.MethodGet && r.URL.Path == "/api/workers":
+			return testResponse(http.StatusOK, `{"workers":[{"id":"w-1","pid":4242,"status":"active","app":"my-app@1.0.0","uptimeMs":90000,"requestsHandled":17}]}`), nil
+		case r.Method == http.MethodPost:
+			restarted = r.URL.EscapedPath()
+			return testResponse(http.StatusOK, `{"ok":true}`), nil
+		}
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		return testResponse(http.StatusNotFound, "404 Not Found"), nil
+	})
+
+	workers, err := client.ListWorkers()
+	if err != nil || len(workers) != 1 {
+		t.Fatalf("ListWorkers() = %v, %v", workers, err)
+	}
+	if w := workers[0]; w.PID != 4242 || w.RequestsHandled != 17 || w.Uptime() != 90*time.Second {
+		t.Fatalf("unexpected worker %+v", w)
+	}
+
+	worker, err := client.RestartWorker("my-app/w 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if worker != nil {
+		t.Fatalf("expected no new worker from %s, got %+v", `{"ok":true}`, worker)
+	}
+	if restarted != "/api/workers/my-app%2Fw%201/restart" {
+		t.Fatalf("restart hit %q, want the ID escaped", restarted)
+	}
+}
+
+func TestRestartWorkerReportsTheNewWorker(t *testing.T) {
+	t.Parallel()
+
+	for _, body := range []string{
+		`{"id":"w-2","pid":5151}`,
+		`{"worker":{"id":"w-2","pid":5151}}`,
+		`{"success":true,"data":{"id":"w-2","pid":5151}}`,
+	} {
+		client := newTestClient(func(r *http.Request) (*http.Response, error) {
+			if r.URL.Path == "/.well-known/buntime" {
+				return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+			}
+			return testResponse(http.StatusOK, body), nil
+		})
+
+		worker, err := client.RestartWorker("w-1")
+		if err != nil || worker == nil || worker.PID != 5151 || worker.ID != "w-2" {
+			t.Errorf("%s: got %+v, %v", body, worker, err)
+		}
+	}
+
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/.well-known/buntime" {
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		}
+		return testResponse(http.StatusNoContent, ""), nil
+	})
+	if worker, err := client.RestartWorker("w-1"); err != nil || worker != nil {
+		t.Fatalf("empty response: got %+v, %v", worker, err)
+	}
+}
+
+func TestListWorkersReportsUnsupportedServer(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/.well-known/buntime" {
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		}
+		return testResponse(http.StatusNotFound, "404 Not Found"), nil
+	})
+
+	_, err := client.ListWorkers()
+	if apiErr, ok := err.(*APIError); !ok || apiErr.Type != ErrorTypeUnsupported {
+		t.Fatalf("expected an unsupported error, got %v", err)
+	}
+}
+
+func TestSetWorkerPoolSizeReturnsTheAppliedSize(t *testing.T) {
+	t.Parallel()
+
+	var body string
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch {
+		case r.URL.Path == "/.well-known/buntime":
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		case r.Method == http.MethodPut && r.URL.Path == "/api/workers/pool":
+			data, _ := io.ReadAll(r.Body)
+			body = string(data)
+			return testResponse(http.StatusOK, `{"size":8,"min":1,"max":8}`), nil
+		}
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		return testResponse(http.StatusNotFound, "404 Not Found"), nil
+	})
+
+	pool, err := client.SetWorkerPoolSize(12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body != `{"size":12}` {
+		t.Fatalf("body = %s", body)
+	}
+	if pool.Size != 8 {
+		t.Fatalf("expected the clamped size, got %+v", pool)
+	}
+}
+
+func TestPoolInfoCheckSize(t *testing.T) {
+	pool := PoolInfo{Size: 4, Min: 2, Max: 8}
+	for _, n := range []int{2, 4, 8} {
+		if err := pool.CheckSize(n); err != nil {
+			t.Errorf("CheckSize(%d) = %v", n, err)
+		}
+	}
+	for _, n := range []int{0, 1, 9} {
+		if err := pool.CheckSize(n); err == nil {
+			t.Errorf("CheckSize(%d) accepted a size out of bounds", n)
+		}
+	}
+	if err := (PoolInfo{Size: 4}).CheckSize(0); err == nil {
+		t.Error("expected an empty pool to be refused without bounds")
+	}
+}
+
+func TestGetPluginDecodesDetails(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/.well-known/buntime":
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		case "/api/plugins/7":
+			return testResponse(http.StatusOK, `{"id":7,"name":"cache","path":"/plugins/cache","description":"Response cache","author":"Zomme","dependencies":["auth"],"configSchema":{"type":"object"}}`), nil
+		case "/api/plugins/8":
+			return testResponse(http.StatusMethodNotAllowed, ""), nil
+		}
+		t.Errorf("unexpected request %s", r.URL)
+		return testResponse(http.StatusNotFound, "404 Not Found"), nil
+	})
+
+	detail, err := client.GetPlugin(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detail.Name != "cache" || detail.Description != "Response cache" || detail.Author != "Zomme" || len(detail.Dependencies) != 1 {
+		t.Fatalf("unexpected detail %+v", detail)
+	}
+	if !detail.Enabled || len(detail.Versions) != 1 || detail.Versions[0] != "latest" {
+		t.Fatalf("expected the detail to be normalized like the list, got %+v", detail.PluginInfo)
+	}
+	if string(detail.ConfigSchema) != `{"type":"object"}` || detail.Manifest != nil {
+		t.Fatalf("unexpected raw fields %s %s", detail.ConfigSchema, detail.Manifest)
+	}
+
+	_, err = client.GetPlugin(8)
+	if apiErr, ok := err.(*APIError); !ok || apiErr.Type != ErrorTypeUnsupported {
+		t.Fatalf("expected an unsupported error, got %v", err)
+	}
+}
+
+func TestPluginConfigRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	var saved map[string]any
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch {
+		case r.URL.Path == "/.well-known/buntime":
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		case r.Method == http.MethodGet && r.URL.Path == "/api/plugins/7/config":
+			return testResponse(http.StatusOK, `{"cacheTtl":60,"enabled":true}`), nil
+		case r.Method == http.MethodPost && r.URL.Path == "/api/plugins/7/config":
+			if err := json.NewDecoder(r.Body).Decode(&saved); err != nil {
+				t.Error(err)
+			}
+			return testResponse(http.StatusOK, `{"ok":true}`), nil
+		}
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		return testResponse(http.StatusNotFound, "404 Not Found"), nil
+	})
+
+	cfg, err := client.GetPluginConfig(7)
+	if err != nil || cfg["cacheTtl"] != float64(60) || cfg["enabled"] != true {
+		t.Fatalf("GetPluginConfig() = %v, %v", cfg, err)
+	}
+
+	cfg["cacheTtl"] = float64(120)
+	if err := client.SetPluginConfig(7, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if saved["cacheTtl"] != float64(120) || saved["enabled"] != true {
+		t.Fatalf("expected the whole config to be posted, got %v", saved)
+	}
+}
+
+func TestPluginConfigReportsUnsupportedServer(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/.well-known/buntime" {
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		}
+		return testResponse(http.StatusNotImplemented, "Not Implemented"), nil
+	})
+
+	_, err := client.GetPluginConfig(7)
+	if apiErr, ok := err.(*APIError); !ok || apiErr.Type != ErrorTypeUnsupported {
+		t.Fatalf("expected an unsupported error, got %v", err)
+	}
+}
+
+func TestGetServerConfig(t *testing.T) {
+	t.Parallel()
+
+	forbidden := false
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/.well-known/buntime":
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		case "/api/config":
+			if forbidden {
+				return testResponse(http.StatusForbidden, `{"error":"missing permission"}`), nil
+			}
+			return testResponse(http.StatusOK, `{"pool":{"size":4},"features":["gzip"]}`), nil
+		}
+		return testResponse(http.StatusNotFound, "404 Not Found"), nil
+	})
+
+	cfg, err := client.GetServerConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pool, ok := cfg["pool"].(map[string]any); !ok || pool["size"] != float64(4) {
+		t.Fatalf("GetServerConfig() = %v", cfg)
+	}
+
+	forbidden = true
+	if _, err := client.GetServerConfig(); err == nil || err.(*APIError).Type != ErrorTypeForbidden {
+		t.Fatalf("expected a forbidden error, got %v", err)
+	}
+}
+
+func TestHandleResponseParsesErrorEnvelopes(t *testing.T) {
+	t.Parallel()
+
+	client := New("https://buntime.home", "", false)
+	for _, tc := range []struct {
+		status      int
+		body        string
+		wantType    ErrorType
+		wantCode    string
+		wantMessage string
+	}{
+		{400, `{"success":false,"code":"INVALID_FILE_TYPE","message":"File must be .tgz, .tar.gz, or .zip"}`, ErrorTypeUnknown, "INVALID_FILE_TYPE", "Request failed (400): File must be .tgz, .tar.gz, or .zip"},
+		{403, `{"code":"AUTH_REQUIRED","message":"Missing key"}`, ErrorTypeAuthRequired, "AUTH_REQUIRED", "Authentication required"},
+		{409, `{"code":"NAME_TAKEN","message":"a key named ci exists"}`, ErrorTypeUnknown, "NAME_TAKEN", "Request failed (409): a key named ci exists"},
+		{502, "<html>Bad Gateway</html>\n", ErrorTypeServerError, "", "Server error (502): <html>Bad Gateway</html>"},
+	} {
+		err := client.handleResponse(testResponse(tc.status, tc.body), nil)
+		apiErr, ok := err.(*APIError)
+		if !ok || apiErr.Type != tc.wantType || apiErr.Code != tc.wantCode || apiErr.Message != tc.wantMessage {
+			t.Errorf("%d: got %#v", tc.status, err)
+		}
+	}
+}
+
+func TestAdvisePermissions(t *testing.T) {
+	if advice := AdvisePermissions(nil); len(advice) != 1 {
+		t.Fatalf("no permissions: got %q, want one warning", advice)
+	}
+	if advice := AdvisePermissions(EditorPermissions); len(advice) != 0 {
+		t.Fatalf("editor permissions: got %q, want none", advice)
+	}
+
+	advice := AdvisePermissions([]Permission{PermAppsRead, PermKeysCreate, PermKeysRead})
+	if len(advice) != 2 || !strings.Contains(advice[0], "keys:create") || !strings.HasSuffix(advice[1], "keys:read") {
+		t.Fatalf("got %q, want keys:create escalation then keys:read beyond editor", advice)
+	}
+}
+
+func TestPingCtxCanceled(t *testing.T) {
+	t.Parallel()
+
+	var discovered bool
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		if err := r.Context().Err(); err != nil {
+			return nil, err
+		}
+		if r.URL.Path == "/.well-known/buntime" {
+			discovered = true
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		}
+		return testResponse(http.StatusOK, `[]`), nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := client.PingCtx(ctx)
+	if apiErr, ok := err.(*APIError); !ok || apiErr.Type != ErrorTypeCanceled {
+		t.Fatalf("PingCtx() error = %v, want a canceled error", err)
+	}
+
+	// The canceled attempt must not settle discovery
+	if err := client.Ping(); err != nil || !discovered {
+		t.Fatalf("Ping() error = %v, discovered = %v", err, discovered)
+	}
+}
+
+func TestUploadFileAbortsWhenCanceled(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "app.zip")
+	if err := os.WriteFile(path, []byte("archive"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	started := make(chan struct{})
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		close(started)
+		<-r.Context().Done()
+		return nil, r.Context().Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	_, err := client.uploadFile(ctx, "/api/apps/upload", path, nil)
+	if apiErr, ok := err.(*APIError); !ok || apiErr.Type != ErrorTypeCanceled {
+		t.Fatalf("uploadFile() error = %v, want a canceled error", err)
+	}
+}
+
+func TestInstallAppCtxStreamsTheArchiveWithItsLength(t *testing.T) {
+	t.Parallel()
+
+	content := strings.Repeat("archive", 10000)
+	path := filepath.Join(t.TempDir(), "app.zip")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/.well-known/buntime":
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		case "/api/apps/upload":
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if int64(len(body)) != r.ContentLength {
+				t.Errorf("body is %d bytes, Content-Length %d", len(body), r.ContentLength)
+			}
+			r.Body = io.NopCloser(strings.NewReader(string(body)))
+			file, _, err := r.FormFile("file")
+			if err != nil {
+				t.Fatalf("expected multipart file: %v", err)
+			}
+			got, _ := io.ReadAll(file)
+			if string(got) != content {
+				t.Errorf("uploaded %d bytes, want %d", len(got), len(content))
+			}
+			return testResponse(http.StatusOK, `{"name":"app","version":"1.0.0"}`), nil
+		}
+		return testResponse(http.StatusOK, `[]`), nil
+	})
+
+	var sent, total int64
+	if _, err := client.InstallAppCtx(context.Background(), path, func(s, t int64) {
+		sent, total = s, t
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if total <= int64(len(content)) || sent != total {
+		t.Fatalf("progress ended at %d of %d", sent, total)
+	}
+}
+
+func TestRequestTimeouts(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "app.zip")
+	if err := os.WriteFile(path, []byte("archive"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	deadlines := make(map[string]time.Duration)
+	client := New("https://buntime.home", "master-key", true, WithTimeout(time.Minute), WithUploadTimeout(time.Hour))
+	client.httpClient = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if deadline, ok := r.Context().Deadline(); ok {
+			deadlines[r.Method+" "+r.URL.Path] = time.Until(deadline)
+		}
+		switch r.URL.Path {
+		case "/.well-known/buntime":
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		case "/api/apps/upload":
+			return testResponse(http.StatusOK, `{"name":"app","version":"1.0.0"}`), nil
+		}
+		return testResponse(http.StatusOK, `[]`), nil
+	})}
+
+	if err := client.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ListApps(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.uploadAPIFile("/apps/upload", path); err != nil {
+		t.Fatal(err)
+	}
+
+	for request, want := range map[string]time.Duration{
+		"GET /api/plugins":      PingTimeout,
+		"GET /api/apps":         time.Minute,
+		"POST /api/apps/upload": time.Hour,
+	} {
+		got, ok := deadlines[request]
+		if !ok || got > want || got < want-5*time.Second {
+			t.Errorf("%s: timeout %v, want %v", request, got, want)
+		}
+	}
+}
+
+func TestUploadTimeoutDefaultsToTheLongerOfTheRequestAndUploadTimeouts(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		timeout, want time.Duration
+	}{
+		{0, DefaultUploadTimeout},
+		{time.Minute, DefaultUploadTimeout},
+		{time.Hour, time.Hour},
+	} {
+		client := New("https://buntime.home", "", false, WithTimeout(tt.timeout))
+		if got := client.uploadTimeoutOrDefault(); got != tt.want {
+			t.Errorf("timeout %v: upload timeout %v, want %v", tt.timeout, got, tt.want)
+		}
+	}
+}
+
+func TestTimedOutRequestIsNetworkError(t *testing.T) {
+	t.Parallel()
+
+	client := New("https://buntime.home", "master-key", true, WithTimeout(20*time.Millisecond), WithRetry(1, 0))
+	client.httpClient = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		<-r.Context().Done()
+		return nil, r.Context().Err()
+	})}
+
+	_, err := client.ListApps()
+	if apiErr, ok := err.(*APIError); !ok || apiErr.Type != ErrorTypeNetworkError {
+		t.Fatalf("ListApps() error = %v, want a network error", err)
+	}
+}
+
+func TestCheckHealthAll(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	running, peak := 0, 0
+	newClient := func(health string, status int) *Client {
+		return newTestClient(func(r *http.Request) (*http.Response, error) {
+			if r.URL.Path == "/.well-known/buntime" {
+				return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+			}
+			mu.Lock()
+			running++
+			peak = max(peak, running)
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+			if status == 0 {
+				return nil, fmt.Errorf("dial tcp: connection refused")
+			}
+			return testResponse(status, health), nil
+		})
+	}
+
+	clients := []*Client{
+		newClient(`{"ok":true,"status":"healthy","version":"1.2.0"}`, http.StatusOK),
+		newClient(`{"ok":false,"status":"degraded"}`, http.StatusOK),
+		newClient(`oops`, http.StatusInternalServerError),
+		newClient("", 0),
+	}
+	checks := CheckHealthAll(context.Background(), clients, 2)
+
+	if !checks[0].Up() || checks[0].Health.Version != "1.2.0" {
+		t.Errorf("healthy server: got %+v", checks[0])
+	}
+	if !checks[1].Online || checks[1].Up() {
+		t.Errorf("degraded server: got %+v", checks[1])
+	}
+	if !checks[2].Online || checks[2].Up() {
+		t.Errorf("failing server: got %+v", checks[2])
+	}
+	if checks[3].Online || checks[3].Err == nil {
+		t.Errorf("unreachable server: got %+v", checks[3])
+	}
+	if peak > 2 {
+		t.Errorf("ran %d checks at once, want at most 2", peak)
+	}
+}
+
+func TestRetriesTransientErrorsOfGets(t *testing.T) {
+	t.Parallel()
+
+	calls := make(map[string]int)
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/.well-known/buntime" {
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		}
+		key := r.Method + " " + r.URL.Path
+		calls[key]++
+		switch {
+		case r.URL.Path == "/api/keys":
+			return testResponse(http.StatusUnauthorized, ``), nil
+		case calls[key] < 3:
+			return nil, fmt.Errorf("read tcp: i/o timeout")
+		}
+		return testResponse(http.StatusOK, `[]`), nil
+	})
+	WithRetry(3, time.Millisecond)(client)
+
+	if _, err := client.ListApps(); err != nil {
+		t.Fatalf("ListApps() error = %v, want success on the third try", err)
+	}
+	if err := client.RemoveApp("front", ""); err == nil {
+		t.Fatal("expected the DELETE to fail without a retry")
+	}
+	if _, err := client.ListKeys(); err == nil {
+		t.Fatal("expected the auth error")
+	}
+
+	want := map[string]int{"GET /api/apps": 3, "DELETE /api/apps/_/front/": 1, "GET /api/keys": 1}
+	for key, n := range want {
+		if calls[key] != n {
+			t.Errorf("%s sent %d times, want %d (all calls: %v)", key, calls[key], n, calls)
+		}
+	}
+}
+
+func TestUploadRetriesOnlyUnreachableServers(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "app.zip")
+	if err := os.WriteFile(path, []byte("archive"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var uploads int
+	status := http.StatusOK
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/.well-known/buntime" {
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		}
+		io.Copy(io.Discard, r.Body)
+		uploads++
+		if uploads == 1 {
+			return nil, fmt.Errorf("dial tcp: connect: connection refused")
+		}
+		return testResponse(status, `{"name":"app","version":"1.0.0"}`), nil
+	})
+	WithUploadRetry(3, time.Millisecond)(client)
+
+	var retries []int
+	onRetry := func(attempt, maxAttempts int, err error) {
+		if maxAttempts != 3 || err == nil {
+			t.Errorf("onRetry(%d, %d, %v)", attempt, maxAttempts, err)
+		}
+		retries = append(retries, attempt)
+	}
+	if _, err := client.uploadAPIFileCtx(context.Background(), "/apps/upload", path, nil, onRetry); err != nil {
+		t.Fatalf("upload error = %v, want success on the second try", err)
+	}
+	if uploads != 2 || len(retries) != 1 || retries[0] != 2 {
+		t.Fatalf("sent %d uploads with retries %v, want 2 with one retry", uploads, retries)
+	}
+
+	// The server answers from now on, rejecting the upload
+	uploads, retries, status = 1, nil, http.StatusBadRequest
+	if _, err := client.uploadAPIFileCtx(context.Background(), "/apps/upload", path, nil, onRetry); err == nil {
+		t.Fatal("expected the 400 to fail the upload")
+	}
+	if uploads != 2 || len(retries) != 0 {
+		t.Fatalf("expected the 400 not to be retried, got retries %v", retries)
+	}
+}
+
+func TestStreamLogs(t *testing.T) {
+	t.Parallel()
+
+	var query string
+	stopped := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/buntime":
+			w.Write([]byte(`{"api":"/api"}`))
+			return
+		case "/api/apps/@acme/front/logs":
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": keep-alive\n\n")
+		fmt.Fprint(w, "event: log\ndata: {\"timestamp\":1700000000000,\"level\":\"error\",\"message\":\"boom\"}\n\n")
+		fmt.Fprint(w, "data: plain\ndata: text\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+		close(stopped)
+	}))
+	defer server.Close()
+
+	client := New(server.URL, "", false)
+	lines, stop, err := client.StreamLogs("@acme/front", true)
+	if err != nil {
+		t.Fatalf("StreamLogs() error = %v", err)
+	}
+
+	first, second := <-lines, <-lines
+	if first.Level != "error" || first.Message != "boom" || !first.Timestamp.Equal(time.UnixMilli(1700000000000)) {
+		t.Errorf("first line = %+v", first)
+	}
+	if second.Message != "plain\ntext" {
+		t.Errorf("second line = %+v, want the raw data", second)
+	}
+	if query != "follow=true" {
+		t.Errorf("query = %q, want follow=true", query)
+	}
+
+	stop()
+	if _, ok := <-lines; ok {
+		t.Fatal("expected the channel to close once stopped")
+	}
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected stopping to close the connection")
+	}
+
+	if _, _, err := client.StreamLogs("missing", false); err == nil {
+		t.Fatal("expected an unsupported error for a missing endpoint")
+	} else if apiErr, ok := err.(*APIError); !ok || apiErr.Type != ErrorTypeUnsupported {
+		t.Fatalf("got %v, want ErrorTypeUnsupported", err)
+	}
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ClockSkewThreshold is how far the local clock may drift from the server's
+// before the CLI warns about it. The Date header only has one-second
+// resolution, so small differences are expected.
+const ClockSkewThreshold = 2 * time.Minute
+
+// clock tracks the offset between the local clock and the server's, taken
+// from the Date header of the latest response
+type clock struct {
+	skew  time.Duration // Local time minus server time
+	known bool
+}
+
+// recordServerTime updates the clock skew from a response's Date header.
+// The request's round trip is split in half to estimate when the server
+// stamped the response.
+func (c *Client) recordServerTime(resp *http.Response, sent, received time.Time) {
+	serverTime, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return
+	}
+	local := sent.Add(received.Sub(sent) / 2)
+
+	c.clockMu.Lock()
+	c.clock = clock{skew: local.Sub(serverTime).Round(time.Second), known: true}
+	c.clockMu.Unlock()
+}
+
+// ClockSkew reports how far the local clock is ahead of the server's
+// (negative when behind). ok is false until a response carried a Date
+// header.
+func (c *Client) ClockSkew() (skew time.Duration, ok bool) {
+	c.clockMu.Lock()
+	defer c.clockMu.Unlock()
+	return c.clock.skew, c.clock.known
+}
+
+// ClockSkewed reports whether the local clock is off by more than
+// ClockSkewThreshold
+func (c *Client) ClockSkewed() bool {
+	skew, ok := c.ClockSkew()
+	return ok && skew.Abs() > ClockSkewThreshold
+}
+
+// ServerNow is the current time on the server's clock, or the local time
+// when the skew is unknown. Relative times such as key expiry use it so a
+// wrong local clock doesn't shift them.
+func (c *Client) ServerNow() time.Time {
+	skew, _ := c.ClockSkew()
+	return time.Now().Add(-skew)
+}
+
+// DescribeClockSkew explains a skew for people, e.g. "the local clock is 5m0s
+// ahead of the server"
+func DescribeClockSkew(skew time.Duration) string {
+	direction := "ahead of"
+	if skew < 0 {
+		direction = "behind"
+	}
+	return fmt.Sprintf("the local clock is %s %s the server", skew.Abs().Round(time.Second), direction)
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"compress/gzip"
+	"io"
+	"strings"
+)
+
+// gzipThreshold is the smallest request body worth compressing; below it the
+// gzip header and the server's extra work outweigh the savings
+const gzipThreshold = 8 << 10
+
+// acceptsGzip reports whether the discovery document lists gzip among the
+// request encodings the server decodes. Servers that don't list it never get
+// compressed bodies.
+func acceptsGzip(encodings []string) bool {
+	for _, encoding := range encodings {
+		if strings.EqualFold(strings.TrimSpace(encoding), "gzip") {
+			return true
+		}
+	}
+	return false
+}
+
+// compressible reports whether a body of this content type shrinks enough
+// under gzip to be worth it. Archives and octet streams are already
+// compressed or opaque.
+func compressible(contentType string) bool {
+	return strings.HasPrefix(contentType, "application/json") || strings.HasPrefix(contentType, "text/")
+}
+
+// gzipBody compresses large text bodies for servers that accept gzip
+// requests. It streams through a pipe, so the compressed copy is never held
+// in memory; the returned encoding is empty when the body is sent as is.
+func (c *Client) gzipBody(body io.Reader, contentType string) (io.Reader, string) {
+	if body == nil || !c.gzipRequests.Load() || !compressible(contentType) {
+		return body, ""
+	}
+	// Only bodies of a known size are considered, which covers the JSON
+	// payloads built in memory
+	sized, ok := body.(interface{ Len() int })
+	if !ok || sized.Len() < gzipThreshold {
+		return body, ""
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		zw := gzip.NewWriter(pw)
+		_, err := io.Copy(zw, body)
+		if closeErr := zw.Close(); err == nil {
+			err = closeErr
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr, "gzip"
+}
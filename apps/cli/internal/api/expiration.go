@@ -0,0 +1,99 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ExpirationNever is the expiresIn value for keys that don't expire
+const ExpirationNever = "never"
+
+var expirationPart = regexp.MustCompile(`(\d+)\s*(d|w|m|y|day|days|week|weeks|month|months|year|years)`)
+
+// NormalizeExpiration parses flexible durations like "1y 2m 15d" or "30d" and
+// returns them in days, the form the server accepts (e.g. "450d")
+func NormalizeExpiration(s string) (string, error) {
+	days, err := expirationDays(s)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%dd", days), nil
+}
+
+// ExpirationDays is the lifetime expiresIn grants in days, or 0 for "never"
+func ExpirationDays(expiresIn string) (int, error) {
+	if strings.EqualFold(strings.TrimSpace(expiresIn), ExpirationNever) {
+		return 0, nil
+	}
+	return expirationDays(expiresIn)
+}
+
+func expirationDays(s string) (int, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	if s == "" {
+		return 0, fmt.Errorf("duration cannot be empty")
+	}
+
+	matches := expirationPart.FindAllStringSubmatch(s, -1)
+	if len(matches) == 0 {
+		return 0, fmt.Errorf("invalid format. Use: 7d, 2w, 6m, 1y")
+	}
+
+	var totalDays int
+	for _, match := range matches {
+		num, err := strconv.Atoi(match[1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid number: %s", match[1])
+		}
+
+		switch unit := match[2]; unit[0] {
+		case 'd':
+			totalDays += num
+		case 'w':
+			totalDays += num * 7
+		case 'm':
+			totalDays += num * 30 // Approximate month as 30 days
+		case 'y':
+			totalDays += num * 365 // Approximate year as 365 days
+		}
+	}
+
+	if totalDays <= 0 {
+		return 0, fmt.Errorf("duration must be greater than 0")
+	}
+	return totalDays, nil
+}
+
+// MaxExpirationDays is the longest key lifetime the server allows, or 0 when
+// keys may live forever
+func (m *KeyMetaInfo) MaxExpirationDays() int {
+	if m == nil || m.MaxExpiration == "" {
+		return 0
+	}
+	days, err := ExpirationDays(m.MaxExpiration)
+	if err != nil {
+		return 0
+	}
+	return days
+}
+
+// CheckExpiration rejects expiresIn values the server would refuse under a
+// maximum lifetime of maxDays. A maxDays of 0 allows anything.
+func CheckExpiration(expiresIn string, maxDays int) error {
+	if maxDays <= 0 {
+		return nil
+	}
+	days, err := ExpirationDays(expiresIn)
+	if err != nil {
+		return err
+	}
+	if days == 0 {
+		return fmt.Errorf("this server requires keys to expire within %d days", maxDays)
+	}
+	if days > maxDays {
+		return fmt.Errorf("%d days exceeds the server's maximum key lifetime of %d days", days, maxDays)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultHealthConcurrency is how many servers CheckHealthAll checks at once
+const DefaultHealthConcurrency = 8
+
+// HealthCheck is the outcome of checking one server's health
+type HealthCheck struct {
+	Online  bool        // The server answered, even if with an error
+	Health  *HealthInfo // Nil when the health endpoint failed
+	Latency time.Duration
+	Err     error
+}
+
+// Up reports whether the server answered and considers itself healthy
+func (h HealthCheck) Up() bool {
+	return h.Online && h.Health != nil && h.Health.OK
+}
+
+// CheckHealth fetches the server's health within PingTimeout (or the client's
+// timeout when shorter) and times the request
+func (c *Client) CheckHealth(ctx context.Context) HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, c.pingTimeout())
+	defer cancel()
+
+	start := time.Now()
+	health, err := c.GetHealthCtx(ctx)
+	check := HealthCheck{Health: health, Latency: time.Since(start), Err: err}
+
+	// An HTTP error status still means the server is up
+	apiErr, ok := err.(*APIError)
+	check.Online = err == nil || (ok && apiErr.Status != 0)
+	return check
+}
+
+// CheckHealthAll checks every client's server, at most limit at a time, and
+// returns the results in the order of clients
+func CheckHealthAll(ctx context.Context, clients []*Client, limit int) []HealthCheck {
+	if limit <= 0 {
+		limit = DefaultHealthConcurrency
+	}
+
+	checks := make([]HealthCheck, len(clients))
+	var g errgroup.Group
+	g.SetLimit(limit)
+	for i, client := range clients {
+		g.Go(func() error {
+			checks[i] = client.CheckHealth(ctx)
+			return nil
+		})
+	}
+	g.Wait()
+	return checks
+}
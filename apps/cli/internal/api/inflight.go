@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+	"time"
+)
+
+// do sends a request, keeping track of requests waiting for the server and
+// of when it last answered. Every request, upload chunks included, goes
+// through here to reach the request log.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	c.inFlight.Add(1)
+	defer c.inFlight.Add(-1)
+
+	sent := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		logRequest(req.Method, req.URL.Redacted(), 0, time.Since(sent), err)
+		return nil, err
+	}
+	c.lastAnswer.Store(time.Now().UnixNano())
+	logRequest(req.Method, req.URL.Redacted(), resp.StatusCode, time.Since(sent), nil)
+	return resp, nil
+}
+
+// Busy reports whether a request is waiting for the server, such as an
+// upload
+func (c *Client) Busy() bool {
+	return c.inFlight.Load() > 0
+}
+
+// AnsweredWithin reports whether the server answered any request in the last
+// d, which shows the connection is up without checking it again
+func (c *Client) AnsweredWithin(d time.Duration) bool {
+	last := c.lastAnswer.Load()
+	return last != 0 && time.Since(time.Unix(0, last)) < d
+}
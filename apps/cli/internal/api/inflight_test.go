@@ -0,0 +1,31 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClientTracksRequestsAndAnswers(t *testing.T) {
+	t.Parallel()
+
+	var client *Client
+	client = newTestClient(func(r *http.Request) (*http.Response, error) {
+		if !client.Busy() {
+			t.Error("expected the client to be busy during a request")
+		}
+		return testResponse(http.StatusOK, `{}`), nil
+	})
+	if client.Busy() || client.AnsweredWithin(time.Minute) {
+		t.Fatal("expected a new client to be idle and never answered")
+	}
+
+	resp, err := client.doRequest("GET", "/health", nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if client.Busy() || !client.AnsweredWithin(time.Minute) {
+		t.Fatal("expected the answer to be recorded once the request is done")
+	}
+}
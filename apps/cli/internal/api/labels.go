@@ -0,0 +1,268 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Labels are arbitrary key/value pairs attached to an app or plugin
+type Labels map[string]string
+
+// String renders labels as "key=value" pairs sorted by key
+func (l Labels) String() string {
+	keys := make([]string, 0, len(l))
+	for key := range l {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + l[key]
+	}
+	return strings.Join(pairs, ", ")
+}
+
+type selectorOp int
+
+const (
+	selectorEquals selectorOp = iota
+	selectorNotEquals
+	selectorExists
+	selectorNotExists
+)
+
+type selectorRequirement struct {
+	key   string
+	op    selectorOp
+	value string
+}
+
+// Selector matches labels against comma-separated requirements, all of which
+// must hold: "key=value", "key==value", "key!=value", "key" (present) and
+// "!key" (absent). The zero Selector matches everything.
+type Selector struct {
+	raw          string
+	requirements []selectorRequirement
+}
+
+// ParseSelector parses a label selector such as "env=prod,tier!=web"
+func ParseSelector(s string) (Selector, error) {
+	sel := Selector{raw: strings.TrimSpace(s)}
+	if sel.raw == "" {
+		return sel, nil
+	}
+
+	for _, part := range strings.Split(sel.raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return Selector{}, fmt.Errorf("invalid selector %q: empty requirement", s)
+		}
+
+		var req selectorRequirement
+		switch {
+		case strings.Contains(part, "!="):
+			kv := strings.SplitN(part, "!=", 2)
+			req = selectorRequirement{key: kv[0], op: selectorNotEquals, value: kv[1]}
+		case strings.Contains(part, "=="):
+			kv := strings.SplitN(part, "==", 2)
+			req = selectorRequirement{key: kv[0], op: selectorEquals, value: kv[1]}
+		case strings.Contains(part, "="):
+			kv := strings.SplitN(part, "=", 2)
+			req = selectorRequirement{key: kv[0], op: selectorEquals, value: kv[1]}
+		case strings.HasPrefix(part, "!"):
+			req = selectorRequirement{key: part[1:], op: selectorNotExists}
+		default:
+			req = selectorRequirement{key: part, op: selectorExists}
+		}
+
+		req.key = strings.TrimSpace(req.key)
+		req.value = strings.TrimSpace(req.value)
+		if req.key == "" || strings.ContainsAny(req.key, "=! ") {
+			return Selector{}, fmt.Errorf("invalid selector %q: bad key in %q", s, part)
+		}
+		sel.requirements = append(sel.requirements, req)
+	}
+
+	return sel, nil
+}
+
+// Empty reports whether the selector matches everything
+func (s Selector) Empty() bool {
+	return len(s.requirements) == 0
+}
+
+// String returns the selector as it was written
+func (s Selector) String() string {
+	return s.raw
+}
+
+// Matches reports whether labels satisfy every requirement
+func (s Selector) Matches(labels Labels) bool {
+	for _, req := range s.requirements {
+		value, ok := labels[req.key]
+		switch req.op {
+		case selectorEquals:
+			if !ok || value != req.value {
+				return false
+			}
+		case selectorNotEquals:
+			if ok && value == req.value {
+				return false
+			}
+		case selectorExists:
+			if !ok {
+				return false
+			}
+		case selectorNotExists:
+			if ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// labelsPath returns the labels endpoint for an app or plugin
+func labelsPath(itemType, name string) (string, error) {
+	switch itemType {
+	case "app":
+		scope, pkgName := parsePackageName(name)
+		return "/apps/" + scope + "/" + pkgName + "/labels", nil
+	case "plugin":
+		return "/plugins/" + url.PathEscape(name) + "/labels", nil
+	default:
+		return "", fmt.Errorf("unknown item type: %s", itemType)
+	}
+}
+
+func labelsUnsupported(resp *http.Response) error {
+	switch resp.StatusCode {
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented:
+	case http.StatusNotFound:
+		// A missing item is reported by the server as JSON; a missing route
+		// is whatever the router falls back to
+		if strings.Contains(resp.Header.Get("Content-Type"), "application/json") {
+			return nil
+		}
+	default:
+		return nil
+	}
+	resp.Body.Close()
+	return &APIError{
+		Type:    ErrorTypeUnsupported,
+		Message: "labels are not supported by this server",
+		Status:  resp.StatusCode,
+	}
+}
+
+// GetLabels returns the labels of an app or plugin ("app" or "plugin")
+func (c *Client) GetLabels(itemType, name string) (Labels, error) {
+	path, err := labelsPath(itemType, name)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doAPIRequest("GET", path, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := labelsUnsupported(resp); err != nil {
+		return nil, err
+	}
+
+	var labels Labels
+	if err := c.handleResponse(resp, &labels); err != nil {
+		return nil, err
+	}
+	if labels == nil {
+		labels = Labels{}
+	}
+	return labels, nil
+}
+
+// SetLabels replaces the labels of an app or plugin ("app" or "plugin")
+func (c *Client) SetLabels(itemType, name string, labels Labels) error {
+	path, err := labelsPath(itemType, name)
+	if err != nil {
+		return err
+	}
+
+	if labels == nil {
+		labels = Labels{}
+	}
+	body, err := json.Marshal(labels)
+	if err != nil {
+		return fmt.Errorf("failed to marshal input: %w", err)
+	}
+
+	resp, err := c.doAPIRequest("PUT", path, bytes.NewReader(body), "application/json")
+	if err != nil {
+		return err
+	}
+	if err := labelsUnsupported(resp); err != nil {
+		return err
+	}
+	return c.handleResponse(resp, nil)
+}
+
+// ListAppsMatching lists the apps whose labels satisfy the selector, using
+// the labels the list response carries. Servers whose list has no labels
+// report ErrorTypeUnsupported.
+func (c *Client) ListAppsMatching(sel Selector) ([]AppInfo, error) {
+	apps, err := c.ListApps()
+	if err != nil || sel.Empty() {
+		return apps, err
+	}
+
+	labeled := false
+	matching := make([]AppInfo, 0, len(apps))
+	for _, app := range apps {
+		labeled = labeled || app.Labels != nil
+		if sel.Matches(app.Labels) {
+			matching = append(matching, app)
+		}
+	}
+	if !labeled && len(apps) > 0 {
+		return nil, selectorUnsupported()
+	}
+	return matching, nil
+}
+
+// ListPluginsMatching lists the plugins whose labels satisfy the selector,
+// using the labels the list response carries. Servers whose list has no
+// labels report ErrorTypeUnsupported.
+func (c *Client) ListPluginsMatching(sel Selector) ([]PluginInfo, error) {
+	plugins, err := c.ListPlugins()
+	if err != nil || sel.Empty() {
+		return plugins, err
+	}
+
+	labeled := false
+	matching := make([]PluginInfo, 0, len(plugins))
+	for _, plugin := range plugins {
+		labeled = labeled || plugin.Labels != nil
+		if sel.Matches(plugin.Labels) {
+			matching = append(matching, plugin)
+		}
+	}
+	if !labeled && len(plugins) > 0 {
+		return nil, selectorUnsupported()
+	}
+	return matching, nil
+}
+
+// selectorUnsupported is the error for a selector on a server that doesn't
+// list labels. An item without labels in a list where others have them
+// simply has none.
+func selectorUnsupported() *APIError {
+	return &APIError{
+		Type:    ErrorTypeUnsupported,
+		Message: "selector not supported by this server",
+	}
+}
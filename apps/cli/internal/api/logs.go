@@ -0,0 +1,129 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// LogLine is one line of an app's log
+type LogLine struct {
+	Timestamp time.Time
+	Level     string // e.g. "info", "error"; empty when the server doesn't say
+	Message   string
+}
+
+// logEvent is the data of a log event. The timestamp is either Unix
+// milliseconds or an RFC 3339 string.
+type logEvent struct {
+	Timestamp json.RawMessage `json:"timestamp"`
+	Level     string          `json:"level"`
+	Message   string          `json:"message"`
+}
+
+// StreamLogs tails an app's log over Server-Sent Events. With follow, the
+// stream stays open for new lines; without it, the server sends the recent
+// lines and ends it. Lines arrive on the channel, which is closed when the
+// stream ends, fails, or the returned stop func is called. Servers without
+// log streaming report ErrorTypeUnsupported.
+func (c *Client) StreamLogs(appName string, follow bool) (<-chan LogLine, func(), error) {
+	ctx, stop := context.WithCancel(c.context())
+
+	if err := c.discoverCtx(ctx); err != nil {
+		stop()
+		return nil, nil, err
+	}
+
+	scope, name := parsePackageName(appName)
+	path := joinPath(c.apiPath, "/apps/"+scope+"/"+name+"/logs")
+	if follow {
+		path += "?follow=true"
+	}
+
+	// Streams have no timeout; they last until stopped
+	req, err := c.newRequest(ctx, "GET", path, nil, "")
+	if err != nil {
+		stop()
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.do(req)
+	if err != nil {
+		stop()
+		return nil, nil, c.classifyError(err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		resp.Body.Close()
+		stop()
+		return nil, nil, &APIError{
+			Type:    ErrorTypeUnsupported,
+			Message: "this server does not stream app logs",
+			Status:  resp.StatusCode,
+		}
+	}
+	if resp.StatusCode >= 300 {
+		stop()
+		return nil, nil, c.handleResponse(resp, nil)
+	}
+
+	lines := make(chan LogLine)
+	go func() {
+		defer close(lines)
+		defer resp.Body.Close()
+
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+		// An event is the data lines up to a blank line
+		var data []string
+		for scanner.Scan() {
+			text := scanner.Text()
+			if text != "" {
+				// Other fields (event names, ids) and comments such as
+				// keep-alives are skipped
+				if field, value, _ := strings.Cut(text, ":"); field == "data" {
+					data = append(data, strings.TrimPrefix(value, " "))
+				}
+				continue
+			}
+			if len(data) == 0 {
+				continue
+			}
+
+			line := parseLogLine(strings.Join(data, "\n"))
+			data = data[:0]
+			select {
+			case lines <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return lines, stop, nil
+}
+
+// parseLogLine decodes an event's data, taking data that isn't a log event as
+// the message itself
+func parseLogLine(data string) LogLine {
+	var event logEvent
+	if err := json.Unmarshal([]byte(data), &event); err != nil || event.Message == "" {
+		return LogLine{Message: data}
+	}
+
+	line := LogLine{Level: event.Level, Message: event.Message}
+	var millis int64
+	var text string
+	if json.Unmarshal(event.Timestamp, &millis) == nil {
+		line.Timestamp = time.UnixMilli(millis)
+	} else if json.Unmarshal(event.Timestamp, &text) == nil {
+		line.Timestamp, _ = time.Parse(time.RFC3339Nano, text)
+	}
+	return line
+}
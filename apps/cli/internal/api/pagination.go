@@ -0,0 +1,195 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// maxListPages stops a server that keeps handing out pages from looping the
+// client forever
+const maxListPages = 1000
+
+// listPage is one response of a list endpoint. Servers that don't paginate
+// send a bare array, or an envelope with neither nextCursor nor total.
+type listPage[T any] struct {
+	items      []T
+	nextCursor string // Opaque token for the next page, preferred when present
+	total      int    // Size of the whole list for offset pagination, -1 when unknown
+}
+
+// decodeListPage reads a bare array or an envelope holding the items under
+// field (or "items")
+func decodeListPage[T any](data []byte, field string) (listPage[T], error) {
+	page := listPage[T]{total: -1}
+
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		err := json.Unmarshal(trimmed, &page.items)
+		return page, err
+	}
+
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return page, err
+	}
+
+	items, ok := envelope[field]
+	if !ok {
+		items = envelope["items"]
+	}
+	if len(items) > 0 {
+		if err := json.Unmarshal(items, &page.items); err != nil {
+			return page, err
+		}
+	}
+	if raw, ok := envelope["nextCursor"]; ok {
+		// null and "" both mean the last page
+		json.Unmarshal(raw, &page.nextCursor)
+	}
+	if raw, ok := envelope["total"]; ok {
+		if err := json.Unmarshal(raw, &page.total); err != nil {
+			page.total = -1
+		}
+	}
+	return page, nil
+}
+
+// listAll fetches every page of a list endpoint. It follows nextCursor when
+// the server sends one, falls back to ?offset= when the server only reports
+// a total, and stops after the first response for servers that don't
+// paginate at all.
+func listAll[T any](c *Client, path, field string) ([]T, error) {
+	all := []T{}
+	query := url.Values{}
+	seen := map[string]bool{}
+
+	for pages := 0; pages < maxListPages; pages++ {
+		requestPath := path
+		if len(query) > 0 {
+			requestPath += "?" + query.Encode()
+		}
+
+		resp, err := c.doAPIRequest("GET", requestPath, nil, "")
+		if err != nil {
+			return nil, err
+		}
+		var raw json.RawMessage
+		if err := c.handleResponse(resp, &raw); err != nil {
+			return nil, err
+		}
+		page, err := decodeListPage[T](raw, field)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page.items...)
+
+		switch {
+		case page.nextCursor != "":
+			// A cursor seen before would start the same pages over
+			if seen[page.nextCursor] {
+				return nil, fmt.Errorf("server repeated page cursor %q for %s", page.nextCursor, path)
+			}
+			seen[page.nextCursor] = true
+			query = url.Values{"cursor": {page.nextCursor}}
+		case page.total > len(all) && len(page.items) > 0:
+			query = url.Values{"offset": {strconv.Itoa(len(all))}}
+		default:
+			return all, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s returned more than %d pages", path, maxListPages)
+}
+
+// ListOptions asks a list endpoint for a single page. The zero value fetches
+// the whole list.
+type ListOptions struct {
+	Limit  int    // Page size, 0 for the server's default
+	Offset int    // Items to skip, for servers that page by offset
+	Cursor string // NextCursor of the previous page, preferred over Offset
+}
+
+func (o ListOptions) whole() bool {
+	return o == ListOptions{}
+}
+
+// PageInfo locates a page within the whole list
+type PageInfo struct {
+	Total      int    // Size of the whole list, -1 when the server doesn't say
+	NextCursor string // Token for the next page, empty on the last one
+	NextOffset int    // Offset of the next page for servers without cursors, 0 on the last one
+}
+
+// HasMore reports whether another page follows
+func (p *PageInfo) HasMore() bool {
+	return p.NextCursor != "" || p.NextOffset > 0
+}
+
+// Next returns the options that fetch the following page
+func (p *PageInfo) Next(limit int) ListOptions {
+	if p.NextCursor != "" {
+		return ListOptions{Limit: limit, Cursor: p.NextCursor}
+	}
+	return ListOptions{Limit: limit, Offset: p.NextOffset}
+}
+
+// listPageOf fetches the page opts points at, or the whole list for zero
+// options. Servers that don't paginate answer with everything in one page.
+func listPageOf[T any](c *Client, path, field string, opts ListOptions) ([]T, *PageInfo, error) {
+	if opts.whole() {
+		items, err := listAll[T](c, path, field)
+		if err != nil {
+			return nil, nil, err
+		}
+		return items, &PageInfo{Total: len(items)}, nil
+	}
+
+	query := url.Values{}
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.Cursor != "" {
+		query.Set("cursor", opts.Cursor)
+	} else if opts.Offset > 0 {
+		query.Set("offset", strconv.Itoa(opts.Offset))
+	}
+	requestPath := path
+	if len(query) > 0 {
+		requestPath += "?" + query.Encode()
+	}
+
+	resp, err := c.doAPIRequest("GET", requestPath, nil, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	var raw json.RawMessage
+	if err := c.handleResponse(resp, &raw); err != nil {
+		return nil, nil, err
+	}
+	page, err := decodeListPage[T](raw, field)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info := &PageInfo{Total: page.total, NextCursor: page.nextCursor}
+	if info.NextCursor == "" && opts.Cursor == "" && len(page.items) > 0 {
+		more := page.total > opts.Offset+len(page.items)
+		if page.total < 0 {
+			// Without a total, a full page may be followed by another
+			more = len(page.items) == opts.Limit
+		}
+		if more {
+			info.NextOffset = opts.Offset + len(page.items)
+		}
+	}
+	if info.Total < 0 && !info.HasMore() && opts.Cursor == "" {
+		// The last offset page tells the size of the whole list
+		info.Total = opts.Offset + len(page.items)
+	}
+	if page.items == nil {
+		page.items = []T{}
+	}
+	return page.items, info, nil
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EditorPermissions is what the editor role grants on the runtime, the usual
+// ceiling for keys used by deploys and automation
+var EditorPermissions = []Permission{
+	PermAppsRead, PermAppsInstall, PermAppsRemove,
+	PermPluginsRead, PermPluginsInstall, PermPluginsRemove, PermPluginsConfig,
+	PermWorkersRead, PermWorkersRestart,
+}
+
+// escalatingPermissions let a key act on other keys, and with them on
+// everything an admin can do
+var escalatingPermissions = map[Permission]string{
+	PermKeysCreate: "keys:create can create admin keys, so this key can grant itself any permission",
+	PermKeysRevoke: "keys:revoke can revoke any key, including the admin keys",
+}
+
+// AdvisePermissions returns warnings about a custom key's permissions: none
+// selected, permissions that escalate privileges, and anything else beyond
+// what the editor role grants. The server accepts all of these; the advice is
+// there so operators don't over-provision keys by accident.
+func AdvisePermissions(perms []Permission) []string {
+	if len(perms) == 0 {
+		return []string{"No permissions selected, so this key can't do anything"}
+	}
+
+	editor := make(map[Permission]bool, len(EditorPermissions))
+	for _, perm := range EditorPermissions {
+		editor[perm] = true
+	}
+
+	var advice []string
+	var beyond []string
+	for _, perm := range perms {
+		if message, ok := escalatingPermissions[perm]; ok {
+			advice = append(advice, message)
+		} else if !editor[perm] {
+			beyond = append(beyond, string(perm))
+		}
+	}
+	if len(beyond) > 0 {
+		advice = append(advice, fmt.Sprintf("Grants more than the editor role: %s", strings.Join(beyond, ", ")))
+	}
+	return advice
+}
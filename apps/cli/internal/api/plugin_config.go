@@ -0,0 +1,58 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// GetPluginConfig fetches a plugin's configuration. A plugin without settings
+// has an empty map. Servers that don't expose plugin configuration report
+// ErrorTypeUnsupported.
+func (c *Client) GetPluginConfig(id int) (map[string]any, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("plugin configuration is not supported by this runtime API")
+	}
+	resp, err := c.doAPIRequest("GET", fmt.Sprintf("/plugins/%d/config", id), nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		resp.Body.Close()
+		return nil, &APIError{
+			Type:    ErrorTypeUnsupported,
+			Message: "this server does not expose plugin configuration",
+			Status:  resp.StatusCode,
+		}
+	}
+
+	var cfg map[string]any
+	if err := c.handleResponse(resp, &cfg); err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		cfg = map[string]any{}
+	}
+	return cfg, nil
+}
+
+// SetPluginConfig replaces a plugin's configuration. Needs the
+// plugins:config permission.
+func (c *Client) SetPluginConfig(id int, cfg map[string]any) error {
+	if id == 0 {
+		return fmt.Errorf("plugin configuration is not supported by this runtime API")
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal input: %w", err)
+	}
+
+	resp, err := c.doAPIRequest("POST", fmt.Sprintf("/plugins/%d/config", id), bytes.NewReader(data), "application/json")
+	if err != nil {
+		return err
+	}
+	return c.handleResponse(resp, nil)
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+)
+
+// ProgressFunc is told how many bytes of an upload have been sent so far and
+// how many there are in total. It runs on the goroutine sending the request.
+type ProgressFunc func(sent, total int64)
+
+// progressReader reports the bytes read from a request body as they are
+// handed to the connection
+type progressReader struct {
+	r          io.Reader
+	sent       int64 // Bytes of the whole upload sent before this body
+	read       int64
+	size       int64 // Length of this body
+	total      int64 // Length of the whole upload
+	onProgress ProgressFunc
+}
+
+// withProgress wraps a body of size bytes that starts sent bytes into an
+// upload of total bytes. A nil onProgress leaves the body as is.
+func withProgress(body io.Reader, size, sent, total int64, onProgress ProgressFunc) io.Reader {
+	if onProgress == nil {
+		return body
+	}
+	return &progressReader{r: body, sent: sent, size: size, total: total, onProgress: onProgress}
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.read += int64(n)
+		p.onProgress(min(p.sent+p.read, p.total), p.total)
+	}
+	return n, err
+}
+
+// Size is the length of the body
+func (p *progressReader) Size() int64 {
+	return p.size
+}
+
+// sizedReader is a streamed body whose length is known up front
+type sizedReader struct {
+	io.Reader
+	size int64
+}
+
+func (s sizedReader) Size() int64 {
+	return s.size
+}
+
+// multipartSize is the length of a form holding a single file field of size
+// bytes, written with the given boundary
+func multipartSize(boundary, filename string, size int64) (int64, error) {
+	var form bytes.Buffer
+	writer := multipart.NewWriter(&form)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return 0, err
+	}
+	if _, err := writer.CreateFormFile("file", filename); err != nil {
+		return 0, fmt.Errorf("failed to create form file: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return 0, fmt.Errorf("failed to close writer: %w", err)
+	}
+	return int64(form.Len()) + size, nil
+}
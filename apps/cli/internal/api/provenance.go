@@ -0,0 +1,73 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"os/user"
+	"strings"
+)
+
+// Provenance identifies the tool and operator behind a change so the
+// server can record what installed a package. It is deliberately minimal:
+// the short hostname and login name, no paths or environment.
+type Provenance struct {
+	Client      string `json:"client,omitempty"`      // e.g. "buntime-cli/1.0.0"
+	InstalledBy string `json:"installedBy,omitempty"` // e.g. "alice@build-01"
+}
+
+// NewProvenance describes the current user and host for the given CLI version
+func NewProvenance(cliVersion string) *Provenance {
+	var name string
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+		// Windows reports DOMAIN\user
+		if idx := strings.LastIndex(name, `\`); idx >= 0 {
+			name = name[idx+1:]
+		}
+	}
+
+	host, _ := os.Hostname()
+	if idx := strings.Index(host, "."); idx >= 0 {
+		host = host[:idx]
+	}
+
+	installedBy := name
+	if host != "" {
+		installedBy = name + "@" + host
+	}
+
+	return &Provenance{
+		Client:      "buntime-cli/" + cliVersion,
+		InstalledBy: strings.TrimPrefix(installedBy, "@"),
+	}
+}
+
+func (p *Provenance) String() string {
+	switch {
+	case p.InstalledBy != "" && p.Client != "":
+		return fmt.Sprintf("%s via %s", p.InstalledBy, p.Client)
+	case p.InstalledBy != "":
+		return p.InstalledBy
+	default:
+		return p.Client
+	}
+}
+
+// SetProvenance attaches provenance headers to state-changing requests.
+// Pass nil to stop sending them.
+func (c *Client) SetProvenance(p *Provenance) {
+	c.provenance = p
+}
+
+func (c *Client) setProvenanceHeaders(req *http.Request) {
+	if c.provenance == nil {
+		return
+	}
+	if c.provenance.Client != "" {
+		req.Header.Set("X-Buntime-Client", c.provenance.Client)
+	}
+	if c.provenance.InstalledBy != "" {
+		req.Header.Set("X-Buntime-Installed-By", c.provenance.InstalledBy)
+	}
+}
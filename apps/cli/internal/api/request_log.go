@@ -0,0 +1,40 @@
+package api
+
+import (
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// requestLog is whether requests are written to the standard logger. It is
+// off by default: the logger writes to stderr, which the TUI draws on.
+var requestLog atomic.Bool
+
+// LogRequests turns the request log on or off for every client. Point the
+// standard logger at a file first.
+func LogRequests(on bool) {
+	requestLog.Store(on)
+}
+
+// Logf writes an event that isn't a request, such as a setting changed from
+// the TUI, to the same log. Nothing is written while the log is off.
+func Logf(format string, args ...any) {
+	if !requestLog.Load() {
+		return
+	}
+	log.Printf(format, args...)
+}
+
+// logRequest records one attempt at a request, e.g.
+// "GET https://buntime.home/api/apps -> 200 (12ms)"
+func logRequest(method, url string, status int, elapsed time.Duration, err error) {
+	if !requestLog.Load() {
+		return
+	}
+	elapsed = elapsed.Round(time.Millisecond)
+	if err != nil {
+		log.Printf("%s %s failed after %s: %v", method, url, elapsed, err)
+		return
+	}
+	log.Printf("%s %s -> %d (%s)", method, url, status, elapsed)
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRequestLog(t *testing.T) {
+	var b strings.Builder
+	log.SetOutput(&b)
+	LogRequests(true)
+	t.Cleanup(func() {
+		LogRequests(false)
+		log.SetOutput(os.Stderr)
+	})
+
+	var received int64
+	client := newTestClient(func(r *http.Request) (*http.Response, error) {
+		switch {
+		case r.URL.Path == "/.well-known/buntime":
+			return testResponse(http.StatusOK, `{"api":"/api"}`), nil
+		case r.URL.Path == "/api/apps" && r.Method == http.MethodGet:
+			return testResponse(http.StatusOK, `[]`), nil
+		case r.URL.Path == "/api/apps/upload/sessions":
+			return testResponse(http.StatusCreated, `{"id":"s1","offset":0}`), nil
+		case r.URL.Path == "/api/apps/upload/sessions/s1" && r.Method == http.MethodPut:
+			received += r.ContentLength
+			return testResponse(http.StatusOK, fmt.Sprintf(`{"id":"s1","offset":%d}`, received)), nil
+		case r.URL.Path == "/api/apps/upload/sessions/s1/complete":
+			return testResponse(http.StatusOK, `{"success":true,"data":{"app":{"name":"big-app","version":"2.0.0"}}}`), nil
+		default:
+			return testResponse(http.StatusOK, `{"ok":true}`), nil
+		}
+	})
+	if _, err := client.GetHealth(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), "GET https://buntime.home/api/health -> 200") {
+		t.Fatalf("expected the request in the log, got %q", b.String())
+	}
+
+	// Upload chunks are sent outside the usual request path
+	archive := writeTestZip(t, map[string]string{"package.json": `{"name":"big-app","version":"2.0.0"}`})
+	if _, err := client.InstallResumable("app", archive, ResumableOptions{ChunkSize: 64}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), "PUT https://buntime.home/api/apps/upload/sessions/s1 -> 200") {
+		t.Fatalf("expected the chunk upload in the log, got %q", b.String())
+	}
+}
@@ -0,0 +1,278 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// DefaultChunkSize is the chunk size used for resumable uploads. Archives
+	// that fit in a single chunk are sent as a regular upload.
+	DefaultChunkSize int64 = 8 << 20
+	// DefaultUploadRetries is how many times a failed chunk is resumed
+	DefaultUploadRetries = 3
+)
+
+// ResumableOptions controls a resumable install
+type ResumableOptions struct {
+	InstallOptions
+	ChunkSize  int64 // Defaults to DefaultChunkSize
+	MaxRetries int   // Defaults to DefaultUploadRetries
+	// OnProgress, when set, is told how much of the archive has been sent.
+	// A resumed chunk reports from the offset the server acknowledged.
+	OnProgress ProgressFunc
+	// OnRetry, when set, is told before a failed chunk is resumed, as retry
+	// attempt of MaxRetries, or a whole-file upload is tried again
+	OnRetry RetryFunc
+}
+
+// uploadSession is the server's view of a resumable upload
+type uploadSession struct {
+	ID     string `json:"id"`
+	Offset int64  `json:"offset"`
+}
+
+// InstallResumable installs an app or plugin archive ("app" or "plugin") in
+// chunks through an upload session, resuming from the last acknowledged
+// offset when a chunk fails. Servers without resumable upload support get a
+// regular whole-file upload.
+//
+// Session protocol, relative to the item's upload endpoint:
+//
+//	POST   /sessions               {"filename","size"} -> {"id","offset"}
+//	GET    /sessions/:id           -> {"id","offset"}
+//	PUT    /sessions/:id           chunk with Content-Range -> {"id","offset"}
+//	POST   /sessions/:id/complete  -> install result
+func (c *Client) InstallResumable(itemType, filePath string, opts ResumableOptions) (*InstallResult, error) {
+	return c.InstallResumableCtx(c.context(), itemType, filePath, opts)
+}
+
+// InstallResumableCtx is InstallResumable with a context that can abort the
+// upload. A canceled upload stops without resuming and reports
+// ErrorTypeCanceled.
+func (c *Client) InstallResumableCtx(ctx context.Context, itemType, filePath string, opts ResumableOptions) (*InstallResult, error) {
+	if opts.ChunkSize <= 0 {
+		opts.ChunkSize = DefaultChunkSize
+	}
+	if opts.MaxRetries <= 0 {
+		opts.MaxRetries = DefaultUploadRetries
+	}
+
+	var endpoint string
+	switch itemType {
+	case "app":
+		endpoint = "/apps/upload"
+		if !opts.Force {
+			if err := c.checkAppVersionFree(filePath); err != nil {
+				return nil, err
+			}
+		}
+	case "plugin":
+		endpoint = "/plugins/upload"
+		if !opts.Force {
+			if err := c.checkPluginVersionFree(filePath); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown item type: %s", itemType)
+	}
+
+	result, err := c.uploadResumable(ctx, endpoint, filePath, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if itemType == "plugin" {
+		if err := c.ReloadPlugins(); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (c *Client) uploadResumable(ctx context.Context, endpoint, filePath string, opts ResumableOptions) (*InstallResult, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	// Nothing to resume for a single-chunk archive
+	if info.Size() <= opts.ChunkSize {
+		return c.uploadAPIFileCtx(ctx, installEndpoint(endpoint, opts.InstallOptions), filePath, opts.OnProgress, opts.OnRetry)
+	}
+
+	session, supported, err := c.createUploadSession(ctx, endpoint, filepath.Base(filePath), info.Size(), opts.InstallOptions)
+	if err != nil {
+		return nil, err
+	}
+	if !supported {
+		return c.uploadAPIFileCtx(ctx, installEndpoint(endpoint, opts.InstallOptions), filePath, opts.OnProgress, opts.OnRetry)
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	sessionPath := endpoint + "/sessions/" + session.ID
+	offset := session.Offset
+	retries := 0
+	delay := c.retryDelay
+	for offset < info.Size() {
+		next, err := c.uploadChunk(ctx, sessionPath, file, offset, info.Size(), opts.ChunkSize, opts.OnProgress)
+		if err == nil {
+			offset = next
+			retries = 0
+			delay = c.retryDelay
+			continue
+		}
+		// An aborted upload is not resumed
+		if ctx.Err() != nil {
+			return nil, c.classifyError(ctx.Err())
+		}
+		// Errors the server answered, like a rejected key or an oversized
+		// chunk, fail the same way when resumed
+		if !chunkRetryable(err) {
+			return nil, err
+		}
+
+		retries++
+		if retries > opts.MaxRetries {
+			return nil, fmt.Errorf("upload failed at byte %d of %d after %d retries: %w", offset, info.Size(), opts.MaxRetries, err)
+		}
+		if opts.OnRetry != nil {
+			opts.OnRetry(retries, opts.MaxRetries, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, c.classifyError(ctx.Err())
+		case <-time.After(delay):
+		}
+		delay *= 2
+
+		// Ask the server how much it actually received before resuming
+		current, statusErr := c.getUploadSession(ctx, sessionPath)
+		if statusErr != nil {
+			continue
+		}
+		offset = current.Offset
+	}
+
+	resp, err := c.doAPIRequestCtx(ctx, "POST", sessionPath+"/complete", nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var raw json.RawMessage
+	if err := c.handleResponse(resp, &raw); err != nil {
+		return nil, versionConflict(err)
+	}
+	return parseInstallResult(raw)
+}
+
+// chunkRetryable reports whether a failed chunk may go through when resumed:
+// the connection failed before the server answered, or it failed on its side
+func chunkRetryable(err error) bool {
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		return false
+	}
+	if apiErr.Status == 0 {
+		return apiErr.Type != ErrorTypeTLSError && apiErr.Type != ErrorTypeCanceled
+	}
+	return apiErr.Status >= 500
+}
+
+// createUploadSession starts a resumable upload. supported is false when the
+// server has no session endpoint.
+func (c *Client) createUploadSession(ctx context.Context, endpoint, filename string, size int64, opts InstallOptions) (*uploadSession, bool, error) {
+	body, err := json.Marshal(map[string]interface{}{"filename": filename, "size": size})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to marshal input: %w", err)
+	}
+
+	resp, err := c.doAPIRequestCtx(ctx, "POST", installEndpoint(endpoint+"/sessions", opts), bytes.NewReader(body), "application/json")
+	if err != nil {
+		return nil, false, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		resp.Body.Close()
+		return nil, false, nil
+	}
+
+	var session uploadSession
+	if err := c.handleResponse(resp, &session); err != nil {
+		return nil, false, versionConflict(err)
+	}
+	if session.ID == "" {
+		return nil, false, nil
+	}
+	return &session, true, nil
+}
+
+func (c *Client) getUploadSession(ctx context.Context, sessionPath string) (*uploadSession, error) {
+	resp, err := c.doAPIRequestCtx(ctx, "GET", sessionPath, nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var session uploadSession
+	if err := c.handleResponse(resp, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// uploadChunk sends the chunk starting at offset and returns the offset the
+// server acknowledged
+func (c *Client) uploadChunk(ctx context.Context, sessionPath string, file *os.File, offset, size, chunkSize int64, onProgress ProgressFunc) (int64, error) {
+	length := chunkSize
+	if offset+length > size {
+		length = size - offset
+	}
+
+	chunk := make([]byte, length)
+	if _, err := file.ReadAt(chunk, offset); err != nil && err != io.EOF {
+		return offset, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	if err := c.discoverCtx(ctx); err != nil {
+		return offset, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.uploadTimeoutOrDefault())
+	defer cancel()
+
+	body := withProgress(bytes.NewReader(chunk), length, offset, size, onProgress)
+	req, err := c.newRequest(ctx, "PUT", joinPath(c.apiPath, sessionPath), body, "application/octet-stream")
+	if err != nil {
+		return offset, err
+	}
+	req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, offset+length-1, size))
+
+	resp, err := c.do(req)
+	if err != nil {
+		return offset, c.classifyError(err)
+	}
+
+	var session uploadSession
+	if err := c.handleResponse(resp, &session); err != nil {
+		return offset, err
+	}
+	if session.Offset <= offset {
+		return offset, fmt.Errorf("server did not acknowledge chunk at byte %d", offset)
+	}
+	return session.Offset, nil
+}
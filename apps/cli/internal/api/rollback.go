@@ -0,0 +1,84 @@
+package api
+
+import "fmt"
+
+// InstallRollback undoes an install that left the server unhealthy. It is
+// taken with PrepareRollback before the upload so undoing the install only
+// touches what the upload added.
+type InstallRollback struct {
+	itemType string
+	// before maps each installed name to the versions it had before the
+	// upload, and active to the app version the server was running
+	before map[string][]string
+	active map[string]string
+}
+
+// PrepareRollback records what is installed before an upload of itemType
+func (c *Client) PrepareRollback(itemType string) (*InstallRollback, error) {
+	r := &InstallRollback{
+		itemType: itemType,
+		before:   make(map[string][]string),
+		active:   make(map[string]string),
+	}
+
+	switch itemType {
+	case "app":
+		apps, err := c.ListApps()
+		if err != nil {
+			return nil, err
+		}
+		for _, app := range apps {
+			r.before[app.Name] = app.Versions
+			r.active[app.Name] = app.CurrentVersion()
+		}
+	case "plugin":
+		plugins, err := c.ListPlugins()
+		if err != nil {
+			return nil, err
+		}
+		for _, plugin := range plugins {
+			r.before[plugin.Name] = plugin.Versions
+		}
+	default:
+		return nil, fmt.Errorf("unknown item type: %s", itemType)
+	}
+
+	return r, nil
+}
+
+// Rollback removes the version the upload installed and, for apps, switches
+// back to the version that ran before. It refuses when the upload replaced
+// something that was installed, since that can't be restored.
+func (c *Client) Rollback(r *InstallRollback, result *InstallResult) error {
+	before := r.before[result.Name]
+	if containsString(before, result.Version) {
+		return fmt.Errorf("cannot roll back %s %s: the install replaced the v%s that was already installed", r.itemType, result.Name, result.Version)
+	}
+
+	if r.itemType == "plugin" {
+		// Plugins are removed by name, taking every version with them
+		if len(before) > 0 {
+			return fmt.Errorf("cannot roll back plugin %s: the server can only remove every version, including the %s installed before", result.Name, before[0])
+		}
+		if err := c.RemovePluginByName(result.Name); err != nil {
+			return err
+		}
+		return c.ReloadPlugins()
+	}
+
+	if err := c.RemoveApp(result.Name, result.Version); err != nil {
+		return err
+	}
+	previous := r.active[result.Name]
+	if previous == "" {
+		return nil
+	}
+	// Servers that can't switch versions fall back on their own once the
+	// new version is gone
+	if err := c.ActivateAppVersion(result.Name, previous); err != nil {
+		if apiErr, ok := err.(*APIError); !ok || apiErr.Type != ErrorTypeUnsupported {
+			return err
+		}
+	}
+	return nil
+}
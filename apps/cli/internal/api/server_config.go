@@ -0,0 +1,35 @@
+package api
+
+import (
+	"net/http"
+)
+
+// GetServerConfig fetches the runtime's own configuration, such as the worker
+// pool size, limits and enabled features, as the server reports it. Servers
+// that don't expose it report ErrorTypeUnsupported; keys without access get
+// ErrorTypeForbidden.
+func (c *Client) GetServerConfig() (map[string]any, error) {
+	resp, err := c.doAPIRequest("GET", "/config", nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		resp.Body.Close()
+		return nil, &APIError{
+			Type:    ErrorTypeUnsupported,
+			Message: "this server does not expose its configuration",
+			Status:  resp.StatusCode,
+		}
+	}
+
+	var cfg map[string]any
+	if err := c.handleResponse(resp, &cfg); err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		cfg = map[string]any{}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,187 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// WorkerInfo is one worker process in the server's pool
+type WorkerInfo struct {
+	ID              string `json:"id"`
+	PID             int    `json:"pid"`
+	Status          string `json:"status"` // e.g. "active", "idle", "ephemeral"
+	App             string `json:"app"`    // App the worker serves, e.g. "my-app@1.2.0"
+	UptimeMs        int64  `json:"uptimeMs"`
+	MemoryBytes     int64  `json:"memoryBytes,omitempty"` // Resident memory, 0 when not reported
+	RequestsHandled int64  `json:"requestsHandled"`
+	InFlight        int    `json:"inFlight,omitempty"` // Requests being handled right now
+}
+
+// WorkerDrained is the status of a worker that has stopped taking requests
+// and finished the ones it had
+const WorkerDrained = "drained"
+
+// Uptime is how long the worker has been running
+func (w WorkerInfo) Uptime() time.Duration {
+	return time.Duration(w.UptimeMs) * time.Millisecond
+}
+
+// ListWorkers lists the workers in the server's pool. Needs the workers:read
+// permission. Servers that don't expose their pool report
+// ErrorTypeUnsupported.
+func (c *Client) ListWorkers() ([]WorkerInfo, error) {
+	resp, err := c.doAPIRequest("GET", "/workers", nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		resp.Body.Close()
+		return nil, &APIError{
+			Type:    ErrorTypeUnsupported,
+			Message: "this server does not expose its worker pool",
+			Status:  resp.StatusCode,
+		}
+	}
+
+	var raw json.RawMessage
+	if err := c.handleResponse(resp, &raw); err != nil {
+		return nil, err
+	}
+	page, err := decodeListPage[WorkerInfo](raw, "workers")
+	if err != nil {
+		return nil, err
+	}
+	if page.items == nil {
+		return []WorkerInfo{}, nil
+	}
+	return page.items, nil
+}
+
+// DrainWorker tells a worker to stop taking new requests and finish the ones
+// in flight, e.g. before restarting it. Its progress shows in ListWorkers.
+// Needs the workers:restart permission. Servers that can't drain workers
+// report ErrorTypeUnsupported.
+func (c *Client) DrainWorker(id string) error {
+	resp, err := c.doAPIRequest("POST", "/workers/"+url.PathEscape(id)+"/drain", nil, "")
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		resp.Body.Close()
+		return &APIError{
+			Type:    ErrorTypeUnsupported,
+			Message: "this server can't drain workers; restart without draining instead",
+			Status:  resp.StatusCode,
+		}
+	}
+	return c.handleResponse(resp, nil)
+}
+
+// RestartWorker recycles a worker, e.g. one that is stuck. It returns the
+// worker that replaced it when the server reports one, or nil when the pool
+// starts a fresh one on the next request instead. Needs the workers:restart
+// permission.
+func (c *Client) RestartWorker(id string) (*WorkerInfo, error) {
+	resp, err := c.doAPIRequest("POST", "/workers/"+url.PathEscape(id)+"/restart", nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var raw json.RawMessage
+	if err := c.handleResponse(resp, &raw); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return decodeRestartedWorker(raw), nil
+}
+
+// decodeRestartedWorker finds the new worker in a restart response, bare or
+// under "worker" or "data". Responses without a PID don't name one.
+func decodeRestartedWorker(raw json.RawMessage) *WorkerInfo {
+	var body struct {
+		WorkerInfo
+		Worker *WorkerInfo `json:"worker"`
+		Data   *WorkerInfo `json:"data"`
+	}
+	if len(raw) == 0 || json.Unmarshal(raw, &body) != nil {
+		return nil
+	}
+	for _, worker := range []*WorkerInfo{body.Worker, body.Data, &body.WorkerInfo} {
+		if worker != nil && worker.PID > 0 {
+			return worker
+		}
+	}
+	return nil
+}
+
+// PoolInfo is the size of the server's worker pool and the sizes it accepts
+type PoolInfo struct {
+	Size int `json:"size"`
+	Min  int `json:"min,omitempty"` // Smallest size accepted, 0 when not reported
+	Max  int `json:"max,omitempty"` // Largest size accepted, 0 when not reported
+}
+
+// CheckSize reports whether the server would accept n as the pool size, so
+// out of range sizes are caught before they are sent
+func (p PoolInfo) CheckSize(n int) error {
+	lowest := max(1, p.Min)
+	if n < lowest {
+		return fmt.Errorf("the pool needs at least %d worker(s)", lowest)
+	}
+	if p.Max > 0 && n > p.Max {
+		return fmt.Errorf("the server allows at most %d workers", p.Max)
+	}
+	return nil
+}
+
+// GetWorkerPool fetches the pool's size and bounds. Needs the workers:read
+// permission. Servers that can't report it report ErrorTypeUnsupported.
+func (c *Client) GetWorkerPool() (*PoolInfo, error) {
+	resp, err := c.doAPIRequest("GET", "/workers/pool", nil, "")
+	if err != nil {
+		return nil, err
+	}
+	return c.decodePool(resp)
+}
+
+// SetWorkerPoolSize scales the pool up or down to n workers and returns the
+// pool as the server applied it, which may differ from n when the server
+// clamps it. Needs the workers:restart permission.
+func (c *Client) SetWorkerPoolSize(n int) (*PoolInfo, error) {
+	body, err := json.Marshal(map[string]int{"size": n})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal input: %w", err)
+	}
+
+	resp, err := c.doAPIRequest("PUT", "/workers/pool", bytes.NewReader(body), "application/json")
+	if err != nil {
+		return nil, err
+	}
+	return c.decodePool(resp)
+}
+
+func (c *Client) decodePool(resp *http.Response) (*PoolInfo, error) {
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		resp.Body.Close()
+		return nil, &APIError{
+			Type:    ErrorTypeUnsupported,
+			Message: "this server does not support resizing its worker pool",
+			Status:  resp.StatusCode,
+		}
+	}
+
+	var pool PoolInfo
+	if err := c.handleResponse(resp, &pool); err != nil {
+		return nil, err
+	}
+	return &pool, nil
+}
@@ -2,17 +2,28 @@ package db
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"time"
 
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/mattn/go-sqlite3"
 )
 
+// ErrCorrupt is returned by New when the config database fails its integrity
+// check. Run Repair to back it up and start from a fresh database.
+var ErrCorrupt = errors.New("config database is corrupted")
+
 type DB struct {
-	conn *sql.DB
+	conn    *sql.DB
+	warning string
 }
 
+// MemoryDir is the BUNTIME_CONFIG_DIR value that keeps the config in memory
+// for the lifetime of the process
+const MemoryDir = ":memory:"
+
 type Server struct {
 	ID         int64
 	Name       string
@@ -21,44 +32,209 @@ type Server struct {
 	Insecure   bool
 	LastUsedAt *time.Time
 	CreatedAt  time.Time
+	Notes      string // Free-form, possibly multi-line operator notes
+	Pinned     bool   // Listed first, regardless of when it was last used
+	// TimeoutSeconds is how long a request to the server may take, or 0 for
+	// the client's default
+	TimeoutSeconds int
+}
+
+// Timeout is the server's request timeout, or 0 when it uses the default
+func (s *Server) Timeout() time.Duration {
+	return time.Duration(s.TimeoutSeconds) * time.Second
+}
+
+// MaxServerTimeout bounds the request timeout a server can be saved with
+const MaxServerTimeout = time.Hour
+
+// ValidateServerTimeout checks a request timeout in seconds, where 0 means
+// the default. The error is worded for showing to the user as is.
+func ValidateServerTimeout(seconds int) error {
+	if seconds < 0 || seconds > int(MaxServerTimeout/time.Second) {
+		return fmt.Errorf("timeout must be between 0 (default) and %d seconds", int(MaxServerTimeout/time.Second))
+	}
+	return nil
 }
 
 func New() (*DB, error) {
-	dbPath, err := getDBPath()
+	dbPath, warning, err := resolveDBPath()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := open(dbPath)
 	if err != nil {
 		return nil, err
 	}
+	db.warning = warning
+	return db, nil
+}
 
+// Warning describes why the config won't persist (temp dir or in-memory
+// fallback), or is empty when the database lives in a durable location
+func (d *DB) Warning() string {
+	return d.warning
+}
+
+func open(dbPath string) (*DB, error) {
 	conn, err := sql.Open("sqlite3", dbPath)
 	if err != nil {
 		return nil, err
 	}
+	if dbPath == MemoryDir {
+		// Every connection to :memory: is a separate database
+		conn.SetMaxOpenConns(1)
+	}
 
 	db := &DB{conn: conn}
+	if err := db.checkIntegrity(); err != nil {
+		conn.Close()
+		return nil, err
+	}
 	if err := db.migrate(); err != nil {
 		conn.Close()
+		if isCorruption(err) {
+			return nil, fmt.Errorf("%w: %v", ErrCorrupt, err)
+		}
 		return nil, err
 	}
 
 	return db, nil
 }
 
+// checkIntegrity runs a quick integrity check so a damaged file is reported
+// as ErrCorrupt instead of failing later with a cryptic SQLite error
+func (d *DB) checkIntegrity() error {
+	rows, err := d.conn.Query(`PRAGMA quick_check`)
+	if err != nil {
+		if isCorruption(err) {
+			return fmt.Errorf("%w: %v", ErrCorrupt, err)
+		}
+		return err
+	}
+	defer rows.Close()
+
+	var problems []string
+	for rows.Next() {
+		var result string
+		if err := rows.Scan(&result); err != nil {
+			return fmt.Errorf("%w: %v", ErrCorrupt, err)
+		}
+		if result != "ok" {
+			problems = append(problems, result)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		if isCorruption(err) {
+			return fmt.Errorf("%w: %v", ErrCorrupt, err)
+		}
+		return err
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrCorrupt, problems[0])
+	}
+
+	return nil
+}
+
+func isCorruption(err error) bool {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code == sqlite3.ErrCorrupt || sqliteErr.Code == sqlite3.ErrNotADB
+	}
+	return false
+}
+
 func (d *DB) Close() error {
 	return d.conn.Close()
 }
 
-func getDBPath() (string, error) {
-	home, err := os.UserHomeDir()
+// Path returns the location of the config database
+func Path() (string, error) {
+	dbPath, _, err := resolveDBPath()
+	return dbPath, err
+}
+
+// resolveDBPath picks where the config database lives. BUNTIME_CONFIG_DIR
+// wins when set (":memory:" keeps it in memory). Otherwise ~/.buntime is used,
+// falling back to $XDG_CONFIG_HOME/buntime and finally a temp dir when the
+// home directory isn't writable.
+func resolveDBPath() (dbPath, warning string, err error) {
+	if dir := os.Getenv("BUNTIME_CONFIG_DIR"); dir != "" {
+		if dir == MemoryDir {
+			return MemoryDir, "Using an in-memory config; saved servers won't persist", nil
+		}
+		if err := ensureWritableDir(dir); err != nil {
+			return "", "", fmt.Errorf("BUNTIME_CONFIG_DIR is not writable: %w", err)
+		}
+		return filepath.Join(dir, "config.db"), "", nil
+	}
+
+	var candidates []string
+	if home, err := os.UserHomeDir(); err == nil {
+		candidates = append(candidates, filepath.Join(home, ".buntime"))
+	}
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		candidates = append(candidates, filepath.Join(xdg, "buntime"))
+	}
+
+	for _, dir := range candidates {
+		if ensureWritableDir(dir) == nil {
+			return filepath.Join(dir, "config.db"), "", nil
+		}
+	}
+
+	dir, err := tempConfigDir()
+	if err != nil {
+		return "", "", fmt.Errorf("no writable config directory found (set BUNTIME_CONFIG_DIR): %w", err)
+	}
+	return filepath.Join(dir, "config.db"), "Config directory is not writable; using " + dir + " (settings may not persist)", nil
+}
+
+// tempConfigDir returns the per-user config directory in the temp dir. Its
+// name is easy to guess, so when someone else got there first, or others can
+// open it, a fresh directory is made instead; the database holds API tokens.
+func tempConfigDir() (string, error) {
+	dir := filepath.Join(os.TempDir(), fmt.Sprintf("buntime-%d", os.Getuid()))
+	if err := os.Mkdir(dir, 0700); err == nil || os.IsExist(err) {
+		if checkPrivateDir(dir) == nil && ensureWritableDir(dir) == nil {
+			return dir, nil
+		}
+	}
+
+	dir, err := os.MkdirTemp("", "buntime-")
 	if err != nil {
 		return "", err
 	}
+	return dir, ensureWritableDir(dir)
+}
+
+// checkPrivateDir checks that dir is a directory, not a link to one, that
+// only the current user can open
+func checkPrivateDir(dir string) error {
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	return checkPrivateDirInfo(dir, info)
+}
 
-	dir := filepath.Join(home, ".buntime")
+// ensureWritableDir creates dir if needed and checks that files can be
+// written to it
+func ensureWritableDir(dir string) error {
 	if err := os.MkdirAll(dir, 0755); err != nil {
-		return "", err
+		return err
 	}
 
-	return filepath.Join(dir, "config.db"), nil
+	probe, err := os.CreateTemp(dir, ".write-test-*")
+	if err != nil {
+		return err
+	}
+	probe.Close()
+	return os.Remove(probe.Name())
 }
 
 func (d *DB) migrate() error {
@@ -70,7 +246,10 @@ func (d *DB) migrate() error {
 		token TEXT,
 		insecure INTEGER NOT NULL DEFAULT 0,
 		last_used_at INTEGER,
-		created_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now'))
+		created_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now')),
+		notes TEXT NOT NULL DEFAULT '',
+		pinned INTEGER NOT NULL DEFAULT 0,
+		timeout_seconds INTEGER NOT NULL DEFAULT 0
 	);
 
 	CREATE TABLE IF NOT EXISTS config (
@@ -78,68 +257,70 @@ func (d *DB) migrate() error {
 		value TEXT NOT NULL
 	);
 	`
-	_, err := d.conn.Exec(schema)
-	return err
-}
+	if _, err := d.conn.Exec(schema); err != nil {
+		return err
+	}
 
-// Server CRUD operations
+	// Columns added after the first release
+	if err := d.addColumnIfMissing("servers", "notes", `TEXT NOT NULL DEFAULT ''`); err != nil {
+		return err
+	}
+	if err := d.addColumnIfMissing("servers", "pinned", `INTEGER NOT NULL DEFAULT 0`); err != nil {
+		return err
+	}
+	return d.addColumnIfMissing("servers", "timeout_seconds", `INTEGER NOT NULL DEFAULT 0`)
+}
 
-func (d *DB) ListServers() ([]Server, error) {
-	rows, err := d.conn.Query(`
-		SELECT id, name, url, token, insecure, last_used_at, created_at
-		FROM servers
-		ORDER BY last_used_at DESC NULLS LAST, created_at DESC
-	`)
+func (d *DB) addColumnIfMissing(table, column, definition string) error {
+	rows, err := d.conn.Query(`PRAGMA table_info(` + table + `)`)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer rows.Close()
 
-	var servers []Server
 	for rows.Next() {
-		var s Server
-		var lastUsed, created sql.NullInt64
-		var token sql.NullString
-		var insecure int
-
-		err := rows.Scan(&s.ID, &s.Name, &s.URL, &token, &insecure, &lastUsed, &created)
-		if err != nil {
-			return nil, err
+		var cid, notNull, pk int
+		var name, colType string
+		var defaultValue sql.NullString
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &defaultValue, &pk); err != nil {
+			return err
 		}
-
-		if token.Valid {
-			s.Token = &token.String
+		if name == column {
+			return nil
 		}
-		s.Insecure = insecure == 1
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
 
-		if lastUsed.Valid {
-			t := time.Unix(lastUsed.Int64, 0)
-			s.LastUsedAt = &t
-		}
-		if created.Valid {
-			s.CreatedAt = time.Unix(created.Int64, 0)
-		}
+	_, err = d.conn.Exec(`ALTER TABLE ` + table + ` ADD COLUMN ` + column + ` ` + definition)
+	return err
+}
 
-		servers = append(servers, s)
-	}
+// Server CRUD operations
 
-	return servers, nil
+const serverColumns = `id, name, url, token, insecure, last_used_at, created_at, notes, pinned, timeout_seconds`
+
+// legacyServerColumns read servers saved before later columns existed, newest
+// schema first, for salvaging old databases that cannot be migrated in place
+var legacyServerColumns = []string{
+	`id, name, url, token, insecure, last_used_at, created_at, notes, pinned, 0 AS timeout_seconds`,
+	`id, name, url, token, insecure, last_used_at, created_at, notes, 0 AS pinned, 0 AS timeout_seconds`,
+	`id, name, url, token, insecure, last_used_at, created_at, '' AS notes, 0 AS pinned, 0 AS timeout_seconds`,
 }
 
-func (d *DB) GetServer(id int64) (*Server, error) {
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanServer(row rowScanner) (*Server, error) {
 	var s Server
 	var lastUsed, created sql.NullInt64
 	var token sql.NullString
-	var insecure int
-
-	err := d.conn.QueryRow(`
-		SELECT id, name, url, token, insecure, last_used_at, created_at
-		FROM servers WHERE id = ?
-	`, id).Scan(&s.ID, &s.Name, &s.URL, &token, &insecure, &lastUsed, &created)
+	var insecure, pinned int
 
-	if err == sql.ErrNoRows {
-		return nil, nil
-	}
+	err := row.Scan(&s.ID, &s.Name, &s.URL, &token, &insecure, &lastUsed, &created, &s.Notes, &pinned, &s.TimeoutSeconds)
 	if err != nil {
 		return nil, err
 	}
@@ -148,6 +329,7 @@ func (d *DB) GetServer(id int64) (*Server, error) {
 		s.Token = &token.String
 	}
 	s.Insecure = insecure == 1
+	s.Pinned = pinned == 1
 
 	if lastUsed.Valid {
 		t := time.Unix(lastUsed.Int64, 0)
@@ -160,38 +342,48 @@ func (d *DB) GetServer(id int64) (*Server, error) {
 	return &s, nil
 }
 
-func (d *DB) GetServerByURL(url string) (*Server, error) {
-	var s Server
-	var lastUsed, created sql.NullInt64
-	var token sql.NullString
-	var insecure int
-
-	err := d.conn.QueryRow(`
-		SELECT id, name, url, token, insecure, last_used_at, created_at
-		FROM servers WHERE url = ?
-	`, url).Scan(&s.ID, &s.Name, &s.URL, &token, &insecure, &lastUsed, &created)
+// ListServers returns the pinned servers first, then the most recently used
+func (d *DB) ListServers() ([]Server, error) {
+	return d.listServers(serverColumns)
+}
 
-	if err == sql.ErrNoRows {
-		return nil, nil
-	}
+func (d *DB) listServers(columns string) ([]Server, error) {
+	rows, err := d.conn.Query(`
+		SELECT ` + columns + `
+		FROM servers
+		ORDER BY pinned DESC, last_used_at DESC NULLS LAST, created_at DESC
+	`)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	if token.Valid {
-		s.Token = &token.String
+	var servers []Server
+	for rows.Next() {
+		s, err := scanServer(rows)
+		if err != nil {
+			return nil, err
+		}
+		servers = append(servers, *s)
 	}
-	s.Insecure = insecure == 1
 
-	if lastUsed.Valid {
-		t := time.Unix(lastUsed.Int64, 0)
-		s.LastUsedAt = &t
-	}
-	if created.Valid {
-		s.CreatedAt = time.Unix(created.Int64, 0)
+	return servers, nil
+}
+
+func (d *DB) GetServer(id int64) (*Server, error) {
+	s, err := scanServer(d.conn.QueryRow(`SELECT `+serverColumns+` FROM servers WHERE id = ?`, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
 	}
+	return s, err
+}
 
-	return &s, nil
+func (d *DB) GetServerByURL(url string) (*Server, error) {
+	s, err := scanServer(d.conn.QueryRow(`SELECT `+serverColumns+` FROM servers WHERE url = ?`, url))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return s, err
 }
 
 func (d *DB) CreateServer(name, url string, token *string, insecure bool) (*Server, error) {
@@ -247,6 +439,40 @@ func (d *DB) UpdateServerToken(id int64, token string) error {
 	return err
 }
 
+// UpdateServerNotes replaces the free-form notes of a server
+func (d *DB) UpdateServerNotes(id int64, notes string) error {
+	_, err := d.conn.Exec(`UPDATE servers SET notes = ? WHERE id = ?`, notes, id)
+	return err
+}
+
+// UpdateServerTimeout sets the request timeout of a server in seconds, or 0
+// for the default
+func (d *DB) UpdateServerTimeout(id int64, seconds int) error {
+	_, err := d.conn.Exec(`UPDATE servers SET timeout_seconds = ? WHERE id = ?`, seconds, id)
+	return err
+}
+
+// SetServerInsecure turns TLS certificate verification off for a server, or
+// back on
+func (d *DB) SetServerInsecure(id int64, insecure bool) error {
+	insecureInt := 0
+	if insecure {
+		insecureInt = 1
+	}
+	_, err := d.conn.Exec(`UPDATE servers SET insecure = ? WHERE id = ?`, insecureInt, id)
+	return err
+}
+
+// SetServerPinned pins a server to the top of the server list, or unpins it
+func (d *DB) SetServerPinned(id int64, pinned bool) error {
+	pinnedInt := 0
+	if pinned {
+		pinnedInt = 1
+	}
+	_, err := d.conn.Exec(`UPDATE servers SET pinned = ? WHERE id = ?`, pinnedInt, id)
+	return err
+}
+
 func (d *DB) ResetAll() error {
 	_, err := d.conn.Exec(`DELETE FROM servers; DELETE FROM config;`)
 	return err
@@ -254,6 +480,19 @@ func (d *DB) ResetAll() error {
 
 // Config key-value store
 
+// Config keys
+const (
+	ConfigVerifyInstalls   = "verify_installs"   // Re-check server health after installs
+	ConfigRollbackInstalls = "rollback_installs" // Remove an install that left the server unhealthy
+	ConfigConfirmLevel     = "confirm_level"     // How destructive actions are confirmed
+	ConfigConfirmHighRisk  = "confirm_high_risk" // "false" lets high-risk actions follow the confirm level
+	ConfigSendProvenance   = "send_provenance"   // "false" stops sending CLI version and user@host on changes
+	ConfigShowLegend       = "show_legend"       // Show every shortcut in a multi-line legend
+	ConfigChooseVersion    = "choose_version"    // "false" enables plugins without asking which version
+	ConfigCompletionAlert  = "completion_alert"  // "bell" or "notify" when long operations finish
+	ConfigDensity          = "density"           // "compact" tightens lists and page titles
+)
+
 func (d *DB) GetConfig(key string) (string, error) {
 	var value string
 	err := d.conn.QueryRow(`SELECT value FROM config WHERE key = ?`, key).Scan(&value)
@@ -270,3 +509,31 @@ func (d *DB) SetConfig(key, value string) error {
 	`, key, value)
 	return err
 }
+
+// GetConfigBool returns a boolean config value (false when unset)
+func (d *DB) GetConfigBool(key string) bool {
+	value, err := d.GetConfig(key)
+	return err == nil && value == "true"
+}
+
+// SendProvenance reports whether provenance headers should be sent. It is
+// on unless the user opted out.
+func (d *DB) SendProvenance() bool {
+	value, err := d.GetConfig(ConfigSendProvenance)
+	return err != nil || value != "false"
+}
+
+// ChooseEnableVersion reports whether enabling a plugin with several versions
+// asks which one to run. It is on unless the user opted out.
+func (d *DB) ChooseEnableVersion() bool {
+	value, err := d.GetConfig(ConfigChooseVersion)
+	return err != nil || value != "false"
+}
+
+// SetConfigBool stores a boolean config value
+func (d *DB) SetConfigBool(key string, value bool) error {
+	if value {
+		return d.SetConfig(key, "true")
+	}
+	return d.SetConfig(key, "false")
+}
@@ -0,0 +1,191 @@
+package db
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestResolveDBPathPrefersConfigDirEnv(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("BUNTIME_CONFIG_DIR", dir)
+
+	path, warning, err := resolveDBPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != filepath.Join(dir, "config.db") || warning != "" {
+		t.Fatalf("unexpected path %q (warning %q)", path, warning)
+	}
+}
+
+func TestResolveDBPathFallsBackWhenHomeIsReadOnly(t *testing.T) {
+	if os.Getuid() == 0 {
+		t.Skip("permission bits are not enforced for root")
+	}
+
+	home := t.TempDir()
+	if err := os.Chmod(home, 0555); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chmod(home, 0755) })
+
+	xdg := t.TempDir()
+	t.Setenv("BUNTIME_CONFIG_DIR", "")
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+
+	path, _, err := resolveDBPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != filepath.Join(xdg, "buntime", "config.db") {
+		t.Fatalf("expected XDG fallback, got %q", path)
+	}
+}
+
+func TestNewInMemory(t *testing.T) {
+	t.Setenv("BUNTIME_CONFIG_DIR", MemoryDir)
+
+	database, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+
+	if database.Warning() == "" {
+		t.Fatal("expected a warning that config won't persist")
+	}
+	if _, err := database.CreateServer("Local", "http://localhost:8000", nil, false); err != nil {
+		t.Fatal(err)
+	}
+	servers, err := database.ListServers()
+	if err != nil || len(servers) != 1 {
+		t.Fatalf("expected 1 server, got %d (err %v)", len(servers), err)
+	}
+}
+
+func TestMigrateAddsNotesToExistingDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.db")
+	conn, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = conn.Exec(`
+		CREATE TABLE servers (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL,
+			url TEXT NOT NULL UNIQUE,
+			token TEXT,
+			insecure INTEGER NOT NULL DEFAULT 0,
+			last_used_at INTEGER,
+			created_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now'))
+		);
+		INSERT INTO servers (name, url) VALUES ('Production', 'https://buntime.example');
+	`)
+	conn.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	database, err := open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+
+	server, err := database.GetServerByURL("https://buntime.example")
+	if err != nil || server == nil {
+		t.Fatalf("expected existing server, got %v (err %v)", server, err)
+	}
+	notes := "prod us-east\non-call: ops"
+	if err := database.UpdateServerNotes(server.ID, notes); err != nil {
+		t.Fatal(err)
+	}
+	server, err = database.GetServer(server.ID)
+	if err != nil || server.Notes != notes {
+		t.Fatalf("expected notes %q, got %+v (err %v)", notes, server, err)
+	}
+}
+
+func TestServerTimeout(t *testing.T) {
+	database, err := open(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+
+	server, err := database.CreateServer("Production", "https://buntime.example", nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server.Timeout() != 0 {
+		t.Fatalf("expected no timeout by default, got %v", server.Timeout())
+	}
+
+	if err := database.UpdateServerTimeout(server.ID, 120); err != nil {
+		t.Fatal(err)
+	}
+	server, err = database.GetServer(server.ID)
+	if err != nil || server.Timeout() != 2*time.Minute {
+		t.Fatalf("expected a 2m timeout, got %+v (err %v)", server, err)
+	}
+
+	for seconds, wantErr := range map[int]bool{0: false, 3600: false, -1: true, 3601: true} {
+		if err := ValidateServerTimeout(seconds); (err != nil) != wantErr {
+			t.Errorf("ValidateServerTimeout(%d) = %v, want error %v", seconds, err, wantErr)
+		}
+	}
+}
+
+func TestListServersPutsPinnedServersFirst(t *testing.T) {
+	t.Setenv("BUNTIME_CONFIG_DIR", MemoryDir)
+
+	database, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+
+	daily, err := database.CreateServer("Daily", "https://daily.example", nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recent, err := database.CreateServer("Recent", "https://recent.example", nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := database.TouchServer(recent.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := database.SetServerPinned(daily.ID, true); err != nil {
+		t.Fatal(err)
+	}
+
+	servers, err := database.ListServers()
+	if err != nil || len(servers) != 2 {
+		t.Fatalf("expected 2 servers, got %d (err %v)", len(servers), err)
+	}
+	if servers[0].ID != daily.ID || !servers[0].Pinned || servers[1].Pinned {
+		t.Fatalf("expected the pinned server first, got %+v", servers)
+	}
+}
+
+func TestValidateServerURL(t *testing.T) {
+	for url, wantErr := range map[string]bool{
+		" https://buntime.example ": false,
+		"http://localhost:8000":     false,
+		"":                          true,
+		"buntime.example":           true,
+		"https://":                  true,
+	} {
+		if err := ValidateServerURL(url); (err != nil) != wantErr {
+			t.Errorf("ValidateServerURL(%q) = %v, want error %v", url, err, wantErr)
+		}
+	}
+	if name := DefaultServerName("https://api.example.com"); name != "Example.com" {
+		t.Errorf("DefaultServerName() = %q", name)
+	}
+}
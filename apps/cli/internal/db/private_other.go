@@ -0,0 +1,11 @@
+//go:build !unix
+
+package db
+
+import "os"
+
+// checkPrivateDirInfo trusts the temp dir where Unix owners and modes don't
+// apply; it is already per user there
+func checkPrivateDirInfo(dir string, info os.FileInfo) error {
+	return nil
+}
@@ -0,0 +1,21 @@
+//go:build unix
+
+package db
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// checkPrivateDirInfo refuses a directory owned by another user or open to
+// the group or others
+func checkPrivateDirInfo(dir string, info os.FileInfo) error {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok && int(stat.Uid) != os.Getuid() {
+		return fmt.Errorf("%s is owned by another user", dir)
+	}
+	if info.Mode().Perm()&0077 != 0 {
+		return fmt.Errorf("%s can be opened by other users (mode %v)", dir, info.Mode().Perm())
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+//go:build unix
+
+package db
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTempConfigDirSkipsADirectoryOthersCanOpen(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+	guessed := filepath.Join(os.TempDir(), fmt.Sprintf("buntime-%d", os.Getuid()))
+	if err := os.Mkdir(guessed, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(guessed, 0777); err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := tempConfigDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dir == guessed {
+		t.Fatalf("expected a fresh directory instead of the world-writable %s", guessed)
+	}
+	if info, err := os.Stat(dir); err != nil || info.Mode().Perm() != 0700 {
+		t.Fatalf("expected a 0700 directory, got %v (err %v)", info.Mode(), err)
+	}
+}
+
+func TestTempConfigDirSkipsADirectoryOwnedByAnotherUser(t *testing.T) {
+	if os.Getuid() != 0 {
+		t.Skip("only root can hand a directory to another user")
+	}
+
+	t.Setenv("TMPDIR", t.TempDir())
+	guessed := filepath.Join(os.TempDir(), fmt.Sprintf("buntime-%d", os.Getuid()))
+	if err := os.Mkdir(guessed, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chown(guessed, 65534, 65534); err != nil {
+		t.Fatal(err)
+	}
+
+	if dir, err := tempConfigDir(); err != nil || dir == guessed {
+		t.Fatalf("tempConfigDir() = %q, %v; want a directory of our own", dir, err)
+	}
+}
+
+func TestTempConfigDirUsesItsOwnPrivateDirectory(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+	guessed := filepath.Join(os.TempDir(), fmt.Sprintf("buntime-%d", os.Getuid()))
+
+	dir, err := tempConfigDir()
+	if err != nil || dir != guessed {
+		t.Fatalf("tempConfigDir() = %q, %v; want %q", dir, err, guessed)
+	}
+	// The same directory is used again on the next run
+	if dir, err := tempConfigDir(); err != nil || dir != guessed {
+		t.Fatalf("tempConfigDir() = %q, %v; want %q again", dir, err, guessed)
+	}
+}
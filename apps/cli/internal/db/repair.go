@@ -0,0 +1,121 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"time"
+)
+
+// RepairResult describes what Repair did with a corrupted config database
+type RepairResult struct {
+	BackupPath       string
+	ServersRecovered int
+	ConfigRecovered  int
+}
+
+// Repair moves the config database aside and recreates it, copying over any
+// servers and settings that can still be read from the damaged file.
+func Repair() (*RepairResult, error) {
+	dbPath, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	if dbPath == MemoryDir {
+		return nil, fmt.Errorf("the config database is in memory; nothing to repair")
+	}
+
+	return repair(dbPath)
+}
+
+func repair(dbPath string) (*RepairResult, error) {
+	backupPath := fmt.Sprintf("%s.corrupt-%s", dbPath, time.Now().Format("20060102-150405"))
+	if err := os.Rename(dbPath, backupPath); err != nil {
+		return nil, fmt.Errorf("failed to back up config database: %w", err)
+	}
+	// Journal files belong to the damaged database and must not be replayed
+	// into the new one
+	for _, suffix := range []string{"-journal", "-wal", "-shm"} {
+		if _, err := os.Stat(dbPath + suffix); err == nil {
+			os.Rename(dbPath+suffix, backupPath+suffix)
+		}
+	}
+
+	servers, config := salvage(backupPath)
+
+	fresh, err := open(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recreate config database: %w", err)
+	}
+	defer fresh.Close()
+
+	result := &RepairResult{BackupPath: backupPath}
+	for _, s := range servers {
+		if err := fresh.restoreServer(s); err == nil {
+			result.ServersRecovered++
+		}
+	}
+	for key, value := range config {
+		if err := fresh.SetConfig(key, value); err == nil {
+			result.ConfigRecovered++
+		}
+	}
+
+	return result, nil
+}
+
+// salvage reads whatever rows are still readable from a damaged database.
+// Unreadable tables are skipped rather than failing the repair.
+func salvage(path string) ([]Server, map[string]string) {
+	conn, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
+	if err != nil {
+		return nil, nil
+	}
+	defer conn.Close()
+
+	damaged := &DB{conn: conn}
+	servers, err := damaged.ListServers()
+	// Databases from before the later columns can't be migrated read-only
+	for _, columns := range legacyServerColumns {
+		if err == nil {
+			break
+		}
+		servers, err = damaged.listServers(columns)
+	}
+
+	config := make(map[string]string)
+	rows, err := conn.Query(`SELECT key, value FROM config`)
+	if err == nil {
+		for rows.Next() {
+			var key, value string
+			if rows.Scan(&key, &value) == nil {
+				config[key] = value
+			}
+		}
+		rows.Close()
+	}
+
+	return servers, config
+}
+
+func (d *DB) restoreServer(s Server) error {
+	insecureInt, pinnedInt := 0, 0
+	if s.Insecure {
+		insecureInt = 1
+	}
+	if s.Pinned {
+		pinnedInt = 1
+	}
+
+	var lastUsed *int64
+	if s.LastUsedAt != nil {
+		t := s.LastUsedAt.Unix()
+		lastUsed = &t
+	}
+
+	_, err := d.conn.Exec(`
+		INSERT INTO servers (name, url, token, insecure, last_used_at, created_at, notes, pinned, timeout_seconds)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, s.Name, s.URL, s.Token, insecureInt, lastUsed, s.CreatedAt.Unix(), s.Notes, pinnedInt, s.TimeoutSeconds)
+	return err
+}
@@ -0,0 +1,75 @@
+package db
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenReportsCorruptDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.db")
+	if err := os.WriteFile(path, []byte("this is not a sqlite database, just garbage bytes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := open(path)
+	if !errors.Is(err, ErrCorrupt) {
+		t.Fatalf("expected ErrCorrupt, got %v", err)
+	}
+
+	result, err := repair(path)
+	if err != nil {
+		t.Fatalf("repair failed: %v", err)
+	}
+	if _, err := os.Stat(result.BackupPath); err != nil {
+		t.Fatalf("expected backup at %s: %v", result.BackupPath, err)
+	}
+
+	database, err := open(path)
+	if err != nil {
+		t.Fatalf("expected fresh database after repair, got %v", err)
+	}
+	database.Close()
+}
+
+func TestRepairKeepsReadableRows(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.db")
+	database, err := open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := "secret"
+	if _, err := database.CreateServer("Production", "https://buntime.example", &token, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := database.SetConfigBool(ConfigVerifyInstalls, true); err != nil {
+		t.Fatal(err)
+	}
+	database.Close()
+
+	result, err := repair(path)
+	if err != nil {
+		t.Fatalf("repair failed: %v", err)
+	}
+	if result.ServersRecovered != 1 || result.ConfigRecovered != 1 {
+		t.Fatalf("expected 1 server and 1 setting recovered, got %+v", result)
+	}
+
+	database, err = open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+
+	server, err := database.GetServerByURL("https://buntime.example")
+	if err != nil || server == nil {
+		t.Fatalf("expected recovered server, got %v (err %v)", server, err)
+	}
+	if server.Token == nil || *server.Token != token || !server.Insecure {
+		t.Fatalf("server fields not preserved: %+v", server)
+	}
+	if !database.GetConfigBool(ConfigVerifyInstalls) {
+		t.Fatal("expected verify_installs to be preserved")
+	}
+}
@@ -0,0 +1,61 @@
+package db
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// ValidateServerURL checks that rawURL, trimmed, is an http(s) URL with a
+// hostname. The errors are worded for showing to the user as is.
+func ValidateServerURL(rawURL string) error {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return errors.New("URL is required")
+	}
+
+	if !strings.HasPrefix(rawURL, "http://") && !strings.HasPrefix(rawURL, "https://") {
+		return errors.New("URL must start with http:// or https://")
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.New("Invalid URL format")
+	}
+
+	if parsed.Host == "" {
+		return errors.New("URL must include a hostname")
+	}
+
+	return nil
+}
+
+// CheckServerURLFree fails, naming the server, when a saved server other than
+// exceptID already uses rawURL. Pass 0 to check against every server.
+func (d *DB) CheckServerURLFree(rawURL string, exceptID int64) error {
+	existing, err := d.GetServerByURL(strings.TrimSpace(rawURL))
+	if err == nil && existing != nil && existing.ID != exceptID {
+		return errors.New("Server with this URL already exists: \"" + existing.Name + "\"")
+	}
+	return nil
+}
+
+// DefaultServerName names a server after its host, e.g. "Example.com" for
+// https://api.example.com
+func DefaultServerName(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "Server"
+	}
+
+	host := parsed.Hostname()
+	host = strings.TrimPrefix(host, "www.")
+	host = strings.TrimPrefix(host, "api.")
+	host = strings.TrimPrefix(host, "buntime.")
+
+	if len(host) > 0 {
+		return strings.ToUpper(host[:1]) + host[1:]
+	}
+
+	return "Server"
+}
@@ -0,0 +1,60 @@
+package deploy
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// TokenPlaceholder stands in for the API key in generated commands so they
+// can be pasted into runbooks without leaking a secret
+const TokenPlaceholder = `"$BUNTIME_API_KEY"`
+
+// InstallCommand describes an install so it can be repeated from the
+// command line
+type InstallCommand struct {
+	ItemType  string // "app" or "plugin"
+	Path      string // Archive that was installed
+	ServerURL string
+	Insecure  bool
+	Force     bool
+	Verify    bool
+	Rollback  bool
+}
+
+// String renders the command, e.g.
+// buntime --url https://buntime.home --token "$BUNTIME_API_KEY" app install /abs/my-app.zip
+// The path is made absolute so the command works from any directory.
+func (c InstallCommand) String() string {
+	path := c.Path
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	args := []string{"buntime", "--url", shellQuote(c.ServerURL), "--token", TokenPlaceholder}
+	if c.Insecure {
+		args = append(args, "--insecure")
+	}
+	args = append(args, c.ItemType, "install", shellQuote(path))
+	if c.Force {
+		args = append(args, "--force")
+	}
+	// --rollback implies --verify
+	if c.Rollback {
+		args = append(args, "--rollback")
+	} else if c.Verify {
+		args = append(args, "--verify")
+	}
+	return strings.Join(args, " ")
+}
+
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// shellQuote quotes s for POSIX shells when it has characters they would
+// interpret
+func shellQuote(s string) string {
+	if shellSafe.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,32 @@
+package deploy
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInstallCommand(t *testing.T) {
+	cmd := InstallCommand{
+		ItemType:  "app",
+		Path:      "/srv/builds/my app's.zip",
+		ServerURL: "https://buntime.home",
+		Insecure:  true,
+		Force:     true,
+		Verify:    true,
+		Rollback:  true,
+	}
+
+	want := `buntime --url https://buntime.home --token "$BUNTIME_API_KEY" --insecure app install '/srv/builds/my app'\''s.zip' --force --rollback`
+	if got := cmd.String(); got != want {
+		t.Fatalf("String() = %s\nwant        %s", got, want)
+	}
+}
+
+func TestInstallCommandMakesPathAbsolute(t *testing.T) {
+	cmd := InstallCommand{ItemType: "plugin", Path: "plugin.tgz", ServerURL: "https://buntime.home"}
+
+	got := cmd.String()
+	if strings.Contains(got, " plugin.tgz") || !strings.Contains(got, "/plugin.tgz") {
+		t.Fatalf("expected an absolute path, got %s", got)
+	}
+}
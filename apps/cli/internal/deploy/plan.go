@@ -0,0 +1,240 @@
+package deploy
+
+import (
+	"fmt"
+
+	"github.com/buntime/cli/internal/api"
+	"golang.org/x/sync/errgroup"
+)
+
+// Action is what a plan step does to an app or plugin
+type Action string
+
+const (
+	ActionInstall Action = "install"
+	ActionEnable  Action = "enable"
+	ActionDisable Action = "disable"
+	ActionRemove  Action = "remove"
+)
+
+// Step is a single change needed to reconcile the server with a spec
+type Step struct {
+	Action   Action `json:"action"`
+	ItemType string `json:"type"` // "app" or "plugin"
+	Name     string `json:"name"`
+	Version  string `json:"version,omitempty"` // Version being installed; empty for other actions
+	Source   string `json:"source,omitempty"`  // Archive to install; empty for other actions
+}
+
+func (s Step) String() string {
+	switch s.Action {
+	case ActionInstall:
+		return fmt.Sprintf("+ install %s %s@%s from %s", s.ItemType, s.Name, s.Version, s.Source)
+	case ActionRemove:
+		return fmt.Sprintf("- remove  %s %s", s.ItemType, s.Name)
+	default:
+		return fmt.Sprintf("~ %-7s %s %s", s.Action, s.ItemType, s.Name)
+	}
+}
+
+// Plan is the ordered list of steps: plugins are installed and toggled
+// before apps that may depend on them, and pruning happens last
+type Plan struct {
+	Steps []Step
+}
+
+// Empty reports whether the server already matches the spec
+func (p *Plan) Empty() bool {
+	return len(p.Steps) == 0
+}
+
+// State is what is currently installed on a server
+type State struct {
+	Apps    []api.AppInfo
+	Plugins []api.PluginInfo
+}
+
+// FetchState lists the server's installed apps and plugins concurrently
+func FetchState(client *api.Client) (*State, error) {
+	var state State
+	var g errgroup.Group
+	g.Go(func() (err error) {
+		state.Apps, err = client.ListApps()
+		return err
+	})
+	g.Go(func() (err error) {
+		state.Plugins, err = client.ListPlugins()
+		return err
+	})
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// BuildPlan works out the steps that bring state in line with spec. With
+// prune, apps and plugins missing from the spec are removed.
+func BuildPlan(spec *Spec, state *State, prune bool) (*Plan, error) {
+	plan := &Plan{}
+	var installApps, removes []Step
+
+	installedPlugins := make(map[string]api.PluginInfo, len(state.Plugins))
+	for _, plugin := range state.Plugins {
+		installedPlugins[plugin.Name] = plugin
+	}
+	wantedPlugins := make(map[string]bool, len(spec.Plugins))
+	for _, item := range spec.Plugins {
+		wantedPlugins[item.Name] = true
+
+		plugin, installed := installedPlugins[item.Name]
+		var versions []string
+		if installed {
+			versions = plugin.Versions
+		}
+		install, err := planInstall("plugin", item, versions, installed)
+		if err != nil {
+			return nil, err
+		}
+
+		if install != nil {
+			plan.Steps = append(plan.Steps, *install)
+			// Fresh installs come up enabled
+			if item.Enabled != nil && !*item.Enabled {
+				plan.Steps = append(plan.Steps, Step{Action: ActionDisable, ItemType: "plugin", Name: item.Name})
+			}
+			continue
+		}
+		if item.Enabled != nil && *item.Enabled != plugin.Enabled {
+			action := ActionDisable
+			if *item.Enabled {
+				action = ActionEnable
+			}
+			plan.Steps = append(plan.Steps, Step{Action: action, ItemType: "plugin", Name: item.Name})
+		}
+	}
+
+	installedApps := make(map[string]api.AppInfo, len(state.Apps))
+	for _, app := range state.Apps {
+		installedApps[app.Name] = app
+	}
+	wantedApps := make(map[string]bool, len(spec.Apps))
+	for _, item := range spec.Apps {
+		wantedApps[item.Name] = true
+
+		app, installed := installedApps[item.Name]
+		install, err := planInstall("app", item, app.Versions, installed)
+		if err != nil {
+			return nil, err
+		}
+		if install != nil {
+			installApps = append(installApps, *install)
+		}
+	}
+
+	if prune {
+		for _, plugin := range state.Plugins {
+			if !wantedPlugins[plugin.Name] {
+				removes = append(removes, Step{Action: ActionRemove, ItemType: "plugin", Name: plugin.Name})
+			}
+		}
+		for _, app := range state.Apps {
+			if !wantedApps[app.Name] {
+				removes = append(removes, Step{Action: ActionRemove, ItemType: "app", Name: app.Name})
+			}
+		}
+	}
+
+	plan.Steps = append(plan.Steps, installApps...)
+	plan.Steps = append(plan.Steps, removes...)
+	return plan, nil
+}
+
+// planInstall returns the install step for an item, or nil when the wanted
+// version is already installed
+func planInstall(itemType string, item Item, versions []string, installed bool) (*Step, error) {
+	version := item.Version
+	if item.Source != "" {
+		pkg, err := api.ReadArchivePackage(item.Source)
+		switch {
+		case err == nil:
+			if pkg.Name != item.Name {
+				return nil, fmt.Errorf("%s %s: %s contains %s", itemType, item.Name, item.Source, pkg.Name)
+			}
+			if version != "" && pkg.Version != version {
+				return nil, fmt.Errorf("%s %s: %s contains version %s, spec wants %s", itemType, item.Name, item.Source, pkg.Version, version)
+			}
+			version = pkg.Version
+		case version == "":
+			// Without a readable archive there is no way to tell what would be installed
+			return nil, fmt.Errorf("%s %s: set a version or use a readable archive: %w", itemType, item.Name, err)
+		}
+	}
+
+	if installed && (version == "" || containsVersion(versions, version)) {
+		return nil, nil
+	}
+
+	if item.Source == "" {
+		if version == "" {
+			return nil, fmt.Errorf("%s %s is not installed and has no source", itemType, item.Name)
+		}
+		return nil, fmt.Errorf("%s %s@%s is not installed and has no source", itemType, item.Name, version)
+	}
+
+	return &Step{
+		Action:   ActionInstall,
+		ItemType: itemType,
+		Name:     item.Name,
+		Version:  version,
+		Source:   item.Source,
+	}, nil
+}
+
+func containsVersion(versions []string, version string) bool {
+	for _, v := range versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// Execute applies a single step to the server
+func Execute(client *api.Client, step Step) error {
+	switch step.Action {
+	case ActionInstall:
+		_, err := client.InstallResumable(step.ItemType, step.Source, api.ResumableOptions{})
+		return err
+	case ActionEnable, ActionDisable:
+		id, err := findPluginID(client, step.Name)
+		if err != nil {
+			return err
+		}
+		if step.Action == ActionEnable {
+			return client.EnablePlugin(id)
+		}
+		return client.DisablePlugin(id)
+	case ActionRemove:
+		if step.ItemType == "plugin" {
+			return client.RemovePluginByName(step.Name)
+		}
+		return client.RemoveApp(step.Name, "all")
+	default:
+		return fmt.Errorf("unknown action: %s", step.Action)
+	}
+}
+
+// findPluginID looks the plugin up again so freshly installed plugins
+// resolve to their new ID
+func findPluginID(client *api.Client, name string) (int, error) {
+	plugins, err := client.ListPlugins()
+	if err != nil {
+		return 0, err
+	}
+	for _, p := range plugins {
+		if p.Name == name {
+			return p.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("plugin not found: %s", name)
+}
@@ -0,0 +1,157 @@
+package deploy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/buntime/cli/internal/api"
+)
+
+func TestParseSpecAcceptsYAMLAndJSON(t *testing.T) {
+	yamlSpec := `
+apps:
+  - name: my-app
+    version: 1.0.0
+    source: ./my-app.zip
+plugins:
+  - name: my-plugin
+    enabled: false
+`
+	jsonSpec := `{"apps":[{"name":"my-app","version":"1.0.0","source":"./my-app.zip"}],"plugins":[{"name":"my-plugin","enabled":false}]}`
+
+	for name, content := range map[string]string{"yaml": yamlSpec, "json": jsonSpec} {
+		spec, err := ParseSpec([]byte(content))
+		if err != nil {
+			t.Fatalf("%s: ParseSpec() error = %v", name, err)
+		}
+		if len(spec.Apps) != 1 || spec.Apps[0].Version != "1.0.0" {
+			t.Fatalf("%s: unexpected apps %#v", name, spec.Apps)
+		}
+		if len(spec.Plugins) != 1 || spec.Plugins[0].Enabled == nil || *spec.Plugins[0].Enabled {
+			t.Fatalf("%s: unexpected plugins %#v", name, spec.Plugins)
+		}
+	}
+}
+
+func TestParseSpecRejectsInvalidSpecs(t *testing.T) {
+	tests := map[string]string{
+		"unknown field":      "apps:\n  - name: a\n    verison: 1.0.0\n",
+		"missing name":       "plugins:\n  - version: 1.0.0\n",
+		"duplicate":          "apps:\n  - name: a\n  - name: a\n",
+		"enabled on an app":  "apps:\n  - name: a\n    enabled: true\n",
+		"not a list of item": "apps: a\n",
+	}
+
+	for name, content := range tests {
+		if _, err := ParseSpec([]byte(content)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestBuildPlan(t *testing.T) {
+	enabled, disabled := true, false
+	spec := &Spec{
+		Apps: []Item{
+			{Name: "current-app", Version: "1.0.0", Source: "missing.zip"},
+			{Name: "outdated-app", Version: "2.0.0", Source: "outdated-app.zip"},
+			{Name: "new-app", Version: "1.0.0", Source: "new-app.zip"},
+		},
+		Plugins: []Item{
+			{Name: "on-plugin", Enabled: &enabled},
+			{Name: "off-plugin", Enabled: &disabled},
+			{Name: "new-plugin", Version: "0.1.0", Source: "new-plugin.tgz", Enabled: &disabled},
+		},
+	}
+	state := &State{
+		Apps: []api.AppInfo{
+			{Name: "current-app", Versions: []string{"1.0.0"}},
+			{Name: "outdated-app", Versions: []string{"1.0.0"}},
+			{Name: "unlisted-app", Versions: []string{"1.0.0"}},
+		},
+		Plugins: []api.PluginInfo{
+			{Name: "on-plugin", Enabled: false},
+			{Name: "off-plugin", Enabled: false},
+			{Name: "unlisted-plugin", Enabled: true},
+		},
+	}
+
+	plan, err := BuildPlan(spec, state, false)
+	if err != nil {
+		t.Fatalf("BuildPlan() error = %v", err)
+	}
+	want := []string{
+		"~ enable  plugin on-plugin",
+		"+ install plugin new-plugin@0.1.0 from new-plugin.tgz",
+		"~ disable plugin new-plugin",
+		"+ install app outdated-app@2.0.0 from outdated-app.zip",
+		"+ install app new-app@1.0.0 from new-app.zip",
+	}
+	assertSteps(t, plan, want)
+
+	plan, err = BuildPlan(spec, state, true)
+	if err != nil {
+		t.Fatalf("BuildPlan(prune) error = %v", err)
+	}
+	assertSteps(t, plan, append(want,
+		"- remove  plugin unlisted-plugin",
+		"- remove  app unlisted-app",
+	))
+}
+
+func TestBuildPlanRequiresSourceForMissingItems(t *testing.T) {
+	spec := &Spec{Apps: []Item{{Name: "my-app", Version: "1.0.0"}}}
+
+	_, err := BuildPlan(spec, &State{}, false)
+	if err == nil || !strings.Contains(err.Error(), "no source") {
+		t.Fatalf("expected a missing source error, got %v", err)
+	}
+}
+
+func assertSteps(t *testing.T, plan *Plan, want []string) {
+	t.Helper()
+
+	got := make([]string, len(plan.Steps))
+	for i, step := range plan.Steps {
+		got[i] = step.String()
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected plan:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestFetchStateListsConcurrently(t *testing.T) {
+	// Each list handler waits until the other one has been hit, so a
+	// sequential fetch would time out
+	arrived := make(chan struct{}, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/apps", "/api/plugins":
+			arrived <- struct{}{}
+			deadline := time.After(2 * time.Second)
+			for len(arrived) < 2 {
+				select {
+				case <-deadline:
+					http.Error(w, "lists were fetched one after the other", http.StatusInternalServerError)
+					return
+				case <-time.After(5 * time.Millisecond):
+				}
+			}
+			w.Write([]byte(`[{"name":"one"}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	state, err := FetchState(api.New(server.URL, "", false))
+	if err != nil {
+		t.Fatalf("FetchState() error = %v", err)
+	}
+	if len(state.Apps) != 1 || len(state.Plugins) != 1 {
+		t.Fatalf("unexpected state %+v", state)
+	}
+}
@@ -0,0 +1,93 @@
+package deploy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Spec is the desired state of a server: the apps and plugins it should run
+type Spec struct {
+	Apps    []Item `yaml:"apps"`
+	Plugins []Item `yaml:"plugins"`
+}
+
+// Item is an app or plugin in a spec. Source is the archive to install when
+// the version is missing; without a source the item must already be
+// installed. Version defaults to the archive's version.
+type Item struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+	Source  string `yaml:"source"`
+	// Enabled is the desired plugin state; nil leaves it as it is
+	Enabled *bool `yaml:"enabled"`
+}
+
+// LoadSpec reads a YAML or JSON spec. Relative sources are resolved against
+// the spec file's directory.
+func LoadSpec(path string) (*Spec, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec: %w", err)
+	}
+
+	spec, err := ParseSpec(content)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	for _, items := range [][]Item{spec.Apps, spec.Plugins} {
+		for i := range items {
+			if items[i].Source != "" && !filepath.IsAbs(items[i].Source) {
+				items[i].Source = filepath.Join(dir, items[i].Source)
+			}
+		}
+	}
+
+	return spec, nil
+}
+
+// ParseSpec parses and validates a spec. JSON is accepted as a subset of YAML.
+func ParseSpec(content []byte) (*Spec, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+
+	var spec Spec
+	if err := decoder.Decode(&spec); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid spec: %w", err)
+	}
+
+	if err := validateItems("app", spec.Apps); err != nil {
+		return nil, err
+	}
+	if err := validateItems("plugin", spec.Plugins); err != nil {
+		return nil, err
+	}
+	for _, app := range spec.Apps {
+		if app.Enabled != nil {
+			return nil, fmt.Errorf("app %s: enabled is only supported for plugins", app.Name)
+		}
+	}
+
+	return &spec, nil
+}
+
+func validateItems(itemType string, items []Item) error {
+	seen := make(map[string]bool, len(items))
+	for i, item := range items {
+		if item.Name == "" {
+			return fmt.Errorf("%s #%d: name is required", itemType, i+1)
+		}
+		if seen[item.Name] {
+			return fmt.Errorf("%s %s: listed more than once", itemType, item.Name)
+		}
+		seen[item.Name] = true
+	}
+	return nil
+}
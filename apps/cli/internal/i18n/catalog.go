@@ -0,0 +1,226 @@
+package i18n
+
+// catalogs maps language codes to their messages. English is the reference:
+// every key must exist there, other languages may be partial.
+var catalogs = map[string]map[string]string{
+	"en": en,
+	"pt": pt,
+}
+
+var en = map[string]string{
+	// Main menu
+	"menu.quick_actions":        "QUICK ACTIONS",
+	"menu.apps.title":           "Manage Apps",
+	"menu.apps.description":     "View and manage applications",
+	"menu.plugins.title":        "Manage Plugins",
+	"menu.plugins.description":  "Enable, disable, install plugins",
+	"menu.keys.title":           "API Keys",
+	"menu.keys.description":     "Manage authentication keys",
+	"menu.activity.title":       "Activity",
+	"menu.activity.description": "Recent changes on the server",
+	"menu.settings.title":       "Settings",
+	"menu.settings.description": "Server configuration",
+	"stats.apps":                "APPS",
+	"stats.plugins":             "PLUGINS",
+	"stats.running":             "running",
+	"stats.enabled":             "enabled",
+
+	// Footer shortcuts
+	"shortcut.activate":      "activate",
+	"shortcut.add":           "add",
+	"shortcut.adjust":        "adjust",
+	"shortcut.all":           "all",
+	"shortcut.app_plugin":    "app/plugin",
+	"shortcut.apply":         "apply",
+	"shortcut.back":          "back",
+	"shortcut.batch_install": "batch install (%d)",
+	"shortcut.cancel":        "cancel",
+	"shortcut.clear_filter":  "clear filter",
+	"shortcut.configure":     "configure",
+	"shortcut.confirm":       "confirm",
+	"shortcut.connect":       "connect",
+	"shortcut.continue":      "continue",
+	"shortcut.copy":          "copy",
+	"shortcut.copy_command":  "copy command",
+	"shortcut.copy_details":  "copy details",
+	"shortcut.copy_path":     "copy path",
+	"shortcut.copy_markdown": "copy as markdown",
+	"shortcut.delete":        "delete",
+	"shortcut.details":       "details",
+	"shortcut.directory":     "directory",
+	"shortcut.disable":       "disable",
+	"shortcut.done":          "done",
+	"shortcut.edit":          "edit",
+	"shortcut.enable":        "enable",
+	"shortcut.file":          "file",
+	"shortcut.filter":        "filter",
+	"shortcut.filter_actor":  "filter actor",
+	"shortcut.filter_type":   "filter type",
+	"shortcut.install":       "install",
+	"shortcut.labels":        "labels",
+	"shortcut.more":          "more",
+	"shortcut.navigate":      "navigate",
+	"shortcut.next":          "next",
+	"shortcut.none":          "none",
+	"shortcut.open":          "open",
+	"shortcut.overwrite":     "overwrite",
+	"shortcut.parent":        "parent",
+	"shortcut.paste_path":    "paste path",
+	"shortcut.pin":           "pin",
+	"shortcut.please_wait":   "Please wait...",
+	"shortcut.prev":          "prev",
+	"shortcut.refresh":       "refresh",
+	"shortcut.resize":        "resize",
+	"shortcut.retry":         "retry",
+	"shortcut.rotate":        "rotate",
+	"shortcut.save":          "save",
+	"shortcut.select":        "select",
+	"shortcut.servers":       "servers",
+	"shortcut.show_all":      "show all",
+	"shortcut.show_disabled": "show disabled",
+	"shortcut.show_enabled":  "show enabled",
+	"shortcut.skip_tls":      "skip TLS check",
+	"shortcut.sort":          "sort",
+	"shortcut.start":         "start",
+	"shortcut.submit":        "submit",
+	"shortcut.switch_key":    "use another key",
+	"shortcut.switch_type":   "install as %s",
+	"shortcut.toggle":        "toggle",
+	"shortcut.unpin":         "unpin",
+	"shortcut.visibility":    "visibility",
+	"shortcut.versions":      "versions",
+
+	// Confirmation prompts
+	"confirm.cannot_undo":       "Warning: This action cannot be undone.",
+	"confirm.press_y":           "Press y to confirm or n to cancel.",
+	"confirm.type_word":         "Type \"%s\" to confirm:",
+	"confirm.enter_or_esc":      "Press Enter to confirm, Esc to cancel",
+	"confirm.mismatch":          "✗ Does not match \"%s\"",
+	"remove.warning":            "You are about to remove:",
+	"key_revoke.warning":        "You are about to delete the following key:",
+	"key_revoke.danger":         "Any systems using this key will lose access immediately.",
+	"key_revoke.deleting":       "Deleting key...",
+	"key_revoke.session_danger": "This is the key this session is connected with. Deleting it locks the CLI out of this server until you enter another key.",
+	"key_rotate.title":          "Rotate this key?",
+	"key_rotate.warning":        "A new secret will be issued for the following key, keeping its name, role and permissions:",
+	"key_rotate.danger":         "The current secret stops working immediately. Update every system using it.",
+	"key_rotate.session_note":   "This is the key this session is connected with; the CLI will switch to the new secret.",
+	"key_rotate.rotating":       "Rotating key...",
+
+	// Permission errors
+	"switch_key.hint": "This key isn't allowed to do that. Press Ctrl+K to retry with a different key.",
+
+	// Server list
+	"server_delete.title":       "DELETE SERVER",
+	"server_delete.question":    "Are you sure you want to delete this server?",
+	"server_delete.cannot_undo": "This action cannot be undone.",
+}
+
+var pt = map[string]string{
+	// Main menu
+	"menu.quick_actions":        "AÇÕES RÁPIDAS",
+	"menu.apps.title":           "Gerenciar Apps",
+	"menu.apps.description":     "Ver e gerenciar aplicações",
+	"menu.plugins.title":        "Gerenciar Plugins",
+	"menu.plugins.description":  "Ativar, desativar e instalar plugins",
+	"menu.keys.title":           "Chaves de API",
+	"menu.keys.description":     "Gerenciar chaves de autenticação",
+	"menu.activity.title":       "Atividade",
+	"menu.activity.description": "Mudanças recentes no servidor",
+	"menu.settings.title":       "Configurações",
+	"menu.settings.description": "Configuração do servidor",
+	"stats.apps":                "APPS",
+	"stats.plugins":             "PLUGINS",
+	"stats.running":             "em execução",
+	"stats.enabled":             "ativos",
+
+	// Footer shortcuts
+	"shortcut.activate":      "ativar",
+	"shortcut.add":           "adicionar",
+	"shortcut.adjust":        "ajustar",
+	"shortcut.all":           "todos",
+	"shortcut.app_plugin":    "app/plugin",
+	"shortcut.apply":         "aplicar",
+	"shortcut.back":          "voltar",
+	"shortcut.batch_install": "instalar em lote (%d)",
+	"shortcut.cancel":        "cancelar",
+	"shortcut.clear_filter":  "limpar filtro",
+	"shortcut.configure":     "configurar",
+	"shortcut.confirm":       "confirmar",
+	"shortcut.connect":       "conectar",
+	"shortcut.continue":      "continuar",
+	"shortcut.copy":          "copiar",
+	"shortcut.copy_command":  "copiar comando",
+	"shortcut.copy_details":  "copiar detalhes",
+	"shortcut.copy_path":     "copiar caminho",
+	"shortcut.copy_markdown": "copiar como markdown",
+	"shortcut.delete":        "excluir",
+	"shortcut.details":       "detalhes",
+	"shortcut.directory":     "diretório",
+	"shortcut.disable":       "desativar",
+	"shortcut.done":          "concluir",
+	"shortcut.edit":          "editar",
+	"shortcut.enable":        "ativar",
+	"shortcut.file":          "arquivo",
+	"shortcut.filter":        "filtrar",
+	"shortcut.filter_actor":  "filtrar autor",
+	"shortcut.filter_type":   "filtrar tipo",
+	"shortcut.install":       "instalar",
+	"shortcut.labels":        "rótulos",
+	"shortcut.more":          "mais",
+	"shortcut.navigate":      "navegar",
+	"shortcut.next":          "próximo",
+	"shortcut.none":          "nenhum",
+	"shortcut.open":          "abrir",
+	"shortcut.overwrite":     "sobrescrever",
+	"shortcut.parent":        "pasta acima",
+	"shortcut.paste_path":    "colar caminho",
+	"shortcut.pin":           "fixar",
+	"shortcut.please_wait":   "Aguarde...",
+	"shortcut.prev":          "anterior",
+	"shortcut.refresh":       "atualizar",
+	"shortcut.resize":        "redimensionar",
+	"shortcut.retry":         "tentar novamente",
+	"shortcut.rotate":        "rotacionar",
+	"shortcut.save":          "salvar",
+	"shortcut.select":        "selecionar",
+	"shortcut.servers":       "servidores",
+	"shortcut.show_all":      "mostrar todos",
+	"shortcut.show_disabled": "mostrar desativados",
+	"shortcut.show_enabled":  "mostrar ativados",
+	"shortcut.skip_tls":      "ignorar TLS",
+	"shortcut.sort":          "ordenar",
+	"shortcut.start":         "iniciar",
+	"shortcut.submit":        "enviar",
+	"shortcut.switch_key":    "usar outra chave",
+	"shortcut.switch_type":   "instalar como %s",
+	"shortcut.toggle":        "alternar",
+	"shortcut.unpin":         "desafixar",
+	"shortcut.visibility":    "visibilidade",
+	"shortcut.versions":      "versões",
+
+	// Confirmation prompts
+	"confirm.cannot_undo":       "Atenção: esta ação não pode ser desfeita.",
+	"confirm.press_y":           "Pressione y para confirmar ou n para cancelar.",
+	"confirm.type_word":         "Digite \"%s\" para confirmar:",
+	"confirm.enter_or_esc":      "Pressione Enter para confirmar, Esc para cancelar",
+	"confirm.mismatch":          "✗ Não corresponde a \"%s\"",
+	"remove.warning":            "Você está prestes a remover:",
+	"key_revoke.warning":        "Você está prestes a excluir a seguinte chave:",
+	"key_revoke.danger":         "Qualquer sistema que use esta chave perderá o acesso imediatamente.",
+	"key_revoke.deleting":       "Excluindo chave...",
+	"key_revoke.session_danger": "Esta é a chave usada nesta sessão. Excluí-la bloqueia o acesso da CLI a este servidor até que outra chave seja informada.",
+	"key_rotate.title":          "Rotacionar esta chave?",
+	"key_rotate.warning":        "Um novo segredo será emitido para a seguinte chave, mantendo nome, papel e permissões:",
+	"key_rotate.danger":         "O segredo atual deixa de funcionar imediatamente. Atualize todos os sistemas que o usam.",
+	"key_rotate.session_note":   "Esta é a chave usada nesta sessão; a CLI passará a usar o novo segredo.",
+	"key_rotate.rotating":       "Rotacionando chave...",
+
+	// Permission errors
+	"switch_key.hint": "Esta chave não tem permissão para isso. Pressione Ctrl+K para tentar com outra chave.",
+
+	// Server list
+	"server_delete.title":       "EXCLUIR SERVIDOR",
+	"server_delete.question":    "Tem certeza de que deseja excluir este servidor?",
+	"server_delete.cannot_undo": "Esta ação não pode ser desfeita.",
+}
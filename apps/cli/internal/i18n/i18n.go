@@ -0,0 +1,101 @@
+// Package i18n holds the message catalog for user-facing text. Screens look
+// strings up by key with T; keys missing from the active language fall back
+// to English, and keys missing everywhere render as the key itself so an
+// untranslated string is obvious without breaking the UI.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultLanguage is used when nothing else is configured or supported
+const DefaultLanguage = "en"
+
+var (
+	mu     sync.RWMutex
+	active = DefaultLanguage
+)
+
+// T returns the message for key in the active language. Extra args are
+// applied with fmt.Sprintf.
+func T(key string, args ...any) string {
+	mu.RLock()
+	lang := active
+	mu.RUnlock()
+
+	msg, ok := catalogs[lang][key]
+	if !ok {
+		msg, ok = catalogs[DefaultLanguage][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// SetLanguage switches the active language. Locale names such as "pt_BR.UTF-8"
+// are accepted; unsupported languages return an error and leave the active
+// language unchanged.
+func SetLanguage(lang string) error {
+	code := normalize(lang)
+	if _, ok := catalogs[code]; !ok {
+		return fmt.Errorf("unsupported language %q (available: %s)", lang, strings.Join(Languages(), ", "))
+	}
+
+	mu.Lock()
+	active = code
+	mu.Unlock()
+	return nil
+}
+
+// Language returns the active language code
+func Language() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return active
+}
+
+// Languages lists the supported language codes
+func Languages() []string {
+	codes := make([]string, 0, len(catalogs))
+	for code := range catalogs {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// Detect picks the language from the environment the way gettext does:
+// LC_ALL, then LC_MESSAGES, then LANG. Unsupported or unset locales (and the
+// "C"/"POSIX" locales) give DefaultLanguage.
+func Detect() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		code := normalize(value)
+		if _, ok := catalogs[code]; ok {
+			return code
+		}
+		// The first variable that is set wins, even if unsupported
+		return DefaultLanguage
+	}
+	return DefaultLanguage
+}
+
+// normalize reduces a locale like "pt_BR.UTF-8" to its language code "pt"
+func normalize(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(locale, "_-.@"); i >= 0 {
+		locale = locale[:i]
+	}
+	return locale
+}
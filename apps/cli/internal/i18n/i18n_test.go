@@ -0,0 +1,95 @@
+package i18n
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestTranslationsMatchEnglish(t *testing.T) {
+	for lang, messages := range catalogs {
+		for key, msg := range messages {
+			ref, ok := en[key]
+			if !ok {
+				t.Errorf("%s: %s is missing from the English catalog", lang, key)
+				continue
+			}
+			if strings.Count(msg, "%") != strings.Count(ref, "%") {
+				t.Errorf("%s: %s has different format verbs than English", lang, key)
+			}
+		}
+	}
+}
+
+func TestTFallsBack(t *testing.T) {
+	defer SetLanguage(DefaultLanguage)
+
+	if err := SetLanguage("pt_BR.UTF-8"); err != nil {
+		t.Fatalf("SetLanguage() error = %v", err)
+	}
+	if got := T("shortcut.cancel"); got != "cancelar" {
+		t.Errorf("T(shortcut.cancel) = %q", got)
+	}
+	if got := T("confirm.type_word", "remove"); got != `Digite "remove" para confirmar:` {
+		t.Errorf("T(confirm.type_word) = %q", got)
+	}
+	if got := T("no.such.key"); got != "no.such.key" {
+		t.Errorf("T(no.such.key) = %q", got)
+	}
+
+	if err := SetLanguage("xx"); err == nil {
+		t.Error("expected an error for an unsupported language")
+	}
+	if Language() != "pt" {
+		t.Errorf("Language() = %q after a failed switch", Language())
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		lcAll, lang string
+		want        string
+	}{
+		{"", "pt_BR.UTF-8", "pt"},
+		{"", "de_DE.UTF-8", "en"},
+		{"C", "pt_BR.UTF-8", "en"},
+		{"pt", "en_US.UTF-8", "pt"},
+		{"", "", "en"},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("LC_ALL", tt.lcAll)
+		t.Setenv("LC_MESSAGES", "")
+		t.Setenv("LANG", tt.lang)
+		if got := Detect(); got != tt.want {
+			t.Errorf("Detect() with LC_ALL=%q LANG=%q = %q, want %q", tt.lcAll, tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestCatalogCoversSourceKeys(t *testing.T) {
+	usage := regexp.MustCompile(`i18n\.T\("([^"]+)"`)
+
+	root := filepath.Join("..", "..")
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, match := range usage.FindAllSubmatch(content, -1) {
+			if _, ok := en[string(match[1])]; !ok {
+				t.Errorf("%s uses %s, which is missing from the English catalog", path, match[1])
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,67 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/buntime/cli/internal/tui/styles"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ConfirmInput is the typed confirmation for destructive actions: the user
+// has to type a word, such as "remove" or the name of the thing being
+// deleted, before Enter submits. Screens handle Esc themselves.
+type ConfirmInput struct {
+	word  string
+	input textinput.Model
+}
+
+// NewConfirmInput creates a focused confirmation input for word
+func NewConfirmInput(word string) *ConfirmInput {
+	ti := textinput.New()
+	ti.Placeholder = word
+	ti.Prompt = ""
+	ti.CharLimit = max(64, len(word))
+	// Fill the small input box, minus its padding and the cursor
+	ti.Width = styles.InputWidthSmall - 4
+	ti.Focus()
+
+	return &ConfirmInput{word: word, input: ti}
+}
+
+// Word is the text that has to be typed
+func (c *ConfirmInput) Word() string {
+	return c.word
+}
+
+// Matches reports whether the typed text is the confirm word
+func (c *ConfirmInput) Matches() bool {
+	return strings.TrimSpace(c.input.Value()) == c.word
+}
+
+// Mismatch reports whether the typed text can no longer become the confirm
+// word, so the view can flag it while typing
+func (c *ConfirmInput) Mismatch() bool {
+	value := strings.TrimLeft(c.input.Value(), " ")
+	return value != "" && !strings.HasPrefix(c.word, strings.TrimRight(value, " "))
+}
+
+// Reset clears the typed text
+func (c *ConfirmInput) Reset() {
+	c.input.Reset()
+}
+
+// Update handles a key press. submitted is true only for Enter on a
+// matching input; Enter on anything else is ignored.
+func (c *ConfirmInput) Update(msg tea.KeyMsg) (submitted bool, cmd tea.Cmd) {
+	if msg.Type == tea.KeyEnter {
+		return c.Matches(), nil
+	}
+	c.input, cmd = c.input.Update(msg)
+	return false, cmd
+}
+
+// View renders the text field without a border; ConfirmModal frames it
+func (c *ConfirmInput) View() string {
+	return c.input.View()
+}
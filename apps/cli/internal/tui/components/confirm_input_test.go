@@ -0,0 +1,45 @@
+package components
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func typeInto(c *ConfirmInput, text string) {
+	for _, r := range text {
+		c.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestConfirmInputOnlySubmitsOnMatch(t *testing.T) {
+	c := NewConfirmInput("prod-server")
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+	typeInto(c, "prod")
+	if c.Mismatch() {
+		t.Fatal("expected a prefix of the word not to be a mismatch")
+	}
+	if submitted, _ := c.Update(enter); submitted {
+		t.Fatal("expected Enter on a partial word not to submit")
+	}
+
+	typeInto(c, "-x")
+	if !c.Mismatch() {
+		t.Fatal("expected a diverging input to be a mismatch")
+	}
+
+	c.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	typeInto(c, "server")
+	if !c.Matches() {
+		t.Fatal("expected the corrected input to match")
+	}
+	if submitted, _ := c.Update(enter); !submitted {
+		t.Fatal("expected Enter on the full word to submit")
+	}
+
+	c.Reset()
+	if c.Matches() || c.Mismatch() {
+		t.Fatal("expected Reset to clear the input")
+	}
+}
@@ -0,0 +1,286 @@
+package components
+
+import (
+	"time"
+
+	"github.com/buntime/cli/internal/tui/styles"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// FieldKind is what a form field is, which decides the keys it takes
+type FieldKind int
+
+const (
+	FieldInput    FieldKind = iota // Single line text input
+	FieldArea                      // Multi-line text area; Enter and ↑↓ edit it
+	FieldCheckbox                  // Space toggles it
+	FieldChoice                    // Anything else the screen draws and drives itself
+	FieldButton                    // Enter is handled by the screen
+)
+
+// Field is one stop of a Form. Inputs, areas and checkboxes point at the
+// screen's own state, so screens read values the way they always did.
+type Field struct {
+	Kind    FieldKind
+	Input   *textinput.Model
+	Area    *textarea.Model
+	Checked *bool
+	// Hidden skips the field while it returns true
+	Hidden func() bool
+	// Validate returns why the field's value is wrong, or ""
+	Validate func() string
+	// Live runs Validate while the user types, once they pause
+	Live bool
+}
+
+// liveValidateDelay is how long typing has to pause before a live field is
+// checked, so the error doesn't flicker with every key
+const liveValidateDelay = 300 * time.Millisecond
+
+// liveValidateMsg checks a live field once typing paused. seq drops the
+// checks of keys typed since.
+type liveValidateMsg struct {
+	form  *Form
+	field int
+	seq   int
+}
+
+// Form keeps the focus of a screen's form: Tab and ↑↓ move between the
+// visible fields, the focused input gets the keys, and fields are drawn with
+// the same borders and buttons everywhere. Fields are addressed by their
+// index, so screens keep their focus constants.
+type Form struct {
+	fields []Field
+	focus  int
+	errors map[int]string // Why each field failed its last check
+	seq    []int          // Edits of each field, to debounce live checks
+}
+
+// NewForm creates a form focused on its first visible field
+func NewForm(fields ...Field) *Form {
+	f := &Form{fields: fields, errors: make(map[int]string), seq: make([]int, len(fields))}
+	f.focus = f.step(len(fields)-1, 1)
+	f.updateFocus()
+	return f
+}
+
+// Focused is the index of the focused field
+func (f *Form) Focused() int {
+	return f.focus
+}
+
+// IsFocused reports whether field i has the focus
+func (f *Form) IsFocused(i int) bool {
+	return f.focus == i
+}
+
+// Typing reports whether the focused field takes text
+func (f *Form) Typing() bool {
+	kind := f.fields[f.focus].Kind
+	return kind == FieldInput || kind == FieldArea
+}
+
+// Focus moves the focus to field i
+func (f *Form) Focus(i int) {
+	f.focus = i
+	f.updateFocus()
+}
+
+// Next moves the focus to the next visible field, wrapping around
+func (f *Form) Next() {
+	f.Focus(f.step(f.focus, 1))
+}
+
+// Prev moves the focus to the previous visible field, wrapping around
+func (f *Form) Prev() {
+	f.Focus(f.step(f.focus, -1))
+}
+
+func (f *Form) step(from, delta int) int {
+	n := len(f.fields)
+	for i := 1; i <= n; i++ {
+		next := ((from+delta*i)%n + n) % n
+		if !f.hidden(next) {
+			return next
+		}
+	}
+	return from
+}
+
+func (f *Form) hidden(i int) bool {
+	return f.fields[i].Hidden != nil && f.fields[i].Hidden()
+}
+
+func (f *Form) updateFocus() {
+	for i, field := range f.fields {
+		switch {
+		case field.Input != nil && i == f.focus:
+			field.Input.Focus()
+		case field.Input != nil:
+			field.Input.Blur()
+		case field.Area != nil && i == f.focus:
+			field.Area.Focus()
+		case field.Area != nil:
+			field.Area.Blur()
+		}
+	}
+}
+
+// Update moves the focus on Tab, Shift+Tab, ↑↓ and Enter, toggles a focused
+// checkbox on Space and passes anything else to the focused input. Screens
+// handle their own keys first, such as Enter on a button.
+func (f *Form) Update(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(liveValidateMsg); ok {
+		if msg.form == f && msg.seq == f.seq[msg.field] {
+			f.check(msg.field)
+		}
+		return nil
+	}
+
+	field := f.fields[f.focus]
+	var cmd tea.Cmd
+
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "enter", "up", "down":
+			// A text area needs these to edit its lines
+			if field.Kind == FieldArea {
+				*field.Area, cmd = field.Area.Update(msg)
+				return cmd
+			}
+		}
+		switch key.String() {
+		case "tab", "down":
+			f.Next()
+			return nil
+		case "shift+tab", "up":
+			f.Prev()
+			return nil
+		case "enter":
+			if field.Kind != FieldButton {
+				f.Next()
+			}
+			return nil
+		case " ", "space":
+			if field.Kind == FieldCheckbox {
+				*field.Checked = !*field.Checked
+				return nil
+			}
+		}
+	}
+
+	switch {
+	case field.Input != nil:
+		before := field.Input.Value()
+		*field.Input, cmd = field.Input.Update(msg)
+		if field.Live && field.Input.Value() != before {
+			cmd = tea.Batch(cmd, f.checkLater(f.focus))
+		}
+	case field.Area != nil:
+		*field.Area, cmd = field.Area.Update(msg)
+	}
+	return cmd
+}
+
+// checkLater checks field i after liveValidateDelay, unless it is edited
+// again before then
+func (f *Form) checkLater(i int) tea.Cmd {
+	f.seq[i]++
+	msg := liveValidateMsg{form: f, field: i, seq: f.seq[i]}
+	return tea.Tick(liveValidateDelay, func(time.Time) tea.Msg { return msg })
+}
+
+// check runs the validation of field i and keeps its error
+func (f *Form) check(i int) string {
+	msg := f.fields[i].Validate()
+	if msg == "" {
+		delete(f.errors, i)
+	} else {
+		f.errors[i] = msg
+	}
+	return msg
+}
+
+// Validate runs the validation of each visible field. Fields that fail are
+// drawn with an error border, the first one is focused and its message
+// returned; "" means the form is valid.
+func (f *Form) Validate() string {
+	first := -1
+	for i, field := range f.fields {
+		if field.Validate == nil || f.hidden(i) {
+			delete(f.errors, i)
+			continue
+		}
+		if f.check(i) != "" && first < 0 {
+			first = i
+		}
+	}
+	if first < 0 {
+		return ""
+	}
+	f.Focus(first)
+	return f.errors[first]
+}
+
+// Invalid reports whether field i failed its last check
+func (f *Form) Invalid(i int) bool {
+	return f.errors[i] != ""
+}
+
+// RenderError draws why field i failed its last check, or nothing
+func (f *Form) RenderError(i int) string {
+	if f.errors[i] == "" {
+		return ""
+	}
+	return styles.TextError.Render("✗ "+f.errors[i]) + "\n"
+}
+
+// RenderLabel draws a field label, with a red star when it is required
+func RenderLabel(text string, required bool) string {
+	label := styles.TextNormal.Render(text)
+	if required {
+		label += styles.TextError.Render(" *")
+	}
+	return label
+}
+
+// RenderInput draws text input i in its box. The view is cut to the box so
+// a long value scrolls instead of wrapping.
+func (f *Form) RenderInput(i int) string {
+	view := ansi.Truncate(f.fields[i].Input.View(), styles.InputWidthDefault-4, "")
+	return styles.RenderInput(view, f.IsFocused(i), f.Invalid(i))
+}
+
+// RenderArea draws text area i in the same box as inputs
+func (f *Form) RenderArea(i int) string {
+	return styles.RenderInput(f.fields[i].Area.View(), f.IsFocused(i), f.Invalid(i))
+}
+
+// RenderCheckbox draws checkbox i followed by its label
+func (f *Form) RenderCheckbox(i int, label string) string {
+	focused := f.IsFocused(i)
+	labelStyle := styles.TextNormal
+	if focused {
+		labelStyle = styles.TextPrimary
+	}
+	checkbox := styles.RenderCheckbox(*f.fields[i].Checked, focused)
+	return lipgloss.JoinHorizontal(lipgloss.Center, checkbox, "  ", labelStyle.Render(label))
+}
+
+// RenderButtons draws the cancel and submit buttons side by side
+func (f *Form) RenderButtons(cancel int, cancelLabel string, submit int, submitLabel string) string {
+	cancelStyle := styles.Button
+	if f.IsFocused(cancel) {
+		cancelStyle = styles.ButtonFocused
+	}
+	submitStyle := styles.Button
+	if f.IsFocused(submit) {
+		submitStyle = styles.ButtonPrimary
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Center,
+		cancelStyle.Render(cancelLabel), "  ", submitStyle.Render(submitLabel))
+}
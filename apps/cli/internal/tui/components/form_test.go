@@ -0,0 +1,136 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFormSkipsHiddenFields(t *testing.T) {
+	name, extra := textinput.New(), textinput.New()
+	showExtra := false
+	f := NewForm(
+		Field{Kind: FieldInput, Input: &name},
+		Field{Kind: FieldInput, Input: &extra, Hidden: func() bool { return !showExtra }},
+		Field{Kind: FieldButton},
+	)
+	if !f.IsFocused(0) || !name.Focused() {
+		t.Fatal("expected the first field to start focused")
+	}
+
+	f.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if !f.IsFocused(2) || name.Focused() {
+		t.Fatalf("expected tab to skip the hidden field, got %d", f.Focused())
+	}
+	f.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if !f.IsFocused(0) {
+		t.Fatalf("expected the focus to wrap around, got %d", f.Focused())
+	}
+
+	showExtra = true
+	f.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	f.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	if !f.IsFocused(1) || !extra.Focused() {
+		t.Fatalf("expected shift+tab to reach the shown field, got %d", f.Focused())
+	}
+}
+
+func TestFormRoutesKeysToTheFocusedField(t *testing.T) {
+	name := textinput.New()
+	notes := textarea.New()
+	insecure := false
+	f := NewForm(
+		Field{Kind: FieldInput, Input: &name},
+		Field{Kind: FieldArea, Area: &notes},
+		Field{Kind: FieldCheckbox, Checked: &insecure},
+	)
+
+	f.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a b")})
+	if name.Value() != "a b" {
+		t.Fatalf("expected the input to get the text, got %q", name.Value())
+	}
+
+	f.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	f.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	f.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !f.IsFocused(1) || notes.LineCount() != 2 {
+		t.Fatal("expected enter to add a line in the text area instead of moving on")
+	}
+
+	f.Update(tea.KeyMsg{Type: tea.KeyTab})
+	f.Update(tea.KeyMsg{Type: tea.KeySpace})
+	if !insecure {
+		t.Fatal("expected space to toggle the checkbox")
+	}
+}
+
+func TestFormValidateFocusesTheFirstInvalidField(t *testing.T) {
+	name, url := textinput.New(), textinput.New()
+	required := func(input *textinput.Model) func() string {
+		return func() string {
+			if input.Value() == "" {
+				return "required"
+			}
+			return ""
+		}
+	}
+	f := NewForm(
+		Field{Kind: FieldInput, Input: &name},
+		Field{Kind: FieldInput, Input: &url, Validate: required(&url)},
+		Field{Kind: FieldButton},
+	)
+	f.Focus(2)
+
+	if msg := f.Validate(); msg != "required" || !f.IsFocused(1) || !f.Invalid(1) {
+		t.Fatalf("expected the URL field to fail and get the focus, got %q at %d", msg, f.Focused())
+	}
+
+	url.SetValue("https://buntime.home")
+	if msg := f.Validate(); msg != "" || f.Invalid(1) {
+		t.Fatalf("expected the form to pass, got %q", msg)
+	}
+}
+
+func TestFormChecksLiveFieldsOnceTypingPauses(t *testing.T) {
+	url := textinput.New()
+	f := NewForm(Field{Kind: FieldInput, Input: &url, Live: true, Validate: func() string {
+		if !strings.HasPrefix(url.Value(), "https://") {
+			return "must start with https://"
+		}
+		return ""
+	}})
+
+	if cmd := f.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")}); cmd == nil {
+		t.Fatal("expected typing to schedule a check")
+	}
+	f.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+
+	// The check of the first key is stale by now
+	f.Update(liveValidateMsg{form: f, field: 0, seq: 1})
+	if f.Invalid(0) {
+		t.Fatal("expected a stale check to be dropped")
+	}
+	f.Update(liveValidateMsg{form: f, field: 0, seq: 2})
+	if !f.Invalid(0) || !strings.Contains(f.RenderError(0), "must start with https://") {
+		t.Fatal("expected the error once typing paused")
+	}
+
+	url.SetValue("https://buntime.hom")
+	url.CursorEnd()
+	f.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	f.Update(liveValidateMsg{form: f, field: 0, seq: 3})
+	if f.Invalid(0) || f.RenderError(0) != "" {
+		t.Fatal("expected the error to clear once the value is fixed")
+	}
+
+	// Checks of another form are not ours
+	other := NewForm(Field{Kind: FieldButton})
+	url.SetValue("x")
+	f.Update(liveValidateMsg{form: other, field: 0, seq: 3})
+	if f.Invalid(0) {
+		t.Fatal("expected another form's check to be ignored")
+	}
+}
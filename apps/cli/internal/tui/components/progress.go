@@ -0,0 +1,171 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/buntime/cli/internal/tui/styles"
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/dustin/go-humanize"
+)
+
+const (
+	// rateSmoothing weighs each new rate sample in the moving average; lower
+	// values give a steadier ETA at the cost of reacting slower
+	rateSmoothing = 0.3
+	// minRateSample is the shortest interval a rate sample is taken over, so
+	// bursts of tiny updates don't make the rate jump around
+	minRateSample = 200 * time.Millisecond
+)
+
+// ProgressBar tracks a transfer of a known size and renders a bar with the
+// percentage, a smoothed transfer rate and an ETA, e.g.
+// "42% · 12 MB/s · ~8s left". Once the bytes are sent it can switch to an
+// indeterminate phase for work whose duration is unknown, like the server
+// extracting an upload.
+type ProgressBar struct {
+	bar   progress.Model
+	total int64
+	done  int64
+
+	sampleAt   time.Time
+	sampleDone int64
+	rate       float64 // Smoothed bytes per second, 0 until the first sample
+
+	indeterminate bool
+	label         string
+	phaseStarted  time.Time
+
+	now func() time.Time
+}
+
+// NewProgressBar creates a progress bar for a transfer of total bytes
+func NewProgressBar(total int64) *ProgressBar {
+	p := &ProgressBar{
+		bar: progress.New(progress.WithDefaultGradient(), progress.WithoutPercentage()),
+		now: time.Now,
+	}
+	p.Reset(total)
+	return p
+}
+
+// Reset starts tracking a new transfer of total bytes
+func (p *ProgressBar) Reset(total int64) {
+	now := p.now()
+	p.total = total
+	p.done = 0
+	p.sampleAt = now
+	p.sampleDone = 0
+	p.rate = 0
+	p.indeterminate = false
+	p.label = ""
+}
+
+// SetWidth sets the width of the bar itself; the stats line is not limited
+func (p *ProgressBar) SetWidth(width int) {
+	p.bar.Width = width
+}
+
+// Add records n more bytes as transferred
+func (p *ProgressBar) Add(n int64) {
+	p.Set(p.done + n)
+}
+
+// Set records the total number of bytes transferred so far. Going backwards
+// (a resumed upload restarting a chunk) is allowed.
+func (p *ProgressBar) Set(done int64) {
+	if done < 0 {
+		done = 0
+	}
+	if p.total > 0 && done > p.total {
+		done = p.total
+	}
+	p.done = done
+
+	now := p.now()
+	elapsed := now.Sub(p.sampleAt)
+	if elapsed < minRateSample {
+		return
+	}
+
+	sample := float64(p.done-p.sampleDone) / elapsed.Seconds()
+	if sample < 0 {
+		sample = 0
+	}
+	if p.rate == 0 {
+		p.rate = sample
+	} else {
+		p.rate = rateSmoothing*sample + (1-rateSmoothing)*p.rate
+	}
+	p.sampleAt = now
+	p.sampleDone = p.done
+}
+
+// SetIndeterminate switches to a phase without measurable progress, shown
+// with label and the time spent in it
+func (p *ProgressBar) SetIndeterminate(label string) {
+	p.indeterminate = true
+	p.label = label
+	p.phaseStarted = p.now()
+}
+
+// Indeterminate reports whether the bar is in a phase without progress
+func (p *ProgressBar) Indeterminate() bool {
+	return p.indeterminate
+}
+
+// Percent returns the completed fraction between 0 and 1
+func (p *ProgressBar) Percent() float64 {
+	if p.total <= 0 {
+		return 0
+	}
+	return float64(p.done) / float64(p.total)
+}
+
+// Rate returns the smoothed transfer rate in bytes per second
+func (p *ProgressBar) Rate() float64 {
+	return p.rate
+}
+
+// ETA returns the estimated time left, and false while there isn't enough
+// data to estimate it
+func (p *ProgressBar) ETA() (time.Duration, bool) {
+	if p.rate <= 0 || p.total <= 0 {
+		return 0, false
+	}
+	remaining := float64(p.total - p.done)
+	return time.Duration(remaining / p.rate * float64(time.Second)), true
+}
+
+// View renders the bar with its stats line below it
+func (p *ProgressBar) View() string {
+	if p.indeterminate {
+		elapsed := p.now().Sub(p.phaseStarted).Round(time.Second)
+		return styles.TextPrimary.Render(p.label) + " " + styles.TextMuted.Render(formatDuration(elapsed))
+	}
+
+	return p.bar.ViewAs(p.Percent()) + "\n" + styles.TextMuted.Render(p.Stats())
+}
+
+// Stats renders "42% · 12 MB/s · ~8s left", leaving out the parts that are
+// not known yet
+func (p *ProgressBar) Stats() string {
+	parts := []string{fmt.Sprintf("%d%%", int(p.Percent()*100))}
+	if p.rate > 0 {
+		parts = append(parts, humanize.Bytes(uint64(p.rate))+"/s")
+	}
+	if eta, ok := p.ETA(); ok && p.done < p.total {
+		parts = append(parts, "~"+formatDuration(eta)+" left")
+	}
+	return strings.Join(parts, " · ")
+}
+
+// formatDuration renders whole seconds, e.g. "8s" or "2m10s"
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d < time.Second {
+		return "<1s"
+	}
+	return d.String()
+}
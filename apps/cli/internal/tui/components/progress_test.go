@@ -0,0 +1,76 @@
+package components
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClock returns a progress bar whose clock only moves when advanced
+func fakeClock(total int64) (*ProgressBar, func(time.Duration)) {
+	now := time.Unix(0, 0)
+	p := NewProgressBar(total)
+	p.now = func() time.Time { return now }
+	p.Reset(total)
+	return p, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestProgressBarRateAndETA(t *testing.T) {
+	p, advance := fakeClock(100 << 20)
+
+	if _, ok := p.ETA(); ok {
+		t.Fatal("expected no ETA before the first rate sample")
+	}
+
+	// 10 MiB/s for one second
+	advance(time.Second)
+	p.Set(10 << 20)
+	if got := p.Rate(); got != 10<<20 {
+		t.Fatalf("expected first sample to set the rate, got %.0f", got)
+	}
+	eta, ok := p.ETA()
+	if !ok || eta != 9*time.Second {
+		t.Fatalf("expected ~9s left, got %v (ok %v)", eta, ok)
+	}
+	if got := p.Stats(); got != "10% · 10 MB/s · ~9s left" {
+		t.Fatalf("unexpected stats %q", got)
+	}
+
+	// A slower second only moves the smoothed rate part of the way
+	advance(time.Second)
+	p.Add(5 << 20)
+	if got, want := p.Rate(), 0.3*float64(5<<20)+0.7*float64(10<<20); got != want {
+		t.Fatalf("expected smoothed rate %.0f, got %.0f", want, got)
+	}
+}
+
+func TestProgressBarIgnoresShortSamples(t *testing.T) {
+	p, advance := fakeClock(1000)
+
+	advance(10 * time.Millisecond)
+	p.Set(500)
+	if p.Rate() != 0 {
+		t.Fatalf("expected no rate from a 10ms sample, got %.0f", p.Rate())
+	}
+	if p.Percent() != 0.5 {
+		t.Fatalf("expected progress to update anyway, got %v", p.Percent())
+	}
+}
+
+func TestProgressBarClampsAndGoesIndeterminate(t *testing.T) {
+	p, advance := fakeClock(1000)
+
+	p.Set(5000)
+	if p.Percent() != 1 {
+		t.Fatalf("expected progress clamped to 100%%, got %v", p.Percent())
+	}
+
+	p.SetIndeterminate("Processing")
+	advance(3 * time.Second)
+	if !p.Indeterminate() {
+		t.Fatal("expected indeterminate phase")
+	}
+	if got := p.View(); !strings.Contains(got, "Processing") || !strings.Contains(got, "3s") {
+		t.Fatalf("unexpected indeterminate view %q", got)
+	}
+}
@@ -0,0 +1,35 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/buntime/cli/internal/tui/styles"
+)
+
+// SkeletonRowCount is how many placeholder rows list screens show while
+// their first page loads
+const SkeletonRowCount = 5
+
+// skeletonFill is how much of a column each placeholder bar covers, in
+// percent, so the rows read as text rather than a solid grid
+var skeletonFill = []int{70, 45, 90, 60, 80, 35}
+
+// SkeletonRows renders n dimmed placeholder rows for a list whose columns
+// have the given widths. The rows line up with the list's real rows, so
+// columns don't move when the data arrives.
+func SkeletonRows(n int, widths []int) string {
+	var b strings.Builder
+	for row := 0; row < n; row++ {
+		cells := make([]string, len(widths))
+		for col, width := range widths {
+			if width <= 0 {
+				continue
+			}
+			fill := min(width, max(1, width*skeletonFill[(row+col)%len(skeletonFill)]/100))
+			cells[col] = strings.Repeat("░", fill) + strings.Repeat(" ", width-fill)
+		}
+		line := strings.TrimRight(strings.Join(cells, " "), " ")
+		b.WriteString("  " + styles.TextMuted.Render(line) + "\n")
+	}
+	return b.String()
+}
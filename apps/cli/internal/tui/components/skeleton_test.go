@@ -0,0 +1,43 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestSkeletonRowsMatchColumnLayout(t *testing.T) {
+	widths := []int{10, 6, 20}
+	out := SkeletonRows(3, widths)
+
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 rows, got %d: %q", len(lines), out)
+	}
+
+	for _, line := range lines {
+		plain := line
+		if !strings.HasPrefix(plain, "  ░") {
+			t.Fatalf("expected rows to start under the cursor column, got %q", plain)
+		}
+		// Each column's bar starts where the real column would
+		start := 2
+		for _, width := range widths {
+			if r := []rune(plain); start >= len(r) || r[start] != '░' {
+				t.Fatalf("expected a bar at column %d in %q", start, plain)
+			}
+			start += width + 1
+		}
+		if w := lipgloss.Width(plain); w > 2+10+1+6+1+20 {
+			t.Fatalf("row wider than the columns: %d", w)
+		}
+	}
+}
+
+func TestSkeletonRowsSkipsEmptyColumns(t *testing.T) {
+	out := SkeletonRows(1, []int{4, 0})
+	if strings.Count(out, "░") > 4 {
+		t.Fatalf("expected no bar for a zero-width column, got %q", out)
+	}
+}
@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"context"
+	"time"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/tui/layout"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// heartbeatInterval is how often an idle connection is checked
+const heartbeatInterval = 15 * time.Second
+
+// heartbeatTickMsg is time to check the connection of client again. A tick
+// for a client that is no longer connected ends the heartbeat.
+type heartbeatTickMsg struct {
+	client *api.Client
+}
+
+// heartbeatMsg is the result of a connection check
+type heartbeatMsg struct {
+	client *api.Client
+	online bool
+}
+
+// heartbeat schedules the next connection check of client
+func heartbeat(client *api.Client) tea.Cmd {
+	return tea.Tick(heartbeatInterval, func(time.Time) tea.Msg {
+		return heartbeatTickMsg{client: client}
+	})
+}
+
+// startHeartbeat marks the new connection online and starts checking it
+func (m *Model) startHeartbeat() tea.Cmd {
+	layout.SetOnline(true)
+	return heartbeat(m.api)
+}
+
+// checkConnection pings the server unless a request is running or one was
+// just answered, which says as much without another request
+func (m *Model) checkConnection(client *api.Client) tea.Cmd {
+	if client.Busy() || (layout.Online() && client.AnsweredWithin(heartbeatInterval)) {
+		return heartbeat(client)
+	}
+	return func() tea.Msg {
+		// Not tied to the screen, so navigating doesn't cancel the check
+		return heartbeatMsg{client: client, online: client.IsReachableCtx(context.Background())}
+	}
+}
+
+// updateConnection shows a change in the connection in the header and a
+// toast, then schedules the next check
+func (m *Model) updateConnection(msg heartbeatMsg) tea.Cmd {
+	if msg.online != layout.Online() {
+		layout.SetOnline(msg.online)
+		if msg.online {
+			m.toast.ShowSuccess("Connection to " + m.currentServer.Name + " restored")
+		} else {
+			m.toast.ShowWarning("Lost connection to " + m.currentServer.Name)
+		}
+	}
+	return heartbeat(msg.client)
+}
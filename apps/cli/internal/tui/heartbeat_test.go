@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/tui/layout"
+)
+
+func TestHeartbeatShowsLostAndRestoredConnections(t *testing.T) {
+	model := newResizeTestModel(t)
+	t.Cleanup(func() { layout.SetOnline(true) })
+	model.Init()
+	model.toast.Hide() // The in-memory config warning
+
+	if cmd := model.updateConnection(heartbeatMsg{client: model.api, online: true}); cmd == nil || model.toast.IsVisible() {
+		t.Fatal("expected no toast while the connection stays up, and another check")
+	}
+
+	model.Update(heartbeatMsg{client: model.api, online: false})
+	if layout.Online() || !strings.Contains(model.toast.View(), "Lost connection to Production") {
+		t.Fatal("expected the lost connection in the header and a toast")
+	}
+	if header := layout.RenderHeader(100, "", model.currentServer); !strings.Contains(header, "offline") {
+		t.Fatalf("expected the header to show offline, got %q", header)
+	}
+
+	model.Update(heartbeatMsg{client: model.api, online: true})
+	if !layout.Online() || !strings.Contains(model.toast.View(), "restored") {
+		t.Fatal("expected the restored connection in the header and a toast")
+	}
+}
+
+func TestHeartbeatOfAnOldConnectionStops(t *testing.T) {
+	model := newResizeTestModel(t)
+	t.Cleanup(func() { layout.SetOnline(true) })
+
+	old := api.New("https://old.example", "", false)
+	if _, cmd := model.Update(heartbeatTickMsg{client: old}); cmd != nil {
+		t.Fatal("expected a tick for an old client to end its heartbeat")
+	}
+	if _, cmd := model.Update(heartbeatMsg{client: old, online: false}); cmd != nil || !layout.Online() {
+		t.Fatal("expected the result for an old client to be dropped")
+	}
+}
@@ -0,0 +1,17 @@
+package layout
+
+// offline is set while the heartbeat can't reach the connected server, so
+// every screen's header shows it. The TUI only renders from the bubbletea
+// goroutine.
+var offline bool
+
+// SetOnline records whether the connected server can be reached
+func SetOnline(online bool) {
+	offline = !online
+}
+
+// Online reports whether the connected server could be reached when last
+// checked
+func Online() bool {
+	return !offline
+}
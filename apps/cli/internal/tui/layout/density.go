@@ -0,0 +1,67 @@
+package layout
+
+import (
+	"strings"
+
+	"github.com/buntime/cli/internal/tui/styles"
+)
+
+// Density is how tightly pages and lists are laid out
+type Density string
+
+const (
+	DensityComfortable Density = "comfortable"
+	DensityCompact     Density = "compact" // No title margins or header separators
+)
+
+// density is shared by every screen, like the legend
+var density = DensityComfortable
+
+// SetDensity switches the layout density; unknown values mean comfortable
+func SetDensity(d Density) {
+	if d != DensityCompact {
+		d = DensityComfortable
+	}
+	density = d
+}
+
+// Compact reports whether the compact density is on
+func Compact() bool {
+	return density == DensityCompact
+}
+
+// ListHeader renders a list's column header, underlined by a separator in
+// the comfortable density
+func ListHeader(header string, width int) string {
+	line := styles.TextMuted.Render(header) + "\n"
+	if !Compact() {
+		line += styles.TextMuted.Render(strings.Repeat("─", width)) + "\n"
+	}
+	return line
+}
+
+// ListRows is how many rows of a list fit on a page with a server header,
+// leaving extra lines for content around the list such as details of the
+// selected item
+func ListRows(height, extra int) int {
+	// Borders, server header and its separator, footer divider and shortcuts
+	chrome := 7
+	if Compact() {
+		chrome += 2 // Title and column header
+	} else {
+		chrome += 5 // Title with its margins, column header and separator
+	}
+	return max(3, height-chrome-extra)
+}
+
+// ScrollOffset moves the first visible row of a list just enough to keep the
+// cursor on screen
+func ScrollOffset(offset, cursor, count, rows int) int {
+	if cursor < offset {
+		offset = cursor
+	} else if cursor >= offset+rows {
+		offset = cursor - rows + 1
+	}
+	// Don't leave empty rows at the end after the list shrinks
+	return max(0, min(offset, count-rows))
+}
@@ -1,9 +1,12 @@
 package layout
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/i18n"
+	"github.com/buntime/cli/internal/tui/components"
 	"github.com/buntime/cli/internal/tui/styles"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -48,14 +51,13 @@ func Card(cfg CardConfig) string {
 
 // ConfirmModalConfig holds configuration for a confirmation modal
 type ConfirmModalConfig struct {
-	Width        int
-	Title        string
-	Warning      string
-	DangerText   string // Optional danger/error message shown before input
-	Items        []ConfirmModalItem
-	ConfirmWord  string
-	CurrentInput string
-	InputView    string // Optional: pre-rendered input view (from textinput.Model)
+	Width      int
+	Title      string
+	Warning    string
+	DangerText string // Optional danger/error message shown before input
+	Items      []ConfirmModalItem
+	Input      *components.ConfirmInput // The confirm word and what was typed
+	Simple     bool                     // Ask for y/n instead of typing the confirm word
 }
 
 // ConfirmModalItem represents an item to display in the confirmation modal
@@ -73,7 +75,7 @@ func ConfirmModal(cfg ConfirmModalConfig) string {
 		content.WriteString(styles.TextWarning.Bold(true).Render(cfg.Title))
 		content.WriteString("\n\n")
 	} else {
-		content.WriteString(styles.TextWarning.Bold(true).Render("Warning: This action cannot be undone."))
+		content.WriteString(styles.TextWarning.Bold(true).Render(i18n.T("confirm.cannot_undo")))
 		content.WriteString("\n\n")
 	}
 
@@ -99,25 +101,23 @@ func ConfirmModal(cfg ConfirmModalConfig) string {
 		content.WriteString("\n\n")
 	}
 
-	// Confirm input prompt
-	confirmWord := cfg.ConfirmWord
-	if confirmWord == "" {
-		confirmWord = "delete"
+	if cfg.Simple {
+		content.WriteString(styles.TextNormal.Render(i18n.T("confirm.press_y")))
+		return Card(CardConfig{
+			Width:   cfg.Width,
+			Variant: CardWarning,
+			Content: content.String(),
+		})
 	}
 
-	content.WriteString(styles.TextNormal.Render("Type \"" + confirmWord + "\" to confirm:"))
+	// Confirm input prompt
+	content.WriteString(styles.TextNormal.Render(i18n.T("confirm.type_word", cfg.Input.Word())))
 	content.WriteString("\n")
 
-	// Input - use pre-rendered view if provided, otherwise render from CurrentInput
-	if cfg.InputView != "" {
-		content.WriteString(styles.RenderInputWithWidth(cfg.InputView, true, false, styles.InputWidthSmall))
-	} else {
-		hasError := cfg.CurrentInput != "" && !strings.HasPrefix(confirmWord, cfg.CurrentInput)
-		inputContent := cfg.CurrentInput
-		if inputContent == "" {
-			inputContent = " "
-		}
-		content.WriteString(styles.RenderInputWithWidth(inputContent+"█", true, hasError, styles.InputWidthSmall))
+	mismatch := cfg.Input.Mismatch()
+	content.WriteString(styles.RenderInputWithWidth(cfg.Input.View(), true, mismatch, styles.InputWidthSmall))
+	if mismatch {
+		content.WriteString("\n" + styles.TextError.Render(i18n.T("confirm.mismatch", cfg.Input.Word())))
 	}
 
 	return Card(CardConfig{
@@ -146,8 +146,12 @@ func Page(cfg PageConfig) string {
 
 	var b strings.Builder
 
-	// Title (with one blank line before content)
-	b.WriteString(styles.SectionTitle.Render(cfg.Title) + "\n")
+	// Title (with one blank line before content, none when compact)
+	title := styles.SectionTitle
+	if Compact() {
+		title = title.UnsetMarginTop().UnsetMarginBottom()
+	}
+	b.WriteString(title.Render(cfg.Title) + "\n")
 
 	// Content (should not start with leading newline)
 	b.WriteString(cfg.Content)
@@ -193,66 +197,75 @@ const (
 	Version     = "1.0.0"
 	MinWidth    = 40
 	SidePadding = 2
+
+	// Smallest terminal the bordered layout renders correctly in
+	MinTermWidth  = 60
+	MinTermHeight = 20
 )
 
-// Screen wraps content in a rounded border box that fills the terminal
-// Footer is always positioned at the bottom of the screen
-// Version is automatically added to the right side of the last footer line
-func Screen(width, height int, content, footer string) string {
-	// Calculate inner width (accounting for border characters)
+// TooSmall reports whether the terminal is below the usable minimum size
+func TooSmall(width, height int) bool {
+	return width < MinTermWidth || height < MinTermHeight
+}
+
+// TooSmallMessage renders a plain notice in place of the layout when the
+// terminal is too small, so the user sees what to do instead of broken borders
+func TooSmallMessage(width, height int) string {
+	lines := []string{
+		styles.TextWarning.Render("Terminal too small"),
+		styles.TextMuted.Render(fmt.Sprintf("need at least %dx%d", MinTermWidth, MinTermHeight)),
+		styles.TextMuted.Render(fmt.Sprintf("current %dx%d", width, height)),
+	}
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, lipgloss.JoinVertical(lipgloss.Center, lines...))
+}
+
+// layoutDims holds the sizes a bordered screen is drawn with
+type layoutDims struct {
+	innerWidth    int // Columns between "│ " and " │"
+	innerHeight   int // Rows between the borders, excluding header and separator
+	contentHeight int // Rows left for content once the footer is placed
+}
+
+// computeLayout works out the bordered screen dimensions. headerLines is 0
+// for screens without a header; otherwise the header also takes a separator
+// row. Width is clamped to MinWidth and heights never go below 1 (inner) and
+// 0 (content), so the footer always renders even on tiny terminals.
+func computeLayout(width, height, headerLines, footerLines int) layoutDims {
 	innerWidth := width - 4
 	if innerWidth < MinWidth {
 		innerWidth = MinWidth
 	}
 
-	// Split content and footer into lines, removing trailing empty line
-	contentLines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
-	footerLines := strings.Split(strings.TrimSuffix(footer, "\n"), "\n")
-
-	// Add version to the last footer line
-	footerLines = appendVersionToFooter(footerLines, innerWidth)
+	// Top and bottom border, plus header lines and separator when present
+	chrome := 2
+	if headerLines > 0 {
+		chrome += headerLines + 1
+	}
 
-	// Calculate inner height (accounting for top and bottom border)
-	innerHeight := height - 2
+	innerHeight := height - chrome
 	if innerHeight < 1 {
 		innerHeight = 1
 	}
 
 	// Reserve space for footer at bottom
-	footerHeight := len(footerLines)
-	contentHeight := innerHeight - footerHeight
+	contentHeight := innerHeight - footerLines
 	if contentHeight < 0 {
 		contentHeight = 0
 	}
 
-	// Build the screen with border
-	var b strings.Builder
-
-	// Top border
-	topBorder := "╭" + strings.Repeat("─", innerWidth+2) + "╮"
-	b.WriteString(centerLine(topBorder, width) + "\n")
-
-	// Content lines (fill available space)
-	for i := 0; i < contentHeight; i++ {
-		var line string
-		if i < len(contentLines) {
-			line = contentLines[i]
-		}
-		line = truncateOrPad(line, innerWidth)
-		b.WriteString(centerLine("│ "+line+" │", width) + "\n")
-	}
-
-	// Footer lines (always at bottom)
-	for _, line := range footerLines {
-		line = truncateOrPad(line, innerWidth)
-		b.WriteString(centerLine("│ "+line+" │", width) + "\n")
+	return layoutDims{
+		innerWidth:    innerWidth,
+		innerHeight:   innerHeight,
+		contentHeight: contentHeight,
 	}
+}
 
-	// Bottom border
-	bottomBorder := "╰" + strings.Repeat("─", innerWidth+2) + "╯"
-	b.WriteString(centerLine(bottomBorder, width))
-
-	return b.String()
+// Screen wraps content in a rounded border box that fills the terminal
+// Footer is always positioned at the bottom of the screen
+// Version is automatically added to the right side of the last footer line
+func Screen(width, height int, content, footer string) string {
+	return renderScreen(width, height, nil, content, footer)
 }
 
 // ScreenWithHeader renders a screen with a header bar (for connected screens)
@@ -260,66 +273,56 @@ func Screen(width, height int, content, footer string) string {
 // Header can be multi-line (e.g., server info + breadcrumb)
 // Version is automatically added to the right side of the last footer line
 func ScreenWithHeader(width, height int, header, content, footer string) string {
-	innerWidth := width - 4
-	if innerWidth < MinWidth {
-		innerWidth = MinWidth
-	}
-
 	headerLines := strings.Split(strings.TrimSuffix(header, "\n"), "\n")
+	return renderScreen(width, height, headerLines, content, footer)
+}
+
+func renderScreen(width, height int, headerLines []string, content, footer string) string {
+	// Split content and footer into lines, removing trailing empty line
 	contentLines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
 	footerLines := strings.Split(strings.TrimSuffix(footer, "\n"), "\n")
+	footerLines = fitShortcuts(footerLines, InnerWidth(width))
 
-	// Add version to the last footer line
-	footerLines = appendVersionToFooter(footerLines, innerWidth)
-
-	// Calculate inner height: total - top border - header lines - header sep - bottom border
-	headerHeight := len(headerLines)
-	innerHeight := height - 3 - headerHeight
-	if innerHeight < 1 {
-		innerHeight = 1
-	}
+	dims := computeLayout(width, height, len(headerLines), len(footerLines))
 
-	// Reserve space for footer at bottom
-	footerHeight := len(footerLines)
-	contentHeight := innerHeight - footerHeight
-	if contentHeight < 0 {
-		contentHeight = 0
-	}
+	// Add version to the last footer line
+	footerLines = appendVersionToFooter(footerLines, dims.innerWidth)
 
 	var b strings.Builder
 
 	// Top border
-	topBorder := "╭" + strings.Repeat("─", innerWidth+2) + "╮"
+	topBorder := "╭" + strings.Repeat("─", dims.innerWidth+2) + "╮"
 	b.WriteString(centerLine(topBorder, width) + "\n")
 
-	// Header lines
-	for _, hLine := range headerLines {
-		headerLine := truncateOrPad(hLine, innerWidth)
-		b.WriteString(centerLine("│ "+headerLine+" │", width) + "\n")
-	}
+	if len(headerLines) > 0 {
+		for _, hLine := range headerLines {
+			headerLine := truncateOrPad(hLine, dims.innerWidth)
+			b.WriteString(centerLine("│ "+headerLine+" │", width) + "\n")
+		}
 
-	// Header separator
-	headerSep := "├" + strings.Repeat("─", innerWidth+2) + "┤"
-	b.WriteString(centerLine(headerSep, width) + "\n")
+		// Header separator
+		headerSep := "├" + strings.Repeat("─", dims.innerWidth+2) + "┤"
+		b.WriteString(centerLine(headerSep, width) + "\n")
+	}
 
 	// Content lines (fill available space)
-	for i := 0; i < contentHeight; i++ {
+	for i := 0; i < dims.contentHeight; i++ {
 		var line string
 		if i < len(contentLines) {
 			line = contentLines[i]
 		}
-		line = truncateOrPad(line, innerWidth)
+		line = truncateOrPad(line, dims.innerWidth)
 		b.WriteString(centerLine("│ "+line+" │", width) + "\n")
 	}
 
 	// Footer lines (always at bottom)
 	for _, line := range footerLines {
-		line = truncateOrPad(line, innerWidth)
+		line = truncateOrPad(line, dims.innerWidth)
 		b.WriteString(centerLine("│ "+line+" │", width) + "\n")
 	}
 
 	// Bottom border
-	bottomBorder := "╰" + strings.Repeat("─", innerWidth+2) + "╯"
+	bottomBorder := "╰" + strings.Repeat("─", dims.innerWidth+2) + "╯"
 	b.WriteString(centerLine(bottomBorder, width))
 
 	return b.String()
@@ -334,6 +337,19 @@ func InnerWidth(termWidth int) int {
 	return w
 }
 
+// InputWidth returns a text input width that fits inside a card on a terminal
+// of the given width, capped at max so inputs don't stretch on wide terminals
+func InputWidth(termWidth, max int) int {
+	w := InnerWidth(termWidth) - 12 // card border, padding and prompt
+	if w > max {
+		w = max
+	}
+	if w < 10 {
+		w = 10
+	}
+	return w
+}
+
 // Divider returns a horizontal divider line
 func Divider(width int) string {
 	return styles.TextMuted.Render(strings.Repeat("─", width))
@@ -351,7 +367,7 @@ func CenterText(text string, width int) string {
 
 // Shortcuts formats shortcut hints for the footer
 func Shortcuts(items []string) string {
-	return strings.Join(items, "   ")
+	return strings.Join(items, shortcutSeparator)
 }
 
 // Helper functions
@@ -427,8 +443,11 @@ func RenderHeader(width int, breadcrumb string, server *db.Server) string {
 		return styles.TextNormal.Bold(true).Render(breadcrumb)
 	}
 
-	// First line: Green dot + server name on left, URL on right
-	left := styles.DotConnected + " " + styles.TextNormal.Bold(true).Render(server.Name)
+	// First line: status dot + server name on left, URL on right
+	left := styles.RenderDot(Online()) + " " + styles.TextNormal.Bold(true).Render(server.Name)
+	if !Online() {
+		left += styles.TextError.Render(" offline")
+	}
 	right := styles.TextMuted.Render(server.URL)
 
 	leftWidth := lipgloss.Width(left)
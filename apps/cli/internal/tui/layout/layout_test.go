@@ -0,0 +1,159 @@
+package layout
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/buntime/cli/internal/tui/styles"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestComputeLayout(t *testing.T) {
+	tests := []struct {
+		name        string
+		width       int
+		height      int
+		headerLines int
+		footerLines int
+		want        layoutDims
+	}{
+		{"plain screen", 100, 30, 0, 1, layoutDims{96, 28, 27}},
+		{"plain screen with multi-line footer", 100, 30, 0, 3, layoutDims{96, 28, 25}},
+		{"single header line", 100, 30, 1, 1, layoutDims{96, 26, 25}},
+		{"two header lines", 100, 30, 2, 2, layoutDims{96, 25, 23}},
+		{"no footer", 80, 24, 0, 0, layoutDims{76, 22, 22}},
+		{"width at minimum", MinWidth + 4, 24, 0, 1, layoutDims{MinWidth, 22, 21}},
+		{"width below minimum", 20, 24, 0, 1, layoutDims{MinWidth, 22, 21}},
+		{"zero width", 0, 24, 0, 1, layoutDims{MinWidth, 22, 21}},
+		{"negative width", -10, 24, 0, 1, layoutDims{MinWidth, 22, 21}},
+		{"height fits chrome exactly", 80, 2, 0, 0, layoutDims{76, 1, 1}},
+		{"height below chrome", 80, 1, 0, 1, layoutDims{76, 1, 0}},
+		{"header larger than height", 80, 4, 3, 1, layoutDims{76, 1, 0}},
+		{"footer larger than inner height", 80, 6, 0, 10, layoutDims{76, 4, 0}},
+		{"zero size", 0, 0, 0, 0, layoutDims{MinWidth, 1, 1}},
+		{"negative height", 80, -5, 1, 1, layoutDims{76, 1, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := computeLayout(tt.width, tt.height, tt.headerLines, tt.footerLines)
+			if got != tt.want {
+				t.Errorf("computeLayout(%d, %d, %d, %d) = %+v, want %+v",
+					tt.width, tt.height, tt.headerLines, tt.footerLines, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScreenFillsTerminal(t *testing.T) {
+	tests := []struct {
+		name   string
+		render func(width, height int) string
+	}{
+		{"Screen", func(w, h int) string {
+			return Screen(w, h, "content", "footer")
+		}},
+		{"ScreenWithHeader", func(w, h int) string {
+			return ScreenWithHeader(w, h, "server\nbreadcrumb", "content", "footer")
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, size := range [][2]int{{80, 24}, {120, 40}, {MinTermWidth, MinTermHeight}} {
+				lines := strings.Split(tt.render(size[0], size[1]), "\n")
+				if len(lines) != size[1] {
+					t.Errorf("%dx%d: rendered %d lines, want %d", size[0], size[1], len(lines), size[1])
+				}
+				for i, line := range lines {
+					if w := lipgloss.Width(line); w > size[0] {
+						t.Errorf("%dx%d: line %d is %d columns wide", size[0], size[1], i, w)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestFitShortcuts(t *testing.T) {
+	defer SetLegendVisible(false)
+
+	var items []string
+	for _, desc := range []string{"navigate", "install", "delete", "filter", "refresh", "clear filter", "back"} {
+		items = append(items, styles.RenderShortcut("key", desc))
+	}
+	footer := []string{Divider(56), Shortcuts(items)}
+	width := 56
+
+	assertFits := func(t *testing.T, lines []string) {
+		t.Helper()
+		for i, line := range lines {
+			// The version is added to the last line
+			limit := width
+			if i == len(lines)-1 {
+				limit -= versionWidth() + 1
+			}
+			if w := lipgloss.Width(line); w > limit {
+				t.Errorf("line %d is %d columns wide: %q", i, w, line)
+			}
+		}
+	}
+
+	SetLegendVisible(false)
+	got := fitShortcuts(footer, width)
+	if len(got) != 2 || !strings.Contains(got[1], "more") || strings.Contains(got[1], "back") {
+		t.Fatalf("expected a truncated line pointing at the legend, got %q", got)
+	}
+	assertFits(t, got)
+
+	SetLegendVisible(true)
+	got = fitShortcuts(footer, width)
+	if len(got) < 3 || got[0] != footer[0] {
+		t.Fatalf("expected the legend to span several lines below the divider, got %q", got)
+	}
+	legend := strings.Join(got[1:], "\n")
+	for _, desc := range []string{"navigate", "clear filter", "back"} {
+		if !strings.Contains(legend, desc) {
+			t.Errorf("legend is missing %q", desc)
+		}
+	}
+	assertFits(t, got)
+
+	// A footer that already fits is left alone when the legend is off
+	SetLegendVisible(false)
+	short := []string{Shortcuts(items[:2])}
+	if got := fitShortcuts(short, width); len(got) != 1 || got[0] != short[0] {
+		t.Fatalf("expected a short footer to be unchanged, got %q", got)
+	}
+}
+
+func TestScrollOffsetKeepsCursorVisible(t *testing.T) {
+	tests := []struct {
+		offset, cursor, count, rows int
+		want                        int
+	}{
+		{offset: 0, cursor: 4, count: 20, rows: 5, want: 0},
+		{offset: 0, cursor: 5, count: 20, rows: 5, want: 1},
+		{offset: 10, cursor: 3, count: 20, rows: 5, want: 3},
+		{offset: 15, cursor: 8, count: 10, rows: 5, want: 5},
+		{offset: 3, cursor: 0, count: 2, rows: 5, want: 0},
+	}
+	for _, tt := range tests {
+		if got := ScrollOffset(tt.offset, tt.cursor, tt.count, tt.rows); got != tt.want {
+			t.Errorf("ScrollOffset(%d, %d, %d, %d) = %d, want %d", tt.offset, tt.cursor, tt.count, tt.rows, got, tt.want)
+		}
+	}
+}
+
+func TestCompactDensityFitsMoreRows(t *testing.T) {
+	defer SetDensity(DensityComfortable)
+
+	comfortable := ListRows(30, 0)
+	SetDensity(DensityCompact)
+	if compact := ListRows(30, 0); compact <= comfortable {
+		t.Fatalf("expected compact lists to fit more than %d rows, got %d", comfortable, compact)
+	}
+	if strings.Contains(ListHeader("NAME", 10), "─") {
+		t.Fatal("expected no separator under compact list headers")
+	}
+}
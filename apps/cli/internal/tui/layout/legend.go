@@ -0,0 +1,111 @@
+package layout
+
+import (
+	"strings"
+
+	"github.com/buntime/cli/internal/i18n"
+	"github.com/buntime/cli/internal/tui/styles"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// shortcutSeparator joins shortcut hints on the footer line; the legend
+// splits on it to lay the hints out again
+const shortcutSeparator = "   "
+
+// LegendKey toggles the shortcut legend on every screen, unless a text field
+// has the focus. Ctrl+H is Backspace in many terminals.
+const LegendKey = "?"
+
+// legendVisible is shared by every screen so the legend stays open while
+// navigating. The TUI only renders from the bubbletea goroutine.
+var legendVisible bool
+
+// SetLegendVisible shows or hides the shortcut legend
+func SetLegendVisible(visible bool) {
+	legendVisible = visible
+}
+
+// LegendVisible reports whether the shortcut legend is shown
+func LegendVisible() bool {
+	return legendVisible
+}
+
+// fitShortcuts lays out the shortcut line (the last footer line) for the
+// available width. With the legend on, every hint is shown in aligned
+// columns over as many lines as needed. Otherwise a line that would be cut
+// off drops the hints that don't fit and points at the legend instead.
+func fitShortcuts(footerLines []string, width int) []string {
+	if len(footerLines) == 0 {
+		return footerLines
+	}
+	last := footerLines[len(footerLines)-1]
+	items := strings.Split(last, shortcutSeparator)
+	if len(items) < 2 {
+		return footerLines
+	}
+
+	var fitted []string
+	if legendVisible {
+		fitted = legendLines(items, width)
+	} else if lipgloss.Width(last)+versionWidth()+1 > width {
+		fitted = []string{truncatedShortcuts(items, width)}
+	} else {
+		return footerLines
+	}
+
+	return append(footerLines[:len(footerLines)-1:len(footerLines)-1], fitted...)
+}
+
+// legendLines arranges shortcut hints into columns
+func legendLines(items []string, width int) []string {
+	colWidth := 0
+	for _, item := range items {
+		colWidth = max(colWidth, lipgloss.Width(item)+len(shortcutSeparator))
+	}
+	cols := max(1, width/colWidth)
+
+	var lines []string
+	for start := 0; start < len(items); start += cols {
+		var line strings.Builder
+		for i := start; i < min(start+cols, len(items)); i++ {
+			item := items[i]
+			line.WriteString(item)
+			if i < start+cols-1 && i < len(items)-1 {
+				line.WriteString(strings.Repeat(" ", colWidth-lipgloss.Width(item)))
+			}
+		}
+		lines = append(lines, line.String())
+	}
+
+	// Keep the version, added to the last line, from being cut off
+	if lipgloss.Width(lines[len(lines)-1])+versionWidth()+1 > width {
+		lines = append(lines, "")
+	}
+	return lines
+}
+
+// truncatedShortcuts keeps as many hints as fit next to the version and a
+// pointer to the legend
+func truncatedShortcuts(items []string, width int) string {
+	more := styles.RenderShortcut(LegendKey, i18n.T("shortcut.more"))
+	available := width - versionWidth() - 1 - lipgloss.Width(more) - len(shortcutSeparator)
+
+	var kept []string
+	used := 0
+	for _, item := range items {
+		w := lipgloss.Width(item)
+		if len(kept) > 0 {
+			w += len(shortcutSeparator)
+		}
+		if used+w > available {
+			break
+		}
+		kept = append(kept, item)
+		used += w
+	}
+	return strings.Join(append(kept, more), shortcutSeparator)
+}
+
+func versionWidth() int {
+	return lipgloss.Width("v" + Version)
+}
@@ -0,0 +1,125 @@
+package tui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/tui/layout"
+	"github.com/buntime/cli/internal/tui/screens"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestLeavingAScreenAbortsItsRequests(t *testing.T) {
+	// A server that never answers, so only cancellation can end a request
+	started := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	model := newResizeTestModel(t)
+	model.api = api.New(server.URL, "btk_test", false)
+	model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	model.Init()
+
+	_, load := model.navigateTo(ScreenApps, nil)
+	if load == nil {
+		t.Fatal("expected the apps screen to start loading")
+	}
+	done := make(chan tea.Msg, 1)
+	go func() { done <- load() }()
+
+	<-started
+	model.goBack()
+
+	var stale tea.Msg
+	select {
+	case stale = <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected leaving the apps screen to abort its load")
+	}
+
+	// The screen navigated to gets a context of its own
+	model.navigateTo(ScreenApps, nil)
+	if model.cancelScreen == nil {
+		t.Fatal("expected a context for the new screen")
+	}
+
+	// The aborted load reaching the new apps screen is dropped rather than
+	// shown as an error while the screen's own load runs
+	model.Update(stale)
+	if view := model.View(); strings.Contains(view, "canceled") {
+		t.Fatalf("expected the stale result to be dropped, got:\n%s", view)
+	}
+}
+
+func TestConnectedScreensWithoutAClientRedirectToConnect(t *testing.T) {
+	t.Setenv("BUNTIME_CONFIG_DIR", db.MemoryDir)
+	database, err := db.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+
+	for _, screen := range []int{screens.ScreenApps, screens.ScreenPlugins, screens.ScreenKeys, screens.ScreenSettings, screens.ScreenMainMenu} {
+		model := NewModel(database)
+		model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+		model.Init()
+
+		model.Update(screens.NavigateMsg{Screen: screen})
+		if model.router.Current() != ScreenServerSelect {
+			t.Errorf("screen %d: expected the server list, got screen %d", screen, model.router.Current())
+		}
+		if view := model.View(); !strings.Contains(view, "Connect to a server first") {
+			t.Errorf("screen %d: expected a note to connect first, got:\n%s", screen, view)
+		}
+	}
+}
+
+func TestNavigatingWithTheWrongDataShowsAnError(t *testing.T) {
+	t.Setenv("BUNTIME_CONFIG_DIR", db.MemoryDir)
+	database, err := db.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+
+	model := NewModel(database)
+	model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	model.Init()
+
+	model.Update(screens.NavigateMsg{Screen: screens.ScreenEditServer, Data: &api.AppInfo{Name: "front"}})
+	if model.router.Current() != ScreenServerSelect {
+		t.Fatalf("expected to stay on the server list, got screen %d", model.router.Current())
+	}
+	if view := model.View(); !strings.Contains(view, "expected *db.Server data") {
+		t.Fatalf("expected an error toast, got:\n%s", view)
+	}
+}
+
+func TestLegendKeyIsTextWhileTyping(t *testing.T) {
+	model := newResizeTestModel(t)
+	model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	t.Cleanup(func() { layout.SetLegendVisible(false) })
+
+	model.navigateTo(ScreenAddServer, nil)
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	if layout.LegendVisible() {
+		t.Fatal("expected ? to be typed into the focused field")
+	}
+
+	model.navigateTo(ScreenMainMenu, nil)
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	if !layout.LegendVisible() {
+		t.Fatal("expected ? to open the legend")
+	}
+}
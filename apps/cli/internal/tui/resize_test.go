@@ -0,0 +1,131 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/tui/screens"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// resizeSequence mimics a user dragging the terminal edge mid-session
+var resizeSequence = []tea.WindowSizeMsg{
+	{Width: 120, Height: 40},
+	{Width: 80, Height: 24},
+	{Width: 200, Height: 60},
+	{Width: 70, Height: 22},
+	{Width: 100, Height: 30},
+}
+
+func newResizeTestModel(t *testing.T) *Model {
+	t.Helper()
+	t.Setenv("BUNTIME_CONFIG_DIR", db.MemoryDir)
+
+	database, err := db.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { database.Close() })
+
+	token := "btk_test"
+	server, err := database.CreateServer("Production", "https://buntime.example", &token, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return NewConnectedModel(database, api.New(server.URL, token, false), server)
+}
+
+// assertWithinBounds fails when any rendered line is wider than the terminal
+func assertWithinBounds(t *testing.T, screen string, view string, size tea.WindowSizeMsg) {
+	t.Helper()
+	for i, line := range strings.Split(view, "\n") {
+		if w := lipgloss.Width(line); w > size.Width {
+			t.Errorf("%s at %dx%d: line %d is %d columns wide: %q", screen, size.Width, size.Height, i, w, line)
+		}
+	}
+}
+
+func TestScreensStayWithinBoundsOnResize(t *testing.T) {
+	model := newResizeTestModel(t)
+	server := model.currentServer
+
+	cases := []struct {
+		name   string
+		screen Screen
+		data   interface{}
+	}{
+		{"server select", ScreenServerSelect, nil},
+		{"add server", ScreenAddServer, nil},
+		{"edit server", ScreenEditServer, server},
+		{"token prompt", ScreenTokenPrompt, server},
+		{"main menu", ScreenMainMenu, nil},
+		{"apps", ScreenApps, nil},
+		{"plugins", ScreenPlugins, nil},
+		{"app install", ScreenAppInstall, nil},
+		{"app remove", ScreenAppRemove, &api.AppInfo{Name: "my-app", Versions: []string{"1.0.0", "0.9.0"}}},
+		{"plugin remove", ScreenPluginRemove, &api.PluginInfo{Name: "my-plugin", Versions: []string{"1.0.0"}}},
+		{"plugin enable", ScreenPluginEnable, &api.PluginInfo{Name: "my-plugin", Versions: []string{"1.1.0", "1.0.0"}, ActiveVersion: "1.0.0"}},
+		{"plugin config", ScreenPluginConfig, &api.PluginInfo{ID: 1, Name: "my-plugin"}},
+		{"keys", ScreenKeys, nil},
+		{"key create", ScreenKeyCreate, nil},
+		{"key revoke", ScreenKeyRevoke, &api.ApiKeyInfo{Name: "ci-deploy", KeyPrefix: "btk_abc"}},
+		{"key rotate", ScreenKeyRotate, &api.ApiKeyInfo{Name: "ci-deploy", KeyPrefix: "btk_abc"}},
+		{"key edit", ScreenKeyEdit, &api.ApiKeyInfo{ID: 3, Name: "ci-deploy", Role: api.KeyRoleCustom, Permissions: []api.Permission{api.PermAppsRead}}},
+		{"settings", ScreenSettings, nil},
+		{"server config", ScreenServerConfig, nil},
+		{"worker pool", ScreenWorkerPool, nil},
+		{"app rollback", ScreenAppRollback, &api.AppInfo{Name: "my-app", Versions: []string{"1.1.0", "1.0.0"}, ActiveVersion: "1.1.0"}},
+		{"plugin detail", ScreenPluginDetail, &api.PluginInfo{ID: 1, Name: "my-plugin", Path: "/plugins/my-plugin", Versions: []string{"1.0.0"}, Enabled: true}},
+		{"app detail", ScreenAppDetail, &api.AppInfo{Name: "my-app", Path: "/apps/my-app", Versions: []string{"1.1.0", "1.0.0"}}},
+		{"activity", ScreenActivity, nil},
+		{"batch install", ScreenBatchInstall, []db.Server{*server}},
+		{"connection error", ScreenConnectionError, &screens.ConnectionFailure{Server: server, Err: &api.APIError{Type: api.ErrorTypeTLSError, Message: "TLS certificate error. Use --insecure (-k) to skip verification."}}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			model.initScreen(tc.screen, tc.data)
+			model.router.Reset(tc.screen)
+
+			for _, size := range resizeSequence {
+				model.Update(size)
+				assertWithinBounds(t, tc.name, model.View(), size)
+			}
+		})
+	}
+}
+
+func TestResizeReachesScreensInHistory(t *testing.T) {
+	model := newResizeTestModel(t)
+	model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	model.initScreen(ScreenMainMenu, nil)
+	model.navigateTo(ScreenSettings, nil)
+
+	size := tea.WindowSizeMsg{Width: 72, Height: 22}
+	model.Update(size)
+	model.goBack()
+
+	assertWithinBounds(t, "main menu", model.View(), size)
+}
+
+func TestTooSmallTerminalShowsNotice(t *testing.T) {
+	model := newResizeTestModel(t)
+	model.initScreen(ScreenMainMenu, nil)
+
+	small := tea.WindowSizeMsg{Width: 50, Height: 15}
+	model.Update(small)
+	view := model.View()
+	if !strings.Contains(view, "Terminal too small") || !strings.Contains(view, "50x15") {
+		t.Fatalf("expected too-small notice, got %q", view)
+	}
+	assertWithinBounds(t, "too small", view, small)
+
+	model.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	if strings.Contains(model.View(), "Terminal too small") {
+		t.Fatal("expected normal layout after growing the terminal")
+	}
+}
@@ -0,0 +1,335 @@
+package screens
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/i18n"
+	"github.com/buntime/cli/internal/tui/components"
+	"github.com/buntime/cli/internal/tui/layout"
+	"github.com/buntime/cli/internal/tui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// activityPageSize is how many entries each request fetches
+const activityPageSize = 50
+
+// ActivityModel shows the server's activity log, newest first
+type ActivityModel struct {
+	api     *api.Client
+	server  *db.Server
+	entries []api.ActivityEntry
+	next    string // Cursor for the next page, empty on the last one
+	cursor  int
+	offset  int // First visible row
+	width   int
+	height  int
+	loading bool
+	err     error
+
+	// Filters are cycled through the values seen so far
+	typeFilter  string
+	actorFilter string
+	types       []string
+	actors      []string
+}
+
+// NewActivityModel creates an activity log screen
+func NewActivityModel(client *api.Client, server *db.Server, width, height int) *ActivityModel {
+	return &ActivityModel{
+		api:     client,
+		server:  server,
+		width:   width,
+		height:  height,
+		loading: true,
+	}
+}
+
+func (m *ActivityModel) Init() tea.Cmd {
+	return m.loadActivity("")
+}
+
+type activityLoadedMsg struct {
+	page   *api.ActivityPage
+	cursor string // The cursor the page was requested with
+	err    error
+}
+
+// loadActivity fetches a page with the current filters. An empty cursor
+// starts over from the newest entry.
+func (m *ActivityModel) loadActivity(cursor string) tea.Cmd {
+	opts := api.ActivityOptions{
+		Type:   m.typeFilter,
+		Actor:  m.actorFilter,
+		Limit:  activityPageSize,
+		Cursor: cursor,
+	}
+	return func() tea.Msg {
+		page, err := m.api.GetActivity(opts)
+		return activityLoadedMsg{page: page, cursor: cursor, err: err}
+	}
+}
+
+func (m *ActivityModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case activityLoadedMsg:
+		if canceled(msg.err) {
+			return m, nil
+		}
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.err = nil
+		if msg.cursor == "" {
+			m.entries = msg.page.Entries
+			m.cursor = 0
+			m.offset = 0
+		} else {
+			m.entries = append(m.entries, msg.page.Entries...)
+		}
+		m.next = msg.page.NextCursor
+		m.collectFilterValues(msg.page.Entries)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.entries)-1 {
+				m.cursor++
+			}
+		case "m":
+			if m.next != "" && !m.loading {
+				m.loading = true
+				return m, m.loadActivity(m.next)
+			}
+		case "t":
+			m.typeFilter = nextFilterValue(m.types, m.typeFilter)
+			return m, m.reload()
+		case "a":
+			m.actorFilter = nextFilterValue(m.actors, m.actorFilter)
+			return m, m.reload()
+		case switchKeyKey:
+			if permissionDenied(m.err) && !m.loading {
+				return m, switchKey(m.api, m.server)
+			}
+		case "r":
+			// A reload is already running
+			if m.loading {
+				return m, nil
+			}
+			return m, m.reload()
+		case "esc":
+			// Esc drops active filters before leaving the screen
+			if m.filtered() {
+				m.typeFilter = ""
+				m.actorFilter = ""
+				return m, m.reload()
+			}
+			return m, goBack()
+		}
+	}
+
+	return m, nil
+}
+
+func (m *ActivityModel) reload() tea.Cmd {
+	m.loading = true
+	return m.loadActivity("")
+}
+
+func (m *ActivityModel) filtered() bool {
+	return m.typeFilter != "" || m.actorFilter != ""
+}
+
+// collectFilterValues remembers the types and actors seen so the filters can
+// cycle through them
+func (m *ActivityModel) collectFilterValues(entries []api.ActivityEntry) {
+	for _, entry := range entries {
+		m.types = addSorted(m.types, entry.Type)
+		m.actors = addSorted(m.actors, entry.Actor)
+	}
+}
+
+func addSorted(values []string, value string) []string {
+	if value == "" {
+		return values
+	}
+	i := sort.SearchStrings(values, value)
+	if i < len(values) && values[i] == value {
+		return values
+	}
+	return append(values[:i], append([]string{value}, values[i:]...)...)
+}
+
+// nextFilterValue cycles "" (no filter) -> values[0] -> ... -> ""
+func nextFilterValue(values []string, current string) string {
+	if current == "" {
+		if len(values) == 0 {
+			return ""
+		}
+		return values[0]
+	}
+	i := sort.SearchStrings(values, current)
+	if i+1 < len(values) {
+		return values[i+1]
+	}
+	return ""
+}
+
+func (m *ActivityModel) View() string {
+	innerWidth := layout.InnerWidth(m.width)
+
+	titleText := "ACTIVITY"
+	if len(m.entries) > 0 {
+		titleText += fmt.Sprintf(" (%d", len(m.entries))
+		if m.next != "" {
+			titleText += "+"
+		}
+		titleText += ")"
+	}
+	if m.typeFilter != "" {
+		titleText += " · type=" + m.typeFilter
+	}
+	if m.actorFilter != "" {
+		titleText += " · actor=" + m.actorFilter
+	}
+
+	var content strings.Builder
+	if m.err != nil {
+		if apiErr, ok := m.err.(*api.APIError); ok && apiErr.Type == api.ErrorTypeUnsupported {
+			content.WriteString(layout.CenterText(styles.TextMuted.Render("This server does not record an activity log."), innerWidth) + "\n")
+		} else {
+			content.WriteString(styles.TextError.Render("Error: "+m.err.Error()) + "\n")
+			if permissionDenied(m.err) {
+				content.WriteString(renderSwitchKeyHint())
+			}
+		}
+	} else if m.loading && len(m.entries) == 0 {
+		header, widths := m.columns(innerWidth)
+		content.WriteString(layout.ListHeader(header, innerWidth))
+		content.WriteString(components.SkeletonRows(components.SkeletonRowCount, widths))
+	} else if len(m.entries) == 0 {
+		content.WriteString(layout.CenterText(styles.TextMuted.Render("No activity recorded."), innerWidth) + "\n")
+	} else {
+		content.WriteString(m.renderEntries(innerWidth))
+	}
+
+	return layout.Page(layout.PageConfig{
+		Width:      m.width,
+		Height:     m.height,
+		Server:     m.server,
+		Breadcrumb: "Main › Activity",
+		Title:      titleText,
+		Content:    content.String(),
+		Shortcuts:  m.getShortcuts(),
+	})
+}
+
+// columns returns the list's header line and column widths, shared by the
+// rows and the loading skeleton
+func (m *ActivityModel) columns(width int) (string, []int) {
+	timeWidth := 14
+	typeWidth := 16
+	actorWidth := 20
+	targetWidth := max(10, width-timeWidth-typeWidth-actorWidth-6)
+
+	header := fmt.Sprintf("  %-*s %-*s %-*s %s",
+		timeWidth, "WHEN",
+		typeWidth, "TYPE",
+		actorWidth, "ACTOR",
+		"TARGET",
+	)
+	return header, []int{timeWidth, typeWidth, actorWidth, targetWidth}
+}
+
+func (m *ActivityModel) renderEntries(width int) string {
+	var b strings.Builder
+
+	headerLine, widths := m.columns(width)
+	timeWidth, typeWidth, actorWidth, targetWidth := widths[0], widths[1], widths[2], widths[3]
+	b.WriteString(layout.ListHeader(headerLine, width))
+
+	// Room for the paging hint and the message of the selected entry
+	rows := layout.ListRows(m.height, 3)
+	m.offset = layout.ScrollOffset(m.offset, m.cursor, len(m.entries), rows)
+
+	now := m.api.ServerNow()
+	end := min(len(m.entries), m.offset+rows)
+	for i := m.offset; i < end; i++ {
+		entry := m.entries[i]
+		cursor := "  "
+		if i == m.cursor {
+			cursor = styles.Caret
+		}
+
+		line := styles.PadRight(formatTimeAgo(entry.Timestamp, now), timeWidth) + " " +
+			styles.PadRight(styles.Truncate(entry.Type, typeWidth), typeWidth) + " " +
+			styles.PadRight(styles.Truncate(orDash(entry.Actor), actorWidth), actorWidth) + " " +
+			styles.Truncate(orDash(entry.Target), targetWidth)
+
+		if i == m.cursor {
+			line = styles.TextPrimary.Render(line)
+		}
+		b.WriteString(cursor + line + "\n")
+	}
+
+	if m.loading {
+		b.WriteString(styles.TextMuted.Render("  Loading more...") + "\n")
+	} else if m.next != "" && end == len(m.entries) {
+		b.WriteString(styles.TextMuted.Render("  Press m to load older entries") + "\n")
+	}
+
+	// Message of the selected entry
+	if m.cursor < len(m.entries) && m.entries[m.cursor].Message != "" {
+		b.WriteString("\n" + styles.TextMuted.Render(styles.Truncate(m.entries[m.cursor].Message, width)) + "\n")
+	}
+
+	return b.String()
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func (m *ActivityModel) getShortcuts() []string {
+	shortcuts := []string{
+		styles.RenderShortcut("↑↓", i18n.T("shortcut.navigate")),
+		styles.RenderShortcut("t", i18n.T("shortcut.filter_type")),
+		styles.RenderShortcut("a", i18n.T("shortcut.filter_actor")),
+	}
+
+	if m.next != "" {
+		shortcuts = append(shortcuts, styles.RenderShortcut("m", i18n.T("shortcut.more")))
+	}
+
+	shortcuts = append(shortcuts, styles.RenderShortcut("r", i18n.T("shortcut.refresh")))
+
+	if permissionDenied(m.err) {
+		shortcuts = append(shortcuts, switchKeyShortcut())
+	}
+
+	if m.filtered() {
+		shortcuts = append(shortcuts, styles.RenderShortcut("Esc", i18n.T("shortcut.clear_filter")))
+	} else {
+		shortcuts = append(shortcuts, styles.RenderShortcut("Esc", i18n.T("shortcut.back")))
+	}
+
+	return shortcuts
+}
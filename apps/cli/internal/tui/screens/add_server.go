@@ -1,13 +1,15 @@
 package screens
 
 import (
-	"net/url"
 	"strings"
 
 	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/i18n"
+	"github.com/buntime/cli/internal/tui/components"
 	"github.com/buntime/cli/internal/tui/layout"
 	"github.com/buntime/cli/internal/tui/messages"
 	"github.com/buntime/cli/internal/tui/styles"
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -16,6 +18,7 @@ import (
 const (
 	focusName = iota
 	focusURL
+	focusNotes
 	focusInsecure
 	focusCancel
 	focusSave
@@ -26,11 +29,12 @@ type AddServerModel struct {
 	db         *db.DB
 	nameInput  textinput.Model
 	urlInput   textinput.Model
+	notesInput textarea.Model
 	insecure   bool
-	focusIndex int
+	form       *components.Form
 	width      int
 	height     int
-	err        string
+	saving     bool
 }
 
 // NewAddServerModel creates a new add server form
@@ -39,23 +43,37 @@ func NewAddServerModel(database *db.DB, width, height int) *AddServerModel {
 	nameInput.Placeholder = "Production"
 	nameInput.Prompt = ""
 	nameInput.CharLimit = 50
-	nameInput.Width = 40
-	nameInput.Focus()
 
 	urlInput := textinput.New()
 	urlInput.Placeholder = "https://buntime.example.com"
 	urlInput.Prompt = ""
 	urlInput.CharLimit = 200
-	urlInput.Width = 40
 
-	return &AddServerModel{
+	m := &AddServerModel{
 		db:         database,
 		nameInput:  nameInput,
 		urlInput:   urlInput,
-		focusIndex: focusName,
+		notesInput: newNotesInput(""),
 		width:      width,
 		height:     height,
 	}
+	m.form = components.NewForm(
+		components.Field{Kind: components.FieldInput, Input: &m.nameInput},
+		components.Field{Kind: components.FieldInput, Input: &m.urlInput, Validate: m.validateURL, Live: true},
+		components.Field{Kind: components.FieldArea, Area: &m.notesInput},
+		components.Field{Kind: components.FieldCheckbox, Checked: &m.insecure},
+		components.Field{Kind: components.FieldButton},
+		components.Field{Kind: components.FieldButton},
+	)
+	m.resizeInputs()
+	return m
+}
+
+// resizeInputs fits the text inputs to the current terminal width
+func (m *AddServerModel) resizeInputs() {
+	m.nameInput.Width = layout.InputWidth(m.width, 40)
+	m.urlInput.Width = layout.InputWidth(m.width, 40)
+	resizeNotesInput(&m.notesInput, m.width)
 }
 
 func (m *AddServerModel) Init() tea.Cmd {
@@ -67,111 +85,62 @@ func (m *AddServerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.resizeInputs()
+		return m, nil
+
+	case messages.ServerSavedMsg:
+		m.saving = false
 		return m, nil
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "tab", "down":
-			m.focusNext()
-			return m, nil
-		case "shift+tab", "up":
-			m.focusPrev()
+		// Ignore input until the pending save finishes
+		if m.saving {
 			return m, nil
+		}
+		switch msg.String() {
 		case "enter":
-			if m.focusIndex == focusSave {
+			switch m.form.Focused() {
+			case focusSave:
 				return m, m.save()
-			}
-			if m.focusIndex == focusCancel {
+			case focusCancel:
 				return m, goBack()
 			}
-			m.focusNext()
-			return m, nil
-		case " ", "space":
-			if m.focusIndex == focusInsecure {
-				m.insecure = !m.insecure
-				return m, nil
-			}
 		case "esc":
 			return m, goBack()
 		}
 	}
 
-	// Update focused input
-	var cmd tea.Cmd
-	if m.focusIndex == focusName {
-		m.nameInput, cmd = m.nameInput.Update(msg)
-	} else if m.focusIndex == focusURL {
-		m.urlInput, cmd = m.urlInput.Update(msg)
-	}
-
-	return m, cmd
+	return m, m.form.Update(msg)
 }
 
-func (m *AddServerModel) focusNext() {
-	m.focusIndex = (m.focusIndex + 1) % 5
-	m.updateFocus()
-}
+func (m *AddServerModel) validateURL() string {
+	urlStr := m.urlInput.Value()
 
-func (m *AddServerModel) focusPrev() {
-	m.focusIndex--
-	if m.focusIndex < 0 {
-		m.focusIndex = 4
-	}
-	m.updateFocus()
-}
-
-func (m *AddServerModel) updateFocus() {
-	m.nameInput.Blur()
-	m.urlInput.Blur()
-
-	switch m.focusIndex {
-	case focusName:
-		m.nameInput.Focus()
-	case focusURL:
-		m.urlInput.Focus()
-	}
-}
-
-func (m *AddServerModel) validate() string {
-	urlStr := strings.TrimSpace(m.urlInput.Value())
-
-	if urlStr == "" {
-		return "URL is required"
-	}
-
-	if !strings.HasPrefix(urlStr, "http://") && !strings.HasPrefix(urlStr, "https://") {
-		return "URL must start with http:// or https://"
-	}
-
-	parsed, err := url.Parse(urlStr)
-	if err != nil {
-		return "Invalid URL format"
-	}
-
-	if parsed.Host == "" {
-		return "URL must include a hostname"
+	if err := db.ValidateServerURL(urlStr); err != nil {
+		return err.Error()
 	}
 
 	// Check for duplicate
-	existing, err := m.db.GetServerByURL(urlStr)
-	if err == nil && existing != nil {
-		return "Server with this URL already exists: \"" + existing.Name + "\""
+	if err := m.db.CheckServerURLFree(urlStr, 0); err != nil {
+		return err.Error()
 	}
 
 	return ""
 }
 
 func (m *AddServerModel) save() tea.Cmd {
-	if errMsg := m.validate(); errMsg != "" {
-		m.err = errMsg
+	if m.form.Validate() != "" {
 		return nil
 	}
 
+	m.saving = true
+
 	urlStr := strings.TrimSpace(m.urlInput.Value())
 	name := strings.TrimSpace(m.nameInput.Value())
+	notes := normalizeNotes(m.notesInput.Value())
 
 	if name == "" {
-		name = m.generateName(urlStr)
+		name = db.DefaultServerName(urlStr)
 	}
 
 	return func() tea.Msg {
@@ -179,26 +148,19 @@ func (m *AddServerModel) save() tea.Cmd {
 		if err != nil {
 			return messages.ServerSavedMsg{Err: err}
 		}
+		if notes != "" {
+			if err := m.db.UpdateServerNotes(server.ID, notes); err != nil {
+				return messages.ServerSavedMsg{Err: err}
+			}
+			server.Notes = notes
+		}
 		return messages.ServerSavedMsg{Server: server}
 	}
 }
 
-func (m *AddServerModel) generateName(urlStr string) string {
-	parsed, err := url.Parse(urlStr)
-	if err != nil {
-		return "Server"
-	}
-
-	host := parsed.Hostname()
-	host = strings.TrimPrefix(host, "www.")
-	host = strings.TrimPrefix(host, "api.")
-	host = strings.TrimPrefix(host, "buntime.")
-
-	if len(host) > 0 {
-		return strings.ToUpper(host[:1]) + host[1:]
-	}
-
-	return "Server"
+// Typing reports whether a text field of the form has the focus
+func (m *AddServerModel) Typing() bool {
+	return m.form.Typing()
 }
 
 func (m *AddServerModel) View() string {
@@ -239,91 +201,38 @@ func (m *AddServerModel) renderForm() string {
 	var b strings.Builder
 
 	// Name field
-	b.WriteString(m.renderLabel("Name", false) + "\n")
-	b.WriteString(m.renderInput(m.nameInput, m.focusIndex == focusName, false) + "\n")
+	b.WriteString(components.RenderLabel("Name", false) + "\n")
+	b.WriteString(m.form.RenderInput(focusName) + "\n")
 	b.WriteString(styles.TextMuted.Italic(true).Render("Auto-generated from hostname if empty") + "\n")
 	b.WriteString("\n")
 
 	// URL field
-	b.WriteString(m.renderLabel("URL", true) + "\n")
-	hasError := m.err != "" && strings.Contains(m.err, "URL")
-	b.WriteString(m.renderInput(m.urlInput, m.focusIndex == focusURL, hasError) + "\n")
-	if m.err != "" {
-		b.WriteString(styles.TextError.Render("✗ "+m.err) + "\n")
-	}
+	b.WriteString(components.RenderLabel("URL", true) + "\n")
+	b.WriteString(m.form.RenderInput(focusURL) + "\n")
+	b.WriteString(m.form.RenderError(focusURL))
+	b.WriteString("\n")
+
+	// Notes field
+	b.WriteString(components.RenderLabel("Notes", false) + "\n")
+	b.WriteString(m.form.RenderArea(focusNotes) + "\n")
 	b.WriteString("\n")
 
 	// Insecure checkbox
-	b.WriteString(m.renderCheckbox("Skip TLS verification (insecure)", m.insecure, m.focusIndex == focusInsecure) + "\n")
+	b.WriteString(m.form.RenderCheckbox(focusInsecure, "Skip TLS verification (insecure)") + "\n")
 	b.WriteString("\n")
 
 	// Buttons
-	b.WriteString(m.renderButtons())
+	b.WriteString(m.form.RenderButtons(focusCancel, "  Cancel  ", focusSave, "   Save   "))
 
 	return b.String()
 }
 
-func (m *AddServerModel) renderLabel(text string, required bool) string {
-	label := styles.TextNormal.Render(text)
-	if required {
-		label += styles.TextError.Render(" *")
-	}
-	return label
-}
-
-func (m *AddServerModel) renderInput(input textinput.Model, focused bool, hasError bool) string {
-	var borderColor lipgloss.Color
-	if hasError {
-		borderColor = styles.ColorError
-	} else if focused {
-		borderColor = styles.ColorPrimary
-	} else {
-		borderColor = styles.ColorSurface
-	}
-
-	inputStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(borderColor).
-		Padding(0, 1).
-		Width(44)
-
-	return inputStyle.Render(input.View())
-}
-
-func (m *AddServerModel) renderCheckbox(label string, checked bool, focused bool) string {
-	checkbox := styles.RenderCheckbox(checked, focused)
-
-	labelStyle := styles.TextNormal
-	if focused {
-		labelStyle = styles.TextPrimary
-	}
-
-	return lipgloss.JoinHorizontal(lipgloss.Center, checkbox, "  ", labelStyle.Render(label))
-}
-
-func (m *AddServerModel) renderButtons() string {
-	cancelStyle := styles.Button
-	if m.focusIndex == focusCancel {
-		cancelStyle = styles.ButtonFocused
-	}
-
-	saveStyle := styles.Button
-	if m.focusIndex == focusSave {
-		saveStyle = styles.ButtonPrimary
-	}
-
-	cancel := cancelStyle.Render("  Cancel  ")
-	save := saveStyle.Render("   Save   ")
-
-	return lipgloss.JoinHorizontal(lipgloss.Center, cancel, "  ", save)
-}
-
 func (m *AddServerModel) renderShortcuts() string {
 	shortcuts := []string{
-		styles.RenderShortcut("Tab", "next"),
-		styles.RenderShortcut("Shift+Tab", "prev"),
-		styles.RenderShortcut("⏎", "submit"),
-		styles.RenderShortcut("Esc", "cancel"),
+		styles.RenderShortcut("Tab", i18n.T("shortcut.next")),
+		styles.RenderShortcut("Shift+Tab", i18n.T("shortcut.prev")),
+		styles.RenderShortcut("⏎", i18n.T("shortcut.submit")),
+		styles.RenderShortcut("Esc", i18n.T("shortcut.cancel")),
 	}
 
 	return layout.Shortcuts(shortcuts)
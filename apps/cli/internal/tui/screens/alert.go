@@ -0,0 +1,102 @@
+package screens
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/buntime/cli/internal/db"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// AlertMode is how the TUI signals that a long operation, such as an upload
+// or a batch install, has finished
+type AlertMode string
+
+const (
+	AlertOff    AlertMode = "off"
+	AlertBell   AlertMode = "bell"   // Ring the terminal bell
+	AlertNotify AlertMode = "notify" // Ring the bell and show a desktop notification
+)
+
+// AlertModes lists the modes in the order Settings cycles through them
+var AlertModes = []AlertMode{AlertOff, AlertBell, AlertNotify}
+
+// Label describes the mode in Settings
+func (a AlertMode) Label() string {
+	switch a {
+	case AlertBell:
+		return "terminal bell"
+	case AlertNotify:
+		return "bell and desktop notification"
+	default:
+		return "off"
+	}
+}
+
+// alertsMuted is set by --no-bell and wins over the saved mode
+var alertsMuted bool
+
+// MuteAlerts turns completion alerts off for this session
+func MuteAlerts() {
+	alertsMuted = true
+}
+
+// loadAlertMode reads the saved mode; alerts are off unless the user turned
+// them on
+func loadAlertMode(database *db.DB) AlertMode {
+	if database == nil {
+		return AlertOff
+	}
+	value, err := database.GetConfig(db.ConfigCompletionAlert)
+	if err != nil {
+		return AlertOff
+	}
+	for _, mode := range AlertModes {
+		if string(mode) == value {
+			return mode
+		}
+	}
+	return AlertOff
+}
+
+// alertDone signals that an operation finished, as configured in Settings.
+// It never produces a message; a failed notification is not worth a toast.
+func alertDone(database *db.DB, title, body string) tea.Cmd {
+	mode := loadAlertMode(database)
+	if alertsMuted || mode == AlertOff {
+		return nil
+	}
+	return func() tea.Msg {
+		// The bell goes to stderr so it can't split a frame being written
+		// to stdout
+		fmt.Fprint(os.Stderr, "\a")
+		if mode == AlertNotify {
+			if cmd := notifyCommand(runtime.GOOS, title, body, os.Getenv); cmd != nil && cmd.Start() == nil {
+				go cmd.Wait()
+			}
+		}
+		return nil
+	}
+}
+
+// notifyCommand returns the platform's desktop notifier, or nil when there is
+// none, such as over SSH or on Windows
+func notifyCommand(goos, title, body string, getenv func(string) string) *exec.Cmd {
+	switch goos {
+	case "darwin":
+		// Pass the text as arguments so quotes in it can't break the script
+		script := `on run argv
+display notification (item 2 of argv) with title (item 1 of argv)
+end run`
+		return exec.Command("osascript", "-e", script, title, body)
+	case "windows":
+		return nil
+	default:
+		if getenv("DISPLAY") == "" && getenv("WAYLAND_DISPLAY") == "" {
+			return nil
+		}
+		return exec.Command("notify-send", title, body)
+	}
+}
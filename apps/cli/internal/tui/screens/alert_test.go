@@ -0,0 +1,51 @@
+package screens
+
+import (
+	"testing"
+
+	"github.com/buntime/cli/internal/db"
+)
+
+func TestAlertsAreOffByDefault(t *testing.T) {
+	t.Setenv("BUNTIME_CONFIG_DIR", db.MemoryDir)
+	database, err := db.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+
+	if cmd := alertDone(database, "Install finished", "Installed my-app"); cmd != nil {
+		t.Fatal("expected no alert before the user turns it on")
+	}
+
+	if err := database.SetConfig(db.ConfigCompletionAlert, string(AlertBell)); err != nil {
+		t.Fatal(err)
+	}
+	if mode := loadAlertMode(database); mode != AlertBell {
+		t.Fatalf("loadAlertMode() = %q, want %q", mode, AlertBell)
+	}
+}
+
+func TestNotifyCommand(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(key string) string { return vars[key] }
+	}
+
+	if cmd := notifyCommand("linux", "Install finished", "Installed my-app", env(nil)); cmd != nil {
+		t.Fatalf("expected no notifier without a display, got %v", cmd.Args)
+	}
+	if cmd := notifyCommand("windows", "Install finished", "Installed my-app", env(nil)); cmd != nil {
+		t.Fatalf("expected no notifier on Windows, got %v", cmd.Args)
+	}
+
+	cmd := notifyCommand("linux", "Install finished", "Installed my-app", env(map[string]string{"DISPLAY": ":0"}))
+	if cmd == nil || len(cmd.Args) != 3 || cmd.Args[0] != "notify-send" || cmd.Args[2] != "Installed my-app" {
+		t.Fatalf("unexpected notifier %v", cmd)
+	}
+
+	// The text is passed as arguments, not spliced into the AppleScript
+	cmd = notifyCommand("darwin", `Say "hi"`, "Installed my-app", env(nil))
+	if cmd == nil || cmd.Args[len(cmd.Args)-2] != `Say "hi"` {
+		t.Fatalf("unexpected notifier %v", cmd)
+	}
+}
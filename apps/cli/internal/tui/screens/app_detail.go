@@ -0,0 +1,118 @@
+package screens
+
+import (
+	"strings"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/i18n"
+	"github.com/buntime/cli/internal/tui/layout"
+	"github.com/buntime/cli/internal/tui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dustin/go-humanize"
+)
+
+// AppDetailModel shows everything the list knows about one app, with every
+// installed version instead of the newest one
+type AppDetailModel struct {
+	server *db.Server
+	app    *api.AppInfo
+	width  int
+	height int
+}
+
+// NewAppDetailModel creates the details screen of an app
+func NewAppDetailModel(server *db.Server, app *api.AppInfo, width, height int) *AppDetailModel {
+	return &AppDetailModel{
+		server: server,
+		app:    app,
+		width:  width,
+		height: height,
+	}
+}
+
+func (m *AppDetailModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m *AppDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "c":
+			return m, copyPath(m.app.Path)
+		case "v":
+			if len(m.app.Versions) > 0 {
+				return m, func() tea.Msg {
+					return NavigateMsg{Screen: ScreenAppRollback, Data: m.app}
+				}
+			}
+		case "esc":
+			return m, goBack()
+		}
+	}
+
+	return m, nil
+}
+
+func (m *AppDetailModel) View() string {
+	return layout.Page(layout.PageConfig{
+		Width:      m.width,
+		Height:     m.height,
+		Server:     m.server,
+		Breadcrumb: "Main › Apps › Details",
+		Title:      "APP DETAILS",
+		Content:    m.renderContent(),
+		Shortcuts:  m.getShortcuts(),
+	})
+}
+
+func (m *AppDetailModel) renderContent() string {
+	var b strings.Builder
+	app := m.app
+
+	b.WriteString(styles.TextPrimary.Bold(true).Render(app.Name) + "\n\n")
+
+	status := styles.RenderStatus(app.Enabled) + " disabled"
+	if app.Enabled {
+		status = styles.RenderStatus(app.Enabled) + " enabled"
+	}
+	fields := [][2]string{
+		{"Status", status},
+		{"Path", app.Path},
+	}
+	if app.Provenance != nil {
+		fields = append(fields, [2]string{"Installed by", app.Provenance.String()})
+	}
+	fields = append(fields, [2]string{"Labels", app.Labels.String()})
+	b.WriteString(renderDetailFields(fields, layout.InnerWidth(m.width)))
+
+	notes := make(map[string]string, len(app.InstalledAt))
+	for version, at := range app.InstalledAt {
+		notes[version] = "installed " + at.Local().Format("2006-01-02 15:04") + " (" + humanize.Time(at) + ")"
+	}
+
+	b.WriteString("\n")
+	b.WriteString(renderDetailVersions(app.Versions, app.CurrentVersion(), notes))
+	if app.ActiveVersion == "" && len(app.Versions) > 0 {
+		b.WriteString("\n" + styles.TextMuted.Render("The server doesn't report the active version; the newest is assumed.") + "\n")
+	}
+
+	return b.String()
+}
+
+func (m *AppDetailModel) getShortcuts() []string {
+	var shortcuts []string
+	if m.app.Path != "" {
+		shortcuts = append(shortcuts, styles.RenderShortcut("c", i18n.T("shortcut.copy_path")))
+	}
+	if len(m.app.Versions) > 0 {
+		shortcuts = append(shortcuts, styles.RenderShortcut("v", i18n.T("shortcut.versions")))
+	}
+	return append(shortcuts, styles.RenderShortcut("Esc", i18n.T("shortcut.back")))
+}
@@ -0,0 +1,195 @@
+package screens
+
+import (
+	"strings"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/i18n"
+	"github.com/buntime/cli/internal/tui/layout"
+	"github.com/buntime/cli/internal/tui/messages"
+	"github.com/buntime/cli/internal/tui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// AppRollbackModel picks the installed version of an app the server should
+// run, such as an older one after a bad deploy
+type AppRollbackModel struct {
+	api       *api.Client
+	server    *db.Server
+	app       *api.AppInfo
+	current   string // Version the app runs now
+	cursor    int
+	switching bool
+	err       error
+	width     int
+	height    int
+}
+
+// NewAppRollbackModel creates the version picker with the cursor on the
+// version that runs now
+func NewAppRollbackModel(client *api.Client, server *db.Server, app *api.AppInfo, width, height int) *AppRollbackModel {
+	m := &AppRollbackModel{
+		api:     client,
+		server:  server,
+		app:     app,
+		current: app.CurrentVersion(),
+		width:   width,
+		height:  height,
+	}
+	for i, version := range app.Versions {
+		if version == m.current {
+			m.cursor = i
+		}
+	}
+	return m
+}
+
+type appRolledBackMsg struct {
+	version string
+	err     error
+}
+
+func (m *AppRollbackModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m *AppRollbackModel) rollback() tea.Cmd {
+	m.switching = true
+	m.err = nil
+	name, version := m.app.Name, m.app.Versions[m.cursor]
+	return func() tea.Msg {
+		return appRolledBackMsg{version: version, err: m.api.ActivateAppVersion(name, version)}
+	}
+}
+
+func (m *AppRollbackModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case appRolledBackMsg:
+		m.switching = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		return m, tea.Batch(
+			func() tea.Msg {
+				return NavigateMsg{Screen: ScreenApps, Data: nil, ReplaceHistory: true}
+			},
+			func() tea.Msg {
+				return messages.ShowSuccess(m.app.Name + " now runs v" + msg.version)
+			},
+		)
+
+	case KeySwitchedMsg:
+		if permissionDenied(m.err) {
+			return m, m.rollback()
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.switching {
+			return m, nil
+		}
+		switch msg.String() {
+		case switchKeyKey:
+			if permissionDenied(m.err) {
+				return m, switchKey(m.api, m.server)
+			}
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.app.Versions)-1 {
+				m.cursor++
+			}
+		case "enter":
+			if m.cursor >= len(m.app.Versions) {
+				return m, nil
+			}
+			// Nothing to switch
+			if m.app.Versions[m.cursor] == m.current {
+				return m, goBack()
+			}
+			return m, m.rollback()
+		case "esc":
+			return m, goBack()
+		}
+	}
+
+	return m, nil
+}
+
+func (m *AppRollbackModel) View() string {
+	return layout.Page(layout.PageConfig{
+		Width:      m.width,
+		Height:     m.height,
+		Server:     m.server,
+		Breadcrumb: "Main › Apps › Version",
+		Title:      "SWITCH VERSION",
+		Content:    m.renderContent(),
+		Shortcuts:  m.getShortcuts(),
+	})
+}
+
+func (m *AppRollbackModel) renderContent() string {
+	var b strings.Builder
+
+	b.WriteString(styles.TextMuted.Render("Choose the version of "))
+	b.WriteString(styles.TextPrimary.Bold(true).Render(m.app.Name))
+	b.WriteString(styles.TextMuted.Render(" to run:"))
+	b.WriteString("\n\n")
+
+	for i, version := range m.app.Versions {
+		cursor := "  "
+		if i == m.cursor {
+			cursor = styles.Caret
+		}
+
+		style := styles.TextNormal
+		if i == m.cursor {
+			style = styles.TextPrimary
+		}
+
+		line := style.Render(version)
+		if version == m.current {
+			line = style.Bold(true).Render(version) + styles.TextSuccess.Render(" ● active")
+		}
+
+		b.WriteString(cursor + line + "\n")
+	}
+
+	if m.app.ActiveVersion == "" {
+		b.WriteString("\n" + styles.TextMuted.Render("The server doesn't report the active version; the newest is assumed.") + "\n")
+	}
+
+	if m.switching {
+		b.WriteString("\n" + styles.TextPrimary.Render("Switching to v"+m.app.Versions[m.cursor]+"...") + "\n")
+	} else if m.err != nil {
+		b.WriteString("\n" + styles.TextError.Render("Error: "+m.err.Error()) + "\n")
+		if permissionDenied(m.err) {
+			b.WriteString(renderSwitchKeyHint())
+		}
+	}
+
+	return b.String()
+}
+
+func (m *AppRollbackModel) getShortcuts() []string {
+	if m.switching {
+		return []string{styles.RenderShortcut("", i18n.T("shortcut.please_wait"))}
+	}
+	shortcuts := []string{
+		styles.RenderShortcut("↑↓", i18n.T("shortcut.navigate")),
+		styles.RenderShortcut("⏎", i18n.T("shortcut.activate")),
+	}
+	if permissionDenied(m.err) {
+		shortcuts = append(shortcuts, switchKeyShortcut())
+	}
+	return append(shortcuts, styles.RenderShortcut("Esc", i18n.T("shortcut.cancel")))
+}
@@ -0,0 +1,45 @@
+package screens
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/db"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestAppRollbackStartsOnTheActiveVersion(t *testing.T) {
+	app := &api.AppInfo{Name: "shop", Versions: []string{"1.2.0", "1.1.0", "1.0.0"}, ActiveVersion: "1.1.0"}
+	m := NewAppRollbackModel(nil, &db.Server{Name: "prod"}, app, 100, 40)
+
+	if m.cursor != 1 {
+		t.Fatalf("expected the cursor on the active version, got %d", m.cursor)
+	}
+	if !strings.Contains(m.View(), "1.1.0 ● active") {
+		t.Fatal("expected the active version to be marked")
+	}
+
+	// Choosing the version that already runs changes nothing
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.switching || cmd == nil {
+		t.Fatal("expected Enter on the active version to go back without a request")
+	}
+	if _, ok := cmd().(GoBackMsg); !ok {
+		t.Fatal("expected Enter on the active version to go back")
+	}
+}
+
+func TestAppRollbackKeepsThePickerOnError(t *testing.T) {
+	app := &api.AppInfo{Name: "shop", Versions: []string{"1.2.0", "1.1.0"}}
+	m := NewAppRollbackModel(nil, &db.Server{Name: "prod"}, app, 100, 40)
+	m.switching = true
+
+	m.Update(appRolledBackMsg{version: "1.1.0", err: &api.APIError{Message: "version not found", Status: 404}})
+	if m.switching || m.err == nil {
+		t.Fatal("expected the error to be shown on the picker")
+	}
+	if !strings.Contains(m.View(), "version not found") {
+		t.Fatal("expected the error in the view")
+	}
+}
@@ -6,21 +6,41 @@ import (
 
 	"github.com/buntime/cli/internal/api"
 	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/i18n"
+	"github.com/buntime/cli/internal/tui/components"
 	"github.com/buntime/cli/internal/tui/layout"
+	"github.com/buntime/cli/internal/tui/messages"
 	"github.com/buntime/cli/internal/tui/styles"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// appSorts are the orders 's' cycles through, starting with the server's
+var appSorts = []listSort[api.AppInfo]{
+	{},
+	{name: "name", compare: func(a, b api.AppInfo) int {
+		return compareNames(a.Name, b.Name)
+	}},
+	{name: "path", compare: func(a, b api.AppInfo) int {
+		return strings.Compare(a.Path, b.Path)
+	}},
+}
+
 // AppsModel shows the apps list
 type AppsModel struct {
 	api     *api.Client
 	server  *db.Server
-	apps    []api.AppInfo
+	all     []api.AppInfo // Everything loaded from the server
+	apps    []api.AppInfo // all narrowed by name, then sorted
+	sort    int           // Index into appSorts
 	cursor  int
+	offset  int // First visible row
 	width   int
 	height  int
 	loading bool
 	err     error
+	filter  labelFilter
+	search  nameFilter
+	pager   listPager
 }
 
 // NewAppsModel creates an apps list screen
@@ -31,45 +51,125 @@ func NewAppsModel(client *api.Client, server *db.Server, width, height int) *App
 		width:   width,
 		height:  height,
 		loading: true,
+		filter:  newLabelFilter(width),
+		search:  newNameFilter(width),
 	}
 }
 
 func (m *AppsModel) Init() tea.Cmd {
-	return m.loadApps()
+	return m.loadApps(firstPage())
 }
 
-func (m *AppsModel) loadApps() tea.Cmd {
+// loadApps fetches the page opts points at. A label filter fetches every
+// matching app at once, since labels may need a request per app.
+func (m *AppsModel) loadApps(opts api.ListOptions) tea.Cmd {
+	sel := m.filter.selector
 	return func() tea.Msg {
-		apps, err := m.api.ListApps()
-		if err != nil {
-			return appsLoadedMsg{err: err}
+		if !sel.Empty() {
+			apps, err := m.api.ListAppsMatching(sel)
+			return appsLoadedMsg{apps: apps, err: err}
 		}
-		return appsLoadedMsg{apps: apps}
+		apps, page, err := m.api.ListAppsPage(opts)
+		return appsLoadedMsg{apps: apps, page: page, opts: opts, err: err}
 	}
 }
 
 type appsLoadedMsg struct {
 	apps []api.AppInfo
+	page *api.PageInfo   // nil when the whole list was fetched
+	opts api.ListOptions // The options the page was requested with
 	err  error
 }
 
+type appToggledMsg struct {
+	name    string
+	enabled bool
+	err     error
+}
+
+// toggle disables an enabled app, or enables a disabled one
+func (m *AppsModel) toggle(app api.AppInfo) tea.Cmd {
+	m.loading = true
+	return func() tea.Msg {
+		var err error
+		if app.Enabled {
+			err = m.api.DisableApp(app.Name)
+		} else {
+			err = m.api.EnableApp(app.Name)
+		}
+		return appToggledMsg{name: app.Name, enabled: !app.Enabled, err: err}
+	}
+}
+
+func (msg appToggledMsg) toast() messages.ShowToastMsg {
+	if msg.err != nil {
+		return messages.ShowError("Failed to update " + msg.name + ": " + msg.err.Error())
+	}
+	if msg.enabled {
+		return messages.ShowSuccess("Enabled " + msg.name)
+	}
+	return messages.ShowSuccess("Disabled " + msg.name)
+}
+
 func (m *AppsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.filter.resize(m.width)
+		m.search.resize(m.width)
 		return m, nil
 
 	case appsLoadedMsg:
+		if canceled(msg.err) {
+			return m, nil
+		}
 		m.loading = false
+		m.pager.loading = false
+		if apiErr, ok := msg.err.(*api.APIError); ok && apiErr.Type == api.ErrorTypeUnsupported && m.filter.active() {
+			// Fall back to the unfiltered list
+			m.filter.clear()
+			m.loading = true
+			return m, tea.Batch(m.loadApps(firstPage()), func() tea.Msg {
+				return messages.ShowWarning("This server does not support labels")
+			})
+		}
 		if msg.err != nil {
 			m.err = msg.err
 			return m, nil
 		}
-		m.apps = msg.apps
+		m.err = nil
+		if continues(msg.opts) {
+			m.all = append(m.all, msg.apps...)
+		} else {
+			m.all = msg.apps
+		}
+		m.pager.page = msg.page
+		m.applyView()
 		return m, nil
 
+	case appToggledMsg:
+		m.pager.reset()
+		return m, tea.Batch(m.loadApps(firstPage()), func() tea.Msg { return msg.toast() })
+
 	case tea.KeyMsg:
+		if m.filter.editing {
+			applied, cmd := m.filter.update(msg)
+			if applied {
+				m.loading = true
+				m.pager.reset()
+				return m, m.loadApps(firstPage())
+			}
+			return m, cmd
+		}
+		if m.search.editing {
+			changed, cmd := m.search.update(msg)
+			if changed {
+				m.applyView()
+			}
+			return m, cmd
+		}
+
 		switch msg.String() {
 		case "up", "k":
 			if m.cursor > 0 {
@@ -83,16 +183,67 @@ func (m *AppsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, func() tea.Msg {
 				return NavigateMsg{Screen: ScreenAppInstall, Data: nil}
 			}
+		case "enter":
+			if m.cursor < len(m.apps) {
+				return m, func() tea.Msg {
+					return NavigateMsg{Screen: ScreenAppDetail, Data: &m.apps[m.cursor]}
+				}
+			}
+		case "v":
+			if m.cursor < len(m.apps) && len(m.apps[m.cursor].Versions) > 0 {
+				return m, func() tea.Msg {
+					return NavigateMsg{Screen: ScreenAppRollback, Data: &m.apps[m.cursor]}
+				}
+			}
+		case " ", "space":
+			if m.cursor < len(m.apps) && !m.loading {
+				return m, m.toggle(m.apps[m.cursor])
+			}
 		case "d":
 			if len(m.apps) > 0 && m.cursor < len(m.apps) {
 				return m, func() tea.Msg {
 					return NavigateMsg{Screen: ScreenAppRemove, Data: &m.apps[m.cursor]}
 				}
 			}
+		case switchKeyKey:
+			if permissionDenied(m.err) && !m.loading {
+				return m, switchKey(m.api, m.server)
+			}
 		case "r":
+			// A reload is already running
+			if m.loading {
+				return m, nil
+			}
 			m.loading = true
-			return m, m.loadApps()
+			m.pager.reset()
+			return m, m.loadApps(firstPage())
+		case "m":
+			if m.pager.more() && !m.loading && !m.pager.loading {
+				return m, m.loadApps(m.pager.next())
+			}
+		case "/":
+			return m, m.search.edit()
+		case "l":
+			return m, m.filter.edit()
+		case "s":
+			m.sort = (m.sort + 1) % len(appSorts)
+			m.applyView()
 		case "esc":
+			// Esc drops active filters before leaving the screen
+			if m.search.active() {
+				m.search.clear()
+				m.applyView()
+				return m, nil
+			}
+			if m.filter.active() {
+				m.filter.clear()
+				if !m.loading {
+					m.loading = true
+					m.pager.reset()
+					return m, m.loadApps(firstPage())
+				}
+				return m, nil
+			}
 			return m, goBack()
 		}
 	}
@@ -100,23 +251,82 @@ func (m *AppsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// applyView narrows the loaded apps to the name filter and sorts them,
+// keeping the cursor on the highlighted app while it matches
+func (m *AppsModel) applyView() {
+	var selected string
+	if m.cursor < len(m.apps) {
+		selected = m.apps[m.cursor].Name
+	}
+
+	m.apps = m.apps[:0:0]
+	for _, app := range m.all {
+		if m.search.matches(app.Name) {
+			m.apps = append(m.apps, app)
+		}
+	}
+	sortList(m.apps, appSorts[m.sort])
+	m.cursor = cursorOn(m.apps, func(a api.AppInfo) string { return a.Name }, selected, m.cursor)
+}
+
+// Typing reports whether the search or label filter is being edited
+func (m *AppsModel) Typing() bool {
+	return m.filter.editing || m.search.editing
+}
+
 func (m *AppsModel) View() string {
 	innerWidth := layout.InnerWidth(m.width)
 
 	titleText := "APPLICATIONS"
 	if !m.loading {
-		titleText += fmt.Sprintf(" (%d)", len(m.apps))
+		if m.search.active() {
+			titleText += fmt.Sprintf(" (%d of %d", len(m.apps), len(m.all))
+		} else {
+			enabled := 0
+			for _, app := range m.apps {
+				if app.Enabled {
+					enabled++
+				}
+			}
+			titleText += fmt.Sprintf(" (%d enabled of %d", enabled, len(m.apps))
+		}
+		if m.pager.more() {
+			titleText += "+"
+		}
+		titleText += ")"
+	}
+	if m.search.active() {
+		titleText += fmt.Sprintf(" · %q", m.search.query())
+	}
+	if m.filter.active() {
+		titleText += " · " + m.filter.selector.String()
+	}
+	if order := appSorts[m.sort].name; order != "" {
+		titleText += " · by " + order
 	}
 
 	var content strings.Builder
+	if m.filter.editing {
+		content.WriteString(m.filter.view())
+	}
+	if m.search.editing {
+		content.WriteString(m.search.view())
+	}
 	if m.loading {
-		content.WriteString(styles.TextMuted.Render("Loading...") + "\n")
+		header, widths := m.columns(innerWidth)
+		content.WriteString(layout.ListHeader(header, innerWidth))
+		content.WriteString(components.SkeletonRows(components.SkeletonRowCount, widths))
 	} else if m.err != nil {
 		content.WriteString(styles.TextError.Render("Error: "+m.err.Error()) + "\n")
+		if permissionDenied(m.err) {
+			content.WriteString(renderSwitchKeyHint())
+		}
+	} else if len(m.apps) == 0 && (m.search.active() || m.filter.active()) {
+		content.WriteString(layout.CenterText(styles.TextMuted.Render(m.noMatchText()), innerWidth) + "\n")
 	} else if len(m.apps) == 0 {
 		content.WriteString(m.renderEmptyState(innerWidth))
 	} else {
-		content.WriteString(m.renderAppList(innerWidth))
+		content.WriteString(m.renderAppList(innerWidth, strings.Count(content.String(), "\n")))
 	}
 
 	return layout.Page(layout.PageConfig{
@@ -130,25 +340,40 @@ func (m *AppsModel) View() string {
 	})
 }
 
-func (m *AppsModel) renderAppList(width int) string {
-	var b strings.Builder
-
-	// Column widths
+// columns returns the list's header line and column widths, shared by the
+// rows and the loading skeleton
+func (m *AppsModel) columns(width int) (string, []int) {
+	statusWidth := 8
 	nameWidth := 25
 	versionWidth := 15
-	pathWidth := width - nameWidth - versionWidth - 6
+	pathWidth := width - statusWidth - nameWidth - versionWidth - 6
 
-	// Header
-	headerLine := fmt.Sprintf("  %-*s %-*s %-*s",
+	header := fmt.Sprintf("  %-*s %-*s %-*s %-*s",
+		statusWidth, "STATUS",
 		nameWidth, "NAME",
 		versionWidth, "VERSION",
 		pathWidth, "PATH",
 	)
-	b.WriteString(styles.TextMuted.Render(headerLine) + "\n")
-	b.WriteString(styles.TextMuted.Render(strings.Repeat("─", width)) + "\n")
+	return header, []int{statusWidth, nameWidth, versionWidth, pathWidth}
+}
+
+// renderAppList renders the rows that fit below the reserved lines already
+// written above the list
+func (m *AppsModel) renderAppList(width, reserved int) string {
+	var b strings.Builder
+
+	headerLine, widths := m.columns(width)
+	statusWidth, nameWidth, versionWidth, pathWidth := widths[0], widths[1], widths[2], widths[3]
+	b.WriteString(layout.ListHeader(headerLine, width))
+
+	footer := m.pager.footer(len(m.all))
+	details := m.renderDetails(width)
+	rows := layout.ListRows(m.height, reserved+strings.Count(footer+details, "\n"))
+	m.offset = layout.ScrollOffset(m.offset, m.cursor, len(m.apps), rows)
 
 	// Rows
-	for i, app := range m.apps {
+	for i := m.offset; i < min(len(m.apps), m.offset+rows); i++ {
+		app := m.apps[i]
 		cursor := "  "
 		if i == m.cursor {
 			cursor = styles.Caret
@@ -156,7 +381,7 @@ func (m *AppsModel) renderAppList(width int) string {
 
 		version := "-"
 		if len(app.Versions) > 0 {
-			version = app.Versions[0]
+			version = app.CurrentVersion()
 			if len(app.Versions) > 1 {
 				version += fmt.Sprintf(" (+%d)", len(app.Versions)-1)
 			}
@@ -166,7 +391,8 @@ func (m *AppsModel) renderAppList(width int) string {
 		path := styles.Truncate(app.Path, pathWidth)
 
 		// Use PadRight for proper visual alignment
-		line := styles.PadRight(name, nameWidth) + " " +
+		line := styles.PadRight(styles.RenderStatus(app.Enabled), statusWidth) + " " +
+			styles.PadRight(name, nameWidth) + " " +
 			styles.PadRight(version, versionWidth) + " " +
 			styles.PadRight(path, pathWidth)
 
@@ -177,6 +403,28 @@ func (m *AppsModel) renderAppList(width int) string {
 		b.WriteString(cursor + line + "\n")
 	}
 
+	b.WriteString(footer)
+	b.WriteString(details)
+	return b.String()
+}
+
+// renderDetails shows the provenance and labels of the selected item, when
+// the server has them
+func (m *AppsModel) renderDetails(width int) string {
+	var b strings.Builder
+	if m.cursor < len(m.apps) {
+		selected := m.apps[m.cursor]
+		if (selected.Provenance != nil || len(selected.Labels) > 0) && !layout.Compact() {
+			b.WriteString("\n")
+		}
+		if selected.Provenance != nil {
+			b.WriteString(styles.TextMuted.Render(styles.Truncate("Installed by "+selected.Provenance.String(), width)) + "\n")
+		}
+		if len(selected.Labels) > 0 {
+			b.WriteString(styles.TextMuted.Render(styles.Truncate("Labels: "+selected.Labels.String(), width)) + "\n")
+		}
+	}
+
 	return b.String()
 }
 
@@ -190,20 +438,64 @@ func (m *AppsModel) renderEmptyState(width int) string {
 	return b.String()
 }
 
+// noMatchText explains an empty list caused by the active filters
+func (m *AppsModel) noMatchText() string {
+	text := "No applications"
+	if m.search.active() {
+		text += fmt.Sprintf(" named %q", m.search.query())
+	}
+	if m.filter.active() {
+		text += " match " + m.filter.selector.String()
+	}
+	return text + "."
+}
+
 func (m *AppsModel) getShortcuts() []string {
+	if m.filter.editing {
+		return m.filter.shortcuts()
+	}
+	if m.search.editing {
+		return m.search.shortcuts()
+	}
+
 	shortcuts := []string{
-		styles.RenderShortcut("↑↓", "navigate"),
-		styles.RenderShortcut("i", "install"),
+		styles.RenderShortcut("↑↓", i18n.T("shortcut.navigate")),
+		styles.RenderShortcut("i", i18n.T("shortcut.install")),
 	}
 
 	if len(m.apps) > 0 {
-		shortcuts = append(shortcuts, styles.RenderShortcut("d", "delete"))
+		toggle := i18n.T("shortcut.enable")
+		if m.cursor < len(m.apps) && m.apps[m.cursor].Enabled {
+			toggle = i18n.T("shortcut.disable")
+		}
+		shortcuts = append(shortcuts,
+			styles.RenderShortcut("⏎", i18n.T("shortcut.details")),
+			styles.RenderShortcut("Space", toggle),
+			styles.RenderShortcut("v", i18n.T("shortcut.versions")),
+			styles.RenderShortcut("d", i18n.T("shortcut.delete")),
+		)
+	}
+
+	if m.pager.more() {
+		shortcuts = append(shortcuts, styles.RenderShortcut("m", i18n.T("shortcut.more")))
 	}
 
 	shortcuts = append(shortcuts,
-		styles.RenderShortcut("r", "refresh"),
-		styles.RenderShortcut("Esc", "back"),
+		styles.RenderShortcut("/", i18n.T("shortcut.filter")),
+		styles.RenderShortcut("l", i18n.T("shortcut.labels")),
+		styles.RenderShortcut("s", i18n.T("shortcut.sort")),
+		styles.RenderShortcut("r", i18n.T("shortcut.refresh")),
 	)
 
+	if permissionDenied(m.err) {
+		shortcuts = append(shortcuts, switchKeyShortcut())
+	}
+
+	if m.search.active() || m.filter.active() {
+		shortcuts = append(shortcuts, styles.RenderShortcut("Esc", i18n.T("shortcut.clear_filter")))
+	} else {
+		shortcuts = append(shortcuts, styles.RenderShortcut("Esc", i18n.T("shortcut.back")))
+	}
+
 	return shortcuts
 }
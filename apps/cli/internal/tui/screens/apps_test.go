@@ -0,0 +1,41 @@
+package screens
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/tui/components"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestAppsShowTheEnabledCount(t *testing.T) {
+	m := NewAppsModel(nil, nil, 100, 40)
+	m.Update(appsLoadedMsg{apps: []api.AppInfo{
+		{Name: "shop", Enabled: true}, {Name: "blog"}, {Name: "docs", Enabled: true},
+	}})
+
+	if view := m.View(); !strings.Contains(view, "APPLICATIONS (2 enabled of 3)") {
+		t.Fatalf("expected the enabled count in the title, got:\n%s", view)
+	}
+
+	m.cursor = 1
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeySpace}); cmd == nil || !m.loading {
+		t.Fatal("expected space to toggle the selected app")
+	}
+	// Only one toggle at a time
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeySpace}); cmd != nil {
+		t.Fatal("expected space to wait for the running toggle")
+	}
+}
+
+func TestAppToggledToast(t *testing.T) {
+	if toast := (appToggledMsg{name: "blog", enabled: true}).toast(); toast.Message != "Enabled blog" {
+		t.Fatalf("got %q", toast.Message)
+	}
+	toast := appToggledMsg{name: "blog", err: errors.New("forbidden")}.toast()
+	if toast.Type != components.ToastError || toast.Message != "Failed to update blog: forbidden" {
+		t.Fatalf("got %#v", toast)
+	}
+}
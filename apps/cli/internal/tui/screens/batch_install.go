@@ -0,0 +1,376 @@
+package screens
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/i18n"
+	"github.com/buntime/cli/internal/tui/layout"
+	"github.com/buntime/cli/internal/tui/styles"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type batchState int
+
+const (
+	batchStateInput batchState = iota
+	batchStateRunning
+	batchStateDone
+)
+
+type batchOutcome int
+
+const (
+	batchPending batchOutcome = iota
+	batchRunning
+	batchSucceeded
+	batchFailed
+	batchSkipped
+)
+
+// batchTarget tracks the install outcome for one server
+type batchTarget struct {
+	server  db.Server
+	outcome batchOutcome
+	message string
+}
+
+// BatchInstallModel uploads the same archive to several saved servers,
+// one server at a time, and reports the outcome for each of them
+type BatchInstallModel struct {
+	targets   []batchTarget
+	db        *db.DB
+	pathInput textinput.Model
+	itemType  string // "app" or "plugin"
+	state     batchState
+	current   int
+	pathErr   string
+	archive   string
+	width     int
+	height    int
+}
+
+// NewBatchInstallModel creates a batch install screen for the given servers
+func NewBatchInstallModel(database *db.DB, servers []db.Server, width, height int) *BatchInstallModel {
+	pi := textinput.New()
+	pi.Placeholder = "/path/to/file.zip"
+	pi.Prompt = ""
+	pi.CharLimit = 500
+	pi.Focus()
+
+	targets := make([]batchTarget, len(servers))
+	for i, server := range servers {
+		targets[i] = batchTarget{server: server}
+	}
+
+	m := &BatchInstallModel{
+		targets:   targets,
+		db:        database,
+		pathInput: pi,
+		itemType:  "app",
+		state:     batchStateInput,
+		width:     width,
+		height:    height,
+	}
+	m.resizeInputs()
+	return m
+}
+
+// resizeInputs fits the text inputs to the current terminal width
+func (m *BatchInstallModel) resizeInputs() {
+	m.pathInput.Width = layout.InputWidth(m.width, 60)
+}
+
+func (m *BatchInstallModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+type batchServerDoneMsg struct {
+	index   int
+	outcome batchOutcome
+	message string
+}
+
+func (m *BatchInstallModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.resizeInputs()
+		return m, nil
+
+	case batchServerDoneMsg:
+		m.targets[msg.index].outcome = msg.outcome
+		m.targets[msg.index].message = msg.message
+		return m, m.runNext()
+
+	case tea.KeyMsg:
+		switch m.state {
+		case batchStateInput:
+			switch msg.String() {
+			case "esc":
+				return m, goBack()
+			case "tab":
+				if m.itemType == "app" {
+					m.itemType = "plugin"
+				} else {
+					m.itemType = "app"
+				}
+				return m, nil
+			case "enter":
+				return m, m.start()
+			}
+		case batchStateRunning:
+			return m, nil
+		case batchStateDone:
+			return m, goBack()
+		}
+	}
+
+	if m.state == batchStateInput {
+		var cmd tea.Cmd
+		m.pathInput, cmd = m.pathInput.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m *BatchInstallModel) start() tea.Cmd {
+	path := strings.TrimSpace(m.pathInput.Value())
+	if path == "" {
+		m.pathErr = "Path cannot be empty"
+		return nil
+	}
+
+	if strings.HasPrefix(path, "~/") {
+		home, _ := os.UserHomeDir()
+		path = filepath.Join(home, path[2:])
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		m.pathErr = "Path does not exist"
+		return nil
+	}
+	if info.IsDir() {
+		m.pathErr = "Batch install requires an archive file"
+		return nil
+	}
+
+	lower := strings.ToLower(path)
+	if !strings.HasSuffix(lower, ".zip") && !strings.HasSuffix(lower, ".tgz") && !strings.HasSuffix(lower, ".tar.gz") {
+		m.pathErr = "File must be .zip, .tgz, or .tar.gz"
+		return nil
+	}
+
+	m.archive = path
+	m.pathErr = ""
+	m.pathInput.Blur()
+	m.state = batchStateRunning
+	m.current = -1
+	return m.runNext()
+}
+
+// runNext starts the install on the next pending server. Servers are handled
+// sequentially so a failing rollout can be spotted before it reaches them all.
+func (m *BatchInstallModel) runNext() tea.Cmd {
+	m.current++
+	if m.current >= len(m.targets) {
+		m.state = batchStateDone
+		succeeded, failed, skipped := m.outcomes()
+		return alertDone(m.db, "Batch install finished",
+			fmt.Sprintf("%s: %d succeeded, %d failed, %d skipped", filepath.Base(m.archive), succeeded, failed, skipped))
+	}
+
+	index := m.current
+	server := m.targets[index].server
+	m.targets[index].outcome = batchRunning
+
+	archive := m.archive
+	itemType := m.itemType
+	sendProvenance := m.db.SendProvenance()
+
+	return func() tea.Msg {
+		var token string
+		if server.Token != nil {
+			token = *server.Token
+		}
+
+		client := newServerClient(&server, token)
+		if sendProvenance {
+			client.SetProvenance(api.NewProvenance(layout.Version))
+		}
+		if err := client.Ping(); err != nil {
+			if apiErr, ok := err.(*api.APIError); ok && apiErr.Type == api.ErrorTypeAuthRequired {
+				return batchServerDoneMsg{index: index, outcome: batchSkipped, message: "authentication required (save a token for this server)"}
+			}
+			return batchServerDoneMsg{index: index, outcome: batchFailed, message: err.Error()}
+		}
+
+		var result *api.InstallResult
+		var err error
+		if itemType == "app" {
+			result, err = client.InstallApp(archive)
+		} else {
+			result, err = client.InstallPlugin(archive)
+		}
+		if err != nil {
+			return batchServerDoneMsg{index: index, outcome: batchFailed, message: err.Error()}
+		}
+
+		message := fmt.Sprintf("installed %s v%s", result.Name, result.Version)
+		if len(result.Warnings) > 0 {
+			message += fmt.Sprintf(" with %d warning(s): %s", len(result.Warnings), strings.Join(result.Warnings, "; "))
+		}
+		return batchServerDoneMsg{
+			index:   index,
+			outcome: batchSucceeded,
+			message: message,
+		}
+	}
+}
+
+// Typing reports whether the archive path is being entered
+func (m *BatchInstallModel) Typing() bool {
+	return m.state == batchStateInput
+}
+
+func (m *BatchInstallModel) View() string {
+	innerWidth := layout.InnerWidth(m.width)
+
+	var content strings.Builder
+	if m.state == batchStateInput {
+		content.WriteString(m.renderInput())
+	} else {
+		content.WriteString(styles.TextMuted.Render("Source: ") +
+			styles.TextNormal.Render(filepath.Base(m.archive)) + "\n\n")
+	}
+	content.WriteString(m.renderTargets(innerWidth))
+
+	if m.state == batchStateDone {
+		content.WriteString("\n" + m.renderSummary() + "\n")
+	}
+
+	return layout.Page(layout.PageConfig{
+		Width:      m.width,
+		Height:     m.height,
+		Breadcrumb: "Servers › Batch Install",
+		Title:      fmt.Sprintf("BATCH INSTALL %s", strings.ToUpper(m.itemType)),
+		Content:    content.String(),
+		Shortcuts:  m.getShortcuts(),
+	})
+}
+
+func (m *BatchInstallModel) renderInput() string {
+	var b strings.Builder
+
+	b.WriteString(styles.TextMuted.Render("Type: "))
+	for _, t := range []string{"app", "plugin"} {
+		if t == m.itemType {
+			b.WriteString(styles.TextPrimary.Render("● "+t) + "   ")
+		} else {
+			b.WriteString(styles.TextNormal.Render("○ "+t) + "   ")
+		}
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString(styles.TextMuted.Render("Enter the path to a .zip/.tgz archive:") + "\n")
+	b.WriteString(styles.RenderInput(m.pathInput.View(), true, m.pathErr != "") + "\n")
+	if m.pathErr != "" {
+		b.WriteString(styles.TextError.Render("Error: "+m.pathErr) + "\n")
+	}
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+func (m *BatchInstallModel) renderTargets(width int) string {
+	var b strings.Builder
+
+	b.WriteString(styles.TextMuted.Render(fmt.Sprintf("TARGET SERVERS (%d)", len(m.targets))) + "\n")
+
+	nameWidth := 20
+	for _, target := range m.targets {
+		var icon string
+		switch target.outcome {
+		case batchRunning:
+			icon = styles.TextPrimary.Render("⠋")
+		case batchSucceeded:
+			icon = styles.CheckEnabled
+		case batchFailed:
+			icon = styles.CheckDisabled
+		case batchSkipped:
+			icon = styles.TextWarning.Render("!")
+		default:
+			icon = styles.TextMuted.Render("○")
+		}
+
+		line := icon + " " + styles.PadRight(truncate(target.server.Name, nameWidth), nameWidth) + " " +
+			styles.TextMuted.Render(target.server.URL)
+		b.WriteString("  " + line + "\n")
+
+		if target.message != "" {
+			style := styles.TextMuted
+			if target.outcome == batchFailed {
+				style = styles.TextError
+			} else if target.outcome == batchSkipped {
+				style = styles.TextWarning
+			}
+			b.WriteString("    " + style.Render(styles.Truncate(target.message, width-6)) + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+// outcomes counts the servers in each final state
+func (m *BatchInstallModel) outcomes() (succeeded, failed, skipped int) {
+	for _, target := range m.targets {
+		switch target.outcome {
+		case batchSucceeded:
+			succeeded++
+		case batchFailed:
+			failed++
+		case batchSkipped:
+			skipped++
+		}
+	}
+	return succeeded, failed, skipped
+}
+
+func (m *BatchInstallModel) renderSummary() string {
+	succeeded, failed, skipped := m.outcomes()
+	summary := fmt.Sprintf("%d succeeded, %d failed, %d skipped", succeeded, failed, skipped)
+	if failed > 0 {
+		return styles.TextError.Render(summary)
+	}
+	if skipped > 0 {
+		return styles.TextWarning.Render(summary)
+	}
+	return styles.TextSuccess.Render(summary)
+}
+
+func (m *BatchInstallModel) getShortcuts() []string {
+	switch m.state {
+	case batchStateInput:
+		return []string{
+			styles.RenderShortcut("Tab", i18n.T("shortcut.app_plugin")),
+			styles.RenderShortcut("⏎", i18n.T("shortcut.start")),
+			styles.RenderShortcut("Esc", i18n.T("shortcut.cancel")),
+		}
+	case batchStateRunning:
+		return []string{
+			styles.RenderShortcut("", i18n.T("shortcut.please_wait")),
+		}
+	default:
+		return []string{
+			styles.RenderShortcut("any key", i18n.T("shortcut.continue")),
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package screens
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/buntime/cli/internal/tui/messages"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openInBrowser opens url in the default browser. Without a browser, such as
+// over SSH or in a container, the URL is shown in a toast to copy instead.
+func openInBrowser(url string) tea.Cmd {
+	return func() tea.Msg {
+		cmd := browserCommand(runtime.GOOS, url, os.Getenv)
+		if cmd == nil {
+			return messages.ShowInfo("No browser available. Open " + url)
+		}
+		if err := cmd.Start(); err != nil {
+			return messages.ShowInfo(fmt.Sprintf("Could not start a browser (%v). Open %s", err, url))
+		}
+		// Reap the opener; it exits once the browser has the URL
+		go cmd.Wait()
+		return messages.ShowSuccess("Opened " + url)
+	}
+}
+
+// browserCommand returns the platform's URL opener, or nil when there is no
+// display to open a browser on
+func browserCommand(goos, url string, getenv func(string) string) *exec.Cmd {
+	switch goos {
+	case "darwin":
+		return exec.Command("open", url)
+	case "windows":
+		// The empty argument is the window title start expects before the URL
+		return exec.Command("cmd", "/c", "start", "", url)
+	default:
+		if getenv("DISPLAY") == "" && getenv("WAYLAND_DISPLAY") == "" {
+			return nil
+		}
+		return exec.Command("xdg-open", url)
+	}
+}
@@ -0,0 +1,39 @@
+package screens
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBrowserCommand(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(key string) string { return vars[key] }
+	}
+	url := "https://buntime.home"
+
+	tests := []struct {
+		name string
+		goos string
+		env  map[string]string
+		want string
+	}{
+		{name: "macOS", goos: "darwin", want: "open " + url},
+		{name: "Windows", goos: "windows", want: "cmd /c start  " + url},
+		{name: "X11", goos: "linux", env: map[string]string{"DISPLAY": ":0"}, want: "xdg-open " + url},
+		{name: "Wayland", goos: "linux", env: map[string]string{"WAYLAND_DISPLAY": "wayland-0"}, want: "xdg-open " + url},
+		{name: "headless", goos: "linux"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := browserCommand(tt.goos, url, env(tt.env))
+			got := ""
+			if cmd != nil {
+				got = strings.Join(cmd.Args, " ")
+			}
+			if got != tt.want {
+				t.Fatalf("browserCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package screens
+
+import (
+	"strings"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/i18n"
+	"github.com/buntime/cli/internal/tui/components"
+	"github.com/buntime/cli/internal/tui/styles"
+)
+
+// ConfirmLevel controls how strictly destructive actions are confirmed
+type ConfirmLevel string
+
+const (
+	ConfirmAlwaysType   ConfirmLevel = "always-type" // Type the confirm word for every action
+	ConfirmHighRiskType ConfirmLevel = "high-risk"   // Type only for high-risk actions, y/n otherwise
+	ConfirmSimple       ConfirmLevel = "simple"      // y/n for every action
+)
+
+// ConfirmLevels lists the levels in the order Settings cycles through them
+var ConfirmLevels = []ConfirmLevel{ConfirmAlwaysType, ConfirmHighRiskType, ConfirmSimple}
+
+// Label returns a human readable description of the level
+func (l ConfirmLevel) Label() string {
+	switch l {
+	case ConfirmHighRiskType:
+		return "type for high-risk only"
+	case ConfirmSimple:
+		return "simple y/n"
+	default:
+		return "always type"
+	}
+}
+
+// confirmPolicy decides whether a destructive action needs the confirm word
+// typed out or a simple y/n is enough
+type confirmPolicy struct {
+	level ConfirmLevel
+	// forceHighRisk keeps typing mandatory for high-risk actions (key revoke,
+	// removing an active version) whatever the level is
+	forceHighRisk bool
+}
+
+func loadConfirmPolicy(database *db.DB) confirmPolicy {
+	policy := confirmPolicy{level: ConfirmAlwaysType, forceHighRisk: true}
+	if database == nil {
+		return policy
+	}
+
+	if value, err := database.GetConfig(db.ConfigConfirmLevel); err == nil {
+		for _, level := range ConfirmLevels {
+			if string(level) == value {
+				policy.level = level
+			}
+		}
+	}
+	if value, err := database.GetConfig(db.ConfigConfirmHighRisk); err == nil && value == "false" {
+		policy.forceHighRisk = false
+	}
+
+	return policy
+}
+
+func (p confirmPolicy) requiresTyping(highRisk bool) bool {
+	if highRisk && p.forceHighRisk {
+		return true
+	}
+	switch p.level {
+	case ConfirmSimple:
+		return false
+	case ConfirmHighRiskType:
+		return highRisk
+	default:
+		return true
+	}
+}
+
+// isSessionKey reports whether key is the one this session authenticates
+// with. Revoking it locks the CLI out of the server, so it always needs the
+// confirm word typed, whatever the confirmation settings are.
+func isSessionKey(server *db.Server, key *api.ApiKeyInfo) bool {
+	if server == nil || server.Token == nil || key.KeyPrefix == "" {
+		return false
+	}
+	return strings.HasPrefix(*server.Token, key.KeyPrefix)
+}
+
+// confirmInputShortcuts only offers Enter once the confirm word is typed
+func confirmInputShortcuts(input *components.ConfirmInput) []string {
+	if !input.Matches() {
+		return []string{
+			styles.RenderShortcut("Esc", i18n.T("shortcut.cancel")),
+		}
+	}
+	return []string{
+		styles.RenderShortcut("⏎", i18n.T("shortcut.confirm")),
+		styles.RenderShortcut("Esc", i18n.T("shortcut.cancel")),
+	}
+}
@@ -0,0 +1,277 @@
+package screens
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/i18n"
+	"github.com/buntime/cli/internal/tui/layout"
+	"github.com/buntime/cli/internal/tui/messages"
+	"github.com/buntime/cli/internal/tui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ConnectionFailure is the navigation data for the connection error screen
+type ConnectionFailure struct {
+	Server *db.Server
+	Err    error
+}
+
+// ConnectionErrorModel explains why connecting to a server failed and what
+// to try next, based on the kind of error
+type ConnectionErrorModel struct {
+	db       *db.DB
+	server   *db.Server
+	err      error
+	retrying bool
+	attempt  int
+	cancel   context.CancelFunc // Aborts the retry in flight
+	width    int
+	height   int
+}
+
+// NewConnectionErrorModel creates a connection error screen
+func NewConnectionErrorModel(database *db.DB, failure *ConnectionFailure, width, height int) *ConnectionErrorModel {
+	return &ConnectionErrorModel{
+		db:     database,
+		server: failure.Server,
+		err:    failure.Err,
+		width:  width,
+		height: height,
+	}
+}
+
+func (m *ConnectionErrorModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m *ConnectionErrorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case connectionResultMsg:
+		if !m.retrying || msg.attempt != m.attempt {
+			return m, nil
+		}
+		m.stopRetrying()
+
+		if msg.err != nil {
+			if apiErr, ok := msg.err.(*api.APIError); ok && apiErr.Type == api.ErrorTypeAuthRequired {
+				server := m.server
+				return m, func() tea.Msg {
+					return NavigateMsg{Screen: ScreenTokenPrompt, Data: server, ReplaceHistory: true}
+				}
+			}
+			m.err = msg.err
+			return m, nil
+		}
+
+		m.db.TouchServer(m.server.ID)
+		return m, func() tea.Msg {
+			return ConnectedMsg{Client: msg.client, Server: m.server}
+		}
+
+	case tea.KeyMsg:
+		if m.retrying {
+			if msg.String() == "esc" {
+				m.stopRetrying()
+			}
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "r", "enter":
+			return m, m.retry()
+		case "k":
+			if m.canSkipTLS() {
+				return m, m.retryInsecure()
+			}
+		case "e":
+			return m, navigateToEditServer(m.server)
+		case "esc", "q":
+			return m, goBack()
+		}
+	}
+
+	return m, nil
+}
+
+// retry pings the server again with its saved settings
+func (m *ConnectionErrorModel) retry() tea.Cmd {
+	m.retrying = true
+	m.attempt++
+	attempt := m.attempt
+	server := m.server
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+
+	return func() tea.Msg {
+		var token string
+		if server.Token != nil {
+			token = *server.Token
+		}
+		client := newServerClient(server, token)
+		if err := client.PingCtx(ctx); err != nil {
+			return connectionResultMsg{attempt: attempt, err: err}
+		}
+		return connectionResultMsg{attempt: attempt, client: client}
+	}
+}
+
+// canSkipTLS reports whether the failure is a certificate the user could
+// choose not to verify
+func (m *ConnectionErrorModel) canSkipTLS() bool {
+	apiErr, ok := m.err.(*api.APIError)
+	return ok && apiErr.Type == api.ErrorTypeTLSError && !m.server.Insecure
+}
+
+// retryInsecure saves the server with TLS verification off and retries. It
+// only runs when the user asks for it, and is logged since it weakens the
+// connection.
+func (m *ConnectionErrorModel) retryInsecure() tea.Cmd {
+	if err := m.db.SetServerInsecure(m.server.ID, true); err != nil {
+		return func() tea.Msg {
+			return messages.ShowError("Could not save the server: " + err.Error())
+		}
+	}
+	server := *m.server
+	server.Insecure = true
+	m.server = &server
+	api.Logf("TLS verification turned off for %s (%s) after: %v", server.Name, server.URL, m.err)
+
+	return tea.Batch(m.retry(), func() tea.Msg {
+		return messages.ShowWarning("TLS verification is off for " + server.Name + "; turn it back on by editing the server")
+	})
+}
+
+// stopRetrying ends the retry, aborting its request if it is still running
+func (m *ConnectionErrorModel) stopRetrying() {
+	m.retrying = false
+	if m.cancel != nil {
+		m.cancel()
+		m.cancel = nil
+	}
+}
+
+func (m *ConnectionErrorModel) View() string {
+	innerWidth := layout.InnerWidth(m.width)
+	summary, steps := remediation(m.err, m.server)
+
+	var content strings.Builder
+	content.WriteString(styles.TextMuted.Render("Server: ") + styles.TextPrimary.Render(m.server.Name) + "\n")
+	content.WriteString(styles.TextMuted.Render("URL:    ") + styles.TextNormal.Render(m.server.URL) + "\n\n")
+
+	var card strings.Builder
+	card.WriteString(styles.TextError.Bold(true).Render(summary) + "\n\n")
+	card.WriteString(styles.TextMuted.Render(m.err.Error()))
+	content.WriteString(layout.Card(layout.CardConfig{
+		Width:   innerWidth - 4,
+		Variant: layout.CardError,
+		Content: card.String(),
+	}))
+	content.WriteString("\n\n")
+
+	content.WriteString(styles.SectionTitle.Render("WHAT TO TRY") + "\n")
+	// Wrap long steps under their own text rather than under the number
+	stepStyle := styles.TextNormal.Width(innerWidth - 7)
+	for i, step := range steps {
+		number := styles.TextNormal.Render(fmt.Sprintf("  %d. ", i+1))
+		content.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, number, stepStyle.Render(step)) + "\n")
+	}
+
+	if m.retrying {
+		content.WriteString("\n" + styles.TextMuted.Render("Retrying...") + "\n")
+	}
+
+	return layout.Page(layout.PageConfig{
+		Width:      m.width,
+		Height:     m.height,
+		Breadcrumb: "Servers › Connection Failed",
+		Title:      "CONNECTION FAILED",
+		Content:    content.String(),
+		Shortcuts:  m.getShortcuts(),
+	})
+}
+
+// remediation summarizes a connection error and lists concrete next steps
+// for it
+func remediation(err error, server *db.Server) (summary string, steps []string) {
+	errorType := api.ErrorTypeUnknown
+	var status int
+	if apiErr, ok := err.(*api.APIError); ok {
+		errorType = apiErr.Type
+		status = apiErr.Status
+	}
+
+	switch errorType {
+	case api.ErrorTypeTLSError:
+		summary = "The server's TLS certificate could not be verified"
+		if !server.Insecure {
+			steps = append(steps, "For a self-signed certificate, press k to skip TLS verification for this server and retry, or pass --insecure (-k)")
+		}
+		steps = append(steps,
+			"Install the CA that signed the certificate in the system trust store",
+			"Check that the URL's hostname matches the certificate",
+			"Check the system clock; a wrong date makes valid certificates look expired",
+		)
+	case api.ErrorTypeConnectionRefused:
+		summary = "Nothing accepted the connection at this address"
+		steps = []string{
+			"Check the URL and port for typos; edit the server with e",
+			"Make sure the runtime is running and listening on that port",
+			"If the hostname doesn't resolve, check DNS or /etc/hosts",
+			"Use the public base URL (https://buntime.home), not the API path",
+		}
+	case api.ErrorTypeNetworkError:
+		summary = "The server could not be reached over the network"
+		steps = []string{
+			"Check your network connection and VPN",
+			"Behind a proxy, check HTTPS_PROXY, HTTP_PROXY and NO_PROXY",
+			"Check that DNS resolves the server's hostname",
+			"A timeout usually means a firewall is dropping the connection",
+		}
+	case api.ErrorTypeServerError, api.ErrorTypeUnhealthy:
+		summary = "The server answered but reported an error"
+		if status != 0 {
+			summary += fmt.Sprintf(" (HTTP %d)", status)
+		}
+		steps = []string{
+			"Check the runtime logs for the cause",
+			"Retry in a moment; the runtime may still be starting",
+			"Make sure the URL points at a Buntime runtime and not another service",
+		}
+	default:
+		summary = "The connection failed"
+		steps = []string{
+			"Check the URL; edit the server with e",
+			"Retry with r",
+		}
+	}
+
+	return summary, steps
+}
+
+func (m *ConnectionErrorModel) getShortcuts() []string {
+	if m.retrying {
+		return []string{
+			styles.RenderShortcut("Esc", i18n.T("shortcut.cancel")),
+		}
+	}
+	shortcuts := []string{
+		styles.RenderShortcut("r", i18n.T("shortcut.retry")),
+	}
+	if m.canSkipTLS() {
+		shortcuts = append(shortcuts, styles.RenderShortcut("k", i18n.T("shortcut.skip_tls")))
+	}
+	return append(shortcuts,
+		styles.RenderShortcut("e", i18n.T("shortcut.edit")),
+		styles.RenderShortcut("Esc", i18n.T("shortcut.back")),
+	)
+}
@@ -0,0 +1,47 @@
+package screens
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/db"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestConnectionErrorSkipsTLSOnlyWhenAsked(t *testing.T) {
+	t.Setenv("BUNTIME_CONFIG_DIR", db.MemoryDir)
+	database, err := db.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+
+	server, err := database.CreateServer("Home", "https://buntime.home", nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tlsErr := &api.APIError{Type: api.ErrorTypeTLSError, Message: "x509: certificate signed by unknown authority"}
+	m := NewConnectionErrorModel(database, &ConnectionFailure{Server: server, Err: tlsErr}, 100, 40)
+	if !strings.Contains(strings.Join(m.getShortcuts(), " "), "skip TLS check") {
+		t.Fatal("expected the quick fix to be offered for a TLS error")
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	defer m.stopRetrying()
+	if cmd == nil || !m.retrying || !m.server.Insecure {
+		t.Fatal("expected k to retry without TLS verification")
+	}
+	if saved, _ := database.GetServer(server.ID); !saved.Insecure {
+		t.Fatal("expected the server to be saved as insecure")
+	}
+	if m.canSkipTLS() {
+		t.Fatal("expected no quick fix once TLS verification is off")
+	}
+
+	refused := &api.APIError{Type: api.ErrorTypeConnectionRefused, Message: "connection refused"}
+	m = NewConnectionErrorModel(database, &ConnectionFailure{Server: &db.Server{Name: "Other"}, Err: refused}, 100, 40)
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")}); cmd != nil || m.retrying {
+		t.Fatal("expected k to do nothing for other errors")
+	}
+}
@@ -1,13 +1,19 @@
 package screens
 
 import (
-	"net/url"
+	"errors"
+	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/buntime/cli/internal/api"
 	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/i18n"
+	"github.com/buntime/cli/internal/tui/components"
 	"github.com/buntime/cli/internal/tui/layout"
 	"github.com/buntime/cli/internal/tui/messages"
 	"github.com/buntime/cli/internal/tui/styles"
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -17,6 +23,8 @@ const (
 	editFocusName = iota
 	editFocusURL
 	editFocusToken
+	editFocusNotes
+	editFocusTimeout
 	editFocusInsecure
 	editFocusCancel
 	editFocusSave
@@ -29,11 +37,14 @@ type EditServerModel struct {
 	nameInput  textinput.Model
 	urlInput   textinput.Model
 	tokenInput textinput.Model
-	insecure   bool
-	focusIndex int
-	width      int
-	height     int
-	err        string
+	notesInput textarea.Model
+	// Request timeout in seconds, empty for the default
+	timeoutInput textinput.Model
+	insecure     bool
+	form         *components.Form
+	width        int
+	height       int
+	saving       bool
 }
 
 // NewEditServerModel creates an edit server form
@@ -42,14 +53,11 @@ func NewEditServerModel(database *db.DB, server *db.Server, width, height int) *
 	nameInput.SetValue(server.Name)
 	nameInput.Prompt = ""
 	nameInput.CharLimit = 50
-	nameInput.Width = 40
-	nameInput.Focus()
 
 	urlInput := textinput.New()
 	urlInput.SetValue(server.URL)
 	urlInput.Prompt = ""
 	urlInput.CharLimit = 200
-	urlInput.Width = 40
 
 	tokenInput := textinput.New()
 	if server.Token != nil {
@@ -60,19 +68,48 @@ func NewEditServerModel(database *db.DB, server *db.Server, width, height int) *
 	tokenInput.EchoMode = textinput.EchoPassword
 	tokenInput.EchoCharacter = '•'
 	tokenInput.CharLimit = 500
-	tokenInput.Width = 100 // Large enough to avoid wrapping
-
-	return &EditServerModel{
-		db:         database,
-		server:     server,
-		nameInput:  nameInput,
-		urlInput:   urlInput,
-		tokenInput: tokenInput,
-		insecure:   server.Insecure,
-		focusIndex: editFocusName,
-		width:      width,
-		height:     height,
+
+	timeoutInput := textinput.New()
+	if server.TimeoutSeconds > 0 {
+		timeoutInput.SetValue(strconv.Itoa(server.TimeoutSeconds))
+	}
+	timeoutInput.Placeholder = fmt.Sprintf("%d (default)", int(api.DefaultTimeout.Seconds()))
+	timeoutInput.Prompt = ""
+	timeoutInput.CharLimit = 4
+
+	m := &EditServerModel{
+		db:           database,
+		server:       server,
+		nameInput:    nameInput,
+		urlInput:     urlInput,
+		tokenInput:   tokenInput,
+		notesInput:   newNotesInput(server.Notes),
+		timeoutInput: timeoutInput,
+		insecure:     server.Insecure,
+		width:        width,
+		height:       height,
 	}
+	m.form = components.NewForm(
+		components.Field{Kind: components.FieldInput, Input: &m.nameInput, Validate: m.validateName, Live: true},
+		components.Field{Kind: components.FieldInput, Input: &m.urlInput, Validate: m.validateURL, Live: true},
+		components.Field{Kind: components.FieldInput, Input: &m.tokenInput},
+		components.Field{Kind: components.FieldArea, Area: &m.notesInput},
+		components.Field{Kind: components.FieldInput, Input: &m.timeoutInput, Validate: m.validateTimeout},
+		components.Field{Kind: components.FieldCheckbox, Checked: &m.insecure},
+		components.Field{Kind: components.FieldButton},
+		components.Field{Kind: components.FieldButton},
+	)
+	m.resizeInputs()
+	return m
+}
+
+// resizeInputs fits the text inputs to the current terminal width
+func (m *EditServerModel) resizeInputs() {
+	m.nameInput.Width = layout.InputWidth(m.width, 40)
+	m.urlInput.Width = layout.InputWidth(m.width, 40)
+	m.tokenInput.Width = layout.InputWidth(m.width, 100)
+	m.timeoutInput.Width = layout.InputWidth(m.width, 20)
+	resizeNotesInput(&m.notesInput, m.width)
 }
 
 func (m *EditServerModel) Init() tea.Cmd {
@@ -84,16 +121,19 @@ func (m *EditServerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.resizeInputs()
+		return m, nil
+
+	case messages.ServerSavedMsg:
+		m.saving = false
 		return m, nil
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "tab", "down":
-			m.focusNext()
-			return m, nil
-		case "shift+tab", "up":
-			m.focusPrev()
+		// Ignore input until the pending save finishes
+		if m.saving {
 			return m, nil
+		}
+		switch msg.String() {
 		case "ctrl+r":
 			if m.tokenInput.EchoMode == textinput.EchoPassword {
 				m.tokenInput.EchoMode = textinput.EchoNormal
@@ -102,110 +142,76 @@ func (m *EditServerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 		case "enter":
-			if m.focusIndex == editFocusSave {
+			switch m.form.Focused() {
+			case editFocusSave:
 				return m, m.save()
-			}
-			if m.focusIndex == editFocusCancel {
+			case editFocusCancel:
 				return m, goBack()
 			}
-			m.focusNext()
-			return m, nil
-		case " ", "space":
-			if m.focusIndex == editFocusInsecure {
-				m.insecure = !m.insecure
-				return m, nil
-			}
 		case "esc":
 			return m, goBack()
 		}
 	}
 
-	// Update focused input
-	var cmd tea.Cmd
-	switch m.focusIndex {
-	case editFocusName:
-		m.nameInput, cmd = m.nameInput.Update(msg)
-	case editFocusURL:
-		m.urlInput, cmd = m.urlInput.Update(msg)
-	case editFocusToken:
-		m.tokenInput, cmd = m.tokenInput.Update(msg)
-	}
-
-	return m, cmd
-}
-
-func (m *EditServerModel) focusNext() {
-	m.focusIndex = (m.focusIndex + 1) % 6
-	m.updateFocus()
-}
-
-func (m *EditServerModel) focusPrev() {
-	m.focusIndex--
-	if m.focusIndex < 0 {
-		m.focusIndex = 5
-	}
-	m.updateFocus()
+	return m, m.form.Update(msg)
 }
 
-func (m *EditServerModel) updateFocus() {
-	m.nameInput.Blur()
-	m.urlInput.Blur()
-	m.tokenInput.Blur()
-
-	switch m.focusIndex {
-	case editFocusName:
-		m.nameInput.Focus()
-	case editFocusURL:
-		m.urlInput.Focus()
-	case editFocusToken:
-		m.tokenInput.Focus()
+func (m *EditServerModel) validateName() string {
+	if strings.TrimSpace(m.nameInput.Value()) == "" {
+		return "Name is required"
 	}
+	return ""
 }
 
-func (m *EditServerModel) validate() string {
+func (m *EditServerModel) validateURL() string {
 	urlStr := strings.TrimSpace(m.urlInput.Value())
-	name := strings.TrimSpace(m.nameInput.Value())
 
-	if name == "" {
-		return "Name is required"
+	if err := db.ValidateServerURL(urlStr); err != nil {
+		return err.Error()
 	}
 
-	if urlStr == "" {
-		return "URL is required"
+	if err := m.db.CheckServerURLFree(urlStr, m.server.ID); err != nil {
+		return err.Error()
 	}
 
-	if !strings.HasPrefix(urlStr, "http://") && !strings.HasPrefix(urlStr, "https://") {
-		return "URL must start with http:// or https://"
-	}
+	return ""
+}
 
-	parsed, err := url.Parse(urlStr)
-	if err != nil {
-		return "Invalid URL format"
+func (m *EditServerModel) validateTimeout() string {
+	if _, err := parseServerTimeout(m.timeoutInput.Value()); err != nil {
+		return err.Error()
 	}
+	return ""
+}
 
-	if parsed.Host == "" {
-		return "URL must include a hostname"
+// parseServerTimeout reads the timeout field as whole seconds, 0 when empty
+func parseServerTimeout(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
 	}
-
-	if urlStr != m.server.URL {
-		existing, err := m.db.GetServerByURL(urlStr)
-		if err == nil && existing != nil {
-			return "Server with this URL already exists: \"" + existing.Name + "\""
-		}
+	seconds, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errors.New("timeout must be a whole number of seconds")
 	}
-
-	return ""
+	if err := db.ValidateServerTimeout(seconds); err != nil {
+		return 0, err
+	}
+	return seconds, nil
 }
 
 func (m *EditServerModel) save() tea.Cmd {
-	if errMsg := m.validate(); errMsg != "" {
-		m.err = errMsg
+	if m.form.Validate() != "" {
 		return nil
 	}
 
+	m.saving = true
+
 	urlStr := strings.TrimSpace(m.urlInput.Value())
 	name := strings.TrimSpace(m.nameInput.Value())
 	tokenStr := strings.TrimSpace(m.tokenInput.Value())
+	notes := normalizeNotes(m.notesInput.Value())
+	timeout, _ := parseServerTimeout(m.timeoutInput.Value())
 
 	var token *string
 	if tokenStr != "" {
@@ -219,11 +225,22 @@ func (m *EditServerModel) save() tea.Cmd {
 		if err != nil {
 			return messages.ServerSavedMsg{Err: err}
 		}
+		if err := m.db.UpdateServerNotes(m.server.ID, notes); err != nil {
+			return messages.ServerSavedMsg{Err: err}
+		}
+		if err := m.db.UpdateServerTimeout(m.server.ID, timeout); err != nil {
+			return messages.ServerSavedMsg{Err: err}
+		}
 		server, _ := m.db.GetServer(m.server.ID)
 		return messages.ServerSavedMsg{Server: server}
 	}
 }
 
+// Typing reports whether a text field of the form has the focus
+func (m *EditServerModel) Typing() bool {
+	return m.form.Typing()
+}
+
 func (m *EditServerModel) View() string {
 	innerWidth := layout.InnerWidth(m.width)
 	var b strings.Builder
@@ -261,111 +278,51 @@ func (m *EditServerModel) renderForm() string {
 	var b strings.Builder
 
 	// Name field
-	b.WriteString(m.renderLabel("Name", true) + "\n")
-	b.WriteString(m.renderInput(m.nameInput, m.focusIndex == editFocusName, false) + "\n")
+	b.WriteString(components.RenderLabel("Name", true) + "\n")
+	b.WriteString(m.form.RenderInput(editFocusName) + "\n")
+	b.WriteString(m.form.RenderError(editFocusName))
 	b.WriteString("\n")
 
 	// URL field
-	b.WriteString(m.renderLabel("URL", true) + "\n")
-	hasURLError := m.err != "" && strings.Contains(m.err, "URL")
-	b.WriteString(m.renderInput(m.urlInput, m.focusIndex == editFocusURL, hasURLError) + "\n")
+	b.WriteString(components.RenderLabel("URL", true) + "\n")
+	b.WriteString(m.form.RenderInput(editFocusURL) + "\n")
+	b.WriteString(m.form.RenderError(editFocusURL))
 	b.WriteString("\n")
 
 	// Token field
-	b.WriteString(m.renderLabel("Token", false) + "\n")
-	b.WriteString(m.renderInput(m.tokenInput, m.focusIndex == editFocusToken, false) + "\n")
+	b.WriteString(components.RenderLabel("Token", false) + "\n")
+	b.WriteString(m.form.RenderInput(editFocusToken) + "\n")
 	b.WriteString(styles.TextMuted.Render("Ctrl+R to toggle visibility") + "\n")
 	b.WriteString("\n")
 
-	// Error message
-	if m.err != "" {
-		b.WriteString(styles.TextError.Render("✗ "+m.err) + "\n")
-		b.WriteString("\n")
-	}
+	// Notes field
+	b.WriteString(components.RenderLabel("Notes", false) + "\n")
+	b.WriteString(m.form.RenderArea(editFocusNotes) + "\n")
+	b.WriteString("\n")
+
+	// Timeout field
+	b.WriteString(components.RenderLabel("Request timeout (seconds)", false) + "\n")
+	b.WriteString(m.form.RenderInput(editFocusTimeout) + "\n")
+	b.WriteString(styles.TextMuted.Render("Uploads may take longer") + "\n")
+	b.WriteString(m.form.RenderError(editFocusTimeout))
+	b.WriteString("\n")
 
 	// Insecure checkbox
-	b.WriteString(m.renderCheckbox("Skip TLS verification (insecure)", m.insecure, m.focusIndex == editFocusInsecure) + "\n")
+	b.WriteString(m.form.RenderCheckbox(editFocusInsecure, "Skip TLS verification (insecure)") + "\n")
 	b.WriteString("\n")
 
 	// Buttons
-	b.WriteString(m.renderButtons())
+	b.WriteString(m.form.RenderButtons(editFocusCancel, "  Cancel  ", editFocusSave, "   Save   "))
 
 	return b.String()
 }
 
-func (m *EditServerModel) renderLabel(text string, required bool) string {
-	label := styles.TextNormal.Render(text)
-	if required {
-		label += styles.TextError.Render(" *")
-	}
-	return label
-}
-
-func (m *EditServerModel) renderInput(input textinput.Model, focused bool, hasError bool) string {
-	var borderColor lipgloss.Color
-	if hasError {
-		borderColor = styles.ColorError
-	} else if focused {
-		borderColor = styles.ColorPrimary
-	} else {
-		borderColor = styles.ColorSurface
-	}
-
-	// Get the input view and truncate if necessary to prevent wrapping
-	inputView := input.View()
-	maxVisibleWidth := 40
-	if lipgloss.Width(inputView) > maxVisibleWidth {
-		// Truncate to prevent overflow (textinput should scroll, not wrap)
-		runes := []rune(inputView)
-		for len(runes) > 0 && lipgloss.Width(string(runes)) > maxVisibleWidth {
-			runes = runes[:len(runes)-1]
-		}
-		inputView = string(runes)
-	}
-
-	inputStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(borderColor).
-		Padding(0, 1).
-		Width(44)
-
-	return inputStyle.Render(inputView)
-}
-
-func (m *EditServerModel) renderCheckbox(label string, checked bool, focused bool) string {
-	checkbox := styles.RenderCheckbox(checked, focused)
-
-	labelStyle := styles.TextNormal
-	if focused {
-		labelStyle = styles.TextPrimary
-	}
-
-	return lipgloss.JoinHorizontal(lipgloss.Center, checkbox, "  ", labelStyle.Render(label))
-}
-
-func (m *EditServerModel) renderButtons() string {
-	cancelStyle := styles.Button
-	if m.focusIndex == editFocusCancel {
-		cancelStyle = styles.ButtonFocused
-	}
-
-	saveStyle := styles.Button
-	if m.focusIndex == editFocusSave {
-		saveStyle = styles.ButtonPrimary
-	}
-
-	cancel := cancelStyle.Render("  Cancel  ")
-	save := saveStyle.Render("   Save   ")
-
-	return lipgloss.JoinHorizontal(lipgloss.Center, cancel, "  ", save)
-}
-
 func (m *EditServerModel) renderShortcuts() string {
 	shortcuts := []string{
-		styles.RenderShortcut("Tab", "next"),
-		styles.RenderShortcut("Shift+Tab", "prev"),
-		styles.RenderShortcut("⏎", "submit"),
-		styles.RenderShortcut("Esc", "cancel"),
+		styles.RenderShortcut("Tab", i18n.T("shortcut.next")),
+		styles.RenderShortcut("Shift+Tab", i18n.T("shortcut.prev")),
+		styles.RenderShortcut("⏎", i18n.T("shortcut.submit")),
+		styles.RenderShortcut("Esc", i18n.T("shortcut.cancel")),
 	}
 
 	return layout.Shortcuts(shortcuts)
@@ -1,19 +1,23 @@
 package screens
 
 import (
-	"archive/zip"
+	"context"
+	"errors"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/atotto/clipboard"
 	"github.com/buntime/cli/internal/api"
 	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/deploy"
+	"github.com/buntime/cli/internal/i18n"
+	"github.com/buntime/cli/internal/tui/components"
 	"github.com/buntime/cli/internal/tui/layout"
+	"github.com/buntime/cli/internal/tui/messages"
 	"github.com/buntime/cli/internal/tui/styles"
 	"github.com/charmbracelet/bubbles/filepicker"
-	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -25,7 +29,12 @@ const (
 	installModeFilePicker
 	installModeDirPicker
 	installModePathInput
+	installModeZipping
 	installModeUploading
+	installModeConfirmOverwrite
+	installModeConfirmType
+	installModeVerifying
+	installModeRollingBack
 	installModeSuccess
 	installModeFailed
 )
@@ -40,34 +49,59 @@ type fileEntry struct {
 
 // InstallModel handles file installation
 type InstallModel struct {
-	api        *api.Client
-	server     *db.Server
-	itemType   string // "app" or "plugin"
-	mode       installMode
-	filePicker filepicker.Model
-	dirPicker  filepicker.Model
-	pathInput  textinput.Model
-	progress   progress.Model
-	result     *api.InstallResult
-	err        error
-	pathErr    string
-	width      int
-	height     int
-	selected   string
-	tempFile   string
+	api         *api.Client
+	db          *db.DB
+	server      *db.Server
+	itemType    string // "app" or "plugin"
+	mode        installMode
+	filePicker  filepicker.Model
+	dirPicker   filepicker.Model
+	pathInput   textinput.Model
+	progress    *components.ProgressBar
+	result      *api.InstallResult
+	err         error
+	pathErr     string
+	width       int
+	height      int
+	selected    string
+	tempFile    string
+	force       bool // Overwrite an already installed version
+	verify      bool // Re-check server health after the install
+	rollback    bool // Remove the install if the server becomes unhealthy
+	healthErr   error
+	rolledBack  bool
+	rollbackErr error
+	undo        *api.InstallRollback // What was installed before, to roll back to
+	copied      bool                 // The reproduce command was copied
+
+	// Directory zip in progress
+	zipCancel     context.CancelFunc
+	zipEvents     <-chan tea.Msg
+	zipFiles      int
+	zipCanceled   bool
+	zipReturnMode installMode // Where Esc goes back to
+
+	// Upload in progress
+	uploadCancel   context.CancelFunc
+	uploadCanceled bool
+	uploadEvents   <-chan tea.Msg
+
+	// detectedType is the item type the selected package looks like when it
+	// differs from itemType
+	detectedType string
 
 	// Filter-related fields
-	filterInput   textinput.Model
-	filterActive  bool
-	currentDir    string
-	allEntries    []fileEntry
-	filteredList  []fileEntry
-	filterCursor  int
-	pickerHeight  int
+	filterInput  textinput.Model
+	filterActive bool
+	currentDir   string
+	allEntries   []fileEntry
+	filteredList []fileEntry
+	filterCursor int
+	pickerHeight int
 }
 
 // NewInstallModel creates an install screen
-func NewInstallModel(client *api.Client, server *db.Server, itemType string, width, height int) *InstallModel {
+func NewInstallModel(client *api.Client, database *db.DB, server *db.Server, itemType string, width, height int) *InstallModel {
 	// File picker for .zip and .tgz files
 	fp := filepicker.New()
 	fp.AllowedTypes = []string{".zip", ".tgz", ".tar.gz"}
@@ -94,35 +128,42 @@ func NewInstallModel(client *api.Client, server *db.Server, itemType string, wid
 	pi.Placeholder = "/path/to/file.zip or /path/to/directory"
 	pi.Prompt = ""
 	pi.CharLimit = 500
-	pi.Width = 60
-
-	prog := progress.New(progress.WithDefaultGradient())
-	prog.Width = 50
 
 	// Filter input
 	fi := textinput.New()
 	fi.Placeholder = "Type to filter..."
 	fi.Prompt = "🔍 "
 	fi.CharLimit = 100
-	fi.Width = 40
 
 	homeDir, _ := os.UserHomeDir()
 
-	return &InstallModel{
+	m := &InstallModel{
 		api:          client,
+		db:           database,
 		server:       server,
 		itemType:     itemType,
+		verify:       database.GetConfigBool(db.ConfigVerifyInstalls),
+		rollback:     database.GetConfigBool(db.ConfigRollbackInstalls),
 		mode:         installModeSelect,
 		filePicker:   fp,
 		dirPicker:    dp,
 		pathInput:    pi,
-		progress:     prog,
+		progress:     components.NewProgressBar(0),
 		width:        width,
 		height:       height,
 		filterInput:  fi,
 		currentDir:   homeDir,
 		pickerHeight: height - 14,
 	}
+	m.resizeInputs()
+	return m
+}
+
+// resizeInputs fits the text inputs and progress bar to the current terminal width
+func (m *InstallModel) resizeInputs() {
+	m.pathInput.Width = layout.InputWidth(m.width, 60)
+	m.filterInput.Width = layout.InputWidth(m.width, 40)
+	m.progress.SetWidth(layout.InputWidth(m.width, 50))
 }
 
 func (m *InstallModel) Init() tea.Cmd {
@@ -238,9 +279,27 @@ func (m *InstallModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.filePicker.Height = msg.Height - 12
 		m.dirPicker.Height = msg.Height - 12
 		m.pickerHeight = msg.Height - 14
+		m.resizeInputs()
 		return m, nil
 
 	case tea.KeyMsg:
+		if m.mode == installModeSuccess && msg.String() == "c" {
+			if command, ok := m.reproduceCommand(); ok {
+				if err := clipboard.WriteAll(command); err != nil {
+					return m, func() tea.Msg {
+						return messages.ShowWarning("Could not copy to the clipboard: " + err.Error())
+					}
+				}
+				m.copied = true
+				return m, nil
+			}
+		}
+
+		// Keep the selection so the install can be retried with the new key
+		if m.mode == installModeFailed && msg.String() == switchKeyKey && permissionDenied(m.err) {
+			return m, switchKey(m.api, m.server)
+		}
+
 		// Handle success/failure states
 		if m.mode == installModeSuccess || m.mode == installModeFailed {
 			// Cleanup temp file if exists
@@ -325,7 +384,7 @@ func (m *InstallModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					// File selected - install it
 					if forFiles {
 						m.selected = entry.path
-						return m, m.install(entry.path)
+						return m, m.checkItemType()
 					}
 				}
 				return m, nil
@@ -346,7 +405,7 @@ func (m *InstallModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Install current directory (only in dir picker mode)
 				if m.mode == installModeDirPicker {
 					m.selected = m.currentDir
-					return m, m.installDirectory(m.currentDir)
+					return m, m.checkItemType()
 				}
 			}
 
@@ -369,28 +428,148 @@ func (m *InstallModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-		// Can't interact while uploading
+		// Esc stops the zip; the rest of the cleanup happens on zipDoneMsg
+		if m.mode == installModeZipping {
+			if msg.String() == "esc" {
+				m.cancelZip()
+			}
+			return m, nil
+		}
+
+		// Esc aborts the upload; the rest of the cleanup happens on
+		// installResultMsg
 		if m.mode == installModeUploading {
+			if msg.String() == "esc" {
+				m.cancelUpload()
+			}
+			return m, nil
+		}
+
+		// Can't interact while verifying
+		if m.mode == installModeVerifying || m.mode == installModeRollingBack {
+			return m, nil
+		}
+
+		// Handle item type mismatch
+		if m.mode == installModeConfirmType {
+			switch msg.String() {
+			case "s", "S":
+				m.itemType = m.detectedType
+				return m, m.retryInstall()
+			case "c", "C":
+				return m, m.retryInstall()
+			case "n", "N", "esc":
+				m.mode = installModeSelect
+			}
+			return m, nil
+		}
+
+		// Handle overwrite confirmation
+		if m.mode == installModeConfirmOverwrite {
+			switch msg.String() {
+			case "y", "Y":
+				m.force = true
+				return m, m.retryInstall()
+			case "n", "N", "esc":
+				m.mode = installModeFailed
+			}
 			return m, nil
 		}
 
+	case KeySwitchedMsg:
+		if m.mode == installModeFailed && permissionDenied(m.err) {
+			return m, m.retryInstall()
+		}
+		return m, nil
+
+	case zipProgressMsg:
+		m.zipFiles = msg.files
+		return m, waitForZip(m.zipEvents)
+
+	case zipDoneMsg:
+		m.zipCancel = nil
+		m.zipEvents = nil
+		if errors.Is(msg.err, context.Canceled) {
+			m.returnFromZip()
+			return m, nil
+		}
+		if msg.err != nil {
+			m.mode = installModeFailed
+			m.err = msg.err
+			return m, m.alertFinished()
+		}
+		// A retry after an overwrite prompt zips again; drop the old archive
+		if m.tempFile != "" {
+			os.Remove(m.tempFile)
+		}
+		m.tempFile = msg.path
+		return m, m.install(msg.path)
+
 	case installProgressMsg:
-		return m, m.progress.SetPercent(msg.percent)
+		if m.mode != installModeUploading {
+			return m, waitForUpload(m.uploadEvents)
+		}
+		// The first byte count ends the "waiting for progress" phase
+		if m.progress.Indeterminate() && msg.done < msg.total {
+			m.progress.Reset(msg.total)
+		}
+		m.progress.Set(msg.done)
+		if msg.done >= msg.total {
+			m.progress.SetIndeterminate("Processing on server...")
+		}
+		return m, waitForUpload(m.uploadEvents)
+
+	case installRetryMsg:
+		// Tell the user the upload is being retried rather than hung
+		text := fmt.Sprintf("Upload interrupted, retrying (attempt %d of %d)...", msg.attempt, msg.maxAttempts)
+		return m, tea.Batch(waitForUpload(m.uploadEvents), func() tea.Msg {
+			return messages.ShowInfo(text)
+		})
 
 	case installResultMsg:
+		m.uploadCancel = nil
+		m.uploadEvents = nil
+		if m.uploadCanceled {
+			m.uploadCanceled = false
+			// An upload that finished before the abort reached it stands
+			if apiErr, ok := msg.err.(*api.APIError); ok && apiErr.Type == api.ErrorTypeCanceled {
+				return m, m.abandonUpload()
+			}
+		}
+		if apiErr, ok := msg.err.(*api.APIError); ok && apiErr.Type == api.ErrorTypeVersionExists && !m.force {
+			m.mode = installModeConfirmOverwrite
+			m.err = msg.err
+			return m, nil
+		}
 		if msg.err != nil {
 			m.mode = installModeFailed
 			m.err = msg.err
-			return m, nil
+			return m, m.alertFinished()
 		}
-		m.mode = installModeSuccess
 		m.result = msg.result
-		return m, nil
+		m.undo = msg.undo
+		if m.verify || m.rollback {
+			m.mode = installModeVerifying
+			return m, m.verifyHealth()
+		}
+		m.mode = installModeSuccess
+		return m, m.alertFinished()
+
+	case installVerifiedMsg:
+		m.healthErr = msg.err
+		if msg.err != nil && m.undo != nil {
+			m.mode = installModeRollingBack
+			return m, m.rollbackInstall()
+		}
+		m.mode = installModeSuccess
+		return m, m.alertFinished()
+
+	case installRolledBackMsg:
+		m.mode = installModeSuccess
+		m.rolledBack = msg.err == nil
+		m.rollbackErr = msg.err
+		return m, m.alertFinished()
 
-	case progress.FrameMsg:
-		progressModel, cmd := m.progress.Update(msg)
-		m.progress = progressModel.(progress.Model)
-		return m, cmd
 	}
 
 	// Update path input
@@ -427,153 +606,252 @@ func (m *InstallModel) submitPath() tea.Cmd {
 		return nil
 	}
 
-	m.selected = path
-
-	if info.IsDir() {
-		// Directory - zip and upload
-		return m.installDirectory(path)
-	}
-
 	// File - check extension
 	ext := strings.ToLower(filepath.Ext(path))
-	if ext != ".zip" && ext != ".tgz" && !strings.HasSuffix(strings.ToLower(path), ".tar.gz") {
+	if !info.IsDir() && ext != ".zip" && ext != ".tgz" && !strings.HasSuffix(strings.ToLower(path), ".tar.gz") {
 		m.pathErr = "File must be .zip, .tgz, or .tar.gz"
 		return nil
 	}
 
-	return m.install(path)
+	m.selected = path
+	return m.checkItemType()
 }
 
-func (m *InstallModel) install(path string) tea.Cmd {
-	m.mode = installModeUploading
-	m.err = nil
-
-	return func() tea.Msg {
-		var result *api.InstallResult
-		var err error
-
-		if m.itemType == "app" {
-			result, err = m.api.InstallApp(path)
-		} else {
-			result, err = m.api.InstallPlugin(path)
-		}
+// checkItemType inspects the selected package before uploading and asks
+// what to do when it looks like the other item type, e.g. an app archive
+// picked from the plugins screen. Packages whose type can't be told apart
+// are left for the server to validate.
+func (m *InstallModel) checkItemType() tea.Cmd {
+	m.detectedType = ""
+	// A new selection asks again before overwriting
+	m.force = false
+	if pkg, err := api.ReadArchivePackage(m.selected); err == nil && pkg.Type != "" && pkg.Type != m.itemType {
+		m.detectedType = pkg.Type
+		m.mode = installModeConfirmType
+		return nil
+	}
+	return m.retryInstall()
+}
 
-		if err != nil {
-			return installResultMsg{err: err}
-		}
-		return installResultMsg{result: result}
+// retryInstall runs the install of the current selection, e.g. after the
+// user confirmed overwriting an existing version
+func (m *InstallModel) retryInstall() tea.Cmd {
+	if info, err := os.Stat(m.selected); err == nil && info.IsDir() {
+		return m.installDirectory(m.selected)
 	}
+	return m.install(m.selected)
 }
 
-func (m *InstallModel) installDirectory(dirPath string) tea.Cmd {
-	m.mode = installModeUploading
-	m.err = nil
+// upload sends an archive to the server honoring the overwrite choice
+func (m *InstallModel) upload(ctx context.Context, path string, onProgress api.ProgressFunc, onRetry api.RetryFunc) (*api.InstallResult, error) {
+	opts := api.ResumableOptions{InstallOptions: api.InstallOptions{Force: m.force}, OnProgress: onProgress, OnRetry: onRetry}
+	return m.api.InstallResumableCtx(ctx, m.itemType, path, opts)
+}
 
-	return func() tea.Msg {
-		// Create temp zip file
-		tempFile, err := os.CreateTemp("", "buntime-*.zip")
-		if err != nil {
-			return installResultMsg{err: fmt.Errorf("failed to create temp file: %w", err)}
+// startUpload uploads path in the background, releasing ctx through cancel
+// when done. Byte counts, retries and the result are delivered on the
+// returned channel, read with waitForUpload.
+func (m *InstallModel) startUpload(ctx context.Context, cancel context.CancelFunc, path string) <-chan tea.Msg {
+	events := make(chan tea.Msg, 1)
+
+	go func() {
+		defer cancel()
+		var undo *api.InstallRollback
+		if m.rollback {
+			var err error
+			if undo, err = m.api.PrepareRollback(m.itemType); err != nil {
+				events <- installResultMsg{err: err}
+				close(events)
+				return
+			}
 		}
-		tempPath := tempFile.Name()
-		m.tempFile = tempPath
-
-		// Create zip archive
-		zipWriter := zip.NewWriter(tempFile)
-
-		err = filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				return err
+		result, err := m.upload(ctx, path, func(sent, total int64) {
+			// Progress is best effort, except the last count: it switches
+			// the bar to waiting on the server
+			msg := installProgressMsg{done: sent, total: total}
+			if sent >= total {
+				events <- msg
+				return
 			}
-
-			// Get relative path
-			relPath, err := filepath.Rel(dirPath, path)
-			if err != nil {
-				return err
+			select {
+			case events <- msg:
+			default:
 			}
+		}, func(attempt, maxAttempts int, _ error) {
+			events <- installRetryMsg{attempt: attempt, maxAttempts: maxAttempts}
+		})
+		events <- installResultMsg{result: result, err: err, undo: undo}
+		close(events)
+	}()
 
-			// Skip root directory
-			if relPath == "." {
-				return nil
-			}
+	return events
+}
 
-			// Skip hidden files and directories
-			if strings.HasPrefix(filepath.Base(path), ".") {
-				if info.IsDir() {
-					return filepath.SkipDir
-				}
-				return nil
-			}
+// waitForUpload delivers the next message from a running upload
+func waitForUpload(events <-chan tea.Msg) tea.Cmd {
+	if events == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		msg, ok := <-events
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
 
-			// Skip node_modules
-			if info.IsDir() && info.Name() == "node_modules" {
-				return filepath.SkipDir
-			}
+// cancelUpload aborts the upload in flight; its installResultMsg finishes the
+// cleanup
+func (m *InstallModel) cancelUpload() {
+	if m.uploadCancel != nil {
+		m.uploadCancel()
+		m.uploadCanceled = true
+	}
+}
 
-			// Create header
-			header, err := zip.FileInfoHeader(info)
-			if err != nil {
-				return err
-			}
-			header.Name = relPath
-			header.Method = zip.Deflate
+// abandonUpload drops the archive zipped for an aborted upload and goes back
+// to choosing what to install
+func (m *InstallModel) abandonUpload() tea.Cmd {
+	if m.tempFile != "" {
+		os.Remove(m.tempFile)
+		m.tempFile = ""
+	}
+	m.mode = installModeSelect
+	m.err = nil
+	return func() tea.Msg {
+		return messages.ShowInfo("Upload canceled")
+	}
+}
 
-			if info.IsDir() {
-				header.Name += "/"
-				_, err = zipWriter.CreateHeader(header)
-				return err
-			}
+// startUploading switches to the uploading view. Until byte counts arrive
+// the bar shows how long the upload has been running.
+func (m *InstallModel) startUploading(size int64) {
+	m.mode = installModeUploading
+	m.err = nil
+	m.progress.Reset(size)
+	if size > 0 {
+		m.progress.SetIndeterminate("Uploading " + formatSize(size) + "...")
+	} else {
+		m.progress.SetIndeterminate("Uploading...")
+	}
+}
 
-			// Write file
-			writer, err := zipWriter.CreateHeader(header)
-			if err != nil {
-				return err
-			}
+func (m *InstallModel) install(path string) tea.Cmd {
+	var size int64
+	if info, err := os.Stat(path); err == nil {
+		size = info.Size()
+	}
+	m.startUploading(size)
+	ctx, cancel := context.WithCancel(context.Background())
+	m.uploadCancel = cancel
+	m.uploadCanceled = false
 
-			file, err := os.Open(path)
-			if err != nil {
-				return err
-			}
-			defer file.Close()
+	m.uploadEvents = m.startUpload(ctx, cancel, path)
+	return waitForUpload(m.uploadEvents)
+}
 
-			_, err = io.Copy(writer, file)
-			return err
-		})
+// installDirectory zips the directory in the background, then uploads it.
+// Esc cancels the zip and returns to where the directory was picked.
+func (m *InstallModel) installDirectory(dirPath string) tea.Cmd {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.zipCancel = cancel
+	m.zipFiles = 0
+	m.zipCanceled = false
+	m.zipReturnMode = m.mode
+	m.mode = installModeZipping
+	m.err = nil
 
-		zipWriter.Close()
-		tempFile.Close()
+	m.zipEvents = startZip(ctx, dirPath)
+	return waitForZip(m.zipEvents)
+}
 
-		if err != nil {
-			os.Remove(tempPath)
-			return installResultMsg{err: fmt.Errorf("failed to create zip: %w", err)}
-		}
+// cancelZip stops a running directory zip; the walk removes the partial
+// archive and reports back with a zipDoneMsg
+func (m *InstallModel) cancelZip() {
+	if m.zipCancel != nil {
+		m.zipCancel()
+		m.zipCanceled = true
+	}
+}
 
-		// Upload the zip
-		var result *api.InstallResult
-		if m.itemType == "app" {
-			result, err = m.api.InstallApp(tempPath)
-		} else {
-			result, err = m.api.InstallPlugin(tempPath)
-		}
+// returnFromZip goes back to where the directory was picked
+func (m *InstallModel) returnFromZip() {
+	m.mode = m.zipReturnMode
+	switch m.mode {
+	case installModeDirPicker:
+		m.filterActive = true
+		m.filterInput.Focus()
+	case installModePathInput:
+		m.pathInput.Focus()
+	default:
+		// Zips started from a confirmation prompt go back to the start
+		m.mode = installModeSelect
+	}
+}
 
-		// Cleanup temp file
-		os.Remove(tempPath)
-		m.tempFile = ""
+// verifyHealth polls the server health after a successful install
+func (m *InstallModel) verifyHealth() tea.Cmd {
+	return func() tea.Msg {
+		err := m.api.VerifyHealth(api.DefaultVerifyWindow, api.DefaultVerifyInterval)
+		return installVerifiedMsg{err: err}
+	}
+}
 
-		if err != nil {
-			return installResultMsg{err: err}
-		}
-		return installResultMsg{result: result}
+type installVerifiedMsg struct {
+	err error
+}
+
+// rollbackInstall removes the version that left the server unhealthy,
+// going back to the one that ran before
+func (m *InstallModel) rollbackInstall() tea.Cmd {
+	result, undo := m.result, m.undo
+	return func() tea.Msg {
+		return installRolledBackMsg{err: m.api.Rollback(undo, result)}
 	}
 }
 
+type installRolledBackMsg struct {
+	err error
+}
+
+// installProgressMsg reports how many bytes of the upload were sent
 type installProgressMsg struct {
-	percent float64
+	done  int64
+	total int64
+}
+
+// installRetryMsg reports that the upload failed and is being tried again
+type installRetryMsg struct {
+	attempt     int
+	maxAttempts int
 }
 
 type installResultMsg struct {
 	result *api.InstallResult
 	err    error
+	undo   *api.InstallRollback // Set when a rollback was prepared
+}
+
+// alertFinished rings the completion alert, if turned on, once the install
+// has succeeded or failed
+func (m *InstallModel) alertFinished() tea.Cmd {
+	switch {
+	case m.mode == installModeFailed:
+		return alertDone(m.db, "Install failed", "The "+m.itemType+" install on "+m.server.Name+" failed")
+	case m.rolledBack:
+		return alertDone(m.db, "Install rolled back", m.result.Name+" left "+m.server.Name+" unhealthy and was removed")
+	case m.healthErr != nil:
+		return alertDone(m.db, "Install unhealthy", m.result.Name+" was installed but "+m.server.Name+" reports unhealthy")
+	default:
+		return alertDone(m.db, "Install finished", "Installed "+m.result.Name+" on "+m.server.Name)
+	}
+}
+
+// Typing reports whether a path or the picker filter is being typed
+func (m *InstallModel) Typing() bool {
+	return m.mode == installModePathInput ||
+		(m.filterActive && (m.mode == installModeFilePicker || m.mode == installModeDirPicker))
 }
 
 func (m *InstallModel) View() string {
@@ -606,8 +884,16 @@ func (m *InstallModel) renderContent(width int) string {
 		return m.renderDirPicker()
 	case installModePathInput:
 		return m.renderPathInput(width)
+	case installModeZipping:
+		return m.renderZipping()
 	case installModeUploading:
 		return m.renderUploading()
+	case installModeConfirmOverwrite:
+		return m.renderConfirmOverwrite(width)
+	case installModeConfirmType:
+		return m.renderConfirmType(width)
+	case installModeVerifying, installModeRollingBack:
+		return m.renderVerifying()
 	case installModeSuccess:
 		return m.renderSuccess(width)
 	case installModeFailed:
@@ -779,21 +1065,103 @@ func (m *InstallModel) renderUploading() string {
 	// Progress bar
 	b.WriteString(m.progress.View() + "\n\n")
 
-	// Steps
+	// Steps; once every byte is sent the server takes over
+	uploadStep := styles.TextPrimary.Render("⠋") + " " + styles.TextNormal.Render("Uploading to server...")
+	extractStep := styles.TextMuted.Render("○") + " " + styles.TextMuted.Render("Extracting files")
+	if m.progress.Percent() >= 1 {
+		uploadStep = styles.TextSuccess.Render("✓") + " " + styles.TextNormal.Render("Uploaded to server")
+		extractStep = styles.TextPrimary.Render("⠋") + " " + styles.TextNormal.Render("Extracting files...")
+	}
 	steps := []string{
 		styles.TextSuccess.Render("✓") + " " + styles.TextNormal.Render("Preparing files"),
-		styles.TextPrimary.Render("⠋") + " " + styles.TextNormal.Render("Uploading to server..."),
-		styles.TextMuted.Render("○") + " " + styles.TextMuted.Render("Extracting files"),
-		styles.TextMuted.Render("○") + " " + styles.TextMuted.Render("Registering " + m.itemType),
+		uploadStep,
+		extractStep,
+		styles.TextMuted.Render("○") + " " + styles.TextMuted.Render("Registering "+m.itemType),
 	}
 
 	for _, step := range steps {
 		b.WriteString(step + "\n")
 	}
 
+	if m.uploadCanceled {
+		b.WriteString("\n" + styles.TextMuted.Render("Canceling...") + "\n")
+	} else {
+		b.WriteString("\n" + styles.TextMuted.Render("Press Esc to cancel") + "\n")
+	}
+
+	return b.String()
+}
+
+func (m *InstallModel) renderZipping() string {
+	var b strings.Builder
+
+	b.WriteString(styles.TextPrimary.Render("Compressing "+filepath.Base(m.selected)+"...") + "\n")
+	b.WriteString(styles.TextMuted.Render(fmt.Sprintf("%d file(s) added", m.zipFiles)) + "\n\n")
+
+	if m.zipCanceled {
+		b.WriteString(styles.TextMuted.Render("Canceling...") + "\n")
+	} else {
+		b.WriteString(styles.TextMuted.Render("Press Esc to cancel") + "\n")
+	}
+
 	return b.String()
 }
 
+func (m *InstallModel) renderVerifying() string {
+	var b strings.Builder
+
+	b.WriteString(styles.TextPrimary.Render("VERIFYING...") + "\n\n")
+	b.WriteString(styles.TextSuccess.Render("✓") + " " + styles.TextNormal.Render("Installed "+m.result.Name+" v"+m.result.Version) + "\n")
+	if m.mode == installModeRollingBack {
+		b.WriteString(styles.TextError.Render("✗") + " " + styles.TextNormal.Render("Server became unhealthy") + "\n")
+		b.WriteString(styles.TextPrimary.Render("⠋") + " " + styles.TextNormal.Render("Rolling back "+m.result.Name+" v"+m.result.Version+"...") + "\n")
+	} else {
+		b.WriteString(styles.TextPrimary.Render("⠋") + " " + styles.TextNormal.Render("Checking server health...") + "\n")
+	}
+
+	return b.String()
+}
+
+func (m *InstallModel) renderConfirmOverwrite(width int) string {
+	var content strings.Builder
+
+	content.WriteString(styles.TextWarning.Bold(true).Render("Version already installed") + "\n\n")
+	if m.err != nil {
+		content.WriteString(styles.TextNormal.Render(m.err.Error()) + "\n\n")
+	}
+	content.WriteString(styles.TextNormal.Render("Overwrite the existing version? (y/n)"))
+
+	return layout.Card(layout.CardConfig{
+		Width:   width - 4,
+		Variant: layout.CardWarning,
+		Content: content.String(),
+	})
+}
+
+func (m *InstallModel) renderConfirmType(width int) string {
+	var content strings.Builder
+
+	content.WriteString(styles.TextWarning.Bold(true).Render("This looks like "+articleFor(m.detectedType)) + "\n\n")
+	content.WriteString(styles.TextNormal.Render(fmt.Sprintf("%s contains %s package, but you are installing %s.",
+		filepath.Base(m.selected), articleFor(m.detectedType), articleFor(m.itemType))) + "\n\n")
+	content.WriteString(styles.TextNormal.Render(fmt.Sprintf("Install it as %s instead? (s to switch, c to continue as %s)",
+		articleFor(m.detectedType), articleFor(m.itemType))))
+
+	return layout.Card(layout.CardConfig{
+		Width:   width - 4,
+		Variant: layout.CardWarning,
+		Content: content.String(),
+	})
+}
+
+// articleFor returns "an app" or "a plugin"
+func articleFor(itemType string) string {
+	if itemType == "app" {
+		return "an app"
+	}
+	return "a " + itemType
+}
+
 func (m *InstallModel) renderSuccess(width int) string {
 	var b strings.Builder
 
@@ -804,6 +1172,40 @@ func (m *InstallModel) renderSuccess(width int) string {
 		b.WriteString(styles.TextNormal.Render("Name: "+m.result.Name) + "\n")
 		b.WriteString(styles.TextNormal.Render("Version: "+m.result.Version) + "\n")
 		b.WriteString(styles.TextNormal.Render("Path: "+m.result.Path) + "\n")
+
+		if len(m.result.Warnings) > 0 {
+			b.WriteString("\n")
+			b.WriteString(styles.TextWarning.Bold(true).Render(fmt.Sprintf("⚠ Installed with %d warning(s):", len(m.result.Warnings))) + "\n")
+			for _, warning := range m.result.Warnings {
+				b.WriteString(styles.TextWarning.Width(width).Render("  - "+warning) + "\n")
+			}
+		}
+	}
+
+	if m.verify || m.rollback {
+		b.WriteString("\n")
+		if m.healthErr != nil {
+			b.WriteString(styles.TextWarning.Bold(true).Render("⚠ Server became unhealthy after install") + "\n")
+			b.WriteString(styles.TextWarning.Render(m.healthErr.Error()) + "\n")
+			if m.rolledBack {
+				b.WriteString(styles.TextSuccess.Render("✓ Rolled back "+m.result.Name+" v"+m.result.Version) + "\n")
+			} else if m.rollbackErr != nil {
+				b.WriteString(styles.TextError.Render("✗ Rollback failed: "+m.rollbackErr.Error()) + "\n")
+			}
+		} else {
+			b.WriteString(styles.TextSuccess.Render("✓ Server healthy after install") + "\n")
+		}
+	}
+
+	b.WriteString("\n")
+	if command, ok := m.reproduceCommand(); ok {
+		b.WriteString(styles.TextMuted.Render("Reproduce from the command line:") + "\n")
+		b.WriteString(styles.TextNormal.Width(width).Render(command) + "\n")
+		if m.copied {
+			b.WriteString(styles.TextSuccess.Render("Copied to clipboard!") + "\n")
+		}
+	} else {
+		b.WriteString(styles.TextMuted.Render("Zip the directory to reproduce this install from the command line.") + "\n")
 	}
 
 	b.WriteString("\n")
@@ -812,6 +1214,44 @@ func (m *InstallModel) renderSuccess(width int) string {
 	return b.String()
 }
 
+// reproduceCommand is the CLI command that repeats this install, without the
+// token. The CLI only installs archives, so directory installs have none.
+func (m *InstallModel) reproduceCommand() (string, bool) {
+	if m.selected == "" {
+		return "", false
+	}
+	if info, err := os.Stat(m.selected); err != nil || info.IsDir() {
+		return "", false
+	}
+	command := deploy.InstallCommand{
+		ItemType:  m.itemType,
+		Path:      m.selected,
+		ServerURL: m.server.URL,
+		Insecure:  m.server.Insecure,
+		Force:     m.force,
+		Verify:    m.verify,
+		Rollback:  m.rollback,
+	}
+	return command.String(), true
+}
+
+// installErrorHints explain the install errors the runtime reports by code
+var installErrorHints = map[string]string{
+	"INVALID_FILE_TYPE": "Install a .zip, .tgz or .tar.gz archive.",
+	"NO_FILE_PROVIDED":  "The server received no archive. Try the upload again.",
+	"NO_WORKER_DIRS":    "The server has no app directory configured (workerDirs).",
+	"NO_PLUGIN_DIRS":    "The server has no plugin directory configured (pluginDirs).",
+	"PATH_TRAVERSAL":    "The package name in package.json is not a valid directory name.",
+}
+
+// installErrorHint is the advice for a failed install, if its code is known
+func installErrorHint(err error) string {
+	if apiErr, ok := err.(*api.APIError); ok {
+		return installErrorHints[apiErr.Code]
+	}
+	return ""
+}
+
 func (m *InstallModel) renderFailed(width int) string {
 	var b strings.Builder
 
@@ -857,6 +1297,12 @@ func (m *InstallModel) renderFailed(width int) string {
 	}
 
 	b.WriteString("\n")
+	if hint := installErrorHint(m.err); hint != "" {
+		b.WriteString(styles.TextMuted.Render(hint) + "\n\n")
+	}
+	if permissionDenied(m.err) {
+		b.WriteString(renderSwitchKeyHint())
+	}
 	b.WriteString(styles.TextMuted.Render("Press any key to go back") + "\n")
 
 	return b.String()
@@ -866,40 +1312,79 @@ func (m *InstallModel) getShortcuts() []string {
 	switch m.mode {
 	case installModeSelect:
 		return []string{
-			styles.RenderShortcut("1/f", "file"),
-			styles.RenderShortcut("2/d", "directory"),
-			styles.RenderShortcut("3/p", "paste path"),
-			styles.RenderShortcut("Esc", "cancel"),
+			styles.RenderShortcut("1/f", i18n.T("shortcut.file")),
+			styles.RenderShortcut("2/d", i18n.T("shortcut.directory")),
+			styles.RenderShortcut("3/p", i18n.T("shortcut.paste_path")),
+			styles.RenderShortcut("Esc", i18n.T("shortcut.cancel")),
 		}
 	case installModeFilePicker:
 		return []string{
-			styles.RenderShortcut("type", "filter"),
-			styles.RenderShortcut("↑↓", "navigate"),
-			styles.RenderShortcut("⏎", "select"),
-			styles.RenderShortcut("←", "parent"),
-			styles.RenderShortcut("Esc", "back"),
+			styles.RenderShortcut("type", i18n.T("shortcut.filter")),
+			styles.RenderShortcut("↑↓", i18n.T("shortcut.navigate")),
+			styles.RenderShortcut("⏎", i18n.T("shortcut.select")),
+			styles.RenderShortcut("←", i18n.T("shortcut.parent")),
+			styles.RenderShortcut("Esc", i18n.T("shortcut.back")),
 		}
 	case installModeDirPicker:
 		return []string{
-			styles.RenderShortcut("type", "filter"),
-			styles.RenderShortcut("↑↓", "navigate"),
-			styles.RenderShortcut("⏎/→", "open"),
-			styles.RenderShortcut("←", "parent"),
-			styles.RenderShortcut("i", "install"),
-			styles.RenderShortcut("Esc", "back"),
+			styles.RenderShortcut("type", i18n.T("shortcut.filter")),
+			styles.RenderShortcut("↑↓", i18n.T("shortcut.navigate")),
+			styles.RenderShortcut("⏎/→", i18n.T("shortcut.open")),
+			styles.RenderShortcut("←", i18n.T("shortcut.parent")),
+			styles.RenderShortcut("i", i18n.T("shortcut.install")),
+			styles.RenderShortcut("Esc", i18n.T("shortcut.back")),
 		}
 	case installModePathInput:
 		return []string{
-			styles.RenderShortcut("⏎", "submit"),
-			styles.RenderShortcut("Esc", "back"),
+			styles.RenderShortcut("⏎", i18n.T("shortcut.submit")),
+			styles.RenderShortcut("Esc", i18n.T("shortcut.back")),
+		}
+	case installModeZipping:
+		return []string{
+			styles.RenderShortcut("Esc", i18n.T("shortcut.cancel")),
 		}
 	case installModeUploading:
 		return []string{
-			styles.RenderShortcut("", "Please wait..."),
+			styles.RenderShortcut("Esc", i18n.T("shortcut.cancel")),
+		}
+	case installModeVerifying, installModeRollingBack:
+		return []string{
+			styles.RenderShortcut("", i18n.T("shortcut.please_wait")),
+		}
+	case installModeConfirmOverwrite:
+		return []string{
+			styles.RenderShortcut("y", i18n.T("shortcut.overwrite")),
+			styles.RenderShortcut("n/Esc", i18n.T("shortcut.cancel")),
+		}
+	case installModeConfirmType:
+		return []string{
+			styles.RenderShortcut("s", i18n.T("shortcut.switch_type", m.detectedType)),
+			styles.RenderShortcut("c", i18n.T("shortcut.continue")),
+			styles.RenderShortcut("n/Esc", i18n.T("shortcut.cancel")),
+		}
+	case installModeSuccess:
+		if _, ok := m.reproduceCommand(); ok {
+			return []string{
+				styles.RenderShortcut("c", i18n.T("shortcut.copy_command")),
+				styles.RenderShortcut("any key", i18n.T("shortcut.continue")),
+			}
+		}
+		return []string{
+			styles.RenderShortcut("any key", i18n.T("shortcut.continue")),
+		}
+	case installModeFailed:
+		if permissionDenied(m.err) {
+			return []string{
+				switchKeyShortcut(),
+				styles.RenderShortcut("any key", i18n.T("shortcut.back")),
+			}
+		}
+		return []string{
+			styles.RenderShortcut("any key", i18n.T("shortcut.continue")),
 		}
 	default:
 		return []string{
-			styles.RenderShortcut("any key", "continue"),
+			styles.RenderShortcut("any key", i18n.T("shortcut.continue")),
 		}
 	}
 }
@@ -0,0 +1,134 @@
+package screens
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/tui/messages"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestEscAbortsUploadAndRemovesTempArchive(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "app.zip")
+	if err := os.WriteFile(archive, []byte("zip"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("BUNTIME_CONFIG_DIR", db.MemoryDir)
+	database, err := db.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+
+	m := NewInstallModel(nil, database, nil, "app", 100, 40)
+	m.tempFile = archive
+	m.startUploading(3)
+	canceled := false
+	m.uploadCancel = func() { canceled = true }
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !canceled {
+		t.Fatal("expected Esc to cancel the upload")
+	}
+	if m.mode != installModeUploading {
+		t.Fatalf("expected to stay uploading until the upload returns, got mode %v", m.mode)
+	}
+
+	_, cmd := m.Update(installResultMsg{err: &api.APIError{Type: api.ErrorTypeCanceled}})
+	if m.mode != installModeSelect {
+		t.Fatalf("expected mode select after the abort, got %v", m.mode)
+	}
+	if m.err != nil {
+		t.Fatalf("expected no error after the abort, got %v", m.err)
+	}
+	if _, err := os.Stat(archive); !os.IsNotExist(err) {
+		t.Fatalf("expected the temp archive to be removed, stat returned %v", err)
+	}
+	if cmd == nil {
+		t.Fatal("expected a toast")
+	}
+	if toast, ok := cmd().(messages.ShowToastMsg); !ok || toast.Message != "Upload canceled" {
+		t.Fatalf("unexpected toast %#v", toast)
+	}
+}
+
+func TestUploadProgressAdvancesTheBar(t *testing.T) {
+	t.Setenv("BUNTIME_CONFIG_DIR", db.MemoryDir)
+	database, err := db.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+
+	m := NewInstallModel(nil, database, nil, "app", 100, 40)
+	m.startUploading(100)
+	events := make(chan tea.Msg, 1)
+	m.uploadEvents = events
+
+	_, cmd := m.Update(installProgressMsg{done: 40, total: 100})
+	if m.progress.Indeterminate() || m.progress.Percent() != 0.4 {
+		t.Fatalf("expected the bar at 40%%, got %v (indeterminate %v)", m.progress.Percent(), m.progress.Indeterminate())
+	}
+	if cmd == nil {
+		t.Fatal("expected to keep reading upload events")
+	}
+	events <- installProgressMsg{done: 100, total: 100}
+	if msg, ok := cmd().(installProgressMsg); !ok || msg.done != 100 {
+		t.Fatalf("expected the next progress message, got %#v", msg)
+	}
+
+	m.Update(installProgressMsg{done: 100, total: 100})
+	if !m.progress.Indeterminate() || !strings.Contains(m.View(), "Uploaded to server") {
+		t.Fatal("expected the server phase once every byte is sent")
+	}
+}
+
+func TestUploadRetryShowsAToastAndKeepsWaiting(t *testing.T) {
+	t.Setenv("BUNTIME_CONFIG_DIR", db.MemoryDir)
+	database, err := db.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+
+	m := NewInstallModel(nil, database, nil, "app", 100, 40)
+	m.startUploading(100)
+	events := make(chan tea.Msg, 1)
+	m.uploadEvents = events
+	events <- installResultMsg{}
+
+	_, cmd := m.Update(installRetryMsg{attempt: 2, maxAttempts: 3})
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok || len(batch) != 2 {
+		t.Fatalf("expected to keep waiting and show a toast, got %#v", batch)
+	}
+	if _, ok := batch[0]().(installResultMsg); !ok {
+		t.Fatal("expected to keep reading upload events")
+	}
+	if toast, ok := batch[1]().(messages.ShowToastMsg); !ok || !strings.Contains(toast.Message, "attempt 2 of 3") {
+		t.Fatalf("unexpected toast %#v", toast)
+	}
+}
+
+func TestFailedInstallExplainsKnownErrorCodes(t *testing.T) {
+	t.Setenv("BUNTIME_CONFIG_DIR", db.MemoryDir)
+	database, err := db.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+
+	m := NewInstallModel(nil, database, nil, "app", 100, 40)
+	m.mode = installModeFailed
+	m.err = &api.APIError{Code: "INVALID_FILE_TYPE", Message: "Request failed (400): File must be .tgz, .tar.gz, or .zip"}
+
+	view := m.View()
+	if !strings.Contains(view, "Install a .zip, .tgz or .tar.gz archive.") || strings.Contains(view, `"code"`) {
+		t.Fatalf("expected the clean message and a hint, got:\n%s", view)
+	}
+}
@@ -0,0 +1,168 @@
+package screens
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// zipProgressMsg reports how many files have been added to the archive
+type zipProgressMsg struct {
+	files int
+}
+
+// zipDoneMsg ends a directory zip. path is the finished archive; on error
+// (including cancellation) the partial archive has already been removed.
+type zipDoneMsg struct {
+	path string
+	err  error
+}
+
+// startZip compresses dirPath into a temp zip in the background. Progress and
+// the final result are delivered on the returned channel, read with
+// waitForZip.
+func startZip(ctx context.Context, dirPath string) <-chan tea.Msg {
+	events := make(chan tea.Msg, 1)
+
+	go func() {
+		path, err := zipDirectory(ctx, dirPath, func(files int) {
+			// Progress is best effort; the UI only needs the latest count
+			select {
+			case events <- zipProgressMsg{files: files}:
+			default:
+			}
+		})
+		events <- zipDoneMsg{path: path, err: err}
+		close(events)
+	}()
+
+	return events
+}
+
+// waitForZip delivers the next message from a running zip
+func waitForZip(events <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-events
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+// zipDirectory writes dirPath to a temp zip, skipping hidden files and
+// node_modules, and returns its path. It stops as soon as ctx is canceled and
+// removes the partial archive on any error.
+func zipDirectory(ctx context.Context, dirPath string, onFile func(files int)) (string, error) {
+	tempFile, err := os.CreateTemp("", "buntime-*.zip")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tempPath := tempFile.Name()
+
+	zipWriter := zip.NewWriter(tempFile)
+	files := 0
+
+	err = filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// Get relative path
+		relPath, err := filepath.Rel(dirPath, path)
+		if err != nil {
+			return err
+		}
+
+		// Skip root directory
+		if relPath == "." {
+			return nil
+		}
+
+		// Skip hidden files and directories
+		if strings.HasPrefix(filepath.Base(path), ".") {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		// Skip node_modules
+		if info.IsDir() && info.Name() == "node_modules" {
+			return filepath.SkipDir
+		}
+
+		// Create header
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(relPath)
+		header.Method = zip.Deflate
+
+		if info.IsDir() {
+			header.Name += "/"
+			_, err = zipWriter.CreateHeader(header)
+			return err
+		}
+
+		// Write file
+		writer, err := zipWriter.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		// Large files are copied in chunks that each check for cancellation
+		if _, err := io.Copy(writer, &contextReader{ctx: ctx, r: file}); err != nil {
+			return err
+		}
+
+		files++
+		onFile(files)
+		return nil
+	})
+
+	if closeErr := zipWriter.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		os.Remove(tempPath)
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "", fmt.Errorf("failed to create zip: %w", err)
+	}
+	return tempPath, nil
+}
+
+// contextReader fails reads once its context is canceled
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c *contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
@@ -0,0 +1,89 @@
+package screens
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestZipDirectorySkipsHiddenFilesAndNodeModules(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"manifest.yaml":           "name: my-app\n",
+		"src/index.ts":            "export default {}",
+		".env":                    "SECRET=1",
+		"node_modules/x/index.js": "",
+	})
+
+	var counts []int
+	path, err := zipDirectory(context.Background(), dir, func(files int) { counts = append(counts, files) })
+	if err != nil {
+		t.Fatalf("zipDirectory() error = %v", err)
+	}
+	defer os.Remove(path)
+
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+
+	var names []string
+	for _, f := range reader.File {
+		names = append(names, f.Name)
+	}
+	want := []string{"manifest.yaml", "src/", "src/index.ts"}
+	if len(names) != len(want) {
+		t.Fatalf("archive has %q, want %q", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("archive has %q, want %q", names, want)
+		}
+	}
+	if len(counts) != 2 || counts[1] != 2 {
+		t.Fatalf("unexpected progress %v", counts)
+	}
+}
+
+func TestZipDirectoryCancelRemovesPartialArchive(t *testing.T) {
+	dir := writeTree(t, map[string]string{"a.txt": "a", "b.txt": "b", "c.txt": "c"})
+	tempDir := t.TempDir()
+	t.Setenv("TMPDIR", tempDir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := zipDirectory(ctx, dir, func(files int) {
+		if files == 1 {
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	entries, err := os.ReadDir(tempDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected the partial archive to be removed, found %d file(s)", len(entries))
+	}
+}
@@ -2,14 +2,17 @@ package screens
 
 import (
 	"fmt"
-	"regexp"
-	"strconv"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/atotto/clipboard"
 	"github.com/buntime/cli/internal/api"
 	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/i18n"
+	"github.com/buntime/cli/internal/tui/components"
 	"github.com/buntime/cli/internal/tui/layout"
+	"github.com/buntime/cli/internal/tui/messages"
 	"github.com/buntime/cli/internal/tui/styles"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
@@ -33,6 +36,7 @@ type roleOption struct {
 	description string
 }
 
+// roleOptions are the roles offered when the server doesn't list its own
 var roleOptions = []roleOption{
 	{api.KeyRoleAdmin, "Admin", "Full access + manage keys"},
 	{api.KeyRoleEditor, "Editor", "Manage plugins/apps"},
@@ -43,16 +47,29 @@ var roleOptions = []roleOption{
 type expirationPreset struct {
 	value string
 	label string
+	days  int // Lifetime in days, 0 for never and custom
 }
 
+// customExpiration is the preset that reveals the duration input
+const customExpiration = "custom"
+
 var expirationPresets = []expirationPreset{
-	{"never", "Never"},
-	{"30d", "30 days"},
-	{"90d", "90 days"},
-	{"1y", "1 year"},
-	{"custom", "Custom"},
+	{api.ExpirationNever, "Never", 0},
+	{"30d", "30 days", 30},
+	{"90d", "90 days", 90},
+	{"1y", "1 year", 365},
+	{customExpiration, "Custom", 0},
 }
 
+// keepExpiration is the edit form's preset that leaves the expiration as is
+const keepExpiration = "keep"
+
+// editExpirationPresets replace Never with Unchanged: an update can give a key
+// a new expiration but not take its expiration away
+var editExpirationPresets = append([]expirationPreset{{keepExpiration, "Unchanged", 0}}, expirationPresets[1:]...)
+
+// allPermissions are the permissions offered when the server doesn't list its
+// own
 var allPermissions = []api.Permission{
 	api.PermPluginsRead,
 	api.PermPluginsInstall,
@@ -68,20 +85,57 @@ var allPermissions = []api.Permission{
 	api.PermWorkersRestart,
 }
 
-// KeyCreateModel handles API key creation in a single form
+// sortPermissions orders permissions as allPermissions lists them, followed
+// by any the CLI doesn't know in alphabetical order
+func sortPermissions(perms []api.Permission) []api.Permission {
+	rank := make(map[api.Permission]int, len(allPermissions))
+	for i, perm := range allPermissions {
+		rank[perm] = i
+	}
+
+	sorted := append([]api.Permission(nil), perms...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ri, knownI := rank[sorted[i]]
+		rj, knownJ := rank[sorted[j]]
+		switch {
+		case knownI && knownJ:
+			return ri < rj
+		case knownI != knownJ:
+			return knownI
+		default:
+			return sorted[i] < sorted[j]
+		}
+	})
+	return sorted
+}
+
+// KeyCreateModel handles API key creation in a single form. The same form
+// edits an existing key.
 type KeyCreateModel struct {
-	api    *api.Client
-	server *db.Server
-	width  int
-	height int
+	api     *api.Client
+	server  *db.Server
+	editing *api.ApiKeyInfo // Key being edited, nil when creating one
+	rotated bool            // result is a rotated key's new secret
+	width   int
+	height  int
 
 	nameInput       textinput.Model
 	expirationInput textinput.Model
 	roleIndex       int
 	expirationIndex int
 	permissions     map[api.Permission]bool
-	permIndex       int // Current permission cursor (0 to len(allPermissions)-1)
-	focusIndex      int
+	permIndex       int // Current permission cursor (0 to len(perms)-1)
+	form            *components.Form
+
+	// Roles and permissions from the server's key meta, or the built-in
+	// defaults when it doesn't provide them
+	roles []roleOption
+	perms []api.Permission
+
+	// Expiration presets within the server's maximum lifetime (maxDays, 0
+	// when there is none)
+	presets []expirationPreset
+	maxDays int
 
 	loading bool
 	err     error
@@ -94,17 +148,14 @@ func NewKeyCreateModel(client *api.Client, server *db.Server, width, height int)
 	nameInput := textinput.New()
 	nameInput.Placeholder = "e.g., Deploy CI/CD"
 	nameInput.Prompt = ""
-	nameInput.Focus()
 	nameInput.CharLimit = 64
-	nameInput.Width = 40
 
 	expInput := textinput.New()
 	expInput.Placeholder = "e.g., 1y 2m 15d"
 	expInput.Prompt = ""
 	expInput.CharLimit = 32
-	expInput.Width = 20
 
-	return &KeyCreateModel{
+	m := &KeyCreateModel{
 		api:             client,
 		server:          server,
 		width:           width,
@@ -115,12 +166,156 @@ func NewKeyCreateModel(client *api.Client, server *db.Server, width, height int)
 		expirationIndex: 3, // Default to 1 year
 		permissions:     make(map[api.Permission]bool),
 		permIndex:       0,
-		focusIndex:      keyFocusName,
+		roles:           roleOptions,
+		perms:           allPermissions,
+		presets:         expirationPresets,
 	}
+	m.form = components.NewForm(
+		components.Field{Kind: components.FieldInput, Input: &m.nameInput, Validate: m.validateName},
+		components.Field{Kind: components.FieldChoice},
+		components.Field{Kind: components.FieldChoice, Hidden: m.notCustomRole, Validate: m.validatePermissions},
+		components.Field{Kind: components.FieldChoice},
+		components.Field{Kind: components.FieldInput, Input: &m.expirationInput, Hidden: m.notCustomExpiration, Validate: m.validateExpiration},
+		components.Field{Kind: components.FieldButton},
+		components.Field{Kind: components.FieldButton},
+	)
+	m.resizeInputs()
+	return m
+}
+
+// NewKeyEditModel opens the key form on an existing key, to rename it or
+// change its role, permissions or expiration
+func NewKeyEditModel(client *api.Client, server *db.Server, key *api.ApiKeyInfo, width, height int) *KeyCreateModel {
+	m := NewKeyCreateModel(client, server, width, height)
+	m.editing = key
+	m.nameInput.SetValue(key.Name)
+	m.nameInput.CursorEnd()
+	m.presets = editExpirationPresets
+	m.expirationIndex = 0
+
+	m.roleIndex = slices.IndexFunc(m.roles, func(opt roleOption) bool { return opt.role == key.Role })
+	if m.roleIndex < 0 {
+		m.roles = append(slices.Clone(m.roles), roleOptionFor(key.Role))
+		m.roleIndex = len(m.roles) - 1
+	}
+	for _, perm := range key.Permissions {
+		m.permissions[perm] = true
+	}
+	return m
+}
+
+// choosablePresets are the expiration presets before the server's maximum
+// lifetime is applied
+func (m *KeyCreateModel) choosablePresets() []expirationPreset {
+	if m.editing != nil {
+		return editExpirationPresets
+	}
+	return expirationPresets
+}
+
+// resizeInputs fits the text inputs to the current terminal width
+func (m *KeyCreateModel) resizeInputs() {
+	m.nameInput.Width = layout.InputWidth(m.width, 40)
+	m.expirationInput.Width = layout.InputWidth(m.width, 20)
 }
 
 func (m *KeyCreateModel) Init() tea.Cmd {
-	return textinput.Blink
+	return tea.Batch(textinput.Blink, m.loadMeta())
+}
+
+type keyMetaLoadedMsg struct {
+	meta *api.KeyMetaInfo
+	err  error
+}
+
+func (m *KeyCreateModel) loadMeta() tea.Cmd {
+	return func() tea.Msg {
+		meta, err := m.api.GetKeyMeta()
+		return keyMetaLoadedMsg{meta: meta, err: err}
+	}
+}
+
+// applyMeta switches the form to the roles and permissions the server lists,
+// keeping the current selections that are still offered
+func (m *KeyCreateModel) applyMeta(meta *api.KeyMetaInfo) {
+	if len(meta.Roles) > 0 {
+		current := m.roles[m.roleIndex].role
+		m.roles = make([]roleOption, len(meta.Roles))
+		m.roleIndex = 0
+		for i, role := range meta.Roles {
+			m.roles[i] = roleOptionFor(role)
+			if role == current {
+				m.roleIndex = i
+			}
+		}
+	}
+
+	if len(meta.Permissions) > 0 {
+		m.perms = orderByGroup(meta.Permissions)
+		offered := make(map[api.Permission]bool, len(m.perms))
+		for _, perm := range m.perms {
+			offered[perm] = true
+		}
+		for perm := range m.permissions {
+			if !offered[perm] {
+				delete(m.permissions, perm)
+			}
+		}
+		m.permIndex = min(m.permIndex, len(m.perms)-1)
+	}
+
+	if maxDays := meta.MaxExpirationDays(); maxDays > 0 {
+		m.applyMaxExpiration(maxDays)
+	}
+}
+
+// applyMaxExpiration hides the presets the server would reject. A selected
+// preset that is no longer offered is clamped to the longest one left.
+func (m *KeyCreateModel) applyMaxExpiration(maxDays int) {
+	current := m.presets[m.expirationIndex].value
+	m.maxDays = maxDays
+	m.presets = nil
+	m.expirationIndex = -1
+	for _, preset := range m.choosablePresets() {
+		switch {
+		case preset.value == customExpiration:
+			if m.expirationIndex < 0 {
+				// Custom is last, so the longest preset is just before it
+				m.expirationIndex = max(0, len(m.presets)-1)
+			}
+		case preset.value == keepExpiration:
+			// Always offered
+		case preset.days == 0 || preset.days > maxDays:
+			continue
+		}
+		if preset.value == current {
+			m.expirationIndex = len(m.presets)
+		}
+		m.presets = append(m.presets, preset)
+	}
+}
+
+func (m *KeyCreateModel) isCustomRole() bool {
+	return m.roles[m.roleIndex].role == api.KeyRoleCustom
+}
+
+func (m *KeyCreateModel) isCustomExpiration() bool {
+	return m.presets[m.expirationIndex].value == customExpiration
+}
+
+// roleOptionFor describes a role from the server, using the built-in label
+// and description for the roles the CLI knows
+func roleOptionFor(role api.KeyRole) roleOption {
+	for _, opt := range roleOptions {
+		if opt.role == role {
+			return opt
+		}
+	}
+	label := string(role)
+	if label != "" {
+		label = strings.ToUpper(label[:1]) + label[1:]
+	}
+	return roleOption{role: role, label: label}
 }
 
 func (m *KeyCreateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -146,6 +341,20 @@ func (m *KeyCreateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.resizeInputs()
+		return m, nil
+
+	case keyMetaLoadedMsg:
+		if canceled(msg.err) {
+			return m, nil
+		}
+		if msg.err != nil {
+			// Older servers don't list roles; the built-in ones still work
+			return m, func() tea.Msg {
+				return messages.ShowWarning("Using the built-in key roles: " + msg.err.Error())
+			}
+		}
+		m.applyMeta(msg.meta)
 		return m, nil
 
 	case keyCreatedMsg:
@@ -157,277 +366,183 @@ func (m *KeyCreateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.result = msg.result
 		return m, nil
 
+	case keyUpdatedMsg:
+		m.loading = false
+		if msg.err != nil {
+			// Shown in the form, next to what needs fixing
+			m.err = msg.err
+			return m, nil
+		}
+		return m, tea.Batch(
+			func() tea.Msg {
+				return NavigateMsg{Screen: ScreenKeys, Data: nil, ReplaceHistory: true}
+			},
+			func() tea.Msg {
+				return messages.ShowSuccess("Updated key " + msg.name)
+			},
+		)
+
+	case KeySwitchedMsg:
+		if permissionDenied(m.err) {
+			return m, m.submit()
+		}
+		return m, nil
+
 	case tea.KeyMsg:
 		if m.loading {
 			return m, nil
 		}
+		if m.form.Focused() == keyFocusPermissions && m.updatePermissions(msg) {
+			return m, nil
+		}
 		switch msg.String() {
+		case switchKeyKey:
+			if permissionDenied(m.err) {
+				return m, switchKey(m.api, m.server)
+			}
+			return m, nil
 		case "esc":
 			return m, goBack()
-		case "tab":
-			return m.focusNext()
-		case "shift+tab":
-			return m.focusPrev()
-		case "up":
-			return m.handleUp()
-		case "down":
-			return m.handleDown()
-		case "left":
-			return m.handleLeft()
-		case "right":
-			return m.handleRight()
-		case "h", "j", "k", "l":
-			// Vim-style navigation only when not in text input
-			if m.focusIndex != keyFocusName && m.focusIndex != keyFocusExpInput {
-				switch msg.String() {
-				case "k":
-					return m.handleUp()
-				case "j":
-					return m.handleDown()
-				case "h":
-					return m.handleLeft()
-				case "l":
-					return m.handleRight()
-				}
+		case "left", "h":
+			if m.moveChoice(-1) {
+				return m, nil
+			}
+		case "right", "l":
+			if m.moveChoice(1) {
+				return m, nil
 			}
 		case "enter":
-			return m.handleEnter()
-		case " ":
-			// Only handle space for permissions toggle, not in text inputs
-			if m.focusIndex == keyFocusPermissions {
-				return m.handleSpace()
+			switch m.form.Focused() {
+			case keyFocusCancel:
+				return m, goBack()
+			case keyFocusCreate:
+				return m, m.submit()
 			}
 		}
 	}
 
-	// Update focused input
-	var cmd tea.Cmd
-	switch m.focusIndex {
-	case keyFocusName:
-		m.nameInput, cmd = m.nameInput.Update(msg)
-	case keyFocusExpInput:
-		m.expirationInput, cmd = m.expirationInput.Update(msg)
-	}
-
-	return m, cmd
+	return m, m.form.Update(msg)
 }
 
-func (m *KeyCreateModel) focusNext() (tea.Model, tea.Cmd) {
-	m.blurInputs()
-
-	switch m.focusIndex {
-	case keyFocusName:
-		m.focusIndex = keyFocusRole
-	case keyFocusRole:
-		if m.roleIndex == 3 { // Custom role
-			m.focusIndex = keyFocusPermissions
-		} else {
-			m.focusIndex = keyFocusExpiration
-		}
-	case keyFocusPermissions:
-		m.focusIndex = keyFocusExpiration
-	case keyFocusExpiration:
-		if m.expirationIndex == 4 { // Custom expiration
-			m.focusIndex = keyFocusExpInput
-		} else {
-			m.focusIndex = keyFocusCancel
-		}
-	case keyFocusExpInput:
-		m.focusIndex = keyFocusCancel
-	case keyFocusCancel:
-		m.focusIndex = keyFocusCreate
-	case keyFocusCreate:
-		m.focusIndex = keyFocusName // Wrap around
+// updatePermissions handles the keys of the permission grid. Vim keys work
+// too, since it has no text to type.
+func (m *KeyCreateModel) updatePermissions(msg tea.KeyMsg) bool {
+	switch msg.String() {
+	case "up", "k":
+		m.permIndex = movePermCursor(m.perms, m.permIndex, -1, 0)
+	case "down", "j":
+		m.permIndex = movePermCursor(m.perms, m.permIndex, 1, 0)
+	case "left", "h":
+		m.permIndex = movePermCursor(m.perms, m.permIndex, 0, -1)
+	case "right", "l":
+		m.permIndex = movePermCursor(m.perms, m.permIndex, 0, 1)
+	case " ", "enter":
+		perm := m.perms[m.permIndex]
+		m.permissions[perm] = !m.permissions[perm]
+	case "a":
+		m.toggleGroup()
+	case "r":
+		m.selectReadOnly()
+	default:
+		return false
 	}
-
-	m.updateInputFocus()
-	return m, nil
+	return true
 }
 
-func (m *KeyCreateModel) focusPrev() (tea.Model, tea.Cmd) {
-	m.blurInputs()
-
-	switch m.focusIndex {
-	case keyFocusName:
-		m.focusIndex = keyFocusCreate // Wrap around
+// moveChoice changes the focused role or expiration preset. It reports false
+// when neither has the focus.
+func (m *KeyCreateModel) moveChoice(delta int) bool {
+	switch m.form.Focused() {
 	case keyFocusRole:
-		m.focusIndex = keyFocusName
-	case keyFocusPermissions:
-		m.focusIndex = keyFocusRole
+		m.roleIndex = min(max(m.roleIndex+delta, 0), len(m.roles)-1)
 	case keyFocusExpiration:
-		if m.roleIndex == 3 {
-			m.focusIndex = keyFocusPermissions
-		} else {
-			m.focusIndex = keyFocusRole
-		}
-	case keyFocusExpInput:
-		m.focusIndex = keyFocusExpiration
-	case keyFocusCancel:
-		if m.expirationIndex == 4 {
-			m.focusIndex = keyFocusExpInput
-		} else {
-			m.focusIndex = keyFocusExpiration
-		}
-	case keyFocusCreate:
-		m.focusIndex = keyFocusCancel
+		m.expirationIndex = min(max(m.expirationIndex+delta, 0), len(m.presets)-1)
+	default:
+		return false
 	}
-
-	m.updateInputFocus()
-	return m, nil
+	return true
 }
 
-func (m *KeyCreateModel) blurInputs() {
-	m.nameInput.Blur()
-	m.expirationInput.Blur()
+func (m *KeyCreateModel) notCustomRole() bool {
+	return !m.isCustomRole()
 }
 
-func (m *KeyCreateModel) updateInputFocus() {
-	switch m.focusIndex {
-	case keyFocusName:
-		m.nameInput.Focus()
-	case keyFocusExpInput:
-		m.expirationInput.Focus()
-	}
+func (m *KeyCreateModel) notCustomExpiration() bool {
+	return !m.isCustomExpiration()
 }
 
-func (m *KeyCreateModel) handleUp() (tea.Model, tea.Cmd) {
-	if m.focusIndex == keyFocusPermissions {
-		if m.permIndex > 0 {
-			m.permIndex--
-		}
+func (m *KeyCreateModel) validateName() string {
+	if strings.TrimSpace(m.nameInput.Value()) == "" {
+		return "Name is required"
 	}
-	return m, nil
+	return ""
 }
 
-func (m *KeyCreateModel) handleDown() (tea.Model, tea.Cmd) {
-	if m.focusIndex == keyFocusPermissions {
-		if m.permIndex < len(allPermissions)-1 {
-			m.permIndex++
+// validatePermissions asks a custom role for at least one permission
+func (m *KeyCreateModel) validatePermissions() string {
+	for _, enabled := range m.permissions {
+		if enabled {
+			return ""
 		}
 	}
-	return m, nil
+	return "Select at least one permission for custom role"
 }
 
-func (m *KeyCreateModel) handleLeft() (tea.Model, tea.Cmd) {
-	switch m.focusIndex {
-	case keyFocusRole:
-		if m.roleIndex > 0 {
-			m.roleIndex--
-		}
-	case keyFocusExpiration:
-		if m.expirationIndex > 0 {
-			m.expirationIndex--
-		}
+func (m *KeyCreateModel) validateExpiration() string {
+	expStr := strings.TrimSpace(m.expirationInput.Value())
+	if expStr == "" {
+		return "Custom expiration is required"
 	}
-	return m, nil
-}
-
-func (m *KeyCreateModel) handleRight() (tea.Model, tea.Cmd) {
-	switch m.focusIndex {
-	case keyFocusRole:
-		if m.roleIndex < len(roleOptions)-1 {
-			m.roleIndex++
-		}
-	case keyFocusExpiration:
-		if m.expirationIndex < len(expirationPresets)-1 {
-			m.expirationIndex++
-		}
+	normalized, err := api.NormalizeExpiration(expStr)
+	if err != nil {
+		return err.Error()
 	}
-	return m, nil
-}
-
-func (m *KeyCreateModel) handleSpace() (tea.Model, tea.Cmd) {
-	if m.focusIndex == keyFocusPermissions {
-		perm := allPermissions[m.permIndex]
-		m.permissions[perm] = !m.permissions[perm]
-	}
-	return m, nil
-}
-
-func (m *KeyCreateModel) handleEnter() (tea.Model, tea.Cmd) {
-	switch m.focusIndex {
-	case keyFocusName, keyFocusExpInput:
-		// Move to next field
-		return m.focusNext()
-	case keyFocusPermissions:
-		// Toggle permission
-		return m.handleSpace()
-	case keyFocusCancel:
-		return m, goBack()
-	case keyFocusCreate:
-		return m, m.submit()
-	default:
-		// For role/expiration, move to next field
-		return m.focusNext()
+	if err := api.CheckExpiration(normalized, m.maxDays); err != nil {
+		return err.Error()
 	}
+	return ""
 }
 
-func (m *KeyCreateModel) validate() string {
-	name := strings.TrimSpace(m.nameInput.Value())
-	if name == "" {
-		return "Name is required"
-	}
-
-	// Validate custom expiration
-	if m.expirationIndex == 4 {
-		expStr := strings.TrimSpace(m.expirationInput.Value())
-		if expStr == "" {
-			return "Custom expiration is required"
-		}
-		if _, err := parseDuration(expStr); err != nil {
-			return err.Error()
-		}
+// selectedPermissions returns the ticked permissions of a custom role in the
+// order the form lists them, so requests are the same for the same choices
+func (m *KeyCreateModel) selectedPermissions() []api.Permission {
+	if m.roles[m.roleIndex].role != api.KeyRoleCustom {
+		return nil
 	}
-
-	// Validate custom role has permissions
-	if m.roleIndex == 3 {
-		count := 0
-		for _, enabled := range m.permissions {
-			if enabled {
-				count++
-			}
-		}
-		if count == 0 {
-			return "Select at least one permission for custom role"
+	var perms []api.Permission
+	for _, perm := range m.perms {
+		if m.permissions[perm] {
+			perms = append(perms, perm)
 		}
 	}
-
-	return ""
+	return perms
 }
 
 func (m *KeyCreateModel) submit() tea.Cmd {
-	if errMsg := m.validate(); errMsg != "" {
+	// Claim the busy flag before anything else so a repeated Enter can't
+	// queue a second create request
+	if m.loading {
+		return nil
+	}
+	m.loading = true
+
+	if errMsg := m.form.Validate(); errMsg != "" {
+		m.loading = false
 		m.err = fmt.Errorf("%s", errMsg)
 		return nil
 	}
 
-	m.loading = true
 	m.err = nil
+	if m.editing != nil {
+		return m.saveChanges()
+	}
+	perms := m.selectedPermissions()
 
 	return func() tea.Msg {
-		var perms []api.Permission
-		if roleOptions[m.roleIndex].role == api.KeyRoleCustom {
-			for p, enabled := range m.permissions {
-				if enabled {
-					perms = append(perms, p)
-				}
-			}
-		}
-
-		// Get expiration value
-		var expiresIn string
-		if m.expirationIndex == 4 {
-			// Parse and normalize to days
-			normalized, _ := parseDuration(strings.TrimSpace(m.expirationInput.Value()))
-			expiresIn = normalized
-		} else {
-			expiresIn = expirationPresets[m.expirationIndex].value
-		}
-
 		input := api.CreateKeyInput{
 			Name:        strings.TrimSpace(m.nameInput.Value()),
-			Role:        roleOptions[m.roleIndex].role,
-			ExpiresIn:   expiresIn,
+			Role:        m.roles[m.roleIndex].role,
+			ExpiresIn:   m.expiresIn(),
 			Permissions: perms,
 		}
 
@@ -436,11 +551,77 @@ func (m *KeyCreateModel) submit() tea.Cmd {
 	}
 }
 
+// expiresIn is the chosen expiration preset, or the custom duration
+// normalized to days
+func (m *KeyCreateModel) expiresIn() string {
+	if m.isCustomExpiration() {
+		normalized, _ := api.NormalizeExpiration(strings.TrimSpace(m.expirationInput.Value()))
+		return normalized
+	}
+	return m.presets[m.expirationIndex].value
+}
+
+// saveChanges sends what the edit form changed about the key. With nothing
+// changed it just goes back.
+func (m *KeyCreateModel) saveChanges() tea.Cmd {
+	input := m.updateInput()
+	if input.Empty() {
+		m.loading = false
+		return goBack()
+	}
+
+	id, name := m.editing.ID, strings.TrimSpace(m.nameInput.Value())
+	return func() tea.Msg {
+		return keyUpdatedMsg{name: name, err: m.api.UpdateKey(id, input)}
+	}
+}
+
+// updateInput lists the fields of the edited key that the form changes
+func (m *KeyCreateModel) updateInput() api.UpdateKeyInput {
+	key := m.editing
+	var input api.UpdateKeyInput
+
+	if name := strings.TrimSpace(m.nameInput.Value()); name != key.Name {
+		input.Name = &name
+	}
+
+	role := m.roles[m.roleIndex].role
+	if role != key.Role {
+		input.Role = &role
+	}
+	if role == api.KeyRoleCustom {
+		perms := m.selectedPermissions()
+		if input.Role != nil || !slices.Equal(sortPermissions(perms), sortPermissions(key.Permissions)) {
+			input.Permissions = &perms
+		}
+	}
+
+	if expiresIn := m.expiresIn(); expiresIn != keepExpiration {
+		// The presets are lifetimes; the server takes the moment they end,
+		// on its own clock
+		days, _ := api.ExpirationDays(expiresIn)
+		expiresAt := m.api.ServerNow().AddDate(0, 0, days).Unix()
+		input.ExpiresAt = &expiresAt
+	}
+
+	return input
+}
+
 type keyCreatedMsg struct {
 	result *api.CreateKeyResult
 	err    error
 }
 
+type keyUpdatedMsg struct {
+	name string
+	err  error
+}
+
+// Typing reports whether the name or a custom expiration is being typed
+func (m *KeyCreateModel) Typing() bool {
+	return m.result == nil && m.form.Typing()
+}
+
 func (m *KeyCreateModel) View() string {
 	innerWidth := layout.InnerWidth(m.width)
 
@@ -449,23 +630,40 @@ func (m *KeyCreateModel) View() string {
 		return m.renderSuccess(innerWidth)
 	}
 
+	breadcrumb, title := m.pageTitle()
 	return layout.Page(layout.PageConfig{
 		Width:      m.width,
 		Height:     m.height,
 		Server:     m.server,
-		Breadcrumb: "Main › API Keys › Create",
-		Title:      "CREATE API KEY",
+		Breadcrumb: breadcrumb,
+		Title:      title,
 		Content:    m.renderForm(innerWidth),
 		Shortcuts:  m.getShortcuts(),
 	})
 }
 
+// pageTitle returns the breadcrumb and title for what the form is doing
+func (m *KeyCreateModel) pageTitle() (string, string) {
+	switch {
+	case m.rotated:
+		return "Main › API Keys › Rotate", "ROTATE API KEY"
+	case m.editing != nil:
+		return "Main › API Keys › Edit", "EDIT API KEY"
+	default:
+		return "Main › API Keys › Create", "CREATE API KEY"
+	}
+}
+
 func (m *KeyCreateModel) renderForm(width int) string {
 	var b strings.Builder
 
 	// Error message
 	if m.err != nil {
-		b.WriteString(styles.TextError.Render("✗ "+m.err.Error()) + "\n\n")
+		b.WriteString(styles.TextError.Render("✗ "+m.err.Error()) + "\n")
+		if permissionDenied(m.err) {
+			b.WriteString(renderSwitchKeyHint())
+		}
+		b.WriteString("\n")
 	}
 
 	// Form card
@@ -483,57 +681,67 @@ func (m *KeyCreateModel) renderFormContent() string {
 	var b strings.Builder
 
 	// Name field
-	b.WriteString(m.renderLabel("Name", true) + "\n")
-	b.WriteString(styles.RenderInput(m.nameInput.View(), m.focusIndex == keyFocusName, false) + "\n\n")
+	b.WriteString(components.RenderLabel("Name", true) + "\n")
+	b.WriteString(m.form.RenderInput(keyFocusName) + "\n\n")
 
 	// Role field
-	b.WriteString(m.renderLabel("Role", false))
-	if m.focusIndex == keyFocusRole {
+	b.WriteString(components.RenderLabel("Role", false))
+	if m.form.IsFocused(keyFocusRole) {
 		b.WriteString(styles.TextMuted.Render("  ←→ to change"))
 	}
 	b.WriteString("\n")
 	b.WriteString(m.renderRoleOptions() + "\n")
-	if m.roleIndex < len(roleOptions) {
-		b.WriteString(styles.TextMuted.Render("  "+roleOptions[m.roleIndex].description) + "\n")
+	if m.roleIndex < len(m.roles) && m.roles[m.roleIndex].description != "" {
+		b.WriteString(styles.TextMuted.Render("  "+m.roles[m.roleIndex].description) + "\n")
 	}
 	b.WriteString("\n")
 
 	// Permissions (only if custom role)
-	if m.roleIndex == 3 {
-		b.WriteString(m.renderLabel("Permissions", false))
-		if m.focusIndex == keyFocusPermissions {
-			b.WriteString(styles.TextMuted.Render("  ↑↓ navigate, Space toggle"))
+	if m.isCustomRole() {
+		b.WriteString(components.RenderLabel("Permissions", false))
+		if m.form.IsFocused(keyFocusPermissions) {
+			b.WriteString(styles.TextMuted.Render("  ↑↓ navigate, Space toggle, a group all/none, r read-only"))
 		}
 		b.WriteString("\n")
-		b.WriteString(m.renderPermissions() + "\n")
+		b.WriteString(m.renderPermissions())
+		b.WriteString(m.renderPermissionAdvice() + "\n")
 	}
 
 	// Expiration field
-	b.WriteString(m.renderLabel("Expiration", false))
-	if m.focusIndex == keyFocusExpiration {
+	b.WriteString(components.RenderLabel("Expiration", false))
+	if m.form.IsFocused(keyFocusExpiration) {
 		b.WriteString(styles.TextMuted.Render("  ←→ to change"))
 	}
 	b.WriteString("\n")
 	b.WriteString(m.renderExpirationOptions() + "\n")
+	if m.editing != nil {
+		b.WriteString(styles.TextMuted.Render("  Currently: "+formatExpiry(m.editing.ExpiresAt, m.api.ServerNow())) + "\n")
+	}
+	if m.maxDays > 0 {
+		b.WriteString(styles.TextMuted.Render(fmt.Sprintf("  Server maximum: %d days", m.maxDays)) + "\n")
+	}
 
 	// Custom expiration input
-	if m.expirationIndex == 4 {
+	if m.isCustomExpiration() {
 		expValue := strings.TrimSpace(m.expirationInput.Value())
 		hasExpError := false
 		expErrorMsg := ""
 		totalDays := 0
 		if expValue != "" {
-			if days, err := parseDuration(expValue); err != nil {
+			normalized, err := api.NormalizeExpiration(expValue)
+			if err == nil {
+				err = api.CheckExpiration(normalized, m.maxDays)
+			}
+			if err != nil {
 				hasExpError = true
 				expErrorMsg = err.Error()
 			} else {
-				// Extract days number from "Xd" format
-				fmt.Sscanf(days, "%dd", &totalDays)
+				totalDays, _ = api.ExpirationDays(normalized)
 			}
 		}
 
 		// Input with calculated days on the right
-		inputView := styles.RenderInput(m.expirationInput.View(), m.focusIndex == keyFocusExpInput, hasExpError)
+		inputView := styles.RenderInput(m.expirationInput.View(), m.form.IsFocused(keyFocusExpInput), hasExpError || m.form.Invalid(keyFocusExpInput))
 		if totalDays > 0 {
 			daysText := styles.TextSuccess.Render(fmt.Sprintf("= %d days", totalDays))
 			inputView = lipgloss.JoinHorizontal(lipgloss.Center, inputView, "  ", daysText)
@@ -555,33 +763,16 @@ func (m *KeyCreateModel) renderFormContent() string {
 }
 
 func (m *KeyCreateModel) renderButtons() string {
-	cancelStyle := styles.Button
-	if m.focusIndex == keyFocusCancel {
-		cancelStyle = styles.ButtonFocused
-	}
-
-	createStyle := styles.Button
-	if m.focusIndex == keyFocusCreate {
-		createStyle = styles.ButtonPrimary
+	label := "  Create  "
+	if m.editing != nil {
+		label = "  Save  "
 	}
-
-	cancel := cancelStyle.Render("  Cancel  ")
-	create := createStyle.Render("  Create  ")
-
-	return lipgloss.JoinHorizontal(lipgloss.Center, cancel, "  ", create)
-}
-
-func (m *KeyCreateModel) renderLabel(text string, required bool) string {
-	label := styles.TextNormal.Render(text)
-	if required {
-		label += styles.TextError.Render(" *")
-	}
-	return label
+	return m.form.RenderButtons(keyFocusCancel, "  Cancel  ", keyFocusCreate, label)
 }
 
 func (m *KeyCreateModel) renderRoleOptions() string {
 	var parts []string
-	for i, opt := range roleOptions {
+	for i, opt := range m.roles {
 		indicator := "○"
 		style := styles.TextNormal
 		if i == m.roleIndex {
@@ -595,7 +786,7 @@ func (m *KeyCreateModel) renderRoleOptions() string {
 
 func (m *KeyCreateModel) renderExpirationOptions() string {
 	var parts []string
-	for i, opt := range expirationPresets {
+	for i, opt := range m.presets {
 		indicator := "○"
 		style := styles.TextNormal
 		if i == m.expirationIndex {
@@ -607,25 +798,59 @@ func (m *KeyCreateModel) renderExpirationOptions() string {
 	return "  " + strings.Join(parts, "   ")
 }
 
+// renderPermissions renders each resource's permissions under a header with
+// how many of them are selected
 func (m *KeyCreateModel) renderPermissions() string {
 	var b strings.Builder
 
+	// m.perms is ordered by group, so each group is a run of it
+	start := 0
+	for _, group := range groupPermissions(m.perms) {
+		selected := 0
+		for _, perm := range group.perms {
+			if m.permissions[perm] {
+				selected++
+			}
+		}
+		header := fmt.Sprintf("%s (%d/%d)", groupTitle(group.name), selected, len(group.perms))
+		b.WriteString("  " + styles.TextMuted.Bold(true).Render(header) + "\n")
+		b.WriteString(m.renderPermissionGrid(start, len(group.perms)))
+		start += len(group.perms)
+	}
+
+	return b.String()
+}
+
+// renderPermissionAdvice warns about risky permission choices before the key
+// is created
+func (m *KeyCreateModel) renderPermissionAdvice() string {
+	var b strings.Builder
+	for _, advice := range api.AdvisePermissions(m.selectedPermissions()) {
+		b.WriteString(styles.TextWarning.Render("  ⚠ "+advice) + "\n")
+	}
+	return b.String()
+}
+
+// renderPermissionGrid renders count permissions from start in 2 columns
+func (m *KeyCreateModel) renderPermissionGrid(start, count int) string {
+	var b strings.Builder
+
 	// Render in 2 columns
-	cols := 2
-	rows := (len(allPermissions) + cols - 1) / cols
-	colWidth := 28
+	cols := permGridColumns
+	rows := (count + cols - 1) / cols
+	colWidth := 20
 
 	for row := 0; row < rows; row++ {
 		var rowParts []string
 		for col := 0; col < cols; col++ {
-			idx := row + col*rows
-			if idx >= len(allPermissions) {
+			idx := start + row + col*rows
+			if idx >= start+count {
 				rowParts = append(rowParts, strings.Repeat(" ", colWidth))
 				continue
 			}
 
-			perm := allPermissions[idx]
-			isFocused := m.focusIndex == keyFocusPermissions && idx == m.permIndex
+			perm := m.perms[idx]
+			isFocused := m.form.IsFocused(keyFocusPermissions) && idx == m.permIndex
 			isChecked := m.permissions[perm]
 
 			checkbox := "[ ]"
@@ -633,7 +858,8 @@ func (m *KeyCreateModel) renderPermissions() string {
 				checkbox = "[x]"
 			}
 
-			label := string(perm)
+			// The group header names the resource
+			_, label := splitPermission(perm)
 			style := styles.TextNormal
 			if isFocused {
 				style = styles.TextPrimary
@@ -652,7 +878,7 @@ func (m *KeyCreateModel) renderPermissions() string {
 			}
 			rowParts = append(rowParts, item)
 		}
-		b.WriteString("  " + strings.Join(rowParts, " ") + "\n")
+		b.WriteString("    " + strings.Join(rowParts, " ") + "\n")
 	}
 
 	return b.String()
@@ -661,7 +887,11 @@ func (m *KeyCreateModel) renderPermissions() string {
 func (m *KeyCreateModel) renderSuccess(width int) string {
 	var content strings.Builder
 
-	content.WriteString(layout.CenterText(styles.TextSuccess.Bold(true).Render("✓ API KEY CREATED"), width) + "\n\n")
+	heading := "✓ API KEY CREATED"
+	if m.rotated {
+		heading = "✓ API KEY ROTATED"
+	}
+	content.WriteString(layout.CenterText(styles.TextSuccess.Bold(true).Render(heading), width) + "\n\n")
 
 	warning := styles.TextMuted.Render("Copy this key now. You won't be able to see it again!") + "\n\n" +
 		styles.BoldWarning.Render(m.result.Key)
@@ -678,70 +908,29 @@ func (m *KeyCreateModel) renderSuccess(width int) string {
 		content.WriteString("\n" + styles.TextSuccess.Render("Copied to clipboard!") + "\n")
 	}
 
+	breadcrumb, title := m.pageTitle()
 	return layout.Page(layout.PageConfig{
 		Width:      m.width,
 		Height:     m.height,
 		Server:     m.server,
-		Breadcrumb: "Main › API Keys › Create",
-		Title:      "CREATE API KEY",
+		Breadcrumb: breadcrumb,
+		Title:      title,
 		Content:    content.String(),
 		Shortcuts: []string{
-			styles.RenderShortcut("c", "copy"),
-			styles.RenderShortcut("⏎", "done"),
-			styles.RenderShortcut("Esc", "back"),
+			styles.RenderShortcut("c", i18n.T("shortcut.copy")),
+			styles.RenderShortcut("⏎", i18n.T("shortcut.done")),
+			styles.RenderShortcut("Esc", i18n.T("shortcut.back")),
 		},
 	})
 }
 
 func (m *KeyCreateModel) getShortcuts() []string {
-	return []string{
-		styles.RenderShortcut("Tab", "next"),
-		styles.RenderShortcut("⏎", "submit"),
-		styles.RenderShortcut("Esc", "cancel"),
-	}
-}
-
-// parseDuration parses flexible duration strings like "1y 2m 15d" or "30d"
-// Returns the normalized duration string for the API (e.g., "450d")
-func parseDuration(s string) (string, error) {
-	s = strings.TrimSpace(strings.ToLower(s))
-	if s == "" {
-		return "", fmt.Errorf("duration cannot be empty")
+	shortcuts := []string{
+		styles.RenderShortcut("Tab", i18n.T("shortcut.next")),
+		styles.RenderShortcut("⏎", i18n.T("shortcut.submit")),
 	}
-
-	// Regex to match duration parts: number followed by unit
-	re := regexp.MustCompile(`(\d+)\s*(d|w|m|y|day|days|week|weeks|month|months|year|years)`)
-	matches := re.FindAllStringSubmatch(s, -1)
-
-	if len(matches) == 0 {
-		return "", fmt.Errorf("invalid format. Use: 7d, 2w, 6m, 1y")
+	if permissionDenied(m.err) {
+		shortcuts = append(shortcuts, switchKeyShortcut())
 	}
-
-	var totalDays int
-
-	for _, match := range matches {
-		num, err := strconv.Atoi(match[1])
-		if err != nil {
-			return "", fmt.Errorf("invalid number: %s", match[1])
-		}
-
-		unit := match[2]
-		switch {
-		case unit == "d" || unit == "day" || unit == "days":
-			totalDays += num
-		case unit == "w" || unit == "week" || unit == "weeks":
-			totalDays += num * 7
-		case unit == "m" || unit == "month" || unit == "months":
-			totalDays += num * 30 // Approximate month as 30 days
-		case unit == "y" || unit == "year" || unit == "years":
-			totalDays += num * 365 // Approximate year as 365 days
-		}
-	}
-
-	if totalDays <= 0 {
-		return "", fmt.Errorf("duration must be greater than 0")
-	}
-
-	// Return as days for the API
-	return fmt.Sprintf("%dd", totalDays), nil
+	return append(shortcuts, styles.RenderShortcut("Esc", i18n.T("shortcut.cancel")))
 }
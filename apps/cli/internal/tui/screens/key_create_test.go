@@ -0,0 +1,247 @@
+package screens
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/tui/components"
+	"github.com/buntime/cli/internal/tui/messages"
+)
+
+func TestKeyCreateFallsBackWithoutKeyMeta(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	m := NewKeyCreateModel(api.New(server.URL, "", false), &db.Server{Name: "test", URL: server.URL}, 100, 40)
+
+	_, cmd := m.Update(m.loadMeta()())
+	if cmd == nil {
+		t.Fatal("expected a warning about the missing key meta")
+	}
+	if toast, ok := cmd().(messages.ShowToastMsg); !ok || toast.Type != components.ToastWarning {
+		t.Fatalf("expected a warning toast, got %#v", toast)
+	}
+
+	if len(m.roles) != len(roleOptions) || len(m.perms) != len(allPermissions) {
+		t.Fatalf("expected the built-in roles and permissions, got %d roles and %d permissions", len(m.roles), len(m.perms))
+	}
+	view := m.View()
+	for _, opt := range roleOptions {
+		if !strings.Contains(view, opt.label) {
+			t.Fatalf("expected the form to offer the %s role", opt.label)
+		}
+	}
+}
+
+func TestKeyCreateUsesServerKeyMeta(t *testing.T) {
+	m := NewKeyCreateModel(nil, &db.Server{Name: "test"}, 100, 40)
+
+	m.Update(keyMetaLoadedMsg{meta: &api.KeyMetaInfo{
+		Roles:       []api.KeyRole{api.KeyRoleViewer, api.KeyRoleEditor, "auditor"},
+		Permissions: []api.Permission{api.PermAppsRead},
+	}})
+
+	if got := m.roles[m.roleIndex].role; got != api.KeyRoleEditor {
+		t.Fatalf("expected the Editor default to stay selected, got %s", got)
+	}
+	if last := m.roles[len(m.roles)-1]; last.label != "Auditor" {
+		t.Fatalf("expected an unknown role to get a label, got %q", last.label)
+	}
+	if len(m.perms) != 1 {
+		t.Fatalf("expected the server's permissions, got %v", m.perms)
+	}
+}
+
+func TestKeyCreateHidesExpirationsBeyondServerMaximum(t *testing.T) {
+	m := NewKeyCreateModel(nil, &db.Server{Name: "test"}, 100, 40)
+
+	m.Update(keyMetaLoadedMsg{meta: &api.KeyMetaInfo{MaxExpiration: "90d"}})
+
+	var offered []string
+	for _, preset := range m.presets {
+		offered = append(offered, preset.value)
+	}
+	if strings.Join(offered, ",") != "30d,90d,custom" {
+		t.Fatalf("expected never and 1y to be hidden, got %v", offered)
+	}
+	if got := m.presets[m.expirationIndex].value; got != "90d" {
+		t.Fatalf("expected the 1 year default to clamp to 90d, got %s", got)
+	}
+
+	m.nameInput.SetValue("ci")
+	m.expirationIndex = len(m.presets) - 1
+	m.expirationInput.SetValue("6m")
+	if errMsg := m.form.Validate(); !strings.Contains(errMsg, "maximum key lifetime of 90 days") {
+		t.Fatalf("expected a custom duration over the maximum to be rejected, got %q", errMsg)
+	}
+	if !m.form.IsFocused(keyFocusExpInput) {
+		t.Fatal("expected the rejected field to get the focus")
+	}
+}
+
+func TestKeyCreateSendsPermissionsInFormOrder(t *testing.T) {
+	m := NewKeyCreateModel(nil, &db.Server{Name: "test"}, 100, 40)
+	for i, opt := range m.roles {
+		if opt.role == api.KeyRoleCustom {
+			m.roleIndex = i
+		}
+	}
+	for _, perm := range []api.Permission{api.PermWorkersRead, api.PermAppsInstall, api.PermPluginsRead, api.PermKeysCreate} {
+		m.permissions[perm] = true
+	}
+
+	want := []api.Permission{api.PermPluginsRead, api.PermAppsInstall, api.PermKeysCreate, api.PermWorkersRead}
+	// Map iteration order varies between runs, so check a few times
+	for range 10 {
+		got := m.selectedPermissions()
+		if len(got) != len(want) {
+			t.Fatalf("selectedPermissions() = %v, want %v", got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("selectedPermissions() = %v, want %v", got, want)
+			}
+		}
+	}
+}
+
+func TestKeyCreateWarnsAboutEscalatingPermissions(t *testing.T) {
+	m := NewKeyCreateModel(nil, &db.Server{Name: "test"}, 100, 60)
+	for i, opt := range m.roles {
+		if opt.role == api.KeyRoleCustom {
+			m.roleIndex = i
+		}
+	}
+	m.permissions[api.PermAppsRead] = true
+	if view := m.View(); strings.Contains(view, "⚠") {
+		t.Fatal("expected no warning for a read-only key")
+	}
+
+	m.permissions[api.PermKeysCreate] = true
+	if view := m.View(); !strings.Contains(view, "keys:create can create admin keys") {
+		t.Fatal("expected a warning about keys:create")
+	}
+}
+
+func TestSortPermissionsPutsUnknownOnesLast(t *testing.T) {
+	got := sortPermissions([]api.Permission{"zeta:read", api.PermAppsRead, "alpha:write", api.PermPluginsRead})
+	want := []api.Permission{api.PermPluginsRead, api.PermAppsRead, "alpha:write", "zeta:read"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("sortPermissions() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestKeyCreateGroupsPermissionsByResource(t *testing.T) {
+	m := NewKeyCreateModel(nil, &db.Server{Name: "test"}, 100, 40)
+
+	// The server may list permissions in any order; the form keeps each
+	// resource together
+	m.Update(keyMetaLoadedMsg{meta: &api.KeyMetaInfo{
+		Permissions: []api.Permission{api.PermAppsRead, api.PermKeysRead, api.PermAppsInstall, "audit"},
+	}})
+	want := []api.Permission{api.PermAppsRead, api.PermAppsInstall, api.PermKeysRead, "audit"}
+	for i := range want {
+		if m.perms[i] != want[i] {
+			t.Fatalf("perms = %v, want %v", m.perms, want)
+		}
+	}
+
+	m.permIndex = 1
+	m.toggleGroup()
+	if !m.permissions[api.PermAppsRead] || !m.permissions[api.PermAppsInstall] || m.permissions[api.PermKeysRead] {
+		t.Fatalf("expected only the apps group selected, got %v", m.permissions)
+	}
+	m.toggleGroup()
+	if m.permissions[api.PermAppsRead] || m.permissions[api.PermAppsInstall] {
+		t.Fatalf("expected a fully selected group to be cleared, got %v", m.permissions)
+	}
+}
+
+func TestKeyCreateReadOnlyPreset(t *testing.T) {
+	m := NewKeyCreateModel(nil, &db.Server{Name: "test"}, 100, 40)
+	m.permissions[api.PermKeysCreate] = true
+
+	m.selectReadOnly()
+	for _, perm := range allPermissions {
+		_, action := splitPermission(perm)
+		if m.permissions[perm] != (action == "read") {
+			t.Fatalf("after the read-only preset, %s selected = %v", perm, m.permissions[perm])
+		}
+	}
+}
+
+func TestPermissionCursorFollowsTheGrid(t *testing.T) {
+	// apps: 3 permissions in 2 rows, keys: 2 in 1 row
+	//   apps:read     apps:remove       (0) (2)
+	//   apps:install                    (1)
+	//   keys:read     keys:create       (3) (4)
+	perms := []api.Permission{"apps:read", "apps:install", "apps:remove", "keys:read", "keys:create"}
+
+	cases := []struct {
+		name       string
+		from       int
+		dRow, dCol int
+		want       int
+	}{
+		{"right crosses columns", 0, 0, 1, 2},
+		{"left crosses back", 2, 0, -1, 0},
+		{"right stops at an empty cell", 1, 0, 1, 1},
+		{"left stops at the first column", 3, 0, -1, 3},
+		{"down stays in the column", 0, 1, 0, 1},
+		{"down into an empty cell takes the row's first", 2, 1, 0, 1},
+		{"down continues in the next group", 1, 1, 0, 3},
+		{"up continues in the previous group's column", 4, -1, 0, 1},
+		{"up stops at the top", 2, -1, 0, 2},
+		{"down stops at the bottom", 4, 1, 0, 4},
+	}
+	for _, tc := range cases {
+		if got := movePermCursor(perms, tc.from, tc.dRow, tc.dCol); got != tc.want {
+			t.Errorf("%s: movePermCursor(%d, %d, %d) = %d, want %d", tc.name, tc.from, tc.dRow, tc.dCol, got, tc.want)
+		}
+	}
+}
+
+func TestKeyEditStartsFromTheKey(t *testing.T) {
+	key := &api.ApiKeyInfo{ID: 4, Name: "ci", Role: api.KeyRoleCustom, Permissions: []api.Permission{api.PermAppsRead, api.PermPluginsRead}}
+	m := NewKeyEditModel(api.New("http://localhost", "", false), &db.Server{Name: "test"}, key, 100, 60)
+
+	if m.nameInput.Value() != "ci" || !m.isCustomRole() || !m.permissions[api.PermAppsRead] {
+		t.Fatal("expected the form to be filled in from the key")
+	}
+	if !m.updateInput().Empty() {
+		t.Fatalf("expected no changes before editing, got %+v", m.updateInput())
+	}
+
+	m.nameInput.SetValue("ci-deploy")
+	input := m.updateInput()
+	if input.Name == nil || *input.Name != "ci-deploy" || input.Role != nil || input.Permissions != nil || input.ExpiresAt != nil {
+		t.Fatalf("expected only the name to change, got %+v", input)
+	}
+
+	m.expirationIndex = 1 // 30 days
+	if input := m.updateInput(); input.ExpiresAt == nil {
+		t.Fatal("expected a new expiration")
+	}
+	if !strings.Contains(m.View(), "EDIT API KEY") {
+		t.Fatal("expected the edit title")
+	}
+}
+
+func TestKeyEditShowsServerErrorsInTheForm(t *testing.T) {
+	key := &api.ApiKeyInfo{ID: 4, Name: "ci", Role: api.KeyRoleEditor}
+	m := NewKeyEditModel(api.New("http://localhost", "", false), &db.Server{Name: "test"}, key, 100, 60)
+
+	_, cmd := m.Update(keyUpdatedMsg{name: "ci", err: &api.APIError{Type: api.ErrorTypeServerError, Message: "Request failed (400): Name is already taken", Status: 400}})
+	if cmd != nil {
+		t.Fatal("expected the error to stay in the form, not become a toast")
+	}
+	if !strings.Contains(m.View(), "Name is already taken") {
+		t.Fatal("expected the server's message in the form")
+	}
+}
@@ -0,0 +1,77 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/buntime/cli/internal/api"
+)
+
+// keyExportFormat is how a key's metadata is laid out for the clipboard
+type keyExportFormat int
+
+const (
+	keyExportPlain keyExportFormat = iota
+	keyExportMarkdown
+)
+
+// keyMetadataFields lists what is known about a key, never its secret
+func keyMetadataFields(key *api.ApiKeyInfo) [][2]string {
+	permissions := "role defaults"
+	if len(key.Permissions) > 0 {
+		perms := make([]string, len(key.Permissions))
+		for i, perm := range sortPermissions(key.Permissions) {
+			perms[i] = string(perm)
+		}
+		permissions = strings.Join(perms, ", ")
+	}
+
+	expires := "never"
+	if key.ExpiresAt != nil {
+		expires = formatKeyTime(*key.ExpiresAt)
+	}
+
+	fields := [][2]string{
+		{"Name", key.Name},
+		{"Role", string(key.Role)},
+		{"Permissions", permissions},
+		{"Prefix", key.KeyPrefix + "..."},
+		{"Created", formatKeyTime(key.CreatedAt)},
+		{"Expires", expires},
+	}
+	if key.Description != nil && *key.Description != "" {
+		fields = append(fields, [2]string{"Description", *key.Description})
+	}
+	return fields
+}
+
+// formatKeyTime renders a timestamp in UTC so pasted records read the same
+// in every time zone
+func formatKeyTime(timestamp int64) string {
+	return time.Unix(timestamp, 0).UTC().Format("2006-01-02 15:04 UTC")
+}
+
+// formatKeyMetadata renders a key's metadata for pasting into tickets and
+// docs
+func formatKeyMetadata(key *api.ApiKeyInfo, format keyExportFormat) string {
+	fields := keyMetadataFields(key)
+	var b strings.Builder
+
+	switch format {
+	case keyExportMarkdown:
+		b.WriteString("| Field | Value |\n")
+		b.WriteString("| --- | --- |\n")
+		for _, field := range fields {
+			value := strings.ReplaceAll(field[1], "|", `\|`)
+			value = strings.ReplaceAll(value, "\n", "<br>")
+			fmt.Fprintf(&b, "| %s | %s |\n", field[0], value)
+		}
+	default:
+		for _, field := range fields {
+			fmt.Fprintf(&b, "%-12s %s\n", field[0]+":", field[1])
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,42 @@
+package screens
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/buntime/cli/internal/api"
+)
+
+func TestFormatKeyMetadata(t *testing.T) {
+	expires := int64(1767225600) // 2026-01-01 00:00 UTC
+	description := "CI | nightly"
+	key := &api.ApiKeyInfo{
+		Name:        "ci-deploy",
+		KeyPrefix:   "btk_abc",
+		Role:        api.KeyRoleCustom,
+		Permissions: []api.Permission{api.PermAppsInstall, api.PermAppsRead},
+		CreatedAt:   1735689600, // 2025-01-01 00:00 UTC
+		ExpiresAt:   &expires,
+		Description: &description,
+	}
+
+	plain := formatKeyMetadata(key, keyExportPlain)
+	for _, want := range []string{
+		"Name:        ci-deploy\n",
+		"Permissions: apps:read, apps:install\n",
+		"Prefix:      btk_abc...\n",
+		"Expires:     2026-01-01 00:00 UTC\n",
+	} {
+		if !strings.Contains(plain, want) {
+			t.Errorf("plain export is missing %q:\n%s", want, plain)
+		}
+	}
+
+	markdown := formatKeyMetadata(key, keyExportMarkdown)
+	if !strings.HasPrefix(markdown, "| Field | Value |\n| --- | --- |\n") {
+		t.Errorf("expected a markdown table, got:\n%s", markdown)
+	}
+	if !strings.Contains(markdown, `| Description | CI \| nightly |`) {
+		t.Errorf("expected pipes in values to be escaped, got:\n%s", markdown)
+	}
+}
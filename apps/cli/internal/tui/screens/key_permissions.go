@@ -0,0 +1,161 @@
+package screens
+
+import (
+	"strings"
+
+	"github.com/buntime/cli/internal/api"
+)
+
+// permGroup is the permissions for one resource, e.g. every "apps:" one
+type permGroup struct {
+	name  string
+	perms []api.Permission
+}
+
+// splitPermission returns the resource and action of a permission such as
+// "apps:install". Permissions without a resource go in an "other" group.
+func splitPermission(perm api.Permission) (string, string) {
+	if group, action, ok := strings.Cut(string(perm), ":"); ok {
+		return group, action
+	}
+	return "other", string(perm)
+}
+
+// groupPermissions splits permissions by resource, keeping the order in which
+// each resource first appears
+func groupPermissions(perms []api.Permission) []permGroup {
+	var groups []permGroup
+	index := map[string]int{}
+	for _, perm := range perms {
+		name, _ := splitPermission(perm)
+		i, ok := index[name]
+		if !ok {
+			i = len(groups)
+			index[name] = i
+			groups = append(groups, permGroup{name: name})
+		}
+		groups[i].perms = append(groups[i].perms, perm)
+	}
+	return groups
+}
+
+// orderByGroup lists permissions group by group, so moving the cursor
+// through them never jumps between groups
+func orderByGroup(perms []api.Permission) []api.Permission {
+	ordered := make([]api.Permission, 0, len(perms))
+	for _, group := range groupPermissions(perms) {
+		ordered = append(ordered, group.perms...)
+	}
+	return ordered
+}
+
+// permGridColumns is how many columns each group's permissions are drawn in.
+// A group fills its first column top to bottom before the next.
+const permGridColumns = 2
+
+// permGridGroup is where a group sits in the ordered permissions
+type permGridGroup struct {
+	start, count int
+}
+
+func (g permGridGroup) rows() int {
+	return (g.count + permGridColumns - 1) / permGridColumns
+}
+
+// at returns the index drawn at row and col, if that cell isn't empty
+func (g permGridGroup) at(row, col int) (int, bool) {
+	if row < 0 || row >= g.rows() || col < 0 || col >= permGridColumns {
+		return 0, false
+	}
+	idx := g.start + row + col*g.rows()
+	return idx, idx < g.start+g.count
+}
+
+// nearest returns the index at row and col, or the row's first one when that
+// cell is empty
+func (g permGridGroup) nearest(row, col int) int {
+	if idx, ok := g.at(row, col); ok {
+		return idx
+	}
+	idx, _ := g.at(row, 0)
+	return idx
+}
+
+// movePermCursor moves the cursor from index by a row or a column as the
+// grid is drawn. Moving up or down past a group continues in the same column
+// of the neighbouring group; moving sideways stays in the row.
+func movePermCursor(perms []api.Permission, index, dRow, dCol int) int {
+	var groups []permGridGroup
+	start := 0
+	for _, group := range groupPermissions(perms) {
+		groups = append(groups, permGridGroup{start: start, count: len(group.perms)})
+		start += len(group.perms)
+	}
+
+	for i, g := range groups {
+		if index < g.start || index >= g.start+g.count {
+			continue
+		}
+		rows := g.rows()
+		row, col := (index-g.start)%rows, (index-g.start)/rows
+
+		if dCol != 0 {
+			if idx, ok := g.at(row, col+dCol); ok {
+				return idx
+			}
+			return index
+		}
+
+		next := row + dRow
+		switch {
+		case next >= 0 && next < rows:
+			return g.nearest(next, col)
+		case next < 0 && i > 0:
+			prev := groups[i-1]
+			return prev.nearest(prev.rows()-1, col)
+		case next >= rows && i+1 < len(groups):
+			return groups[i+1].nearest(0, col)
+		}
+		return index
+	}
+	return index
+}
+
+// groupTitle is how a group is headed in the form, e.g. "Apps"
+func groupTitle(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// toggleGroup selects every permission in the cursor's group, or clears them
+// all when they are already selected
+func (m *KeyCreateModel) toggleGroup() {
+	if m.permIndex >= len(m.perms) {
+		return
+	}
+	name, _ := splitPermission(m.perms[m.permIndex])
+	var group []api.Permission
+	all := true
+	for _, perm := range m.perms {
+		if g, _ := splitPermission(perm); g == name {
+			group = append(group, perm)
+			all = all && m.permissions[perm]
+		}
+	}
+	for _, perm := range group {
+		m.permissions[perm] = !all
+	}
+}
+
+// selectReadOnly replaces the selection with every read permission, a
+// starting point for least-privilege keys
+func (m *KeyCreateModel) selectReadOnly() {
+	m.permissions = make(map[api.Permission]bool)
+	for _, perm := range m.perms {
+		if _, action := splitPermission(perm); action == "read" {
+			m.permissions[perm] = true
+		}
+	}
+}
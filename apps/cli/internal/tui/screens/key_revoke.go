@@ -5,6 +5,8 @@ import (
 
 	"github.com/buntime/cli/internal/api"
 	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/i18n"
+	"github.com/buntime/cli/internal/tui/components"
 	"github.com/buntime/cli/internal/tui/layout"
 	"github.com/buntime/cli/internal/tui/messages"
 	"github.com/buntime/cli/internal/tui/styles"
@@ -20,28 +22,28 @@ type KeyRevokeModel struct {
 	width  int
 	height int
 
-	confirmInput textinput.Model
+	confirmInput *components.ConfirmInput
+	simple       bool // y/n confirmation instead of typing the key name
+	sessionKey   bool // The key this session is connected with
 	loading      bool
 	err          error
 }
 
 // NewKeyRevokeModel creates a new key revocation screen
-func NewKeyRevokeModel(client *api.Client, server *db.Server, key *api.ApiKeyInfo, width, height int) *KeyRevokeModel {
-	ti := textinput.New()
-	ti.Placeholder = key.Name
-	ti.Prompt = ""
-	ti.Focus()
-	ti.CharLimit = 64
-	ti.Width = 40
-
-	return &KeyRevokeModel{
+func NewKeyRevokeModel(client *api.Client, database *db.DB, server *db.Server, key *api.ApiKeyInfo, width, height int) *KeyRevokeModel {
+	sessionKey := isSessionKey(server, key)
+	m := &KeyRevokeModel{
 		api:          client,
 		server:       server,
 		key:          key,
 		width:        width,
 		height:       height,
-		confirmInput: ti,
+		confirmInput: components.NewConfirmInput(key.Name),
+		sessionKey:   sessionKey,
+		// Revoking a key cuts off its users immediately, so it is always high-risk
+		simple: !sessionKey && !loadConfirmPolicy(database).requiresTyping(true),
 	}
+	return m
 }
 
 func (m *KeyRevokeModel) Init() tea.Cmd {
@@ -71,26 +73,44 @@ func (m *KeyRevokeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			},
 		)
 
+	case KeySwitchedMsg:
+		if permissionDenied(m.err) {
+			return m, m.revokeKey()
+		}
+		return m, nil
+
 	case tea.KeyMsg:
 		if m.loading {
 			return m, nil
 		}
-		switch msg.String() {
-		case "esc":
+		if msg.String() == switchKeyKey && permissionDenied(m.err) {
+			return m, switchKey(m.api, m.server)
+		}
+		if msg.String() == "esc" {
 			// Navigate back to keys list, replacing history
 			return m, func() tea.Msg {
 				return NavigateMsg{Screen: ScreenKeys, Data: nil, ReplaceHistory: true}
 			}
-		case "enter":
-			if strings.TrimSpace(m.confirmInput.Value()) == m.key.Name {
+		}
+		if m.simple {
+			switch msg.String() {
+			case "y", "Y", "enter":
 				return m, m.revokeKey()
+			case "n", "N":
+				return m, func() tea.Msg {
+					return NavigateMsg{Screen: ScreenKeys, Data: nil, ReplaceHistory: true}
+				}
 			}
+			return m, nil
+		}
+		submitted, cmd := m.confirmInput.Update(msg)
+		if submitted {
+			return m, m.revokeKey()
 		}
+		return m, cmd
 	}
 
-	var cmd tea.Cmd
-	m.confirmInput, cmd = m.confirmInput.Update(msg)
-	return m, cmd
+	return m, nil
 }
 
 func (m *KeyRevokeModel) revokeKey() tea.Cmd {
@@ -103,6 +123,11 @@ func (m *KeyRevokeModel) revokeKey() tea.Cmd {
 	}
 }
 
+// Typing reports whether the key name has to be typed to confirm
+func (m *KeyRevokeModel) Typing() bool {
+	return !m.simple && !m.loading
+}
+
 func (m *KeyRevokeModel) View() string {
 	innerWidth := layout.InnerWidth(m.width)
 
@@ -122,35 +147,56 @@ func (m *KeyRevokeModel) renderContent(width int) string {
 
 	// Error
 	if m.err != nil {
-		b.WriteString(styles.TextError.Render("Error: "+m.err.Error()) + "\n\n")
+		b.WriteString(styles.TextError.Render("Error: "+m.err.Error()) + "\n")
+		if permissionDenied(m.err) {
+			b.WriteString(renderSwitchKeyHint())
+		}
+		b.WriteString("\n")
+	}
+
+	dangerText := i18n.T("key_revoke.danger")
+	if m.sessionKey {
+		dangerText = i18n.T("key_revoke.session_danger")
 	}
 
 	b.WriteString(layout.ConfirmModal(layout.ConfirmModalConfig{
 		Width:      width - 4,
-		Warning:    "You are about to delete the following key:",
-		DangerText: "Any systems using this key will lose access immediately.",
+		Warning:    i18n.T("key_revoke.warning"),
+		DangerText: dangerText,
 		Items: []layout.ConfirmModalItem{
 			{Label: "Name", Value: m.key.Name},
 			{Label: "Role", Value: string(m.key.Role)},
 			{Label: "Prefix", Value: m.key.KeyPrefix + "..."},
 		},
-		ConfirmWord: m.key.Name,
-		InputView:   m.confirmInput.View(),
+		Input:  m.confirmInput,
+		Simple: m.simple,
 	}))
 	b.WriteString("\n\n")
 
 	if m.loading {
-		b.WriteString(styles.TextMuted.Render("Deleting key...") + "\n")
+		b.WriteString(styles.TextMuted.Render(i18n.T("key_revoke.deleting")) + "\n")
 	} else {
-		b.WriteString(styles.TextMuted.Render("Press Enter to confirm, Esc to cancel") + "\n")
+		b.WriteString(styles.TextMuted.Render(i18n.T("confirm.enter_or_esc")) + "\n")
 	}
 
 	return b.String()
 }
 
 func (m *KeyRevokeModel) getShortcuts() []string {
-	return []string{
-		styles.RenderShortcut("⏎", "confirm"),
-		styles.RenderShortcut("Esc", "cancel"),
+	if m.loading {
+		return []string{}
+	}
+	var shortcuts []string
+	if m.simple {
+		shortcuts = []string{
+			styles.RenderShortcut("y/⏎", i18n.T("shortcut.confirm")),
+			styles.RenderShortcut("n/Esc", i18n.T("shortcut.cancel")),
+		}
+	} else {
+		shortcuts = confirmInputShortcuts(m.confirmInput)
+	}
+	if permissionDenied(m.err) {
+		shortcuts = append([]string{switchKeyShortcut()}, shortcuts...)
 	}
+	return shortcuts
 }
@@ -0,0 +1,222 @@
+package screens
+
+import (
+	"strings"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/i18n"
+	"github.com/buntime/cli/internal/tui/components"
+	"github.com/buntime/cli/internal/tui/layout"
+	"github.com/buntime/cli/internal/tui/styles"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// KeyRotateModel confirms issuing a new secret for a key, then shows it the
+// way a newly created key is shown
+type KeyRotateModel struct {
+	api    *api.Client
+	db     *db.DB
+	server *db.Server
+	key    *api.ApiKeyInfo
+	width  int
+	height int
+
+	confirmInput *components.ConfirmInput
+	simple       bool // y/n confirmation instead of typing the key name
+	sessionKey   bool // The key this session is connected with
+	loading      bool
+	err          error
+
+	// The new secret, shown by the key form's success screen
+	rotated *KeyCreateModel
+}
+
+// NewKeyRotateModel creates a key rotation screen
+func NewKeyRotateModel(client *api.Client, database *db.DB, server *db.Server, key *api.ApiKeyInfo, width, height int) *KeyRotateModel {
+	return &KeyRotateModel{
+		api:          client,
+		db:           database,
+		server:       server,
+		key:          key,
+		width:        width,
+		height:       height,
+		confirmInput: components.NewConfirmInput(key.Name),
+		sessionKey:   isSessionKey(server, key),
+		// The old secret stops working at once, so it is high-risk like revoking
+		simple: !loadConfirmPolicy(database).requiresTyping(true),
+	}
+}
+
+type keyRotatedMsg struct {
+	result *api.CreateKeyResult
+	err    error
+}
+
+func (m *KeyRotateModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m *KeyRotateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.rotated != nil {
+		_, cmd := m.rotated.Update(msg)
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case keyRotatedMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		if m.sessionKey {
+			m.adoptSecret(msg.result.Key)
+		}
+		m.rotated = NewKeyCreateModel(m.api, m.server, m.width, m.height)
+		m.rotated.result = msg.result
+		m.rotated.rotated = true
+		return m, nil
+
+	case KeySwitchedMsg:
+		if permissionDenied(m.err) {
+			return m, m.rotateKey()
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.loading {
+			return m, nil
+		}
+		if msg.String() == switchKeyKey && permissionDenied(m.err) {
+			return m, switchKey(m.api, m.server)
+		}
+		if msg.String() == "esc" {
+			return m, goBack()
+		}
+		if m.simple {
+			switch msg.String() {
+			case "y", "Y", "enter":
+				return m, m.rotateKey()
+			case "n", "N":
+				return m, goBack()
+			}
+			return m, nil
+		}
+		submitted, cmd := m.confirmInput.Update(msg)
+		if submitted {
+			return m, m.rotateKey()
+		}
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m *KeyRotateModel) rotateKey() tea.Cmd {
+	m.loading = true
+	m.err = nil
+
+	return func() tea.Msg {
+		result, err := m.api.RotateKey(m.key.ID)
+		return keyRotatedMsg{result: result, err: err}
+	}
+}
+
+// adoptSecret switches this session, and the saved server when it keeps the
+// key, to the new secret of the session's own key so the CLI stays connected
+func (m *KeyRotateModel) adoptSecret(secret string) {
+	m.api.SetToken(secret)
+	if m.db != nil && m.server.Token != nil {
+		m.db.UpdateServerToken(m.server.ID, secret)
+	}
+	m.server.Token = &secret
+}
+
+// Typing reports whether the key name has to be typed to confirm
+func (m *KeyRotateModel) Typing() bool {
+	return !m.simple && !m.loading
+}
+
+func (m *KeyRotateModel) View() string {
+	if m.rotated != nil {
+		return m.rotated.View()
+	}
+
+	innerWidth := layout.InnerWidth(m.width)
+
+	return layout.Page(layout.PageConfig{
+		Width:      m.width,
+		Height:     m.height,
+		Server:     m.server,
+		Breadcrumb: "Main › API Keys › Rotate",
+		Title:      "ROTATE API KEY",
+		Content:    m.renderContent(innerWidth),
+		Shortcuts:  m.getShortcuts(),
+	})
+}
+
+func (m *KeyRotateModel) renderContent(width int) string {
+	var b strings.Builder
+
+	if m.err != nil {
+		b.WriteString(styles.TextError.Render("Error: "+m.err.Error()) + "\n")
+		if permissionDenied(m.err) {
+			b.WriteString(renderSwitchKeyHint())
+		}
+		b.WriteString("\n")
+	}
+
+	dangerText := i18n.T("key_rotate.danger")
+	if m.sessionKey {
+		dangerText = i18n.T("key_rotate.session_note")
+	}
+
+	b.WriteString(layout.ConfirmModal(layout.ConfirmModalConfig{
+		Width:      width - 4,
+		Title:      i18n.T("key_rotate.title"),
+		Warning:    i18n.T("key_rotate.warning"),
+		DangerText: dangerText,
+		Items: []layout.ConfirmModalItem{
+			{Label: "Name", Value: m.key.Name},
+			{Label: "Role", Value: string(m.key.Role)},
+			{Label: "Prefix", Value: m.key.KeyPrefix + "..."},
+		},
+		Input:  m.confirmInput,
+		Simple: m.simple,
+	}))
+	b.WriteString("\n\n")
+
+	if m.loading {
+		b.WriteString(styles.TextMuted.Render(i18n.T("key_rotate.rotating")) + "\n")
+	} else {
+		b.WriteString(styles.TextMuted.Render(i18n.T("confirm.enter_or_esc")) + "\n")
+	}
+
+	return b.String()
+}
+
+func (m *KeyRotateModel) getShortcuts() []string {
+	if m.loading {
+		return []string{}
+	}
+	var shortcuts []string
+	if m.simple {
+		shortcuts = []string{
+			styles.RenderShortcut("y/⏎", i18n.T("shortcut.confirm")),
+			styles.RenderShortcut("n/Esc", i18n.T("shortcut.cancel")),
+		}
+	} else {
+		shortcuts = confirmInputShortcuts(m.confirmInput)
+	}
+	if permissionDenied(m.err) {
+		shortcuts = append([]string{switchKeyShortcut()}, shortcuts...)
+	}
+	return shortcuts
+}
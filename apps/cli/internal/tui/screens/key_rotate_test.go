@@ -0,0 +1,44 @@
+package screens
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/db"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestKeyRotateShowsTheNewSecret(t *testing.T) {
+	key := &api.ApiKeyInfo{ID: 7, Name: "ci", Role: api.KeyRoleEditor, KeyPrefix: "btk_ol"}
+	m := NewKeyRotateModel(nil, nil, &db.Server{Name: "test"}, key, 100, 40)
+	if m.simple {
+		t.Fatal("expected rotating to need the key name typed by default")
+	}
+
+	m.Update(keyRotatedMsg{result: &api.CreateKeyResult{ID: 7, Name: "ci", Key: "btk_new_secret", Role: api.KeyRoleEditor}})
+	view := m.View()
+	if !strings.Contains(view, "API KEY ROTATED") || !strings.Contains(view, "btk_new_secret") {
+		t.Fatal("expected the new secret on the success screen")
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if nav, ok := cmd().(NavigateMsg); !ok || nav.Screen != ScreenKeys {
+		t.Fatalf("expected Enter to return to the key list, got %#v", nav)
+	}
+}
+
+func TestKeyRotateKeepsTheSessionConnected(t *testing.T) {
+	old := "btk_old_secret"
+	server := &db.Server{Name: "test", Token: &old}
+	key := &api.ApiKeyInfo{ID: 7, Name: "ci", KeyPrefix: "btk_old"}
+	m := NewKeyRotateModel(api.New("http://localhost", old, false), nil, server, key, 100, 40)
+	if !m.sessionKey {
+		t.Fatal("expected the session's own key to be recognized")
+	}
+
+	m.Update(keyRotatedMsg{result: &api.CreateKeyResult{ID: 7, Name: "ci", Key: "btk_new_secret"}})
+	if server.Token == nil || *server.Token != "btk_new_secret" {
+		t.Fatal("expected the server to switch to the new secret")
+	}
+}
@@ -5,9 +5,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/atotto/clipboard"
 	"github.com/buntime/cli/internal/api"
 	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/i18n"
+	"github.com/buntime/cli/internal/tui/components"
 	"github.com/buntime/cli/internal/tui/layout"
+	"github.com/buntime/cli/internal/tui/messages"
 	"github.com/buntime/cli/internal/tui/styles"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -18,10 +22,12 @@ type KeysModel struct {
 	server  *db.Server
 	keys    []api.ApiKeyInfo
 	cursor  int
+	offset  int // First visible row
 	width   int
 	height  int
 	loading bool
 	err     error
+	pager   listPager
 }
 
 // NewKeysModel creates an API keys list screen
@@ -39,18 +45,23 @@ func (m *KeysModel) Init() tea.Cmd {
 	return m.loadKeys()
 }
 
+// loadKeys starts the list over from the first page
 func (m *KeysModel) loadKeys() tea.Cmd {
+	m.pager.reset()
+	return m.loadKeysPage(firstPage())
+}
+
+func (m *KeysModel) loadKeysPage(opts api.ListOptions) tea.Cmd {
 	return func() tea.Msg {
-		keys, err := m.api.ListKeys()
-		if err != nil {
-			return keysLoadedMsg{err: err}
-		}
-		return keysLoadedMsg{keys: keys}
+		keys, page, err := m.api.ListKeysPage(opts)
+		return keysLoadedMsg{keys: keys, page: page, opts: opts, err: err}
 	}
 }
 
 type keysLoadedMsg struct {
 	keys []api.ApiKeyInfo
+	page *api.PageInfo
+	opts api.ListOptions // The options the page was requested with
 	err  error
 }
 
@@ -62,12 +73,22 @@ func (m *KeysModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case keysLoadedMsg:
+		if canceled(msg.err) {
+			return m, nil
+		}
 		m.loading = false
+		m.pager.loading = false
 		if msg.err != nil {
 			m.err = msg.err
 			return m, nil
 		}
-		m.keys = msg.keys
+		if continues(msg.opts) {
+			m.keys = append(m.keys, msg.keys...)
+		} else {
+			m.keys = msg.keys
+		}
+		m.pager.page = msg.page
+		m.err = nil
 		return m, nil
 
 	case keyRevokedMsg:
@@ -92,15 +113,43 @@ func (m *KeysModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, func() tea.Msg {
 				return NavigateMsg{Screen: ScreenKeyCreate, Data: nil}
 			}
+		case "e":
+			if len(m.keys) > 0 && m.cursor < len(m.keys) {
+				return m, func() tea.Msg {
+					return NavigateMsg{Screen: ScreenKeyEdit, Data: &m.keys[m.cursor]}
+				}
+			}
+		case "o":
+			if len(m.keys) > 0 && m.cursor < len(m.keys) {
+				return m, func() tea.Msg {
+					return NavigateMsg{Screen: ScreenKeyRotate, Data: &m.keys[m.cursor]}
+				}
+			}
 		case "d":
 			if len(m.keys) > 0 && m.cursor < len(m.keys) {
 				return m, func() tea.Msg {
 					return NavigateMsg{Screen: ScreenKeyRevoke, Data: &m.keys[m.cursor]}
 				}
 			}
+		case "c":
+			return m, m.copyKeyMetadata(keyExportPlain)
+		case "C":
+			return m, m.copyKeyMetadata(keyExportMarkdown)
+		case switchKeyKey:
+			if permissionDenied(m.err) && !m.loading {
+				return m, switchKey(m.api, m.server)
+			}
 		case "r":
+			// A reload is already running
+			if m.loading {
+				return m, nil
+			}
 			m.loading = true
 			return m, m.loadKeys()
+		case "m":
+			if m.pager.more() && !m.loading && !m.pager.loading {
+				return m, m.loadKeysPage(m.pager.next())
+			}
 		case "esc":
 			return m, goBack()
 		}
@@ -109,6 +158,23 @@ func (m *KeysModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// copyKeyMetadata copies the selected key's details, never its secret, to the
+// clipboard
+func (m *KeysModel) copyKeyMetadata(format keyExportFormat) tea.Cmd {
+	if m.cursor >= len(m.keys) {
+		return nil
+	}
+	key := &m.keys[m.cursor]
+	if err := clipboard.WriteAll(formatKeyMetadata(key, format)); err != nil {
+		return func() tea.Msg {
+			return messages.ShowWarning("Could not copy to the clipboard: " + err.Error())
+		}
+	}
+	return func() tea.Msg {
+		return messages.ShowSuccess("Copied the details of " + key.Name)
+	}
+}
+
 type keyRevokedMsg struct {
 	err error
 }
@@ -118,14 +184,23 @@ func (m *KeysModel) View() string {
 
 	titleText := "API KEYS"
 	if !m.loading {
-		titleText += fmt.Sprintf(" (%d)", len(m.keys))
+		titleText += fmt.Sprintf(" (%d", len(m.keys))
+		if m.pager.more() {
+			titleText += "+"
+		}
+		titleText += ")"
 	}
 
 	var content strings.Builder
 	if m.loading {
-		content.WriteString(styles.TextMuted.Render("Loading...") + "\n")
+		header, widths := m.columns()
+		content.WriteString(layout.ListHeader(header, innerWidth-2))
+		content.WriteString(components.SkeletonRows(components.SkeletonRowCount, widths))
 	} else if m.err != nil {
 		content.WriteString(styles.TextError.Render("Error: "+m.err.Error()) + "\n")
+		if permissionDenied(m.err) {
+			content.WriteString(renderSwitchKeyHint())
+		}
 	} else if len(m.keys) == 0 {
 		content.WriteString(m.renderEmptyState(innerWidth))
 	} else {
@@ -143,27 +218,57 @@ func (m *KeysModel) View() string {
 	})
 }
 
-func (m *KeysModel) renderKeyList(width int) string {
-	var b strings.Builder
-
+// columns returns the list's header line and column widths, shared by the
+// rows and the loading skeleton
+func (m *KeysModel) columns() (string, []int) {
 	// Column widths (adjusted to fit better)
 	nameWidth := 20
 	roleWidth := 10
-	prefixWidth := 20
+	prefixWidth := 16
 	lastUsedWidth := 12
+	expiresWidth := 12
 
-	// Header
-	headerLine := fmt.Sprintf("  %-*s %-*s %-*s %-*s",
+	header := fmt.Sprintf("  %-*s %-*s %-*s %-*s %-*s",
 		nameWidth, "NAME",
 		roleWidth, "ROLE",
 		prefixWidth, "PREFIX",
 		lastUsedWidth, "LAST USED",
+		expiresWidth, "EXPIRES",
 	)
-	b.WriteString(styles.TextMuted.Render(headerLine) + "\n")
-	b.WriteString(styles.TextMuted.Render(strings.Repeat("─", width-2)) + "\n")
+	return header, []int{nameWidth, roleWidth, prefixWidth, lastUsedWidth, expiresWidth}
+}
+
+func (m *KeysModel) renderKeyList(width int) string {
+	var b strings.Builder
+
+	headerLine, widths := m.columns()
+	nameWidth, roleWidth, prefixWidth, lastUsedWidth, expiresWidth := widths[0], widths[1], widths[2], widths[3], widths[4]
+
+	// Relative times use the server's clock so a skewed local clock doesn't
+	// shift them
+	now := m.api.ServerNow()
+	if m.api.ClockSkewed() {
+		skew, _ := m.api.ClockSkew()
+		warning := "Warning: " + api.DescribeClockSkew(skew) + "; times below use the server's clock."
+		b.WriteString(styles.TextWarning.Render(styles.Truncate(warning, width)) + "\n\n")
+	}
+
+	reserved := strings.Count(b.String(), "\n") // Clock warning
+	b.WriteString(layout.ListHeader(headerLine, width-2))
+
+	legend := m.pager.footer(len(m.keys))
+	if m.hasSessionKey() {
+		if !layout.Compact() {
+			legend += "\n"
+		}
+		legend += styles.TextMuted.Render(sessionKeyIndicator+" key this session is connected with") + "\n"
+	}
+	rows := layout.ListRows(m.height, reserved+strings.Count(legend, "\n"))
+	m.offset = layout.ScrollOffset(m.offset, m.cursor, len(m.keys), rows)
 
 	// Rows
-	for i, key := range m.keys {
+	for i := m.offset; i < min(len(m.keys), m.offset+rows); i++ {
+		key := m.keys[i]
 		cursor := "  "
 		if i == m.cursor {
 			cursor = styles.Caret
@@ -171,17 +276,21 @@ func (m *KeysModel) renderKeyList(width int) string {
 
 		lastUsed := "never"
 		if key.LastUsedAt != nil {
-			lastUsed = formatTimeAgo(*key.LastUsedAt)
+			lastUsed = formatTimeAgo(*key.LastUsedAt, now)
 		}
 
 		name := truncateKey(key.Name, nameWidth)
+		if isSessionKey(m.server, &key) {
+			name = truncateKey(key.Name, nameWidth-2) + " " + sessionKeyIndicator
+		}
 		prefix := truncateKey(key.KeyPrefix+"...", prefixWidth)
 
-		line := fmt.Sprintf("%-*s %-*s %-*s %-*s",
+		line := fmt.Sprintf("%-*s %-*s %-*s %-*s %-*s",
 			nameWidth, name,
 			roleWidth, string(key.Role),
 			prefixWidth, prefix,
 			lastUsedWidth, lastUsed,
+			expiresWidth, formatExpiry(key.ExpiresAt, now),
 		)
 
 		if i == m.cursor {
@@ -191,9 +300,22 @@ func (m *KeysModel) renderKeyList(width int) string {
 		b.WriteString(cursor + line + "\n")
 	}
 
+	b.WriteString(legend)
 	return b.String()
 }
 
+// sessionKeyIndicator marks the key the CLI is connected with
+const sessionKeyIndicator = "●"
+
+func (m *KeysModel) hasSessionKey() bool {
+	for i := range m.keys {
+		if isSessionKey(m.server, &m.keys[i]) {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *KeysModel) renderEmptyState(width int) string {
 	var b strings.Builder
 
@@ -206,25 +328,37 @@ func (m *KeysModel) renderEmptyState(width int) string {
 
 func (m *KeysModel) getShortcuts() []string {
 	shortcuts := []string{
-		styles.RenderShortcut("↑↓", "navigate"),
-		styles.RenderShortcut("a", "add"),
+		styles.RenderShortcut("↑↓", i18n.T("shortcut.navigate")),
+		styles.RenderShortcut("a", i18n.T("shortcut.add")),
 	}
 
 	if len(m.keys) > 0 {
-		shortcuts = append(shortcuts, styles.RenderShortcut("d", "delete"))
+		shortcuts = append(shortcuts,
+			styles.RenderShortcut("e", i18n.T("shortcut.edit")),
+			styles.RenderShortcut("o", i18n.T("shortcut.rotate")),
+			styles.RenderShortcut("d", i18n.T("shortcut.delete")),
+			styles.RenderShortcut("c", i18n.T("shortcut.copy_details")),
+			styles.RenderShortcut("C", i18n.T("shortcut.copy_markdown")),
+		)
 	}
 
-	shortcuts = append(shortcuts,
-		styles.RenderShortcut("r", "refresh"),
-		styles.RenderShortcut("Esc", "back"),
-	)
+	if m.pager.more() {
+		shortcuts = append(shortcuts, styles.RenderShortcut("m", i18n.T("shortcut.more")))
+	}
+
+	shortcuts = append(shortcuts, styles.RenderShortcut("r", i18n.T("shortcut.refresh")))
+
+	if permissionDenied(m.err) {
+		shortcuts = append(shortcuts, switchKeyShortcut())
+	}
+	shortcuts = append(shortcuts, styles.RenderShortcut("Esc", i18n.T("shortcut.back")))
 
 	return shortcuts
 }
 
-func formatTimeAgo(timestamp int64) string {
+func formatTimeAgo(timestamp int64, now time.Time) string {
 	t := time.Unix(timestamp, 0)
-	diff := time.Since(t)
+	diff := now.Sub(t)
 
 	if diff < time.Minute {
 		return "just now"
@@ -260,3 +394,35 @@ func truncateKey(s string, max int) string {
 	}
 	return s[:max-3] + "..."
 }
+
+// formatExpiry describes when a key expires relative to now
+func formatExpiry(expiresAt *int64, now time.Time) string {
+	if expiresAt == nil {
+		return "never"
+	}
+	t := time.Unix(*expiresAt, 0)
+	left := t.Sub(now)
+
+	if left <= 0 {
+		return "expired"
+	}
+	if left < 24*time.Hour {
+		hours := int(left.Hours())
+		if hours == 0 {
+			return "in <1 hour"
+		}
+		if hours == 1 {
+			return "in 1 hour"
+		}
+		return fmt.Sprintf("in %d hours", hours)
+	}
+	if left < 60*24*time.Hour {
+		days := int(left.Hours() / 24)
+		if days == 1 {
+			return "in 1 day"
+		}
+		return fmt.Sprintf("in %d days", days)
+	}
+
+	return t.Format("2006-01-02")
+}
@@ -0,0 +1,101 @@
+package screens
+
+import (
+	"strings"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/i18n"
+	"github.com/buntime/cli/internal/tui/layout"
+	"github.com/buntime/cli/internal/tui/styles"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// labelFilter is the label selector shared by the apps and plugins lists
+type labelFilter struct {
+	input    textinput.Model
+	editing  bool
+	err      string
+	selector api.Selector
+}
+
+func newLabelFilter(width int) labelFilter {
+	input := textinput.New()
+	input.Placeholder = "e.g., env=prod,tier!=web"
+	input.Prompt = ""
+	input.CharLimit = 128
+
+	f := labelFilter{input: input}
+	f.resize(width)
+	return f
+}
+
+func (f *labelFilter) resize(width int) {
+	f.input.Width = layout.InputWidth(width, 40)
+}
+
+// active reports whether a selector is narrowing the list
+func (f *labelFilter) active() bool {
+	return !f.selector.Empty()
+}
+
+func (f *labelFilter) edit() tea.Cmd {
+	f.editing = true
+	f.err = ""
+	f.input.SetValue(f.selector.String())
+	f.input.CursorEnd()
+	f.input.Focus()
+	return textinput.Blink
+}
+
+func (f *labelFilter) clear() {
+	f.selector = api.Selector{}
+	f.input.SetValue("")
+}
+
+// update handles a key while the selector is being edited. applied is true
+// when the selector changed and the list has to be reloaded.
+func (f *labelFilter) update(msg tea.KeyMsg) (applied bool, cmd tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		f.editing = false
+		f.input.Blur()
+		return false, nil
+	case "enter":
+		sel, err := api.ParseSelector(f.input.Value())
+		if err != nil {
+			f.err = err.Error()
+			return false, nil
+		}
+		f.editing = false
+		f.input.Blur()
+		changed := sel.String() != f.selector.String()
+		f.selector = sel
+		return changed, nil
+	}
+
+	f.err = ""
+	f.input, cmd = f.input.Update(msg)
+	return false, cmd
+}
+
+func (f *labelFilter) view() string {
+	var b strings.Builder
+
+	b.WriteString(styles.TextMuted.Render("Filter by label selector:") + "\n")
+	b.WriteString(styles.RenderInput(f.input.View(), true, f.err != "") + "\n")
+	if f.err != "" {
+		b.WriteString(styles.TextError.Render("Error: "+f.err) + "\n")
+	}
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// shortcuts returns the shortcuts for the selector prompt
+func (f *labelFilter) shortcuts() []string {
+	return []string{
+		styles.RenderShortcut("Enter", i18n.T("shortcut.apply")),
+		styles.RenderShortcut("Esc", i18n.T("shortcut.cancel")),
+	}
+}
@@ -0,0 +1,127 @@
+package screens
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/buntime/cli/internal/i18n"
+	"github.com/buntime/cli/internal/tui/layout"
+	"github.com/buntime/cli/internal/tui/styles"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// nameFilter narrows a loaded list as you type, by a case-insensitive
+// substring of each item's name. Shared by the apps and plugins lists.
+type nameFilter struct {
+	input   textinput.Model
+	editing bool
+}
+
+func newNameFilter(width int) nameFilter {
+	input := textinput.New()
+	input.Placeholder = "Type to filter..."
+	input.Prompt = ""
+	input.CharLimit = 64
+
+	f := nameFilter{input: input}
+	f.resize(width)
+	return f
+}
+
+func (f *nameFilter) resize(width int) {
+	f.input.Width = layout.InputWidth(width, 40)
+}
+
+func (f *nameFilter) query() string {
+	return strings.ToLower(strings.TrimSpace(f.input.Value()))
+}
+
+// active reports whether the filter is narrowing the list
+func (f *nameFilter) active() bool {
+	return f.query() != ""
+}
+
+func (f *nameFilter) matches(name string) bool {
+	return strings.Contains(strings.ToLower(name), f.query())
+}
+
+func (f *nameFilter) edit() tea.Cmd {
+	f.editing = true
+	f.input.CursorEnd()
+	f.input.Focus()
+	return textinput.Blink
+}
+
+func (f *nameFilter) clear() {
+	f.input.SetValue("")
+}
+
+// update handles a key while the filter is being typed. changed is true when
+// the query changed and the list has to be narrowed again.
+func (f *nameFilter) update(msg tea.KeyMsg) (changed bool, cmd tea.Cmd) {
+	before := f.query()
+	switch msg.String() {
+	case "enter":
+		f.editing = false
+		f.input.Blur()
+		return false, nil
+	case "esc":
+		f.editing = false
+		f.input.Blur()
+		f.clear()
+		return before != "", nil
+	}
+
+	f.input, cmd = f.input.Update(msg)
+	return f.query() != before, cmd
+}
+
+func (f *nameFilter) view() string {
+	var b strings.Builder
+
+	b.WriteString(styles.TextMuted.Render("Filter by name:") + "\n")
+	b.WriteString(styles.RenderInput(f.input.View(), true, false) + "\n")
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// shortcuts returns the shortcuts for the filter prompt
+func (f *nameFilter) shortcuts() []string {
+	return []string{
+		styles.RenderShortcut("Enter", i18n.T("shortcut.apply")),
+		styles.RenderShortcut("Esc", i18n.T("shortcut.clear_filter")),
+	}
+}
+
+// listSort is one order a list can be shown in. A nil compare keeps the
+// order the server returned.
+type listSort[T any] struct {
+	name    string
+	compare func(a, b T) int
+}
+
+// sortList orders items in place. Equal items keep the server's order.
+func sortList[T any](items []T, order listSort[T]) {
+	if order.compare != nil {
+		slices.SortStableFunc(items, order.compare)
+	}
+}
+
+// compareNames orders names alphabetically, ignoring case
+func compareNames(a, b string) int {
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// cursorOn returns the row of the item named selected, so the highlight
+// follows it when the list is narrowed or reordered. When it's gone the
+// cursor is only kept in range.
+func cursorOn[T any](items []T, nameOf func(T) string, selected string, cursor int) int {
+	if selected != "" {
+		if i := slices.IndexFunc(items, func(item T) bool { return nameOf(item) == selected }); i >= 0 {
+			return i
+		}
+	}
+	return min(cursor, max(len(items)-1, 0))
+}
@@ -0,0 +1,97 @@
+package screens
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/buntime/cli/internal/api"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func typeKeys(m tea.Model, keys string) {
+	for _, r := range keys {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func pluginNames(plugins []api.PluginInfo) string {
+	names := make([]string, len(plugins))
+	for i, p := range plugins {
+		names[i] = p.Name
+	}
+	return strings.Join(names, ",")
+}
+
+func TestPluginsFilterByNameKeepsTheHighlightedPlugin(t *testing.T) {
+	m := NewPluginsModel(nil, nil, nil, 100, 40)
+	m.Update(pluginsLoadedMsg{plugins: []api.PluginInfo{
+		{Name: "metrics"}, {Name: "auth-jwt"}, {Name: "auth-basic"},
+	}})
+	m.cursor = 2 // auth-basic
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	typeKeys(m, "AUTH")
+	if got := pluginNames(m.plugins); got != "auth-jwt,auth-basic" {
+		t.Fatalf("expected the list narrowed as you type, got %s", got)
+	}
+	if m.plugins[m.cursor].Name != "auth-basic" {
+		t.Fatalf("expected the cursor to stay on auth-basic, got %s", m.plugins[m.cursor].Name)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.search.editing || !m.search.active() {
+		t.Fatal("expected enter to close the prompt and keep the filter")
+	}
+	if title := m.View(); !strings.Contains(title, `"auth"`) {
+		t.Fatalf("expected the filter in the title, got %q", title)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if len(m.plugins) != 3 || m.plugins[m.cursor].Name != "auth-basic" {
+		t.Fatalf("expected esc to clear the filter and keep the cursor, got %s", pluginNames(m.plugins))
+	}
+}
+
+func TestPluginsSortModesCycle(t *testing.T) {
+	m := NewPluginsModel(nil, nil, nil, 100, 40)
+	m.Update(pluginsLoadedMsg{plugins: []api.PluginInfo{
+		{Name: "zeta", Base: "/z"},
+		{Name: "Beta", Enabled: true},
+		{Name: "alpha", Base: "/a"},
+	}})
+
+	tests := []struct {
+		want  string
+		title string
+	}{
+		{"alpha,Beta,zeta", "by name"},
+		{"Beta,alpha,zeta", "by enabled first"},
+		{"alpha,zeta,Beta", "by base"},
+		{"zeta,Beta,alpha", ""},
+	}
+	for _, tt := range tests {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+		if got := pluginNames(m.plugins); got != tt.want {
+			t.Fatalf("expected %s, got %s", tt.want, got)
+		}
+		if view := m.View(); tt.title != "" && !strings.Contains(view, tt.title) {
+			t.Fatalf("expected %q in the title", tt.title)
+		}
+	}
+}
+
+func TestAppsFilterByName(t *testing.T) {
+	m := NewAppsModel(nil, nil, 100, 40)
+	m.Update(appsLoadedMsg{apps: []api.AppInfo{{Name: "shop"}, {Name: "blog"}, {Name: "shop-admin"}}})
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	typeKeys(m, "shop")
+	if len(m.apps) != 2 || len(m.all) != 3 {
+		t.Fatalf("expected 2 of 3 apps, got %d of %d", len(m.apps), len(m.all))
+	}
+
+	typeKeys(m, "x")
+	if view := m.View(); !strings.Contains(view, `No applications named "shopx".`) {
+		t.Fatalf("expected a no match message, got %q", view)
+	}
+}
@@ -3,9 +3,11 @@ package screens
 import (
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/buntime/cli/internal/api"
 	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/i18n"
 	"github.com/buntime/cli/internal/tui/layout"
 	"github.com/buntime/cli/internal/tui/styles"
 	tea "github.com/charmbracelet/bubbletea"
@@ -39,10 +41,11 @@ type MainMenuModel struct {
 // NewMainMenuModel creates a main menu screen
 func NewMainMenuModel(client *api.Client, server *db.Server, width, height int) *MainMenuModel {
 	items := []MenuItem{
-		{title: "Manage Apps", description: "View and manage applications", screen: ScreenApps},
-		{title: "Manage Plugins", description: "Enable, disable, install plugins", screen: ScreenPlugins},
-		{title: "API Keys", description: "Manage authentication keys", screen: ScreenKeys},
-		{title: "Settings", description: "Server configuration", screen: ScreenSettings},
+		{title: i18n.T("menu.apps.title"), description: i18n.T("menu.apps.description"), screen: ScreenApps},
+		{title: i18n.T("menu.plugins.title"), description: i18n.T("menu.plugins.description"), screen: ScreenPlugins},
+		{title: i18n.T("menu.keys.title"), description: i18n.T("menu.keys.description"), screen: ScreenKeys},
+		{title: i18n.T("menu.activity.title"), description: i18n.T("menu.activity.description"), screen: ScreenActivity},
+		{title: i18n.T("menu.settings.title"), description: i18n.T("menu.settings.description"), screen: ScreenSettings},
 	}
 
 	return &MainMenuModel{
@@ -62,28 +65,43 @@ func (m *MainMenuModel) Init() tea.Cmd {
 func (m *MainMenuModel) loadStats() tea.Cmd {
 	return func() tea.Msg {
 		var appsCount, pluginsCount int
-
-		apps, err := m.api.ListApps()
-		if err == nil {
-			appsCount = len(apps)
-		}
-
-		plugins, err := m.api.ListPlugins()
-		if err == nil {
-			for _, p := range plugins {
-				if p.Enabled {
-					pluginsCount++
+		var appsErr, pluginsErr error
+
+		// Fetch both lists at once; the message is sent when both are done
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			var apps []api.AppInfo
+			if apps, appsErr = m.api.ListApps(); appsErr == nil {
+				appsCount = len(apps)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			var plugins []api.PluginInfo
+			if plugins, pluginsErr = m.api.ListPlugins(); pluginsErr == nil {
+				for _, p := range plugins {
+					if p.Enabled {
+						pluginsCount++
+					}
 				}
 			}
-		}
+		}()
+		wg.Wait()
 
-		return statsLoadedMsg{apps: appsCount, plugins: pluginsCount}
+		return statsLoadedMsg{
+			apps:     appsCount,
+			plugins:  pluginsCount,
+			canceled: canceled(appsErr) || canceled(pluginsErr),
+		}
 	}
 }
 
 type statsLoadedMsg struct {
-	apps    int
-	plugins int
+	apps     int
+	plugins  int
+	canceled bool // The counts are incomplete because the menu was left
 }
 
 func (m *MainMenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -94,6 +112,9 @@ func (m *MainMenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case statsLoadedMsg:
+		if msg.canceled {
+			return m, nil
+		}
 		m.loading = false
 		m.appsCount = msg.apps
 		m.pluginsCount = msg.plugins
@@ -121,6 +142,10 @@ func (m *MainMenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return NavigateMsg{Screen: ScreenServerSelect, Data: nil}
 			}
 		case "r":
+			// A reload is already running
+			if m.loading {
+				return m, nil
+			}
 			m.loading = true
 			return m, m.loadStats()
 		}
@@ -142,7 +167,7 @@ func (m *MainMenuModel) View() string {
 	b.WriteString("\n")
 
 	// Quick actions title
-	b.WriteString(styles.SectionTitle.Render("QUICK ACTIONS") + "\n")
+	b.WriteString(styles.SectionTitle.Render(i18n.T("menu.quick_actions")) + "\n")
 
 	// Menu items
 	for i, item := range m.menuItems {
@@ -175,10 +200,10 @@ func (m *MainMenuModel) renderStats(width int) string {
 	cardWidth := 20
 
 	// Apps card
-	appsCard := m.renderStatCard("APPS", m.appsCount, "running", cardWidth)
+	appsCard := m.renderStatCard(i18n.T("stats.apps"), m.appsCount, i18n.T("stats.running"), cardWidth)
 
 	// Plugins card
-	pluginsCard := m.renderStatCard("PLUGINS", m.pluginsCount, "enabled", cardWidth)
+	pluginsCard := m.renderStatCard(i18n.T("stats.plugins"), m.pluginsCount, i18n.T("stats.enabled"), cardWidth)
 
 	return lipgloss.JoinHorizontal(lipgloss.Center, appsCard, "  ", pluginsCard)
 }
@@ -208,11 +233,11 @@ func (m *MainMenuModel) renderStatCard(title string, count int, label string, ca
 
 func (m *MainMenuModel) renderShortcuts() string {
 	shortcuts := []string{
-		styles.RenderShortcut("↑↓", "navigate"),
-		styles.RenderShortcut("⏎", "select"),
-		styles.RenderShortcut("s", "servers"),
-		styles.RenderShortcut("r", "refresh"),
-		styles.RenderShortcut("Esc", "back"),
+		styles.RenderShortcut("↑↓", i18n.T("shortcut.navigate")),
+		styles.RenderShortcut("⏎", i18n.T("shortcut.select")),
+		styles.RenderShortcut("s", i18n.T("shortcut.servers")),
+		styles.RenderShortcut("r", i18n.T("shortcut.refresh")),
+		styles.RenderShortcut("Esc", i18n.T("shortcut.back")),
 	}
 
 	return layout.Shortcuts(shortcuts)
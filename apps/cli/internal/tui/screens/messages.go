@@ -12,6 +12,24 @@ type ConnectedMsg struct {
 	Server *db.Server
 }
 
+// newServerClient creates the client of a server picked in the TUI, with the
+// server's own request timeout when it has one. Uploads that can't reach the
+// server are retried, and the install screen reports each retry.
+func newServerClient(server *db.Server, token string) *api.Client {
+	return api.New(server.URL, token, server.Insecure,
+		api.WithTimeout(server.Timeout()),
+		api.WithUploadRetry(api.DefaultRetryAttempts, api.DefaultUploadRetryDelay),
+	)
+}
+
+// canceled reports whether err is a request aborted because the screen that
+// made it was left. Such a result is stale: it may reach a newer instance of
+// the same screen, whose own request is still running, so it is dropped.
+func canceled(err error) bool {
+	apiErr, ok := err.(*api.APIError)
+	return ok && apiErr.Type == api.ErrorTypeCanceled
+}
+
 // GoBackMsg indicates navigation back
 type GoBackMsg struct{}
 
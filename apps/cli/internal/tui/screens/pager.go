@@ -0,0 +1,63 @@
+package screens
+
+import (
+	"fmt"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/tui/styles"
+)
+
+// listPageSize is how many rows the apps, plugins and keys lists fetch at a
+// time
+const listPageSize = 50
+
+// listPager tracks how much of a list loaded page by page has arrived
+type listPager struct {
+	page    *api.PageInfo // Last page fetched, nil when the list came whole
+	loading bool          // A further page is on its way
+}
+
+// firstPage returns the options that start the list over
+func firstPage() api.ListOptions {
+	return api.ListOptions{Limit: listPageSize}
+}
+
+// continues reports whether a page fetched with opts extends the list rather
+// than replacing it
+func continues(opts api.ListOptions) bool {
+	return opts.Cursor != "" || opts.Offset > 0
+}
+
+func (p *listPager) reset() {
+	p.page = nil
+	p.loading = false
+}
+
+func (p *listPager) more() bool {
+	return p.page != nil && p.page.HasMore()
+}
+
+// next marks a further page as loading and returns the options to fetch it
+func (p *listPager) next() api.ListOptions {
+	p.loading = true
+	return p.page.Next(listPageSize)
+}
+
+// footer tells how much of the list is shown, or nothing once the whole list
+// is loaded
+func (p *listPager) footer(loaded int) string {
+	if p.page == nil || (!p.more() && !p.loading) {
+		return ""
+	}
+
+	text := fmt.Sprintf("  Showing %d", loaded)
+	if p.page.Total >= 0 {
+		text += fmt.Sprintf(" of %d", p.page.Total)
+	}
+	if p.loading {
+		text += " · loading more..."
+	} else {
+		text += " · press m to load more"
+	}
+	return styles.TextMuted.Render(text) + "\n"
+}
@@ -0,0 +1,41 @@
+package screens
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/buntime/cli/internal/api"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestAppsLoadFurtherPagesOnDemand(t *testing.T) {
+	m := NewAppsModel(nil, nil, 100, 40)
+	m.Update(appsLoadedMsg{
+		apps: []api.AppInfo{{Name: "a"}, {Name: "b"}},
+		page: &api.PageInfo{Total: 3, NextOffset: 2},
+		opts: firstPage(),
+	})
+	if got := m.pager.footer(len(m.apps)); !strings.Contains(got, "Showing 2 of 3") {
+		t.Fatalf("expected a showing footer, got %q", got)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	if cmd == nil || !m.pager.loading {
+		t.Fatal("expected m to load the next page")
+	}
+	if _, again := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")}); again != nil {
+		t.Fatal("expected no second request while a page is loading")
+	}
+
+	m.Update(appsLoadedMsg{
+		apps: []api.AppInfo{{Name: "c"}},
+		page: &api.PageInfo{Total: 3},
+		opts: api.ListOptions{Limit: listPageSize, Offset: 2},
+	})
+	if len(m.apps) != 3 || m.apps[2].Name != "c" {
+		t.Fatalf("expected the page to be appended, got %v", m.apps)
+	}
+	if m.pager.more() || m.pager.footer(len(m.apps)) != "" {
+		t.Fatal("expected no footer once the whole list is loaded")
+	}
+}
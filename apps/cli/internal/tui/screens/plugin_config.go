@@ -0,0 +1,360 @@
+package screens
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/i18n"
+	"github.com/buntime/cli/internal/tui/components"
+	"github.com/buntime/cli/internal/tui/layout"
+	"github.com/buntime/cli/internal/tui/messages"
+	"github.com/buntime/cli/internal/tui/styles"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// configField is one editable key of a plugin's configuration
+type configField struct {
+	key   string
+	value any // Value the server sent, which decides how the edit is parsed
+	input textinput.Model
+}
+
+// parse reads the edited text back as the type of the original value.
+// Objects, arrays and nulls are edited as JSON.
+func (f *configField) parse() (any, error) {
+	text := f.input.Value()
+	switch f.value.(type) {
+	case string:
+		return text, nil
+	case bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(text))
+		if err != nil {
+			return nil, fmt.Errorf("%s must be true or false", f.key)
+		}
+		return b, nil
+	case float64:
+		n, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a number", f.key)
+		}
+		return n, nil
+	default:
+		var v any
+		if err := json.Unmarshal([]byte(text), &v); err != nil {
+			return nil, fmt.Errorf("%s must be valid JSON", f.key)
+		}
+		return v, nil
+	}
+}
+
+// formatConfigValue renders a config value for editing
+func formatConfigValue(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
+
+// PluginConfigModel edits a plugin's configuration
+type PluginConfigModel struct {
+	api     *api.Client
+	server  *db.Server
+	plugin  *api.PluginInfo
+	config  map[string]any // As loaded, merged with the edits on save
+	fields  []configField
+	focus   int
+	offset  int // First visible field
+	loading bool
+	saving  bool
+	err     error
+	width   int
+	height  int
+}
+
+// NewPluginConfigModel creates the configuration screen of a plugin
+func NewPluginConfigModel(client *api.Client, server *db.Server, plugin *api.PluginInfo, width, height int) *PluginConfigModel {
+	return &PluginConfigModel{
+		api:     client,
+		server:  server,
+		plugin:  plugin,
+		loading: true,
+		width:   width,
+		height:  height,
+	}
+}
+
+type pluginConfigLoadedMsg struct {
+	config map[string]any
+	err    error
+}
+
+type pluginConfigSavedMsg struct {
+	err error
+}
+
+func (m *PluginConfigModel) Init() tea.Cmd {
+	return m.loadConfig()
+}
+
+func (m *PluginConfigModel) loadConfig() tea.Cmd {
+	id := m.plugin.ID
+	return func() tea.Msg {
+		cfg, err := m.api.GetPluginConfig(id)
+		return pluginConfigLoadedMsg{config: cfg, err: err}
+	}
+}
+
+// setConfig builds a field per key, sorted so the order is stable
+func (m *PluginConfigModel) setConfig(cfg map[string]any) {
+	m.config = cfg
+	keys := make([]string, 0, len(cfg))
+	for key := range cfg {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	m.fields = make([]configField, len(keys))
+	for i, key := range keys {
+		input := textinput.New()
+		input.Prompt = ""
+		input.SetValue(formatConfigValue(cfg[key]))
+		m.fields[i] = configField{key: key, value: cfg[key], input: input}
+	}
+	m.focus = 0
+	m.offset = 0
+	m.resizeInputs()
+	m.updateFocus()
+}
+
+func (m *PluginConfigModel) resizeInputs() {
+	for i := range m.fields {
+		m.fields[i].input.Width = layout.InputWidth(m.width, 60)
+	}
+}
+
+func (m *PluginConfigModel) updateFocus() {
+	for i := range m.fields {
+		if i == m.focus {
+			m.fields[i].input.Focus()
+		} else {
+			m.fields[i].input.Blur()
+		}
+	}
+}
+
+// merged applies the edits on top of the loaded configuration
+func (m *PluginConfigModel) merged() (map[string]any, error) {
+	cfg := make(map[string]any, len(m.config))
+	for key, value := range m.config {
+		cfg[key] = value
+	}
+	for i := range m.fields {
+		value, err := m.fields[i].parse()
+		if err != nil {
+			return nil, err
+		}
+		cfg[m.fields[i].key] = value
+	}
+	return cfg, nil
+}
+
+func (m *PluginConfigModel) save() tea.Cmd {
+	cfg, err := m.merged()
+	if err != nil {
+		m.err = err
+		return nil
+	}
+
+	m.saving = true
+	m.err = nil
+	id := m.plugin.ID
+	return func() tea.Msg {
+		return pluginConfigSavedMsg{err: m.api.SetPluginConfig(id, cfg)}
+	}
+}
+
+func (m *PluginConfigModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.resizeInputs()
+		return m, nil
+
+	case pluginConfigLoadedMsg:
+		if canceled(msg.err) {
+			return m, nil
+		}
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.err = nil
+		m.setConfig(msg.config)
+		return m, textinput.Blink
+
+	case pluginConfigSavedMsg:
+		m.saving = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		name := m.plugin.Name
+		return m, tea.Batch(goBack(), func() tea.Msg {
+			return messages.ShowSuccess("Saved the configuration of " + name)
+		})
+
+	case KeySwitchedMsg:
+		if !permissionDenied(m.err) {
+			return m, nil
+		}
+		if m.config == nil {
+			m.loading = true
+			m.err = nil
+			return m, m.loadConfig()
+		}
+		return m, m.save()
+
+	case tea.KeyMsg:
+		if m.loading || m.saving {
+			return m, nil
+		}
+		switch msg.String() {
+		case switchKeyKey:
+			if permissionDenied(m.err) {
+				return m, switchKey(m.api, m.server)
+			}
+			return m, nil
+		case "tab", "down":
+			if len(m.fields) > 0 {
+				m.focus = (m.focus + 1) % len(m.fields)
+				m.updateFocus()
+			}
+			return m, nil
+		case "shift+tab", "up":
+			if len(m.fields) > 0 {
+				m.focus = (m.focus - 1 + len(m.fields)) % len(m.fields)
+				m.updateFocus()
+			}
+			return m, nil
+		case "enter":
+			if len(m.fields) > 0 {
+				return m, m.save()
+			}
+			return m, nil
+		case "esc":
+			return m, goBack()
+		}
+
+		if m.focus < len(m.fields) {
+			var cmd tea.Cmd
+			m.fields[m.focus].input, cmd = m.fields[m.focus].input.Update(msg)
+			return m, cmd
+		}
+	}
+
+	return m, nil
+}
+
+// Typing reports whether a config value has the focus
+func (m *PluginConfigModel) Typing() bool {
+	return !m.loading && len(m.fields) > 0
+}
+
+func (m *PluginConfigModel) View() string {
+	innerWidth := layout.InnerWidth(m.width)
+
+	var content strings.Builder
+	if m.loading {
+		content.WriteString(components.SkeletonRows(components.SkeletonRowCount, []int{20, 40}))
+	} else if apiErr, ok := m.err.(*api.APIError); ok && apiErr.Type == api.ErrorTypeUnsupported && m.config == nil {
+		content.WriteString(layout.CenterText(styles.TextMuted.Render("This server does not expose plugin configuration."), innerWidth) + "\n")
+	} else if m.config == nil && m.err != nil {
+		content.WriteString(styles.TextError.Render("Error: "+m.err.Error()) + "\n")
+		if permissionDenied(m.err) {
+			content.WriteString(renderSwitchKeyHint())
+		}
+	} else if len(m.fields) == 0 {
+		content.WriteString(layout.CenterText(styles.TextMuted.Render(m.plugin.Name+" has no configuration."), innerWidth) + "\n")
+	} else {
+		content.WriteString(m.renderFields(innerWidth))
+	}
+
+	return layout.Page(layout.PageConfig{
+		Width:      m.width,
+		Height:     m.height,
+		Server:     m.server,
+		Breadcrumb: "Main › Plugins › Configure",
+		Title:      "CONFIGURE " + strings.ToUpper(m.plugin.Name),
+		Content:    content.String(),
+		Shortcuts:  m.getShortcuts(),
+	})
+}
+
+func (m *PluginConfigModel) renderFields(width int) string {
+	var b strings.Builder
+
+	var status string
+	if m.saving {
+		status = "\n" + styles.TextPrimary.Render("Saving...") + "\n"
+	} else if m.err != nil {
+		status = "\n" + styles.TextError.Render("Error: "+m.err.Error()) + "\n"
+		if permissionDenied(m.err) {
+			status += renderSwitchKeyHint()
+		}
+	}
+
+	keyWidth := 0
+	for _, field := range m.fields {
+		keyWidth = max(keyWidth, len(field.key))
+	}
+	keyWidth = min(keyWidth, width/3)
+
+	rows := layout.ListRows(m.height, strings.Count(status, "\n"))
+	m.offset = layout.ScrollOffset(m.offset, m.focus, len(m.fields), rows)
+	for i := m.offset; i < min(len(m.fields), m.offset+rows); i++ {
+		field := m.fields[i]
+		cursor := "  "
+		key := styles.TextMuted.Render(styles.PadRight(styles.Truncate(field.key, keyWidth), keyWidth))
+		if i == m.focus {
+			cursor = styles.Caret
+			key = styles.TextPrimary.Render(styles.PadRight(styles.Truncate(field.key, keyWidth), keyWidth))
+		}
+		b.WriteString(cursor + key + "  " + field.input.View() + "\n")
+	}
+
+	b.WriteString(status)
+	return b.String()
+}
+
+func (m *PluginConfigModel) getShortcuts() []string {
+	if m.saving {
+		return []string{styles.RenderShortcut("", i18n.T("shortcut.please_wait"))}
+	}
+
+	var shortcuts []string
+	if len(m.fields) > 0 {
+		shortcuts = append(shortcuts,
+			styles.RenderShortcut("↑↓", i18n.T("shortcut.navigate")),
+			styles.RenderShortcut("⏎", i18n.T("shortcut.save")),
+		)
+	}
+	if permissionDenied(m.err) {
+		shortcuts = append(shortcuts, switchKeyShortcut())
+	}
+	return append(shortcuts, styles.RenderShortcut("Esc", i18n.T("shortcut.cancel")))
+}
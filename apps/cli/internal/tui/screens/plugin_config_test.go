@@ -0,0 +1,53 @@
+package screens
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/buntime/cli/internal/api"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPluginConfigMergesEditsWithTheirTypes(t *testing.T) {
+	m := NewPluginConfigModel(nil, nil, &api.PluginInfo{ID: 7, Name: "cache"}, 100, 40)
+	m.Update(pluginConfigLoadedMsg{config: map[string]any{
+		"ttl":     float64(60),
+		"enabled": true,
+		"prefix":  "app:",
+		"hosts":   []any{"a", "b"},
+	}})
+
+	// Fields are sorted by key
+	edits := map[string]string{"enabled": "false", "hosts": `["c"]`, "prefix": "svc:", "ttl": "90"}
+	for i := range m.fields {
+		m.fields[i].input.SetValue(edits[m.fields[i].key])
+	}
+
+	cfg, err := m.merged()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg["ttl"] != float64(90) || cfg["enabled"] != false || cfg["prefix"] != "svc:" {
+		t.Fatalf("unexpected merged config %v", cfg)
+	}
+	if hosts, ok := cfg["hosts"].([]any); !ok || len(hosts) != 1 || hosts[0] != "c" {
+		t.Fatalf("expected hosts to be parsed as JSON, got %v", cfg["hosts"])
+	}
+
+	m.fields[3].input.SetValue("soon")
+	if _, err := m.merged(); err == nil || !strings.Contains(err.Error(), "ttl must be a number") {
+		t.Fatalf("expected a number error, got %v", err)
+	}
+}
+
+func TestPluginConfigWithoutSettingsShowsEmptyState(t *testing.T) {
+	m := NewPluginConfigModel(nil, nil, &api.PluginInfo{ID: 7, Name: "cache"}, 100, 40)
+	m.Update(pluginConfigLoadedMsg{config: map[string]any{}})
+
+	if view := m.View(); !strings.Contains(view, "cache has no configuration.") {
+		t.Fatalf("expected the empty state, got:\n%s", view)
+	}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil || m.saving {
+		t.Fatal("expected Enter to do nothing without fields")
+	}
+}
@@ -0,0 +1,239 @@
+package screens
+
+import (
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/i18n"
+	"github.com/buntime/cli/internal/tui/layout"
+	"github.com/buntime/cli/internal/tui/messages"
+	"github.com/buntime/cli/internal/tui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PluginDetailModel shows everything known about one plugin. It opens with
+// what the list had and fills in the rest once the server answers.
+type PluginDetailModel struct {
+	api     *api.Client
+	server  *db.Server
+	plugin  *api.PluginInfo
+	detail  *api.PluginDetail // nil until loaded, or when the server has no details
+	loading bool
+	err     error
+	width   int
+	height  int
+}
+
+// NewPluginDetailModel creates the details screen of a plugin
+func NewPluginDetailModel(client *api.Client, server *db.Server, plugin *api.PluginInfo, width, height int) *PluginDetailModel {
+	return &PluginDetailModel{
+		api:     client,
+		server:  server,
+		plugin:  plugin,
+		loading: true,
+		width:   width,
+		height:  height,
+	}
+}
+
+type pluginDetailLoadedMsg struct {
+	detail *api.PluginDetail
+	err    error
+}
+
+func (m *PluginDetailModel) Init() tea.Cmd {
+	return m.loadDetail()
+}
+
+func (m *PluginDetailModel) loadDetail() tea.Cmd {
+	m.loading = true
+	id := m.plugin.ID
+	return func() tea.Msg {
+		detail, err := m.api.GetPlugin(id)
+		return pluginDetailLoadedMsg{detail: detail, err: err}
+	}
+}
+
+func (m *PluginDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case pluginDetailLoadedMsg:
+		if canceled(msg.err) {
+			return m, nil
+		}
+		m.loading = false
+		// Servers without the endpoint keep what the list sent
+		if apiErr, ok := msg.err.(*api.APIError); ok && apiErr.Type == api.ErrorTypeUnsupported {
+			return m, nil
+		}
+		m.err = msg.err
+		m.detail = msg.detail
+		if m.detail != nil {
+			m.plugin = &m.detail.PluginInfo
+		}
+		return m, nil
+
+	case KeySwitchedMsg:
+		if permissionDenied(m.err) {
+			return m, m.loadDetail()
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "c":
+			return m, copyPath(m.plugin.Path)
+		case "r":
+			if !m.loading {
+				return m, m.loadDetail()
+			}
+		case switchKeyKey:
+			if permissionDenied(m.err) && !m.loading {
+				return m, switchKey(m.api, m.server)
+			}
+		case "esc":
+			return m, goBack()
+		}
+	}
+
+	return m, nil
+}
+
+func (m *PluginDetailModel) View() string {
+	return layout.Page(layout.PageConfig{
+		Width:      m.width,
+		Height:     m.height,
+		Server:     m.server,
+		Breadcrumb: "Main › Plugins › Details",
+		Title:      "PLUGIN DETAILS",
+		Content:    m.renderContent(),
+		Shortcuts:  m.getShortcuts(),
+	})
+}
+
+func (m *PluginDetailModel) renderContent() string {
+	var b strings.Builder
+	width := layout.InnerWidth(m.width)
+	plugin := m.plugin
+
+	b.WriteString(styles.TextPrimary.Bold(true).Render(plugin.Name) + "\n")
+	if m.detail != nil && m.detail.Description != "" {
+		b.WriteString(styles.TextMuted.Render(styles.Truncate(m.detail.Description, width)) + "\n")
+	}
+	b.WriteString("\n")
+
+	status := styles.RenderStatus(plugin.Enabled) + " disabled"
+	if plugin.Enabled {
+		status = styles.RenderStatus(plugin.Enabled) + " enabled"
+	}
+	fields := [][2]string{
+		{"Status", status},
+		{"Base", plugin.Base},
+		{"Path", plugin.Path},
+	}
+	if m.detail != nil {
+		fields = append(fields,
+			[2]string{"Author", m.detail.Author},
+			[2]string{"Homepage", m.detail.Homepage},
+			[2]string{"Depends on", strings.Join(m.detail.Dependencies, ", ")},
+		)
+	}
+	if plugin.Provenance != nil {
+		fields = append(fields, [2]string{"Installed by", plugin.Provenance.String()})
+	}
+	fields = append(fields, [2]string{"Labels", plugin.Labels.String()})
+	b.WriteString(renderDetailFields(fields, width))
+
+	b.WriteString("\n")
+	b.WriteString(renderDetailVersions(plugin.Versions, plugin.ActiveVersion, nil))
+
+	if m.loading {
+		b.WriteString("\n" + styles.TextMuted.Render("Loading details...") + "\n")
+	} else if m.err != nil {
+		b.WriteString("\n" + styles.TextError.Render("Error: "+m.err.Error()) + "\n")
+		if permissionDenied(m.err) {
+			b.WriteString(renderSwitchKeyHint())
+		}
+	}
+
+	return b.String()
+}
+
+func (m *PluginDetailModel) getShortcuts() []string {
+	var shortcuts []string
+	if m.plugin.Path != "" {
+		shortcuts = append(shortcuts, styles.RenderShortcut("c", i18n.T("shortcut.copy_path")))
+	}
+	shortcuts = append(shortcuts, styles.RenderShortcut("r", i18n.T("shortcut.refresh")))
+	if permissionDenied(m.err) {
+		shortcuts = append(shortcuts, switchKeyShortcut())
+	}
+	return append(shortcuts, styles.RenderShortcut("Esc", i18n.T("shortcut.back")))
+}
+
+// renderDetailFields lines up label and value pairs, leaving out the empty
+// ones. Shared by the plugin and app details.
+func renderDetailFields(fields [][2]string, width int) string {
+	labelWidth := 0
+	for _, field := range fields {
+		if field[1] != "" {
+			labelWidth = max(labelWidth, len(field[0]))
+		}
+	}
+
+	var b strings.Builder
+	for _, field := range fields {
+		if field[1] == "" {
+			continue
+		}
+		label := styles.TextMuted.Render(styles.PadRight(field[0], labelWidth))
+		value := styles.Truncate(field[1], max(width-labelWidth-2, 10))
+		b.WriteString(label + "  " + styles.TextNormal.Render(value) + "\n")
+	}
+	return b.String()
+}
+
+// renderDetailVersions lists every installed version, marking the active
+// one. notes adds a muted note after a version, such as when it was
+// installed.
+func renderDetailVersions(versions []string, active string, notes map[string]string) string {
+	var b strings.Builder
+
+	b.WriteString(styles.TextMuted.Render("Versions") + "\n")
+	if len(versions) == 0 {
+		b.WriteString("  " + styles.TextMuted.Render("none") + "\n")
+	}
+	for _, version := range versions {
+		line := "  " + styles.TextNormal.Render(version)
+		if version == active {
+			line = "  " + styles.TextNormal.Bold(true).Render(version) + styles.TextSuccess.Render(" ● active")
+		}
+		if note := notes[version]; note != "" {
+			line += styles.TextMuted.Render("  " + note)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	return b.String()
+}
+
+// copyPath copies an install path to the clipboard
+func copyPath(path string) tea.Cmd {
+	if path == "" {
+		return nil
+	}
+	if err := clipboard.WriteAll(path); err != nil {
+		return func() tea.Msg {
+			return messages.ShowWarning("Could not copy to the clipboard: " + err.Error())
+		}
+	}
+	return func() tea.Msg {
+		return messages.ShowSuccess("Copied " + path)
+	}
+}
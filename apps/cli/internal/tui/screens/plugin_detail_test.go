@@ -0,0 +1,90 @@
+package screens
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/db"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPluginDetailFillsInFromTheServer(t *testing.T) {
+	plugin := &api.PluginInfo{ID: 1, Name: "auth", Versions: []string{"1.1.0", "1.0.0"}}
+	m := NewPluginDetailModel(nil, &db.Server{Name: "prod"}, plugin, 100, 40)
+
+	detail := &api.PluginDetail{
+		PluginInfo: api.PluginInfo{
+			ID: 1, Name: "auth", Enabled: true, Base: "/auth", Path: "/plugins/auth",
+			Versions: []string{"1.1.0", "1.0.0"}, ActiveVersion: "1.0.0",
+		},
+		Author:       "Zomme",
+		Dependencies: []string{"sessions", "crypto"},
+	}
+	m.Update(pluginDetailLoadedMsg{detail: detail})
+
+	view := m.View()
+	for _, want := range []string{"/plugins/auth", "/auth", "enabled", "sessions, crypto", "1.1.0", "1.0.0 ● active"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in the details, got:\n%s", want, view)
+		}
+	}
+}
+
+func TestPluginDetailKeepsTheListDataWhenUnsupported(t *testing.T) {
+	plugin := &api.PluginInfo{ID: 1, Name: "auth", Base: "/auth", Versions: []string{"1.0.0"}}
+	m := NewPluginDetailModel(nil, &db.Server{Name: "prod"}, plugin, 100, 40)
+
+	m.Update(pluginDetailLoadedMsg{err: &api.APIError{Type: api.ErrorTypeUnsupported, Message: "no details"}})
+	if m.loading || m.err != nil {
+		t.Fatalf("expected an unsupported server not to be an error, got %v", m.err)
+	}
+	if view := m.View(); !strings.Contains(view, "/auth") || strings.Contains(view, "Error") {
+		t.Fatalf("expected the list data without an error, got:\n%s", view)
+	}
+	// Nothing to copy without a path
+	if shortcuts := strings.Join(m.getShortcuts(), " "); strings.Contains(shortcuts, "copy path") {
+		t.Fatal("expected no copy shortcut for a plugin without a path")
+	}
+}
+
+func TestAppDetailShowsEveryVersion(t *testing.T) {
+	installed := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	app := &api.AppInfo{
+		Name:          "shop",
+		Path:          "/apps/shop",
+		Versions:      []string{"2.0.0", "1.9.0", "1.8.0"},
+		ActiveVersion: "1.9.0",
+		InstalledAt:   map[string]time.Time{"1.8.0": installed},
+	}
+	m := NewAppDetailModel(&db.Server{Name: "prod"}, app, 100, 40)
+
+	view := m.View()
+	for _, want := range []string{"/apps/shop", "2.0.0", "1.9.0 ● active", "1.8.0", "installed " + installed.Local().Format("2006-01-02")} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in the details, got:\n%s", want, view)
+		}
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	if nav, ok := cmd().(NavigateMsg); !ok || nav.Screen != ScreenAppRollback {
+		t.Fatal("expected v to open the version picker")
+	}
+}
+
+func TestEnterOpensTheDetails(t *testing.T) {
+	plugins := NewPluginsModel(nil, nil, nil, 100, 40)
+	plugins.Update(pluginsLoadedMsg{plugins: []api.PluginInfo{{ID: 1, Name: "auth"}}})
+	_, cmd := plugins.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if nav, ok := cmd().(NavigateMsg); !ok || nav.Screen != ScreenPluginDetail {
+		t.Fatal("expected enter to open the plugin details")
+	}
+
+	apps := NewAppsModel(nil, nil, 100, 40)
+	apps.Update(appsLoadedMsg{apps: []api.AppInfo{{Name: "shop"}}})
+	_, cmd = apps.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if nav, ok := cmd().(NavigateMsg); !ok || nav.Screen != ScreenAppDetail {
+		t.Fatal("expected enter to open the app details")
+	}
+}
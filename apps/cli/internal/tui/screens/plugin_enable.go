@@ -0,0 +1,181 @@
+package screens
+
+import (
+	"strings"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/i18n"
+	"github.com/buntime/cli/internal/tui/layout"
+	"github.com/buntime/cli/internal/tui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PluginEnableModel asks which version to run when enabling a plugin that has
+// several installed, so re-enabling doesn't silently switch versions
+type PluginEnableModel struct {
+	api      *api.Client
+	server   *db.Server
+	plugin   *api.PluginInfo
+	verify   bool // Re-check server health after enabling
+	cursor   int
+	enabling bool
+	err      error
+	width    int
+	height   int
+}
+
+// NewPluginEnableModel creates the version picker with the cursor on the
+// version that ran last, or the newest when that isn't known
+func NewPluginEnableModel(client *api.Client, database *db.DB, server *db.Server, plugin *api.PluginInfo, width, height int) *PluginEnableModel {
+	m := &PluginEnableModel{
+		api:    client,
+		server: server,
+		plugin: plugin,
+		verify: database.GetConfigBool(db.ConfigVerifyInstalls),
+		width:  width,
+		height: height,
+	}
+	def := plugin.DefaultEnableVersion()
+	for i, version := range plugin.Versions {
+		if version == def {
+			m.cursor = i
+		}
+	}
+	return m
+}
+
+func (m *PluginEnableModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m *PluginEnableModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case pluginToggledMsg:
+		m.enabling = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		return m, tea.Batch(
+			func() tea.Msg {
+				return NavigateMsg{Screen: ScreenPlugins, Data: nil, ReplaceHistory: true}
+			},
+			func() tea.Msg { return msg.toast() },
+		)
+
+	case KeySwitchedMsg:
+		if permissionDenied(m.err) {
+			m.enabling = true
+			m.err = nil
+			return m, togglePlugin(m.api, *m.plugin, true, m.plugin.Versions[m.cursor], m.verify)
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.enabling {
+			return m, nil
+		}
+		switch msg.String() {
+		case switchKeyKey:
+			if permissionDenied(m.err) {
+				return m, switchKey(m.api, m.server)
+			}
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.plugin.Versions)-1 {
+				m.cursor++
+			}
+		case "enter":
+			if m.cursor < len(m.plugin.Versions) {
+				m.enabling = true
+				m.err = nil
+				return m, togglePlugin(m.api, *m.plugin, true, m.plugin.Versions[m.cursor], m.verify)
+			}
+		case "esc":
+			return m, goBack()
+		}
+	}
+
+	return m, nil
+}
+
+func (m *PluginEnableModel) View() string {
+	return layout.Page(layout.PageConfig{
+		Width:      m.width,
+		Height:     m.height,
+		Server:     m.server,
+		Breadcrumb: "Main › Plugins › Enable",
+		Title:      "ENABLE PLUGIN",
+		Content:    m.renderContent(),
+		Shortcuts:  m.getShortcuts(),
+	})
+}
+
+func (m *PluginEnableModel) renderContent() string {
+	var b strings.Builder
+
+	b.WriteString(styles.TextMuted.Render("Choose the version of "))
+	b.WriteString(styles.TextPrimary.Bold(true).Render(m.plugin.Name))
+	b.WriteString(styles.TextMuted.Render(" to run:"))
+	b.WriteString("\n\n")
+
+	for i, version := range m.plugin.Versions {
+		cursor := "  "
+		if i == m.cursor {
+			cursor = styles.Caret
+		}
+
+		versionText := version
+		switch {
+		case m.plugin.ActiveVersion != "" && version == m.plugin.ActiveVersion:
+			versionText += styles.TextMuted.Render(" (last active)")
+		case i == 0:
+			versionText += styles.TextMuted.Render(" (newest)")
+		}
+
+		style := styles.TextNormal
+		if i == m.cursor {
+			style = styles.TextPrimary
+		}
+
+		b.WriteString(cursor + style.Render(versionText) + "\n")
+	}
+
+	if m.plugin.ActiveVersion == "" {
+		b.WriteString("\n" + styles.TextMuted.Render("The server doesn't report which version ran last.") + "\n")
+	}
+
+	if m.enabling {
+		b.WriteString("\n" + styles.TextPrimary.Render("Enabling...") + "\n")
+	} else if m.err != nil {
+		b.WriteString("\n" + styles.TextError.Render("Error: "+m.err.Error()) + "\n")
+		if permissionDenied(m.err) {
+			b.WriteString(renderSwitchKeyHint())
+		}
+	}
+
+	return b.String()
+}
+
+func (m *PluginEnableModel) getShortcuts() []string {
+	if m.enabling {
+		return []string{styles.RenderShortcut("", i18n.T("shortcut.please_wait"))}
+	}
+	shortcuts := []string{
+		styles.RenderShortcut("↑↓", i18n.T("shortcut.navigate")),
+		styles.RenderShortcut("⏎", i18n.T("shortcut.enable")),
+	}
+	if permissionDenied(m.err) {
+		shortcuts = append(shortcuts, switchKeyShortcut())
+	}
+	return append(shortcuts, styles.RenderShortcut("Esc", i18n.T("shortcut.cancel")))
+}
@@ -6,31 +6,120 @@ import (
 
 	"github.com/buntime/cli/internal/api"
 	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/i18n"
+	"github.com/buntime/cli/internal/tui/components"
 	"github.com/buntime/cli/internal/tui/layout"
+	"github.com/buntime/cli/internal/tui/messages"
 	"github.com/buntime/cli/internal/tui/styles"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// pluginStatusFilter narrows the plugin list to enabled or disabled plugins
+type pluginStatusFilter int
+
+const (
+	showAllPlugins pluginStatusFilter = iota
+	showEnabledPlugins
+	showDisabledPlugins
+)
+
+// next cycles all -> enabled -> disabled -> all
+func (f pluginStatusFilter) next() pluginStatusFilter {
+	return (f + 1) % 3
+}
+
+func (f pluginStatusFilter) matches(plugin api.PluginInfo) bool {
+	switch f {
+	case showEnabledPlugins:
+		return plugin.Enabled
+	case showDisabledPlugins:
+		return !plugin.Enabled
+	default:
+		return true
+	}
+}
+
+func (f pluginStatusFilter) String() string {
+	switch f {
+	case showEnabledPlugins:
+		return "enabled"
+	case showDisabledPlugins:
+		return "disabled"
+	default:
+		return "all"
+	}
+}
+
+// pluginSorts are the orders 's' cycles through, starting with the server's
+var pluginSorts = []listSort[api.PluginInfo]{
+	{},
+	{name: "name", compare: func(a, b api.PluginInfo) int {
+		return compareNames(a.Name, b.Name)
+	}},
+	{name: "enabled first", compare: func(a, b api.PluginInfo) int {
+		if a.Enabled != b.Enabled {
+			if a.Enabled {
+				return -1
+			}
+			return 1
+		}
+		return compareNames(a.Name, b.Name)
+	}},
+	{name: "base", compare: func(a, b api.PluginInfo) int {
+		// Plugins without a base go last
+		if (a.Base == "") != (b.Base == "") {
+			if a.Base == "" {
+				return 1
+			}
+			return -1
+		}
+		if c := strings.Compare(a.Base, b.Base); c != 0 {
+			return c
+		}
+		return compareNames(a.Name, b.Name)
+	}},
+}
+
 // PluginsModel shows the plugins list
 type PluginsModel struct {
 	api     *api.Client
+	db      *db.DB
 	server  *db.Server
-	plugins []api.PluginInfo
+	all     []api.PluginInfo // Everything the server returned for the label filter
+	plugins []api.PluginInfo // all narrowed by name and status, then sorted
+	status  pluginStatusFilter
+	sort    int // Index into pluginSorts
 	cursor  int
+	offset  int // First visible row
 	width   int
 	height  int
 	loading bool
 	err     error
+	filter  labelFilter
+	search  nameFilter
+	pager   listPager
+	marked  map[string]bool // Plugins selected for a bulk action, by name
+	bulk    *bulkToggle     // Set while a bulk action waits for confirmation
+}
+
+// bulkToggle is an enable or disable of several plugins at once
+type bulkToggle struct {
+	enable  bool
+	plugins []api.PluginInfo // Only the plugins the action changes
 }
 
 // NewPluginsModel creates a plugins list screen
-func NewPluginsModel(client *api.Client, server *db.Server, width, height int) *PluginsModel {
+func NewPluginsModel(client *api.Client, database *db.DB, server *db.Server, width, height int) *PluginsModel {
 	return &PluginsModel{
 		api:     client,
+		db:      database,
 		server:  server,
 		width:   width,
 		height:  height,
 		loading: true,
+		filter:  newLabelFilter(width),
+		search:  newNameFilter(width),
+		marked:  make(map[string]bool),
 	}
 }
 
@@ -38,38 +127,209 @@ func (m *PluginsModel) Init() tea.Cmd {
 	return m.loadPlugins()
 }
 
+// loadPlugins starts the list over from the first page
 func (m *PluginsModel) loadPlugins() tea.Cmd {
+	m.pager.reset()
+	return m.loadPluginsPage(firstPage())
+}
+
+// loadPluginsPage fetches the page opts points at. A label filter fetches
+// every matching plugin at once, since labels may need a request per plugin.
+func (m *PluginsModel) loadPluginsPage(opts api.ListOptions) tea.Cmd {
+	sel := m.filter.selector
 	return func() tea.Msg {
-		plugins, err := m.api.ListPlugins()
-		if err != nil {
-			return pluginsLoadedMsg{err: err}
+		if !sel.Empty() {
+			plugins, err := m.api.ListPluginsMatching(sel)
+			return pluginsLoadedMsg{plugins: plugins, err: err}
 		}
-		return pluginsLoadedMsg{plugins: plugins}
+		plugins, page, err := m.api.ListPluginsPage(opts)
+		return pluginsLoadedMsg{plugins: plugins, page: page, opts: opts, err: err}
 	}
 }
 
 type pluginsLoadedMsg struct {
 	plugins []api.PluginInfo
+	page    *api.PageInfo   // nil when the whole list was fetched
+	opts    api.ListOptions // The options the page was requested with
 	err     error
 }
 
+// pluginToggledMsg reports an enable or disable from the plugin list or the
+// version picker
+type pluginToggledMsg struct {
+	name      string
+	version   string // Version enabled, empty when the server picked it
+	enabled   bool
+	err       error
+	healthErr error // Set when the server became unhealthy afterwards
+}
+
+// togglePlugin enables or disables a plugin. With verify set, server health
+// is re-checked afterwards, like after an install.
+func togglePlugin(client *api.Client, plugin api.PluginInfo, enable bool, version string, verify bool) tea.Cmd {
+	return func() tea.Msg {
+		var err error
+		if enable {
+			err = client.EnablePluginVersion(plugin.ID, version)
+		} else {
+			err = client.DisablePlugin(plugin.ID)
+		}
+		msg := pluginToggledMsg{name: plugin.Name, version: version, enabled: enable, err: err}
+		if err == nil && verify {
+			msg.healthErr = client.VerifyHealth(api.DefaultVerifyWindow, api.DefaultVerifyInterval)
+		}
+		return msg
+	}
+}
+
+// pluginsToggledMsg reports a bulk enable or disable
+type pluginsToggledMsg struct {
+	enable    bool
+	done      int
+	failed    []string // "name: error" for each plugin that failed
+	healthErr error    // Set when the server became unhealthy afterwards
+}
+
+// togglePlugins enables or disables plugins one after the other, so the
+// list is reloaded once when they are all done. With verify set, server
+// health is re-checked once after them.
+func togglePlugins(client *api.Client, plugins []api.PluginInfo, enable, verify bool) tea.Cmd {
+	return func() tea.Msg {
+		msg := pluginsToggledMsg{enable: enable}
+		for _, plugin := range plugins {
+			var err error
+			if enable {
+				err = client.EnablePluginVersion(plugin.ID, "")
+			} else {
+				err = client.DisablePlugin(plugin.ID)
+			}
+			if err != nil {
+				msg.failed = append(msg.failed, plugin.Name+": "+err.Error())
+				continue
+			}
+			msg.done++
+		}
+		if msg.done > 0 && verify {
+			msg.healthErr = client.VerifyHealth(api.DefaultVerifyWindow, api.DefaultVerifyInterval)
+		}
+		return msg
+	}
+}
+
+// unhealthyAfter reports a health check that failed after what was done,
+// the way the install screen does
+func unhealthyAfter(what string, err error) messages.ShowToastMsg {
+	return messages.ShowWarning("Server became unhealthy after " + what + ": " + err.Error())
+}
+
+// toast summarizes the bulk action, e.g. "Enabled 3, 1 failed"
+func (msg pluginsToggledMsg) toast() messages.ShowToastMsg {
+	verb := "Disabled"
+	if msg.enable {
+		verb = "Enabled"
+	}
+	if msg.healthErr != nil {
+		action := "disabling"
+		if msg.enable {
+			action = "enabling"
+		}
+		what := fmt.Sprintf("%s %d plugins", action, msg.done)
+		if len(msg.failed) > 0 {
+			what += fmt.Sprintf(" (%d failed)", len(msg.failed))
+		}
+		return unhealthyAfter(what, msg.healthErr)
+	}
+	if len(msg.failed) == 0 {
+		return messages.ShowSuccess(fmt.Sprintf("%s %d plugins", verb, msg.done))
+	}
+	return messages.ShowError(fmt.Sprintf("%s %d, %d failed (%s)", verb, msg.done, len(msg.failed), strings.Join(msg.failed, "; ")))
+}
+
+func (msg pluginToggledMsg) toast() messages.ShowToastMsg {
+	if msg.err != nil {
+		return messages.ShowError("Failed to update " + msg.name + ": " + msg.err.Error())
+	}
+	if msg.healthErr != nil {
+		action := "disabling "
+		if msg.enabled {
+			action = "enabling "
+		}
+		return unhealthyAfter(action+msg.name, msg.healthErr)
+	}
+	if !msg.enabled {
+		return messages.ShowSuccess("Disabled " + msg.name)
+	}
+	if msg.version != "" {
+		return messages.ShowSuccess("Enabled " + msg.name + " v" + msg.version)
+	}
+	return messages.ShowSuccess("Enabled " + msg.name)
+}
+
 func (m *PluginsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.filter.resize(m.width)
+		m.search.resize(m.width)
 		return m, nil
 
 	case pluginsLoadedMsg:
+		if canceled(msg.err) {
+			return m, nil
+		}
 		m.loading = false
+		m.pager.loading = false
+		if apiErr, ok := msg.err.(*api.APIError); ok && apiErr.Type == api.ErrorTypeUnsupported && m.filter.active() {
+			// Fall back to the unfiltered list
+			m.filter.clear()
+			m.loading = true
+			return m, tea.Batch(m.loadPlugins(), func() tea.Msg {
+				return messages.ShowWarning("This server does not support labels")
+			})
+		}
 		if msg.err != nil {
 			m.err = msg.err
 			return m, nil
 		}
-		m.plugins = msg.plugins
+		m.err = nil
+		if continues(msg.opts) {
+			m.all = append(m.all, msg.plugins...)
+		} else {
+			m.all = msg.plugins
+		}
+		m.pager.page = msg.page
+		m.applyView()
 		return m, nil
 
+	case pluginToggledMsg:
+		m.loading = true
+		return m, tea.Batch(m.loadPlugins(), func() tea.Msg { return msg.toast() })
+
+	case pluginsToggledMsg:
+		m.loading = true
+		return m, tea.Batch(m.loadPlugins(), func() tea.Msg { return msg.toast() })
+
 	case tea.KeyMsg:
+		if m.bulk != nil {
+			return m.updateBulkConfirm(msg)
+		}
+		if m.filter.editing {
+			applied, cmd := m.filter.update(msg)
+			if applied {
+				m.loading = true
+				return m, m.loadPlugins()
+			}
+			return m, cmd
+		}
+		if m.search.editing {
+			changed, cmd := m.search.update(msg)
+			if changed {
+				m.applyView()
+			}
+			return m, cmd
+		}
+
 		switch msg.String() {
 		case "up", "k":
 			if m.cursor > 0 {
@@ -83,16 +343,105 @@ func (m *PluginsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, func() tea.Msg {
 				return NavigateMsg{Screen: ScreenPluginInstall, Data: nil}
 			}
+		case "enter":
+			if m.cursor < len(m.plugins) {
+				return m, func() tea.Msg {
+					return NavigateMsg{Screen: ScreenPluginDetail, Data: &m.plugins[m.cursor]}
+				}
+			}
 		case "d":
 			if len(m.plugins) > 0 && m.cursor < len(m.plugins) {
 				return m, func() tea.Msg {
 					return NavigateMsg{Screen: ScreenPluginRemove, Data: &m.plugins[m.cursor]}
 				}
 			}
+		case "c":
+			if len(m.plugins) > 0 && m.cursor < len(m.plugins) {
+				return m, func() tea.Msg {
+					return NavigateMsg{Screen: ScreenPluginConfig, Data: &m.plugins[m.cursor]}
+				}
+			}
+		case " ", "space":
+			if m.cursor < len(m.plugins) {
+				name := m.plugins[m.cursor].Name
+				if m.marked[name] {
+					delete(m.marked, name)
+				} else {
+					m.marked[name] = true
+				}
+			}
+		case "a":
+			for _, plugin := range m.plugins {
+				m.marked[plugin.Name] = true
+			}
+		case "n":
+			m.marked = make(map[string]bool)
+		case "e":
+			if m.loading {
+				return m, nil
+			}
+			if len(m.marked) > 0 {
+				return m, m.confirmBulk(true)
+			}
+			if m.cursor >= len(m.plugins) {
+				return m, nil
+			}
+			return m, m.toggle(m.plugins[m.cursor])
+		case "x":
+			if len(m.marked) > 0 && !m.loading {
+				return m, m.confirmBulk(false)
+			}
+		case switchKeyKey:
+			if permissionDenied(m.err) && !m.loading {
+				return m, switchKey(m.api, m.server)
+			}
 		case "r":
+			// A reload is already running
+			if m.loading {
+				return m, nil
+			}
 			m.loading = true
 			return m, m.loadPlugins()
+		case "m":
+			if m.pager.more() && !m.loading && !m.pager.loading {
+				return m, m.loadPluginsPage(m.pager.next())
+			}
+		case "/":
+			return m, m.search.edit()
+		case "l":
+			return m, m.filter.edit()
+		case "s":
+			m.sort = (m.sort + 1) % len(pluginSorts)
+			m.applyView()
+		case "tab":
+			m.status = m.status.next()
+			m.applyView()
 		case "esc":
+			// Esc drops the selection and active filters before leaving the
+			// screen
+			if len(m.marked) > 0 {
+				m.marked = make(map[string]bool)
+				return m, nil
+			}
+			if m.search.active() {
+				m.search.clear()
+				m.applyView()
+				return m, nil
+			}
+			if m.filter.active() {
+				m.status = showAllPlugins
+				m.filter.clear()
+				if !m.loading {
+					m.loading = true
+					return m, m.loadPlugins()
+				}
+				return m, nil
+			}
+			if m.status != showAllPlugins {
+				m.status = showAllPlugins
+				m.applyView()
+				return m, nil
+			}
 			return m, goBack()
 		}
 	}
@@ -100,29 +449,145 @@ func (m *PluginsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// toggle disables an enabled plugin, or enables a disabled one. With several
+// versions installed the user picks the version first, unless they opted out
+// in settings.
+func (m *PluginsModel) toggle(plugin api.PluginInfo) tea.Cmd {
+	if plugin.Enabled {
+		m.loading = true
+		return togglePlugin(m.api, plugin, false, "", m.verify())
+	}
+	if len(plugin.Versions) > 1 && m.db.ChooseEnableVersion() {
+		return func() tea.Msg {
+			return NavigateMsg{Screen: ScreenPluginEnable, Data: &plugin}
+		}
+	}
+	m.loading = true
+	return togglePlugin(m.api, plugin, true, "", m.verify())
+}
+
+// verify reports whether server health is re-checked after a toggle, as set
+// for installs in settings
+func (m *PluginsModel) verify() bool {
+	return m.db != nil && m.db.GetConfigBool(db.ConfigVerifyInstalls)
+}
+
+// confirmBulk asks before enabling or disabling the marked plugins. Plugins
+// already in that state are left out.
+func (m *PluginsModel) confirmBulk(enable bool) tea.Cmd {
+	bulk := &bulkToggle{enable: enable}
+	for _, plugin := range m.all {
+		if m.marked[plugin.Name] && plugin.Enabled != enable {
+			bulk.plugins = append(bulk.plugins, plugin)
+		}
+	}
+	if len(bulk.plugins) == 0 {
+		state := "disabled"
+		if enable {
+			state = "enabled"
+		}
+		return func() tea.Msg {
+			return messages.ShowInfo("The selected plugins are already " + state)
+		}
+	}
+	m.bulk = bulk
+	return nil
+}
+
+func (m *PluginsModel) updateBulkConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y", "enter":
+		bulk := m.bulk
+		m.bulk = nil
+		m.marked = make(map[string]bool)
+		m.loading = true
+		return m, togglePlugins(m.api, bulk.plugins, bulk.enable, m.verify())
+	case "n", "N", "esc":
+		m.bulk = nil
+	}
+	return m, nil
+}
+
+// applyView narrows the loaded plugins to the name and status filters and
+// sorts them, keeping the cursor on the highlighted plugin while it matches
+func (m *PluginsModel) applyView() {
+	var selected string
+	if m.cursor < len(m.plugins) {
+		selected = m.plugins[m.cursor].Name
+	}
+
+	m.plugins = m.plugins[:0:0]
+	for _, plugin := range m.all {
+		if m.status.matches(plugin) && m.search.matches(plugin.Name) {
+			m.plugins = append(m.plugins, plugin)
+		}
+	}
+	sortList(m.plugins, pluginSorts[m.sort])
+	m.cursor = cursorOn(m.plugins, func(p api.PluginInfo) string { return p.Name }, selected, m.cursor)
+}
+
+// Typing reports whether the search or label filter is being edited
+func (m *PluginsModel) Typing() bool {
+	return m.filter.editing || m.search.editing
+}
+
 func (m *PluginsModel) View() string {
 	innerWidth := layout.InnerWidth(m.width)
 
 	titleText := "PLUGINS"
 	if !m.loading {
-		enabled := 0
-		for _, p := range m.plugins {
-			if p.Enabled {
-				enabled++
+		switch {
+		case m.status == showAllPlugins && !m.search.active():
+			enabled := 0
+			for _, p := range m.plugins {
+				if p.Enabled {
+					enabled++
+				}
 			}
+			titleText += fmt.Sprintf(" (%d enabled of %d)", enabled, len(m.plugins))
+		case m.status == showAllPlugins:
+			titleText += fmt.Sprintf(" (%d of %d)", len(m.plugins), len(m.all))
+		default:
+			titleText += fmt.Sprintf(" (%d %s of %d)", len(m.plugins), m.status, len(m.all))
 		}
-		titleText += fmt.Sprintf(" (%d enabled of %d)", enabled, len(m.plugins))
+	}
+	if m.search.active() {
+		titleText += fmt.Sprintf(" · %q", m.search.query())
+	}
+	if m.filter.active() {
+		titleText += " · " + m.filter.selector.String()
+	}
+	if order := pluginSorts[m.sort].name; order != "" {
+		titleText += " · by " + order
+	}
+	if len(m.marked) > 0 {
+		titleText += fmt.Sprintf(" · %d selected", len(m.marked))
 	}
 
 	var content strings.Builder
-	if m.loading {
-		content.WriteString(styles.TextMuted.Render("Loading...") + "\n")
+	if m.filter.editing {
+		content.WriteString(m.filter.view())
+	}
+	if m.search.editing {
+		content.WriteString(m.search.view())
+	}
+	if m.bulk != nil {
+		content.WriteString(m.renderBulkConfirm(innerWidth))
+	} else if m.loading {
+		header, widths := m.columns(innerWidth)
+		content.WriteString(layout.ListHeader(header, innerWidth))
+		content.WriteString(components.SkeletonRows(components.SkeletonRowCount, widths))
 	} else if m.err != nil {
 		content.WriteString(styles.TextError.Render("Error: "+m.err.Error()) + "\n")
+		if permissionDenied(m.err) {
+			content.WriteString(renderSwitchKeyHint())
+		}
+	} else if len(m.plugins) == 0 && (m.filtered() || m.status != showAllPlugins) {
+		content.WriteString(layout.CenterText(styles.TextMuted.Render(m.noMatchText()), innerWidth) + "\n")
 	} else if len(m.plugins) == 0 {
 		content.WriteString(m.renderEmptyState(innerWidth))
 	} else {
-		content.WriteString(m.renderPluginList(innerWidth))
+		content.WriteString(m.renderPluginList(innerWidth, strings.Count(content.String(), "\n")))
 	}
 
 	return layout.Page(layout.PageConfig{
@@ -136,27 +601,40 @@ func (m *PluginsModel) View() string {
 	})
 }
 
-func (m *PluginsModel) renderPluginList(width int) string {
-	var b strings.Builder
-
-	// Column widths
+// columns returns the list's header line and column widths, shared by the
+// rows and the loading skeleton
+func (m *PluginsModel) columns(width int) (string, []int) {
 	statusWidth := 8
 	nameWidth := 25
 	versionWidth := 12
 	baseWidth := width - statusWidth - nameWidth - versionWidth - 6
 
-	// Header
-	headerLine := fmt.Sprintf("  %-*s %-*s %-*s %-*s",
+	header := fmt.Sprintf("  %-*s %-*s %-*s %-*s",
 		statusWidth, "STATUS",
 		nameWidth, "NAME",
 		versionWidth, "VERSION",
 		baseWidth, "BASE",
 	)
-	b.WriteString(styles.TextMuted.Render(headerLine) + "\n")
-	b.WriteString(styles.TextMuted.Render(strings.Repeat("─", width)) + "\n")
+	return header, []int{statusWidth, nameWidth, versionWidth, baseWidth}
+}
+
+// renderPluginList renders the rows that fit below the reserved lines
+// already written above the list
+func (m *PluginsModel) renderPluginList(width, reserved int) string {
+	var b strings.Builder
+
+	headerLine, widths := m.columns(width)
+	statusWidth, nameWidth, versionWidth, baseWidth := widths[0], widths[1], widths[2], widths[3]
+	b.WriteString(layout.ListHeader(headerLine, width))
+
+	footer := m.pager.footer(len(m.all))
+	details := m.renderDetails(width)
+	rows := layout.ListRows(m.height, reserved+strings.Count(footer+details, "\n"))
+	m.offset = layout.ScrollOffset(m.offset, m.cursor, len(m.plugins), rows)
 
 	// Rows
-	for i, plugin := range m.plugins {
+	for i := m.offset; i < min(len(m.plugins), m.offset+rows); i++ {
+		plugin := m.plugins[i]
 		cursor := "  "
 		if i == m.cursor {
 			cursor = styles.Caret
@@ -166,6 +644,13 @@ func (m *PluginsModel) renderPluginList(width int) string {
 		if plugin.Enabled {
 			status = styles.CheckEnabled
 		}
+		if len(m.marked) > 0 {
+			checkbox := styles.CheckboxUnchecked
+			if m.marked[plugin.Name] {
+				checkbox = styles.CheckboxChecked
+			}
+			status = checkbox + " " + status
+		}
 
 		version := "-"
 		if len(plugin.Versions) > 0 {
@@ -192,9 +677,69 @@ func (m *PluginsModel) renderPluginList(width int) string {
 		b.WriteString(cursor + line + "\n")
 	}
 
+	b.WriteString(footer)
+	b.WriteString(details)
+	return b.String()
+}
+
+func (m *PluginsModel) renderBulkConfirm(width int) string {
+	verb := "Disable"
+	if m.bulk.enable {
+		verb = "Enable"
+	}
+	items := make([]layout.ConfirmModalItem, len(m.bulk.plugins))
+	for i, plugin := range m.bulk.plugins {
+		items[i] = layout.ConfirmModalItem{Label: "Plugin", Value: plugin.Name}
+	}
+	return layout.ConfirmModal(layout.ConfirmModalConfig{
+		Width:   width - 4,
+		Title:   fmt.Sprintf("%s %d plugins?", verb, len(m.bulk.plugins)),
+		Warning: "These plugins will be " + strings.ToLower(verb) + "d on " + m.server.Name + ":",
+		Items:   items,
+		Simple:  true,
+	})
+}
+
+// renderDetails shows the provenance and labels of the selected item, when
+// the server has them
+func (m *PluginsModel) renderDetails(width int) string {
+	var b strings.Builder
+	if m.cursor < len(m.plugins) {
+		selected := m.plugins[m.cursor]
+		if (selected.Provenance != nil || len(selected.Labels) > 0) && !layout.Compact() {
+			b.WriteString("\n")
+		}
+		if selected.Provenance != nil {
+			b.WriteString(styles.TextMuted.Render(styles.Truncate("Installed by "+selected.Provenance.String(), width)) + "\n")
+		}
+		if len(selected.Labels) > 0 {
+			b.WriteString(styles.TextMuted.Render(styles.Truncate("Labels: "+selected.Labels.String(), width)) + "\n")
+		}
+	}
+
 	return b.String()
 }
 
+// noMatchText explains an empty list caused by the active filters
+func (m *PluginsModel) noMatchText() string {
+	text := "No plugins"
+	if m.status != showAllPlugins {
+		text = fmt.Sprintf("No %s plugins", m.status)
+	}
+	if m.search.active() {
+		text += fmt.Sprintf(" named %q", m.search.query())
+	}
+	if m.filter.active() {
+		text += " match " + m.filter.selector.String()
+	}
+	return text + "."
+}
+
+// filtered reports whether a name or label filter is narrowing the list
+func (m *PluginsModel) filtered() bool {
+	return m.search.active() || m.filter.active()
+}
+
 func (m *PluginsModel) renderEmptyState(width int) string {
 	var b strings.Builder
 
@@ -206,19 +751,81 @@ func (m *PluginsModel) renderEmptyState(width int) string {
 }
 
 func (m *PluginsModel) getShortcuts() []string {
+	if m.filter.editing {
+		return m.filter.shortcuts()
+	}
+	if m.search.editing {
+		return m.search.shortcuts()
+	}
+	if m.bulk != nil {
+		return []string{
+			styles.RenderShortcut("y", i18n.T("shortcut.confirm")),
+			styles.RenderShortcut("n/Esc", i18n.T("shortcut.cancel")),
+		}
+	}
+
 	shortcuts := []string{
-		styles.RenderShortcut("↑↓", "navigate"),
-		styles.RenderShortcut("i", "install"),
+		styles.RenderShortcut("↑↓", i18n.T("shortcut.navigate")),
+		styles.RenderShortcut("i", i18n.T("shortcut.install")),
 	}
 
-	if len(m.plugins) > 0 {
-		shortcuts = append(shortcuts, styles.RenderShortcut("d", "delete"))
+	if len(m.marked) > 0 {
+		shortcuts = append(shortcuts,
+			styles.RenderShortcut("Space", i18n.T("shortcut.select")),
+			styles.RenderShortcut("a", i18n.T("shortcut.all")),
+			styles.RenderShortcut("n", i18n.T("shortcut.none")),
+			styles.RenderShortcut("e", i18n.T("shortcut.enable")),
+			styles.RenderShortcut("x", i18n.T("shortcut.disable")),
+		)
+	} else if len(m.plugins) > 0 {
+		toggle := i18n.T("shortcut.enable")
+		if m.cursor < len(m.plugins) && m.plugins[m.cursor].Enabled {
+			toggle = i18n.T("shortcut.disable")
+		}
+		shortcuts = append(shortcuts,
+			styles.RenderShortcut("⏎", i18n.T("shortcut.details")),
+			styles.RenderShortcut("Space", i18n.T("shortcut.select")),
+			styles.RenderShortcut("d", i18n.T("shortcut.delete")),
+			styles.RenderShortcut("e", toggle),
+			styles.RenderShortcut("c", i18n.T("shortcut.configure")),
+		)
+	}
+
+	var showNext string
+	switch m.status.next() {
+	case showEnabledPlugins:
+		showNext = i18n.T("shortcut.show_enabled")
+	case showDisabledPlugins:
+		showNext = i18n.T("shortcut.show_disabled")
+	default:
+		showNext = i18n.T("shortcut.show_all")
+	}
+
+	if m.pager.more() {
+		shortcuts = append(shortcuts, styles.RenderShortcut("m", i18n.T("shortcut.more")))
 	}
 
 	shortcuts = append(shortcuts,
-		styles.RenderShortcut("r", "refresh"),
-		styles.RenderShortcut("Esc", "back"),
+		styles.RenderShortcut("/", i18n.T("shortcut.filter")),
+		styles.RenderShortcut("l", i18n.T("shortcut.labels")),
+		styles.RenderShortcut("s", i18n.T("shortcut.sort")),
+		styles.RenderShortcut("Tab", showNext),
+		styles.RenderShortcut("r", i18n.T("shortcut.refresh")),
 	)
 
+	if permissionDenied(m.err) {
+		shortcuts = append(shortcuts, switchKeyShortcut())
+	}
+
+	// With a selection Esc clears it, like n
+	if len(m.marked) > 0 {
+		return shortcuts
+	}
+	if m.filtered() || m.status != showAllPlugins {
+		shortcuts = append(shortcuts, styles.RenderShortcut("Esc", i18n.T("shortcut.clear_filter")))
+	} else {
+		shortcuts = append(shortcuts, styles.RenderShortcut("Esc", i18n.T("shortcut.back")))
+	}
+
 	return shortcuts
 }
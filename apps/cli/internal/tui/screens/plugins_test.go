@@ -0,0 +1,74 @@
+package screens
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/tui/components"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPluginsBulkEnableConfirmsTheMarkedPlugins(t *testing.T) {
+	m := NewPluginsModel(nil, nil, &db.Server{Name: "prod"}, 100, 40)
+	m.Update(pluginsLoadedMsg{plugins: []api.PluginInfo{
+		{ID: 1, Name: "auth"}, {ID: 2, Name: "metrics", Enabled: true}, {ID: 3, Name: "cache"},
+	}})
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(tea.KeyMsg{Type: tea.KeySpace}) // Unmark cache
+	if len(m.marked) != 2 || m.marked["cache"] {
+		t.Fatalf("expected auth and metrics marked, got %v", m.marked)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	if m.bulk == nil || len(m.bulk.plugins) != 1 || m.bulk.plugins[0].Name != "auth" {
+		t.Fatalf("expected to confirm enabling only auth, got %+v", m.bulk)
+	}
+	if view := m.View(); !strings.Contains(view, "Enable 1 plugins?") {
+		t.Fatalf("expected a confirmation, got:\n%s", view)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.bulk != nil || len(m.marked) != 2 {
+		t.Fatal("expected esc to cancel and keep the selection")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if len(m.marked) != 0 {
+		t.Fatal("expected n to clear the selection")
+	}
+}
+
+func TestPluginsBulkToggleSummary(t *testing.T) {
+	m := NewPluginsModel(nil, nil, &db.Server{Name: "prod"}, 100, 40)
+	msg := pluginsToggledMsg{enable: true, done: 3, failed: []string{"auth: forbidden"}}
+	if _, cmd := m.Update(msg); cmd == nil || !m.loading {
+		t.Fatal("expected the list to reload once")
+	}
+
+	toast := msg.toast()
+	if toast.Type != components.ToastError || toast.Message != "Enabled 3, 1 failed (auth: forbidden)" {
+		t.Fatalf("expected a summary toast, got %#v", toast)
+	}
+	if toast := (pluginsToggledMsg{done: 2}).toast(); toast.Message != "Disabled 2 plugins" {
+		t.Fatalf("got %q", toast.Message)
+	}
+}
+
+func TestPluginToggleReportsAnUnhealthyServer(t *testing.T) {
+	unhealthy := errors.New("server reported unhealthy status: degraded")
+
+	toast := pluginToggledMsg{name: "auth", enabled: true, healthErr: unhealthy}.toast()
+	if toast.Type != components.ToastWarning || toast.Message != "Server became unhealthy after enabling auth: server reported unhealthy status: degraded" {
+		t.Fatalf("got %#v", toast)
+	}
+	toast = pluginsToggledMsg{done: 2, failed: []string{"cache: forbidden"}, healthErr: unhealthy}.toast()
+	if toast.Type != components.ToastWarning || !strings.HasPrefix(toast.Message, "Server became unhealthy after disabling 2 plugins (1 failed): ") {
+		t.Fatalf("got %#v", toast)
+	}
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/buntime/cli/internal/api"
 	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/i18n"
+	"github.com/buntime/cli/internal/tui/components"
 	"github.com/buntime/cli/internal/tui/layout"
 	"github.com/buntime/cli/internal/tui/styles"
 	tea "github.com/charmbracelet/bubbletea"
@@ -24,48 +26,70 @@ const (
 // RemoveModel handles version removal
 type RemoveModel struct {
 	api          *api.Client
+	db           *db.DB
 	server       *db.Server
 	itemType     string // "app" or "plugin"
 	name         string
 	pluginID     int // Only used for plugins (API uses ID)
 	versions     []string
+	current      int // Index of the version that runs now
 	selected     map[int]bool
 	cursor       int
 	state        removeState
-	confirmInput string
+	confirmInput *components.ConfirmInput
+	confirm      confirmPolicy
 	err          error
 	width        int
 	height       int
 }
 
 // NewRemoveModel creates a remove screen for apps
-func NewRemoveModel(client *api.Client, server *db.Server, itemType, name string, versions []string, width, height int) *RemoveModel {
+func NewRemoveModel(client *api.Client, database *db.DB, server *db.Server, itemType, name string, versions []string, width, height int) *RemoveModel {
 	return &RemoveModel{
-		api:      client,
-		server:   server,
-		itemType: itemType,
-		name:     name,
-		versions: versions,
-		selected: make(map[int]bool),
-		state:    removeStateSelect,
-		width:    width,
-		height:   height,
+		api:          client,
+		db:           database,
+		confirm:      loadConfirmPolicy(database),
+		confirmInput: components.NewConfirmInput("remove"),
+		server:       server,
+		itemType:     itemType,
+		name:         name,
+		versions:     versions,
+		selected:     make(map[int]bool),
+		state:        removeStateSelect,
+		width:        width,
+		height:       height,
 	}
 }
 
+// NewRemoveAppModel creates a remove screen for an app, marking the version
+// the server runs as current
+func NewRemoveAppModel(client *api.Client, database *db.DB, server *db.Server, app *api.AppInfo, width, height int) *RemoveModel {
+	m := NewRemoveModel(client, database, server, "app", app.Name, app.Versions, width, height)
+	current := app.CurrentVersion()
+	for i, version := range app.Versions {
+		if version == current {
+			m.current = i
+		}
+	}
+	return m
+}
+
 // NewRemovePluginModel creates a remove screen for plugins (uses ID)
-func NewRemovePluginModel(client *api.Client, server *db.Server, plugin *api.PluginInfo, width, height int) *RemoveModel {
+func NewRemovePluginModel(client *api.Client, database *db.DB, server *db.Server, plugin *api.PluginInfo, width, height int) *RemoveModel {
 	return &RemoveModel{
-		api:      client,
-		server:   server,
-		itemType: "plugin",
-		name:     plugin.Name,
-		pluginID: plugin.ID,
-		versions: plugin.Versions,
-		selected: make(map[int]bool),
-		state:    removeStateConfirm, // Skip selection, go directly to confirm
-		width:    width,
-		height:   height,
+		api:          client,
+		db:           database,
+		confirm:      loadConfirmPolicy(database),
+		confirmInput: components.NewConfirmInput("remove"),
+		server:       server,
+		itemType:     "plugin",
+		name:         plugin.Name,
+		pluginID:     plugin.ID,
+		versions:     plugin.Versions,
+		selected:     make(map[int]bool),
+		state:        removeStateConfirm, // Skip selection, go directly to confirm
+		width:        width,
+		height:       height,
 	}
 }
 
@@ -80,7 +104,18 @@ func (m *RemoveModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		return m, nil
 
+	case KeySwitchedMsg:
+		if m.state == removeStateFailed && permissionDenied(m.err) {
+			m.state = removeStateRemoving
+			m.err = nil
+			return m, m.remove()
+		}
+		return m, nil
+
 	case tea.KeyMsg:
+		if msg.String() == switchKeyKey && m.state == removeStateFailed && permissionDenied(m.err) {
+			return m, switchKey(m.api, m.server)
+		}
 		switch m.state {
 		case removeStateSelect:
 			return m.updateSelect(msg)
@@ -101,10 +136,10 @@ func (m *RemoveModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.err != nil {
 			m.state = removeStateFailed
 			m.err = msg.err
-			return m, nil
+			return m, alertDone(m.db, "Removal failed", "Removing "+m.name+" from "+m.server.Name+" failed")
 		}
 		m.state = removeStateSuccess
-		return m, nil
+		return m, alertDone(m.db, "Removal finished", "Removed "+m.name+" from "+m.server.Name)
 	}
 
 	return m, nil
@@ -143,32 +178,45 @@ func (m *RemoveModel) updateSelect(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// isHighRisk reports whether the removal takes down something that is in use:
+// a whole plugin or the current version of an app
+func (m *RemoveModel) isHighRisk() bool {
+	return m.itemType == "plugin" || m.selected[m.current]
+}
+
 func (m *RemoveModel) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "backspace":
-		if len(m.confirmInput) > 0 {
-			m.confirmInput = m.confirmInput[:len(m.confirmInput)-1]
-		}
-	case "enter":
-		if m.confirmInput == "remove" {
+	if !m.confirm.requiresTyping(m.isHighRisk()) {
+		switch msg.String() {
+		case "y", "Y", "enter":
 			m.state = removeStateRemoving
 			return m, m.remove()
+		case "n", "N", "esc":
+			return m.cancelConfirm()
 		}
-	case "esc":
-		// For plugins, go back to plugins list (no version selection screen)
-		if m.itemType == "plugin" {
-			return m, func() tea.Msg {
-				return NavigateMsg{Screen: ScreenPlugins, Data: nil, ReplaceHistory: true}
-			}
-		}
-		// For apps, go back to version selection
-		m.state = removeStateSelect
-		m.confirmInput = ""
-	default:
-		if len(msg.String()) == 1 && len(m.confirmInput) < 10 {
-			m.confirmInput += msg.String()
+		return m, nil
+	}
+
+	if msg.String() == "esc" {
+		return m.cancelConfirm()
+	}
+	submitted, cmd := m.confirmInput.Update(msg)
+	if submitted {
+		m.state = removeStateRemoving
+		return m, m.remove()
+	}
+	return m, cmd
+}
+
+func (m *RemoveModel) cancelConfirm() (tea.Model, tea.Cmd) {
+	// For plugins, go back to plugins list (no version selection screen)
+	if m.itemType == "plugin" {
+		return m, func() tea.Msg {
+			return NavigateMsg{Screen: ScreenPlugins, Data: nil, ReplaceHistory: true}
 		}
 	}
+	// For apps, go back to version selection
+	m.state = removeStateSelect
+	m.confirmInput.Reset()
 	return m, nil
 }
 
@@ -211,6 +259,11 @@ type removeResultMsg struct {
 	err error
 }
 
+// Typing reports whether the removal is confirmed by typing
+func (m *RemoveModel) Typing() bool {
+	return m.state == removeStateConfirm && m.confirm.requiresTyping(m.isHighRisk())
+}
+
 func (m *RemoveModel) View() string {
 	innerWidth := layout.InnerWidth(m.width)
 	titleText := fmt.Sprintf("REMOVE %s", strings.ToUpper(m.itemType))
@@ -268,7 +321,7 @@ func (m *RemoveModel) renderSelect() string {
 		}
 
 		versionText := version
-		if i == 0 {
+		if i == m.current {
 			versionText += styles.TextMuted.Render(" (current)")
 		}
 
@@ -287,7 +340,7 @@ func (m *RemoveModel) renderSelect() string {
 	b.WriteString(styles.TextMuted.Render(fmt.Sprintf("%d version(s) selected", count)))
 
 	// Warning for current version
-	if m.selected[0] && len(m.versions) > 0 {
+	if m.selected[m.current] && len(m.versions) > 0 {
 		b.WriteString("\n\n")
 		b.WriteString(styles.TextWarning.Render(
 			"WARNING: Removing current version will disable this " + m.itemType))
@@ -323,11 +376,11 @@ func (m *RemoveModel) renderConfirm(width int) string {
 	}
 
 	return layout.ConfirmModal(layout.ConfirmModalConfig{
-		Width:        width - 4,
-		Warning:      "You are about to remove:",
-		Items:        items,
-		ConfirmWord:  "remove",
-		CurrentInput: m.confirmInput,
+		Width:   width - 4,
+		Warning: i18n.T("remove.warning"),
+		Items:   items,
+		Input:   m.confirmInput,
+		Simple:  !m.confirm.requiresTyping(m.isHighRisk()),
 	})
 }
 
@@ -373,6 +426,9 @@ func (m *RemoveModel) renderFailed() string {
 		b.WriteString(styles.TextError.Render("Error: "+m.err.Error()) + "\n\n")
 	}
 
+	if permissionDenied(m.err) {
+		b.WriteString(renderSwitchKeyHint())
+	}
 	b.WriteString(styles.TextMuted.Render("Press any key to go back.") + "\n")
 
 	return b.String()
@@ -382,22 +438,36 @@ func (m *RemoveModel) getShortcuts() []string {
 	switch m.state {
 	case removeStateSelect:
 		return []string{
-			styles.RenderShortcut("↑↓", "navigate"),
-			styles.RenderShortcut("space", "toggle"),
-			styles.RenderShortcut("a", "all"),
-			styles.RenderShortcut("n", "none"),
-			styles.RenderShortcut("⏎", "confirm"),
-			styles.RenderShortcut("Esc", "cancel"),
+			styles.RenderShortcut("↑↓", i18n.T("shortcut.navigate")),
+			styles.RenderShortcut("space", i18n.T("shortcut.toggle")),
+			styles.RenderShortcut("a", i18n.T("shortcut.all")),
+			styles.RenderShortcut("n", i18n.T("shortcut.none")),
+			styles.RenderShortcut("⏎", i18n.T("shortcut.confirm")),
+			styles.RenderShortcut("Esc", i18n.T("shortcut.cancel")),
 		}
 	case removeStateConfirm:
-		return []string{
-			styles.RenderShortcut("Esc", "cancel"),
+		if !m.confirm.requiresTyping(m.isHighRisk()) {
+			return []string{
+				styles.RenderShortcut("y", i18n.T("shortcut.confirm")),
+				styles.RenderShortcut("n/Esc", i18n.T("shortcut.cancel")),
+			}
 		}
+		return confirmInputShortcuts(m.confirmInput)
 	case removeStateRemoving:
 		return []string{}
+	case removeStateFailed:
+		if permissionDenied(m.err) {
+			return []string{
+				switchKeyShortcut(),
+				styles.RenderShortcut("any key", i18n.T("shortcut.back")),
+			}
+		}
+		return []string{
+			styles.RenderShortcut("any key", i18n.T("shortcut.continue")),
+		}
 	default:
 		return []string{
-			styles.RenderShortcut("any key", "continue"),
+			styles.RenderShortcut("any key", i18n.T("shortcut.continue")),
 		}
 	}
 }
@@ -0,0 +1,210 @@
+package screens
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/i18n"
+	"github.com/buntime/cli/internal/tui/components"
+	"github.com/buntime/cli/internal/tui/layout"
+	"github.com/buntime/cli/internal/tui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// configRow is one setting of the server configuration, with nested keys
+// joined by dots, e.g. "pool.size"
+type configRow struct {
+	key   string
+	value string
+}
+
+// flattenConfig lists the leaves of a configuration, sorted by key. Arrays
+// are shown as JSON rather than split into rows.
+func flattenConfig(cfg map[string]any) []configRow {
+	var rows []configRow
+	var walk func(prefix string, v any)
+	walk = func(prefix string, v any) {
+		nested, ok := v.(map[string]any)
+		if !ok || len(nested) == 0 {
+			rows = append(rows, configRow{key: prefix, value: formatConfigValue(v)})
+			return
+		}
+		for key, value := range nested {
+			walk(prefix+"."+key, value)
+		}
+	}
+	for key, value := range cfg {
+		walk(key, value)
+	}
+
+	sort.Slice(rows, func(i, j int) bool { return rows[i].key < rows[j].key })
+	return rows
+}
+
+// ServerConfigModel shows the runtime's own configuration, read-only
+type ServerConfigModel struct {
+	api     *api.Client
+	server  *db.Server
+	rows    []configRow
+	offset  int // First visible row
+	loading bool
+	err     error
+	width   int
+	height  int
+}
+
+// NewServerConfigModel creates the server configuration screen
+func NewServerConfigModel(client *api.Client, server *db.Server, width, height int) *ServerConfigModel {
+	return &ServerConfigModel{
+		api:     client,
+		server:  server,
+		loading: true,
+		width:   width,
+		height:  height,
+	}
+}
+
+type serverConfigLoadedMsg struct {
+	config map[string]any
+	err    error
+}
+
+func (m *ServerConfigModel) Init() tea.Cmd {
+	return m.loadConfig()
+}
+
+func (m *ServerConfigModel) loadConfig() tea.Cmd {
+	return func() tea.Msg {
+		cfg, err := m.api.GetServerConfig()
+		return serverConfigLoadedMsg{config: cfg, err: err}
+	}
+}
+
+func (m *ServerConfigModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case serverConfigLoadedMsg:
+		if canceled(msg.err) {
+			return m, nil
+		}
+		m.loading = false
+		m.err = msg.err
+		if msg.err == nil {
+			m.rows = flattenConfig(msg.config)
+			m.offset = 0
+		}
+		return m, nil
+
+	case KeySwitchedMsg:
+		if !permissionDenied(m.err) {
+			return m, nil
+		}
+		m.loading = true
+		m.err = nil
+		return m, m.loadConfig()
+
+	case tea.KeyMsg:
+		if m.loading {
+			if msg.String() == "esc" {
+				return m, goBack()
+			}
+			return m, nil
+		}
+		switch msg.String() {
+		case switchKeyKey:
+			if permissionDenied(m.err) {
+				return m, switchKey(m.api, m.server)
+			}
+		case "up", "k":
+			m.offset = max(0, m.offset-1)
+		case "down", "j":
+			m.offset = min(m.offset+1, max(0, len(m.rows)-m.visibleRows()))
+		case "r":
+			m.loading = true
+			m.err = nil
+			return m, m.loadConfig()
+		case "esc":
+			return m, goBack()
+		}
+	}
+
+	return m, nil
+}
+
+func (m *ServerConfigModel) visibleRows() int {
+	return layout.ListRows(m.height, 0)
+}
+
+func (m *ServerConfigModel) View() string {
+	innerWidth := layout.InnerWidth(m.width)
+
+	var content strings.Builder
+	apiErr, _ := m.err.(*api.APIError)
+	switch {
+	case m.loading:
+		content.WriteString(components.SkeletonRows(components.SkeletonRowCount, []int{20, 40}))
+	case apiErr != nil && apiErr.Type == api.ErrorTypeUnsupported:
+		content.WriteString(layout.CenterText(styles.TextMuted.Render("This server does not expose its configuration."), innerWidth) + "\n")
+	case permissionDenied(m.err):
+		// Not every key may read the runtime's settings; that's not a failure
+		content.WriteString(styles.TextWarning.Render("This key is not allowed to view the server configuration.") + "\n")
+		content.WriteString(renderSwitchKeyHint())
+	case m.err != nil:
+		content.WriteString(styles.TextError.Render("Error: "+m.err.Error()) + "\n")
+	case len(m.rows) == 0:
+		content.WriteString(layout.CenterText(styles.TextMuted.Render("The server reported no configuration."), innerWidth) + "\n")
+	default:
+		content.WriteString(m.renderRows(innerWidth))
+	}
+
+	return layout.Page(layout.PageConfig{
+		Width:      m.width,
+		Height:     m.height,
+		Server:     m.server,
+		Breadcrumb: "Main › Settings › Server Configuration",
+		Title:      "SERVER CONFIGURATION",
+		Content:    content.String(),
+		Shortcuts:  m.getShortcuts(),
+	})
+}
+
+func (m *ServerConfigModel) renderRows(width int) string {
+	var b strings.Builder
+
+	keyWidth := 0
+	for _, row := range m.rows {
+		keyWidth = max(keyWidth, len(row.key))
+	}
+	keyWidth = min(keyWidth, width/3)
+	valueWidth := max(10, width-keyWidth-4)
+
+	rows := m.visibleRows()
+	m.offset = max(0, min(m.offset, len(m.rows)-rows))
+	for i := m.offset; i < min(len(m.rows), m.offset+rows); i++ {
+		row := m.rows[i]
+		key := styles.TextMuted.Render(styles.PadRight(styles.Truncate(row.key, keyWidth), keyWidth))
+		b.WriteString("  " + key + "  " + styles.Truncate(row.value, valueWidth) + "\n")
+	}
+
+	return b.String()
+}
+
+func (m *ServerConfigModel) getShortcuts() []string {
+	var shortcuts []string
+	if len(m.rows) > m.visibleRows() {
+		shortcuts = append(shortcuts, styles.RenderShortcut("↑↓", i18n.T("shortcut.navigate")))
+	}
+	if permissionDenied(m.err) {
+		shortcuts = append(shortcuts, switchKeyShortcut())
+	}
+	return append(shortcuts,
+		styles.RenderShortcut("r", i18n.T("shortcut.refresh")),
+		styles.RenderShortcut("Esc", i18n.T("shortcut.back")),
+	)
+}
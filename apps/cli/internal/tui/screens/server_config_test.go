@@ -0,0 +1,41 @@
+package screens
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/buntime/cli/internal/api"
+)
+
+func TestFlattenConfigJoinsNestedKeys(t *testing.T) {
+	rows := flattenConfig(map[string]any{
+		"pool":     map[string]any{"size": float64(4), "idleTimeout": "30s"},
+		"features": []any{"gzip", "sessions"},
+		"debug":    false,
+	})
+
+	want := []configRow{
+		{key: "debug", value: "false"},
+		{key: "features", value: `["gzip","sessions"]`},
+		{key: "pool.idleTimeout", value: "30s"},
+		{key: "pool.size", value: "4"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("flattenConfig() = %v", rows)
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("row %d = %v, want %v", i, rows[i], want[i])
+		}
+	}
+}
+
+func TestServerConfigExplainsAMissingPermission(t *testing.T) {
+	m := NewServerConfigModel(nil, nil, 100, 40)
+	m.Update(serverConfigLoadedMsg{err: &api.APIError{Type: api.ErrorTypeForbidden, Message: "forbidden"}})
+
+	view := m.View()
+	if !strings.Contains(view, "not allowed to view the server configuration") || strings.Contains(view, "Error:") {
+		t.Fatalf("expected a permission note instead of an error, got:\n%s", view)
+	}
+}
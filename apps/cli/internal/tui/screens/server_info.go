@@ -0,0 +1,52 @@
+package screens
+
+import (
+	"sync"
+	"time"
+
+	"github.com/buntime/cli/internal/api"
+)
+
+// serverInfoTTL is how long a health result is reused before it is fetched
+// again
+const serverInfoTTL = 30 * time.Second
+
+// ServerInfoCache keeps the connected server's last health and version so
+// screens that show them don't refetch on every visit. There is one per
+// connection.
+type ServerInfoCache struct {
+	mu        sync.Mutex
+	health    *api.HealthInfo
+	fetchedAt time.Time
+	now       func() time.Time // Replaced in tests
+}
+
+// NewServerInfoCache creates an empty cache
+func NewServerInfoCache() *ServerInfoCache {
+	return &ServerInfoCache{now: time.Now}
+}
+
+// Health returns the cached health, or false when there is none or it is
+// older than serverInfoTTL. A nil cache never has anything.
+func (c *ServerInfoCache) Health() (*api.HealthInfo, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.health == nil || c.now().Sub(c.fetchedAt) > serverInfoTTL {
+		return nil, false
+	}
+	return c.health, true
+}
+
+// StoreHealth caches a freshly fetched health result
+func (c *ServerInfoCache) StoreHealth(health *api.HealthInfo) {
+	if c == nil || health == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.health = health
+	c.fetchedAt = c.now()
+}
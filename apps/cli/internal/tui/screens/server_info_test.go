@@ -0,0 +1,37 @@
+package screens
+
+import (
+	"testing"
+	"time"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/db"
+)
+
+func TestSettingsReusesCachedHealth(t *testing.T) {
+	t.Setenv("BUNTIME_CONFIG_DIR", db.MemoryDir)
+	database, err := db.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+
+	now := time.Unix(1_700_000_000, 0)
+	info := NewServerInfoCache()
+	info.now = func() time.Time { return now }
+	info.StoreHealth(&api.HealthInfo{OK: true, Version: "1.4.0"})
+
+	m := NewSettingsModel(nil, info, database, &db.Server{Name: "test"}, 100, 40)
+	if cmd := m.Init(); cmd != nil || m.loading || m.health.Version != "1.4.0" {
+		t.Fatal("expected Settings to show the cached health without fetching")
+	}
+
+	now = now.Add(serverInfoTTL + time.Second)
+	if _, ok := info.Health(); ok {
+		t.Fatal("expected the cached health to expire")
+	}
+	m = NewSettingsModel(nil, info, database, &db.Server{Name: "test"}, 100, 40)
+	if cmd := m.Init(); cmd == nil {
+		t.Fatal("expected Settings to fetch expired health")
+	}
+}
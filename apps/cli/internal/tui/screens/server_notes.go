@@ -0,0 +1,63 @@
+package screens
+
+import (
+	"strings"
+
+	"github.com/buntime/cli/internal/tui/layout"
+	"github.com/buntime/cli/internal/tui/styles"
+	"github.com/charmbracelet/bubbles/textarea"
+)
+
+const (
+	notesHeight   = 3
+	notesMaxChars = 1000
+
+	// notesIndicator marks servers that have notes in the server list
+	notesIndicator = "✎"
+)
+
+// newNotesInput creates the multi-line notes field shared by the server forms
+func newNotesInput(value string) textarea.Model {
+	input := textarea.New()
+	input.Placeholder = "Optional, e.g. prod us-east, on-call: Alice"
+	input.Prompt = ""
+	input.ShowLineNumbers = false
+	input.CharLimit = notesMaxChars
+	input.SetHeight(notesHeight)
+	input.SetValue(value)
+	input.Blur()
+	return input
+}
+
+// resizeNotesInput fits the notes field to the terminal like the text inputs
+func resizeNotesInput(input *textarea.Model, termWidth int) {
+	input.SetWidth(layout.InputWidth(termWidth, 40))
+}
+
+// normalizeNotes trims trailing whitespace so blank notes are stored empty
+func normalizeNotes(notes string) string {
+	lines := strings.Split(strings.TrimSpace(notes), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderNotes renders server notes as muted lines, showing at most maxLines
+// and truncating each line to width
+func renderNotes(notes string, width, maxLines int) string {
+	lines := strings.Split(notes, "\n")
+	more := maxLines > 0 && len(lines) > maxLines
+	if more {
+		lines = lines[:maxLines]
+	}
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(styles.TextMuted.Render(styles.Truncate(line, width)) + "\n")
+	}
+	if more {
+		b.WriteString(styles.TextMuted.Italic(true).Render("…") + "\n")
+	}
+	return b.String()
+}
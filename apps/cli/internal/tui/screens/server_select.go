@@ -1,13 +1,15 @@
 package screens
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/buntime/cli/internal/api"
 	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/i18n"
 	"github.com/buntime/cli/internal/tui/layout"
-	"github.com/buntime/cli/internal/tui/messages"
 	"github.com/buntime/cli/internal/tui/styles"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
@@ -15,6 +17,9 @@ import (
 	"github.com/dustin/go-humanize"
 )
 
+// pinIndicator marks pinned servers in the server list
+const pinIndicator = "★"
+
 // HealthStatus represents the health check result for a server
 type HealthStatus int
 
@@ -33,10 +38,14 @@ type ServerSelectModel struct {
 	spinner       spinner.Model
 	connecting    bool
 	connectingIdx int
+	attempt       int // Bumped per connection attempt so cancelled results are dropped
+	cancelConnect context.CancelFunc
+	cancelHealth  context.CancelFunc // Aborts the running health checks
 	width         int
 	height        int
 	err           error
 	healthStatus  map[int64]HealthStatus // server ID -> health status
+	selected      map[int64]bool         // server ID -> selected for batch operations
 
 	// Delete confirmation
 	confirmingDelete bool
@@ -56,6 +65,7 @@ func NewServerSelectModel(database *db.DB, width, height int) *ServerSelectModel
 		width:         width,
 		height:        height,
 		healthStatus:  make(map[int64]HealthStatus),
+		selected:      make(map[int64]bool),
 	}
 }
 
@@ -76,9 +86,18 @@ type serversLoadedMsg struct {
 	err     error
 }
 
+// serverPinnedMsg reorders the list after a server was pinned or unpinned,
+// keeping the cursor on it
+type serverPinnedMsg struct {
+	servers  []db.Server
+	serverID int64
+	err      error
+}
+
 type healthCheckMsg struct {
 	serverID int64
 	online   bool
+	canceled bool
 }
 
 func (m *ServerSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -94,6 +113,16 @@ func (m *ServerSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		m.servers = msg.servers
+		// Drop selections for servers that no longer exist
+		known := make(map[int64]bool, len(m.servers))
+		for _, server := range m.servers {
+			known[server.ID] = true
+		}
+		for id := range m.selected {
+			if !known[id] {
+				delete(m.selected, id)
+			}
+		}
 		// Reset cursor if out of bounds
 		if m.cursor >= len(m.servers) {
 			m.cursor = max(0, len(m.servers)-1)
@@ -101,8 +130,25 @@ func (m *ServerSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Start health checks for all servers
 		return m, m.checkAllHealth()
 
+	case serverPinnedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.servers = msg.servers
+		for i, server := range m.servers {
+			if server.ID == msg.serverID {
+				m.cursor = i
+			}
+		}
+		return m, nil
+
 	case healthCheckMsg:
-		if msg.online {
+		if msg.canceled {
+			if m.healthStatus[msg.serverID] == HealthChecking {
+				m.healthStatus[msg.serverID] = HealthUnknown
+			}
+		} else if msg.online {
 			m.healthStatus[msg.serverID] = HealthOnline
 		} else {
 			m.healthStatus[msg.serverID] = HealthOffline
@@ -118,21 +164,23 @@ func (m *ServerSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case connectionResultMsg:
-		m.connecting = false
+		if !m.connecting || msg.attempt != m.attempt {
+			return m, nil
+		}
+		m.stopConnecting()
 		idx := m.connectingIdx
 		m.connectingIdx = -1
 
 		if msg.err != nil {
-			if apiErr, ok := msg.err.(*api.APIError); ok {
-				if apiErr.Type == api.ErrorTypeAuthRequired {
-					if idx >= 0 && idx < len(m.servers) {
-						server := m.servers[idx]
-						return m, navigateToTokenPrompt(&server)
-					}
-				}
+			if idx < 0 || idx >= len(m.servers) {
+				return m, nil
+			}
+			server := m.servers[idx]
+			if apiErr, ok := msg.err.(*api.APIError); ok && apiErr.Type == api.ErrorTypeAuthRequired {
+				return m, navigateToTokenPrompt(&server)
 			}
 			return m, func() tea.Msg {
-				return messages.ShowError("Connection failed: " + msg.err.Error())
+				return NavigateMsg{Screen: ScreenConnectionError, Data: &ConnectionFailure{Server: &server, Err: msg.err}}
 			}
 		}
 
@@ -165,7 +213,7 @@ func (m *ServerSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		if m.connecting {
 			if msg.String() == "esc" {
-				m.connecting = false
+				m.stopConnecting()
 				m.connectingIdx = -1
 				return m, nil
 			}
@@ -185,6 +233,24 @@ func (m *ServerSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if len(m.servers) > 0 && m.cursor < len(m.servers) {
 				return m, m.connectToServer(&m.servers[m.cursor])
 			}
+		case " ", "space":
+			if len(m.servers) > 0 && m.cursor < len(m.servers) {
+				id := m.servers[m.cursor].ID
+				if m.selected[id] {
+					delete(m.selected, id)
+				} else {
+					m.selected[id] = true
+				}
+			}
+		case "b":
+			if targets := m.selectedServers(); len(targets) > 0 {
+				return m, func() tea.Msg {
+					return NavigateMsg{Screen: ScreenBatchInstall, Data: targets}
+				}
+			}
+		case "esc":
+			m.selected = make(map[int64]bool)
+			m.stopHealthChecks()
 		case "a":
 			return m, navigateToAddServer()
 		case "e":
@@ -197,8 +263,17 @@ func (m *ServerSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.deleteTarget = &m.servers[m.cursor]
 				return m, nil
 			}
+		case "o":
+			if len(m.servers) > 0 && m.cursor < len(m.servers) {
+				return m, openInBrowser(m.servers[m.cursor].URL)
+			}
+		case "p":
+			if len(m.servers) > 0 && m.cursor < len(m.servers) {
+				return m, m.togglePin(m.servers[m.cursor])
+			}
 		case "r":
 			// Reset health status and reload
+			m.stopHealthChecks()
 			m.healthStatus = make(map[int64]HealthStatus)
 			return m, m.loadServers
 		}
@@ -208,9 +283,42 @@ func (m *ServerSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// selectedServers returns the servers marked for batch operations, in list order
+func (m *ServerSelectModel) selectedServers() []db.Server {
+	var servers []db.Server
+	for _, server := range m.servers {
+		if m.selected[server.ID] {
+			servers = append(servers, server)
+		}
+	}
+	return servers
+}
+
+// stopConnecting ends the connection attempt, aborting its request if it is
+// still running
+func (m *ServerSelectModel) stopConnecting() {
+	m.connecting = false
+	if m.cancelConnect != nil {
+		m.cancelConnect()
+		m.cancelConnect = nil
+	}
+}
+
+// stopHealthChecks aborts the health checks still running
+func (m *ServerSelectModel) stopHealthChecks() {
+	if m.cancelHealth != nil {
+		m.cancelHealth()
+		m.cancelHealth = nil
+	}
+}
+
 func (m *ServerSelectModel) connectToServer(server *db.Server) tea.Cmd {
 	m.connecting = true
 	m.connectingIdx = m.cursor
+	m.attempt++
+	attempt := m.attempt
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelConnect = cancel
 
 	return tea.Batch(
 		m.spinner.Tick,
@@ -219,16 +327,27 @@ func (m *ServerSelectModel) connectToServer(server *db.Server) tea.Cmd {
 			if server.Token != nil {
 				token = *server.Token
 			}
-			client := api.New(server.URL, token, server.Insecure)
-			err := client.Ping()
+			client := newServerClient(server, token)
+			err := client.PingCtx(ctx)
 			if err != nil {
-				return connectionResultMsg{err: err, client: client}
+				return connectionResultMsg{attempt: attempt, err: err, client: client}
 			}
-			return connectionResultMsg{client: client}
+			return connectionResultMsg{attempt: attempt, client: client}
 		},
 	)
 }
 
+// togglePin pins or unpins server and reloads the list in its new order
+func (m *ServerSelectModel) togglePin(server db.Server) tea.Cmd {
+	return func() tea.Msg {
+		if err := m.db.SetServerPinned(server.ID, !server.Pinned); err != nil {
+			return serverPinnedMsg{err: err}
+		}
+		servers, err := m.db.ListServers()
+		return serverPinnedMsg{servers: servers, serverID: server.ID, err: err}
+	}
+}
+
 func (m *ServerSelectModel) renderServerRow(idx int, server db.Server, width int) string {
 	// Status dot based on health check
 	var dot string
@@ -259,6 +378,15 @@ func (m *ServerSelectModel) renderServerRow(idx int, server db.Server, width int
 		cursor = styles.Caret
 	}
 
+	// Batch selection marker (only shown once something is selected)
+	if len(m.selected) > 0 {
+		if m.selected[server.ID] {
+			cursor += styles.CheckboxChecked + " "
+		} else {
+			cursor += styles.CheckboxUnchecked + " "
+		}
+	}
+
 	// Build row - calculate widths
 	nameWidth := 20
 	timeWidth := 18
@@ -267,11 +395,18 @@ func (m *ServerSelectModel) renderServerRow(idx int, server db.Server, width int
 		urlWidth = 20
 	}
 
-	name := truncate(server.Name, nameWidth)
+	var marks string
+	if server.Pinned {
+		marks += " " + pinIndicator
+	}
+	if server.Notes != "" {
+		marks += " " + notesIndicator
+	}
+	name := truncate(server.Name, nameWidth-utf8.RuneCountInString(marks)) + marks
 	url := truncate(server.URL, urlWidth)
 	time := truncate(timeAgo, timeWidth)
 
-	line := fmt.Sprintf("%s %-*s %-*s %s", dot, nameWidth, name, urlWidth, url, time)
+	line := fmt.Sprintf("%s %s %-*s %s", dot, styles.PadRight(name, nameWidth), urlWidth, url, time)
 
 	if idx == m.cursor {
 		line = styles.TextPrimary.Render(line)
@@ -281,8 +416,9 @@ func (m *ServerSelectModel) renderServerRow(idx int, server db.Server, width int
 }
 
 type connectionResultMsg struct {
-	client *api.Client
-	err    error
+	attempt int
+	client  *api.Client
+	err     error
 }
 
 // checkAllHealth starts health checks for all servers in parallel
@@ -296,6 +432,10 @@ func (m *ServerSelectModel) checkAllHealth() tea.Cmd {
 		m.healthStatus[server.ID] = HealthChecking
 	}
 
+	m.stopHealthChecks()
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelHealth = cancel
+
 	// Create commands for all health checks
 	cmds := make([]tea.Cmd, len(m.servers))
 	for i, server := range m.servers {
@@ -306,8 +446,8 @@ func (m *ServerSelectModel) checkAllHealth() tea.Cmd {
 				token = *s.Token
 			}
 			client := api.New(s.URL, token, s.Insecure)
-			online := client.IsReachable()
-			return healthCheckMsg{serverID: s.ID, online: online}
+			online := client.IsReachableCtx(ctx)
+			return healthCheckMsg{serverID: s.ID, online: online, canceled: ctx.Err() != nil}
 		}
 	}
 
@@ -361,15 +501,44 @@ func (m *ServerSelectModel) View() string {
 
 func (m *ServerSelectModel) renderServerList(width int) string {
 	var b strings.Builder
-	b.WriteString(styles.SectionTitle.Render("SAVED SERVERS") + "\n")
+	title := styles.SectionTitle.Render("SAVED SERVERS")
+	if summary := m.healthSummary(m.servers); summary != "" {
+		title += " " + styles.TextMuted.Render(summary)
+	}
+	b.WriteString(title + "\n")
 
 	for i, server := range m.servers {
 		b.WriteString(m.renderServerRow(i, server, width) + "\n")
 	}
 
+	// Notes of the server under the cursor
+	if m.cursor < len(m.servers) && m.servers[m.cursor].Notes != "" {
+		b.WriteString("\n")
+		b.WriteString(renderNotes(m.servers[m.cursor].Notes, width, 3))
+	}
+
 	return b.String()
 }
 
+// healthSummary counts how many of servers answered their health check,
+// e.g. "(3/4 online)". It is empty until the first check completes.
+func (m *ServerSelectModel) healthSummary(servers []db.Server) string {
+	online, checked := 0, 0
+	for _, server := range servers {
+		switch m.healthStatus[server.ID] {
+		case HealthOnline:
+			online++
+			checked++
+		case HealthOffline:
+			checked++
+		}
+	}
+	if checked == 0 {
+		return ""
+	}
+	return fmt.Sprintf("(%d/%d online)", online, len(servers))
+}
+
 func (m *ServerSelectModel) renderEmptyState(width int) string {
 	var b strings.Builder
 
@@ -396,11 +565,11 @@ func (m *ServerSelectModel) renderDeleteConfirmation(width int) string {
 		Width(width - 4)
 
 	var cardContent strings.Builder
-	cardContent.WriteString(styles.TextWarning.Bold(true).Render("DELETE SERVER") + "\n\n")
-	cardContent.WriteString("Are you sure you want to delete this server?\n\n")
+	cardContent.WriteString(styles.TextWarning.Bold(true).Render(i18n.T("server_delete.title")) + "\n\n")
+	cardContent.WriteString(i18n.T("server_delete.question") + "\n\n")
 	cardContent.WriteString("  " + styles.TextMuted.Render("Name: ") + m.deleteTarget.Name + "\n")
 	cardContent.WriteString("  " + styles.TextMuted.Render("URL: ") + m.deleteTarget.URL + "\n\n")
-	cardContent.WriteString(styles.TextError.Render("This action cannot be undone."))
+	cardContent.WriteString(styles.TextError.Render(i18n.T("server_delete.cannot_undo")))
 
 	b.WriteString(cardStyle.Render(cardContent.String()))
 	b.WriteString("\n")
@@ -411,30 +580,44 @@ func (m *ServerSelectModel) renderDeleteConfirmation(width int) string {
 func (m *ServerSelectModel) renderShortcuts() string {
 	if m.confirmingDelete {
 		shortcuts := []string{
-			styles.RenderShortcut("y/⏎", "confirm"),
-			styles.RenderShortcut("n/Esc", "cancel"),
+			styles.RenderShortcut("y/⏎", i18n.T("shortcut.confirm")),
+			styles.RenderShortcut("n/Esc", i18n.T("shortcut.cancel")),
 		}
 		return layout.Shortcuts(shortcuts)
 	}
 
 	shortcuts := []string{
-		styles.RenderShortcut("↑↓", "navigate"),
-		styles.RenderShortcut("⏎", "connect"),
-		styles.RenderShortcut("a", "add"),
+		styles.RenderShortcut("↑↓", i18n.T("shortcut.navigate")),
+		styles.RenderShortcut("⏎", i18n.T("shortcut.connect")),
+		styles.RenderShortcut("a", i18n.T("shortcut.add")),
 	}
 
 	if len(m.servers) > 0 {
 		shortcuts = append(shortcuts,
-			styles.RenderShortcut("e", "edit"),
-			styles.RenderShortcut("d", "delete"),
+			styles.RenderShortcut("e", i18n.T("shortcut.edit")),
+			styles.RenderShortcut("d", i18n.T("shortcut.delete")),
+			styles.RenderShortcut("o", i18n.T("shortcut.open")),
+			m.pinShortcut(),
+			styles.RenderShortcut("space", i18n.T("shortcut.select")),
 		)
 	}
 
-	shortcuts = append(shortcuts, styles.RenderShortcut("r", "refresh"))
+	if len(m.selected) > 0 {
+		shortcuts = append(shortcuts, styles.RenderShortcut("b", i18n.T("shortcut.batch_install", len(m.selected))))
+	}
+
+	shortcuts = append(shortcuts, styles.RenderShortcut("r", i18n.T("shortcut.refresh")))
 
 	return layout.Shortcuts(shortcuts)
 }
 
+func (m *ServerSelectModel) pinShortcut() string {
+	if m.cursor < len(m.servers) && m.servers[m.cursor].Pinned {
+		return styles.RenderShortcut("p", i18n.T("shortcut.unpin"))
+	}
+	return styles.RenderShortcut("p", i18n.T("shortcut.pin"))
+}
+
 // Navigation commands
 func navigateToAddServer() tea.Cmd {
 	return func() tea.Msg {
@@ -461,6 +644,12 @@ type NavigateMsg struct {
 	ReplaceHistory bool // If true, replaces current screen in history instead of pushing
 }
 
+// TextEntry is a screen that can have a text field focused. While Typing,
+// keys the TUI handles on every screen, like the legend's ?, go to the field.
+type TextEntry interface {
+	Typing() bool
+}
+
 const (
 	ScreenServerSelect = iota
 	ScreenAddServer
@@ -477,6 +666,18 @@ const (
 	ScreenKeys
 	ScreenKeyCreate
 	ScreenKeyRevoke
+	ScreenKeyEdit
+	ScreenKeyRotate
+	ScreenBatchInstall
+	ScreenConnectionError
+	ScreenActivity
+	ScreenPluginEnable
+	ScreenPluginConfig
+	ScreenServerConfig
+	ScreenWorkerPool
+	ScreenAppRollback
+	ScreenPluginDetail
+	ScreenAppDetail
 )
 
 // Helper functions
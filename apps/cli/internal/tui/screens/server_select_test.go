@@ -0,0 +1,106 @@
+package screens
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/buntime/cli/internal/db"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestServerSelectEscAbortsConnecting(t *testing.T) {
+	started := make(chan struct{})
+	aborted := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/.well-known/buntime" {
+			http.NotFound(w, r)
+			return
+		}
+		close(started)
+		<-r.Context().Done()
+		close(aborted)
+	}))
+	defer server.Close()
+
+	m := NewServerSelectModel(nil, 100, 40)
+	m.servers = []db.Server{{ID: 1, Name: "test", URL: server.URL}}
+
+	batch, ok := m.connectToServer(&m.servers[0])().(tea.BatchMsg)
+	if !ok || len(batch) != 2 {
+		t.Fatalf("expected the spinner and the ping, got %#v", batch)
+	}
+	result := make(chan tea.Msg, 1)
+	go func() { result <- batch[1]() }()
+
+	<-started
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	select {
+	case <-aborted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected Esc to abort the ping")
+	}
+	if _, cmd := m.Update(<-result); cmd != nil || m.connecting {
+		t.Fatal("expected the aborted attempt to be dropped")
+	}
+}
+
+func TestServerSelectSummarizesHealthAsChecksComplete(t *testing.T) {
+	m := NewServerSelectModel(nil, 100, 40)
+	m.servers = []db.Server{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}, {ID: 3, Name: "c"}}
+	for _, server := range m.servers {
+		m.healthStatus[server.ID] = HealthChecking
+	}
+	if strings.Contains(m.View(), "online)") {
+		t.Fatal("expected no summary before any check completes")
+	}
+
+	m.Update(healthCheckMsg{serverID: 1, online: true})
+	if !strings.Contains(m.View(), "(1/3 online)") {
+		t.Fatal("expected the summary to count the first check")
+	}
+
+	m.Update(healthCheckMsg{serverID: 2, online: false})
+	m.Update(healthCheckMsg{serverID: 3, online: true})
+	if !strings.Contains(m.View(), "(2/3 online)") {
+		t.Fatal("expected the summary to follow later checks")
+	}
+}
+
+func TestServerSelectPinKeepsTheCursorOnTheServer(t *testing.T) {
+	t.Setenv("BUNTIME_CONFIG_DIR", db.MemoryDir)
+	database, err := db.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+	if _, err := database.CreateServer("first", "https://first.example", nil, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := database.CreateServer("second", "https://second.example", nil, false); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewServerSelectModel(database, 100, 40)
+	m.Update(m.loadServers())
+	m.cursor = len(m.servers) - 1
+	target := m.servers[m.cursor].ID
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	m.Update(cmd())
+	if m.servers[0].ID != target || !m.servers[0].Pinned || m.cursor != 0 {
+		t.Fatalf("expected the pinned server first under the cursor, got cursor %d in %+v", m.cursor, m.servers)
+	}
+	if !strings.Contains(m.View(), pinIndicator) {
+		t.Fatal("expected the pinned row to be marked")
+	}
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	m.Update(cmd())
+	if m.servers[m.cursor].ID != target || m.servers[m.cursor].Pinned {
+		t.Fatal("expected p to unpin the server")
+	}
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/buntime/cli/internal/api"
 	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/i18n"
+	"github.com/buntime/cli/internal/tui/components"
 	"github.com/buntime/cli/internal/tui/layout"
 	"github.com/buntime/cli/internal/tui/styles"
 	tea "github.com/charmbracelet/bubbletea"
@@ -23,7 +25,17 @@ type settingsAction int
 
 const (
 	actionEditServer settingsAction = iota
+	actionViewServerConfig
+	actionResizeWorkerPool
 	actionToggleInsecure
+	actionToggleVerifyInstalls
+	actionToggleRollbackInstalls
+	actionCycleConfirmLevel
+	actionToggleConfirmHighRisk
+	actionToggleProvenance
+	actionToggleChooseVersion
+	actionCycleAlert
+	actionToggleDensity
 	actionDeleteServer
 )
 
@@ -36,6 +48,7 @@ type settingsMenuItem struct {
 // SettingsModel handles the settings screen
 type SettingsModel struct {
 	api          *api.Client
+	info         *ServerInfoCache
 	db           *db.DB
 	server       *db.Server
 	width        int
@@ -45,37 +58,61 @@ type SettingsModel struct {
 	health       *api.HealthInfo
 	loading      bool
 	state        settingsState
-	confirmInput string
+	confirmInput *components.ConfirmInput
+	confirm      confirmPolicy
+	saving       bool // Insecure toggle write in flight
 	err          error
 }
 
 // NewSettingsModel creates a new settings screen
-func NewSettingsModel(client *api.Client, database *db.DB, server *db.Server, width, height int) *SettingsModel {
+func NewSettingsModel(client *api.Client, info *ServerInfoCache, database *db.DB, server *db.Server, width, height int) *SettingsModel {
+	confirm := loadConfirmPolicy(database)
 	items := []settingsMenuItem{
-		{action: actionEditServer, title: "Edit Server", description: "Change name, URL or token"},
+		{action: actionEditServer, title: "Edit Server", description: "Change name, URL, token or notes"},
+		{action: actionViewServerConfig, title: "Server Configuration", description: "View the runtime's pool size, limits and features"},
+		{action: actionResizeWorkerPool, title: "Worker Pool Size", description: "Scale the server's worker pool up or down"},
 		{action: actionToggleInsecure, title: "Toggle Insecure Mode", description: "Skip TLS verification"},
+		{action: actionToggleVerifyInstalls, title: "Toggle Install Verification", description: verifyInstallsDescription(database.GetConfigBool(db.ConfigVerifyInstalls))},
+		{action: actionToggleRollbackInstalls, title: "Toggle Rollback on Failure", description: rollbackInstallsDescription(database.GetConfigBool(db.ConfigRollbackInstalls))},
+		{action: actionCycleConfirmLevel, title: "Confirmation Style", description: confirmLevelDescription(confirm.level)},
+		{action: actionToggleConfirmHighRisk, title: "Toggle High-Risk Typing", description: confirmHighRiskDescription(confirm.forceHighRisk)},
+		{action: actionToggleProvenance, title: "Toggle Install Provenance", description: provenanceDescription(database.SendProvenance())},
+		{action: actionToggleChooseVersion, title: "Toggle Version Choice on Enable", description: chooseVersionDescription(database.ChooseEnableVersion())},
+		{action: actionCycleAlert, title: "Completion Alert", description: alertDescription(loadAlertMode(database))},
+		{action: actionToggleDensity, title: "Toggle Compact Lists", description: densityDescription(layout.Compact())},
 		{action: actionDeleteServer, title: "Delete Server", description: "Remove from saved servers"},
 	}
 
 	return &SettingsModel{
 		api:       client,
+		info:      info,
 		db:        database,
 		server:    server,
 		width:     width,
 		height:    height,
 		menuItems: items,
+		confirm:   confirm,
 		loading:   true,
 		state:     settingsStateMenu,
 	}
 }
 
 func (m *SettingsModel) Init() tea.Cmd {
+	// Reuse a recent result; r refreshes it
+	if health, ok := m.info.Health(); ok {
+		m.health = health
+		m.loading = false
+		return nil
+	}
 	return m.loadHealth()
 }
 
 func (m *SettingsModel) loadHealth() tea.Cmd {
 	return func() tea.Msg {
 		health, err := m.api.GetHealth()
+		if err == nil {
+			m.info.StoreHealth(health)
+		}
 		return healthLoadedMsg{health: health, err: err}
 	}
 }
@@ -97,6 +134,9 @@ func (m *SettingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case healthLoadedMsg:
+		if canceled(msg.err) {
+			return m, nil
+		}
 		m.loading = false
 		m.health = msg.health
 		if msg.err != nil {
@@ -115,6 +155,11 @@ func (m *SettingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case serverUpdatedMsg:
+		m.saving = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
 		if msg.server != nil {
 			m.server = msg.server
 		}
@@ -146,7 +191,13 @@ func (m *SettingsModel) updateMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 	case "enter":
 		return m.handleAction()
+	case "o":
+		return m, openInBrowser(m.server.URL)
 	case "r":
+		// A reload is already running
+		if m.loading {
+			return m, nil
+		}
 		m.loading = true
 		m.err = nil
 		return m, m.loadHealth()
@@ -157,26 +208,28 @@ func (m *SettingsModel) updateMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m *SettingsModel) updateConfirmDelete(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
-		m.state = settingsStateMenu
-		m.confirmInput = ""
-		return m, nil
-	case "backspace":
-		if len(m.confirmInput) > 0 {
-			m.confirmInput = m.confirmInput[:len(m.confirmInput)-1]
-		}
-	case "enter":
-		if m.confirmInput == m.server.Name {
+	// Deleting a saved server only touches local config, so it is low-risk
+	if !m.confirm.requiresTyping(false) {
+		switch msg.String() {
+		case "y", "Y", "enter":
 			m.state = settingsStateDeleting
 			return m, m.deleteServer()
+		case "n", "N", "esc":
+			m.state = settingsStateMenu
 		}
-	default:
-		if len(msg.String()) == 1 && len(m.confirmInput) < len(m.server.Name) {
-			m.confirmInput += msg.String()
-		}
+		return m, nil
 	}
-	return m, nil
+
+	if msg.String() == "esc" {
+		m.state = settingsStateMenu
+		return m, nil
+	}
+	submitted, cmd := m.confirmInput.Update(msg)
+	if submitted {
+		m.state = settingsStateDeleting
+		return m, m.deleteServer()
+	}
+	return m, cmd
 }
 
 func (m *SettingsModel) handleAction() (tea.Model, tea.Cmd) {
@@ -187,11 +240,109 @@ func (m *SettingsModel) handleAction() (tea.Model, tea.Cmd) {
 		return m, func() tea.Msg {
 			return NavigateMsg{Screen: ScreenEditServer, Data: m.server}
 		}
+	case actionViewServerConfig:
+		return m, func() tea.Msg {
+			return NavigateMsg{Screen: ScreenServerConfig}
+		}
+	case actionResizeWorkerPool:
+		return m, func() tea.Msg {
+			return NavigateMsg{Screen: ScreenWorkerPool}
+		}
 	case actionToggleInsecure:
+		if m.saving {
+			return m, nil
+		}
+		m.saving = true
 		return m, m.toggleInsecure()
+	case actionToggleVerifyInstalls:
+		enabled := !m.db.GetConfigBool(db.ConfigVerifyInstalls)
+		if err := m.db.SetConfigBool(db.ConfigVerifyInstalls, enabled); err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.menuItems[m.cursor].description = verifyInstallsDescription(enabled)
+		return m, nil
+	case actionToggleRollbackInstalls:
+		enabled := !m.db.GetConfigBool(db.ConfigRollbackInstalls)
+		if err := m.db.SetConfigBool(db.ConfigRollbackInstalls, enabled); err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.menuItems[m.cursor].description = rollbackInstallsDescription(enabled)
+		return m, nil
+	case actionCycleConfirmLevel:
+		next := ConfirmLevels[0]
+		for i, level := range ConfirmLevels {
+			if level == m.confirm.level {
+				next = ConfirmLevels[(i+1)%len(ConfirmLevels)]
+			}
+		}
+		if err := m.db.SetConfig(db.ConfigConfirmLevel, string(next)); err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.confirm.level = next
+		m.menuItems[m.cursor].description = confirmLevelDescription(next)
+		return m, nil
+	case actionToggleConfirmHighRisk:
+		enabled := !m.confirm.forceHighRisk
+		if err := m.db.SetConfigBool(db.ConfigConfirmHighRisk, enabled); err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.confirm.forceHighRisk = enabled
+		m.menuItems[m.cursor].description = confirmHighRiskDescription(enabled)
+		return m, nil
+	case actionToggleProvenance:
+		enabled := !m.db.SendProvenance()
+		if err := m.db.SetConfigBool(db.ConfigSendProvenance, enabled); err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.menuItems[m.cursor].description = provenanceDescription(enabled)
+		if enabled {
+			m.api.SetProvenance(api.NewProvenance(layout.Version))
+		} else {
+			m.api.SetProvenance(nil)
+		}
+		return m, nil
+	case actionToggleChooseVersion:
+		enabled := !m.db.ChooseEnableVersion()
+		if err := m.db.SetConfigBool(db.ConfigChooseVersion, enabled); err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.menuItems[m.cursor].description = chooseVersionDescription(enabled)
+		return m, nil
+	case actionCycleAlert:
+		current := loadAlertMode(m.db)
+		next := AlertModes[0]
+		for i, mode := range AlertModes {
+			if mode == current {
+				next = AlertModes[(i+1)%len(AlertModes)]
+			}
+		}
+		if err := m.db.SetConfig(db.ConfigCompletionAlert, string(next)); err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.menuItems[m.cursor].description = alertDescription(next)
+		return m, nil
+	case actionToggleDensity:
+		next := layout.DensityCompact
+		if layout.Compact() {
+			next = layout.DensityComfortable
+		}
+		if err := m.db.SetConfig(db.ConfigDensity, string(next)); err != nil {
+			m.err = err
+			return m, nil
+		}
+		layout.SetDensity(next)
+		m.menuItems[m.cursor].description = densityDescription(layout.Compact())
+		return m, nil
 	case actionDeleteServer:
 		m.state = settingsStateConfirmDelete
-		m.confirmInput = ""
+		m.confirmInput = components.NewConfirmInput(m.server.Name)
 		return m, nil
 	}
 
@@ -203,15 +354,66 @@ func (m *SettingsModel) toggleInsecure() tea.Cmd {
 	return func() tea.Msg {
 		err := m.db.UpdateServer(m.server.ID, m.server.Name, m.server.URL, m.server.Token, newInsecure)
 		if err != nil {
-			return healthLoadedMsg{err: err}
+			return serverUpdatedMsg{err: err}
 		}
 		server, _ := m.db.GetServer(m.server.ID)
 		return serverUpdatedMsg{server: server}
 	}
 }
 
+func verifyInstallsDescription(enabled bool) string {
+	if enabled {
+		return "Re-check server health after installs and plugin toggles (on)"
+	}
+	return "Re-check server health after installs and plugin toggles (off)"
+}
+
+func rollbackInstallsDescription(enabled bool) string {
+	if enabled {
+		return "Remove installs that leave the server unhealthy (on)"
+	}
+	return "Remove installs that leave the server unhealthy (off)"
+}
+
+func confirmLevelDescription(level ConfirmLevel) string {
+	return "Confirm destructive actions: " + level.Label()
+}
+
+func confirmHighRiskDescription(enabled bool) string {
+	if enabled {
+		return "Always type to revoke keys or remove active versions (on)"
+	}
+	return "Always type to revoke keys or remove active versions (off)"
+}
+
+func provenanceDescription(enabled bool) string {
+	if enabled {
+		return "Send CLI version and user@host with changes (on)"
+	}
+	return "Send CLI version and user@host with changes (off)"
+}
+
+func chooseVersionDescription(enabled bool) string {
+	if enabled {
+		return "Ask which version to run when enabling a plugin (on)"
+	}
+	return "Ask which version to run when enabling a plugin (off)"
+}
+
+func densityDescription(compact bool) string {
+	if compact {
+		return "Fit more rows by dropping spacing and separators (on)"
+	}
+	return "Fit more rows by dropping spacing and separators (off)"
+}
+
+func alertDescription(mode AlertMode) string {
+	return "When installs and removals finish: " + mode.Label()
+}
+
 type serverUpdatedMsg struct {
 	server *db.Server
+	err    error
 }
 
 func (m *SettingsModel) deleteServer() tea.Cmd {
@@ -221,6 +423,11 @@ func (m *SettingsModel) deleteServer() tea.Cmd {
 	}
 }
 
+// Typing reports whether the server name is being typed to delete it
+func (m *SettingsModel) Typing() bool {
+	return m.state == settingsStateConfirmDelete && m.confirm.requiresTyping(false)
+}
+
 func (m *SettingsModel) View() string {
 	innerWidth := layout.InnerWidth(m.width)
 
@@ -272,14 +479,14 @@ func (m *SettingsModel) renderMenu(width int) string {
 
 func (m *SettingsModel) renderConfirmDelete(width int) string {
 	return layout.ConfirmModal(layout.ConfirmModalConfig{
-		Width:       width - 4,
-		Warning:     "You are about to delete the following server:",
-		ConfirmWord: m.server.Name,
+		Width:   width - 4,
+		Warning: "You are about to delete the following server:",
 		Items: []layout.ConfirmModalItem{
 			{Label: "Name", Value: m.server.Name},
 			{Label: "URL", Value: m.server.URL},
 		},
-		CurrentInput: m.confirmInput,
+		Input:  m.confirmInput,
+		Simple: !m.confirm.requiresTyping(false),
 	})
 }
 
@@ -339,6 +546,12 @@ func (m *SettingsModel) renderServerInfo(width int) string {
 		content.WriteString(styles.TextMuted.Render("not set"))
 	}
 
+	// Notes
+	if m.server.Notes != "" {
+		content.WriteString("\n\n" + styles.TextMuted.Render("Notes:") + "\n")
+		content.WriteString(strings.TrimSuffix(renderNotes(m.server.Notes, width-10, 0), "\n"))
+	}
+
 	return layout.Card(layout.CardConfig{
 		Width:   width - 4,
 		Variant: layout.CardDefault,
@@ -367,17 +580,22 @@ func (m *SettingsModel) renderMenuItem(index int, item settingsMenuItem) string
 func (m *SettingsModel) getShortcuts() []string {
 	switch m.state {
 	case settingsStateConfirmDelete:
-		return []string{
-			styles.RenderShortcut("Esc", "cancel"),
+		if !m.confirm.requiresTyping(false) {
+			return []string{
+				styles.RenderShortcut("y", i18n.T("shortcut.confirm")),
+				styles.RenderShortcut("n/Esc", i18n.T("shortcut.cancel")),
+			}
 		}
+		return confirmInputShortcuts(m.confirmInput)
 	case settingsStateDeleting:
 		return []string{}
 	default:
 		return []string{
-			styles.RenderShortcut("↑↓", "navigate"),
-			styles.RenderShortcut("⏎", "select"),
-			styles.RenderShortcut("r", "refresh"),
-			styles.RenderShortcut("Esc", "back"),
+			styles.RenderShortcut("↑↓", i18n.T("shortcut.navigate")),
+			styles.RenderShortcut("⏎", i18n.T("shortcut.select")),
+			styles.RenderShortcut("o", i18n.T("shortcut.open")),
+			styles.RenderShortcut("r", i18n.T("shortcut.refresh")),
+			styles.RenderShortcut("Esc", i18n.T("shortcut.back")),
 		}
 	}
 }
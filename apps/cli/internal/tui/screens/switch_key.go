@@ -0,0 +1,49 @@
+package screens
+
+import (
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/i18n"
+	"github.com/buntime/cli/internal/tui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// switchKeyKey opens the token prompt after a permission error. A control
+// key, so it works on screens with a text input focused.
+const switchKeyKey = "ctrl+k"
+
+// SwitchKeyData asks the token prompt to swap the key of the connected
+// client instead of starting a new connection
+type SwitchKeyData struct {
+	Client *api.Client
+	Server *db.Server
+}
+
+// KeySwitchedMsg is sent to the screen that was refused once the client uses
+// the new key, so it can retry what failed. List screens retry on their own,
+// since going back reloads them.
+type KeySwitchedMsg struct{}
+
+// permissionDenied reports whether err is a 403 for a key that lacks a
+// permission, which a more privileged key for the same server may get past
+func permissionDenied(err error) bool {
+	apiErr, ok := err.(*api.APIError)
+	return ok && apiErr.Type == api.ErrorTypeForbidden
+}
+
+// switchKey opens the token prompt for another key for the connected server
+func switchKey(client *api.Client, server *db.Server) tea.Cmd {
+	return func() tea.Msg {
+		return NavigateMsg{Screen: ScreenTokenPrompt, Data: &SwitchKeyData{Client: client, Server: server}}
+	}
+}
+
+// renderSwitchKeyHint explains how to retry with a different key
+func renderSwitchKeyHint() string {
+	return styles.TextMuted.Render(i18n.T("switch_key.hint")) + "\n"
+}
+
+// switchKeyShortcut is the footer entry for switchKeyKey
+func switchKeyShortcut() string {
+	return styles.RenderShortcut("Ctrl+K", i18n.T("shortcut.switch_key"))
+}
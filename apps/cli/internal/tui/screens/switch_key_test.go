@@ -0,0 +1,53 @@
+package screens
+
+import (
+	"testing"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/db"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPermissionErrorOffersAnotherKeyAndRetries(t *testing.T) {
+	server := &db.Server{Name: "prod", URL: "http://localhost:1"}
+	client := api.New(server.URL, "reader", false)
+	m := NewRemoveModel(client, nil, server, "app", "my-app", []string{"1.0.0"}, 100, 30)
+	m.state = removeStateFailed
+	m.err = &api.APIError{Type: api.ErrorTypeForbidden, Message: "Permission denied (403)", Status: 403}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlK})
+	if cmd == nil {
+		t.Fatal("expected Ctrl+K to open the token prompt")
+	}
+	nav, ok := cmd().(NavigateMsg)
+	if !ok || nav.Screen != ScreenTokenPrompt {
+		t.Fatalf("expected navigation to the token prompt, got %#v", nav)
+	}
+	if data, ok := nav.Data.(*SwitchKeyData); !ok || data.Client != client || data.Server != server {
+		t.Fatalf("expected the connected client and server, got %#v", nav.Data)
+	}
+
+	_, cmd = m.Update(KeySwitchedMsg{})
+	if m.state != removeStateRemoving || cmd == nil {
+		t.Fatalf("expected the removal to be retried, state = %v", m.state)
+	}
+}
+
+func TestOtherErrorsDontOfferAnotherKey(t *testing.T) {
+	server := &db.Server{Name: "prod", URL: "http://localhost:1"}
+	m := NewRemoveModel(api.New(server.URL, "", false), nil, server, "app", "my-app", []string{"1.0.0"}, 100, 30)
+	m.state = removeStateFailed
+	m.err = &api.APIError{Type: api.ErrorTypeServerError, Message: "Server error (500)", Status: 500}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlK})
+	if cmd == nil {
+		t.Fatal("expected any key to leave the failed screen")
+	}
+	if nav, ok := cmd().(NavigateMsg); !ok || nav.Screen != ScreenApps {
+		t.Fatalf("expected to go back to the apps list, got %#v", nav)
+	}
+
+	if _, cmd := m.Update(KeySwitchedMsg{}); cmd != nil {
+		t.Fatal("expected no retry for an error a different key can't fix")
+	}
+}
@@ -1,10 +1,13 @@
 package screens
 
 import (
+	"context"
 	"strings"
 
 	"github.com/buntime/cli/internal/api"
 	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/i18n"
+	"github.com/buntime/cli/internal/tui/components"
 	"github.com/buntime/cli/internal/tui/layout"
 	"github.com/buntime/cli/internal/tui/messages"
 	"github.com/buntime/cli/internal/tui/styles"
@@ -24,13 +27,16 @@ const (
 type TokenPromptModel struct {
 	db         *db.DB
 	server     *db.Server
+	client     *api.Client // Connected client whose key is being switched, nil when connecting
 	tokenInput textinput.Model
 	saveToken  bool
-	focusIndex int
+	form       *components.Form
 	width      int
 	height     int
 	err        string
 	connecting bool
+	attempt    int // Bumped per connection attempt so cancelled results are dropped
+	cancel     context.CancelFunc
 }
 
 // NewTokenPromptModel creates a token prompt screen
@@ -41,18 +47,38 @@ func NewTokenPromptModel(database *db.DB, server *db.Server, width, height int)
 	tokenInput.EchoMode = textinput.EchoPassword
 	tokenInput.EchoCharacter = '•'
 	tokenInput.CharLimit = 500
-	tokenInput.Width = 40
-	tokenInput.Focus()
 
-	return &TokenPromptModel{
+	m := &TokenPromptModel{
 		db:         database,
 		server:     server,
 		tokenInput: tokenInput,
 		saveToken:  true,
-		focusIndex: tokenFocusInput,
 		width:      width,
 		height:     height,
 	}
+	m.form = components.NewForm(
+		components.Field{Kind: components.FieldInput, Input: &m.tokenInput, Validate: m.validateToken},
+		components.Field{Kind: components.FieldCheckbox, Checked: &m.saveToken},
+		components.Field{Kind: components.FieldButton},
+		components.Field{Kind: components.FieldButton},
+	)
+	m.resizeInputs()
+	return m
+}
+
+// NewSwitchKeyModel creates a token prompt that swaps the key of an
+// existing connection after a permission error. The new key isn't saved
+// unless asked, since it may only be needed for one operation.
+func NewSwitchKeyModel(database *db.DB, data *SwitchKeyData, width, height int) *TokenPromptModel {
+	m := NewTokenPromptModel(database, data.Server, width, height)
+	m.client = data.Client
+	m.saveToken = false
+	return m
+}
+
+// resizeInputs fits the text inputs to the current terminal width
+func (m *TokenPromptModel) resizeInputs() {
+	m.tokenInput.Width = layout.InputWidth(m.width, 40)
 }
 
 func (m *TokenPromptModel) Init() tea.Cmd {
@@ -64,16 +90,19 @@ func (m *TokenPromptModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.resizeInputs()
 		return m, nil
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "tab", "down":
-			m.focusNext()
-			return m, nil
-		case "shift+tab", "up":
-			m.focusPrev()
+		// Ignore input while a connection attempt is in flight, except Esc
+		// to give up on it
+		if m.connecting {
+			if msg.String() == "esc" {
+				m.stopConnecting()
+			}
 			return m, nil
+		}
+		switch msg.String() {
 		case "ctrl+r":
 			if m.tokenInput.EchoMode == textinput.EchoPassword {
 				m.tokenInput.EchoMode = textinput.EchoNormal
@@ -82,37 +111,43 @@ func (m *TokenPromptModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 		case "enter":
-			switch m.focusIndex {
+			switch m.form.Focused() {
 			case tokenFocusConnect:
 				return m, m.connect()
 			case tokenFocusCancel:
 				return m, goBack()
-			default:
-				m.focusNext()
-			}
-			return m, nil
-		case " ", "space":
-			if m.focusIndex == tokenFocusSave {
-				m.saveToken = !m.saveToken
-				return m, nil
 			}
 		case "esc":
 			return m, goBack()
 		}
 
 	case tokenConnectResultMsg:
-		m.connecting = false
+		if !m.connecting || msg.attempt != m.attempt {
+			return m, nil
+		}
+		m.stopConnecting()
 		if msg.err != nil {
 			return m, func() tea.Msg {
 				return messages.ShowError("Authentication failed: " + msg.err.Error())
 			}
 		}
 
+		token := strings.TrimSpace(m.tokenInput.Value())
 		if m.saveToken {
-			token := m.tokenInput.Value()
 			m.db.UpdateServerToken(m.server.ID, token)
 		}
 
+		if m.client != nil {
+			// Every screen shares the client, so they all pick up the new
+			// key; the refused screen then retries
+			m.client.SetToken(token)
+			return m, tea.Sequence(
+				goBack(),
+				func() tea.Msg { return KeySwitchedMsg{} },
+				func() tea.Msg { return messages.ShowSuccess("Switched to the new API key") },
+			)
+		}
+
 		m.db.TouchServer(m.server.ID)
 
 		return m, func() tea.Msg {
@@ -120,58 +155,59 @@ func (m *TokenPromptModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
-	var cmd tea.Cmd
-	if m.focusIndex == tokenFocusInput {
-		m.tokenInput, cmd = m.tokenInput.Update(msg)
-	}
-
-	return m, cmd
-}
-
-func (m *TokenPromptModel) focusNext() {
-	m.focusIndex = (m.focusIndex + 1) % 4
-	m.updateFocus()
-}
-
-func (m *TokenPromptModel) focusPrev() {
-	m.focusIndex--
-	if m.focusIndex < 0 {
-		m.focusIndex = 3
-	}
-	m.updateFocus()
+	return m, m.form.Update(msg)
 }
 
-func (m *TokenPromptModel) updateFocus() {
-	if m.focusIndex == tokenFocusInput {
-		m.tokenInput.Focus()
-	} else {
-		m.tokenInput.Blur()
+func (m *TokenPromptModel) validateToken() string {
+	if strings.TrimSpace(m.tokenInput.Value()) == "" {
+		return "API key is required"
 	}
+	return ""
 }
 
 func (m *TokenPromptModel) connect() tea.Cmd {
-	token := strings.TrimSpace(m.tokenInput.Value())
-	if token == "" {
-		m.err = "API key is required"
+	if errMsg := m.form.Validate(); errMsg != "" {
+		m.err = errMsg
 		return nil
 	}
 
+	token := strings.TrimSpace(m.tokenInput.Value())
 	m.connecting = true
 	m.err = ""
+	m.attempt++
+	attempt := m.attempt
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
 
 	return func() tea.Msg {
-		client := api.New(m.server.URL, token, m.server.Insecure)
-		err := client.Ping()
+		client := newServerClient(m.server, token)
+		err := client.PingCtx(ctx)
 		if err != nil {
-			return tokenConnectResultMsg{err: err}
+			return tokenConnectResultMsg{attempt: attempt, err: err}
 		}
-		return tokenConnectResultMsg{client: client}
+		return tokenConnectResultMsg{attempt: attempt, client: client}
+	}
+}
+
+// stopConnecting ends the connection attempt, aborting its request if it is
+// still running
+func (m *TokenPromptModel) stopConnecting() {
+	m.connecting = false
+	if m.cancel != nil {
+		m.cancel()
+		m.cancel = nil
 	}
 }
 
 type tokenConnectResultMsg struct {
-	client *api.Client
-	err    error
+	attempt int
+	client  *api.Client
+	err     error
+}
+
+// Typing reports whether the key input has the focus
+func (m *TokenPromptModel) Typing() bool {
+	return m.form.Typing()
 }
 
 func (m *TokenPromptModel) View() string {
@@ -181,11 +217,17 @@ func (m *TokenPromptModel) View() string {
 	b.WriteString("\n")
 
 	// Title
-	b.WriteString(styles.SectionTitle.Render("AUTHENTICATION REQUIRED") + "\n")
-	b.WriteString("\n")
+	if m.client != nil {
+		b.WriteString(styles.SectionTitle.Render("USE A DIFFERENT KEY") + "\n")
+		b.WriteString("\n")
+		b.WriteString(styles.TextMuted.Render("The current key isn't allowed to do that. Enter a key with more permissions.") + "\n")
+	} else {
+		b.WriteString(styles.SectionTitle.Render("AUTHENTICATION REQUIRED") + "\n")
+		b.WriteString("\n")
 
-	// Description
-	b.WriteString(styles.TextMuted.Render("Server requires API key for authentication.") + "\n")
+		// Description
+		b.WriteString(styles.TextMuted.Render("Server requires API key for authentication.") + "\n")
+	}
 	b.WriteString(styles.TextMuted.Render("Server: ") + styles.TextPrimary.Render(m.server.Name) + "\n")
 	b.WriteString("\n")
 
@@ -216,9 +258,8 @@ func (m *TokenPromptModel) renderForm() string {
 	var b strings.Builder
 
 	// API Key field
-	b.WriteString(m.renderLabel("API Key", true) + "\n")
-	hasError := m.err != ""
-	b.WriteString(m.renderInput(m.tokenInput, m.focusIndex == tokenFocusInput, hasError) + "\n")
+	b.WriteString(components.RenderLabel("API Key", true) + "\n")
+	b.WriteString(m.form.RenderInput(tokenFocusInput) + "\n")
 	b.WriteString(styles.TextMuted.Render("Ctrl+R to toggle visibility") + "\n")
 	b.WriteString("\n")
 
@@ -229,82 +270,29 @@ func (m *TokenPromptModel) renderForm() string {
 	}
 
 	// Save checkbox
-	b.WriteString(m.renderCheckbox("Save API key for this server", m.saveToken, m.focusIndex == tokenFocusSave) + "\n")
+	b.WriteString(m.form.RenderCheckbox(tokenFocusSave, "Save API key for this server") + "\n")
 	b.WriteString("\n")
 
 	// Buttons
-	b.WriteString(m.renderButtons())
-
-	return b.String()
-}
-
-func (m *TokenPromptModel) renderLabel(text string, required bool) string {
-	label := styles.TextNormal.Render(text)
-	if required {
-		label += styles.TextError.Render(" *")
-	}
-	return label
-}
-
-func (m *TokenPromptModel) renderInput(input textinput.Model, focused bool, hasError bool) string {
-	var borderColor lipgloss.Color
-	if hasError {
-		borderColor = styles.ColorError
-	} else if focused {
-		borderColor = styles.ColorPrimary
-	} else {
-		borderColor = styles.ColorSurface
-	}
-
-	inputStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(borderColor).
-		Padding(0, 1).
-		Width(44)
-
-	return inputStyle.Render(input.View())
-}
-
-func (m *TokenPromptModel) renderCheckbox(label string, checked bool, focused bool) string {
-	checkbox := styles.RenderCheckbox(checked, focused)
-
-	labelStyle := styles.TextNormal
-	if focused {
-		labelStyle = styles.TextPrimary
-	}
-
-	return lipgloss.JoinHorizontal(lipgloss.Center, checkbox, "  ", labelStyle.Render(label))
-}
-
-func (m *TokenPromptModel) renderButtons() string {
-	cancelStyle := styles.Button
-	if m.focusIndex == tokenFocusCancel {
-		cancelStyle = styles.ButtonFocused
-	}
-
-	connectStyle := styles.Button
-	if m.focusIndex == tokenFocusConnect {
-		connectStyle = styles.ButtonPrimary
-	}
-
 	connectText := " Connect  "
+	if m.client != nil {
+		connectText = " Use Key  "
+	}
 	if m.connecting {
 		connectText = "Connecting..."
 	}
+	b.WriteString(m.form.RenderButtons(tokenFocusCancel, "  Cancel  ", tokenFocusConnect, connectText))
 
-	cancel := cancelStyle.Render("  Cancel  ")
-	connect := connectStyle.Render(connectText)
-
-	return lipgloss.JoinHorizontal(lipgloss.Center, cancel, "  ", connect)
+	return b.String()
 }
 
 func (m *TokenPromptModel) renderShortcuts() string {
 	shortcuts := []string{
-		styles.RenderShortcut("Tab", "next"),
-		styles.RenderShortcut("Shift+Tab", "prev"),
-		styles.RenderShortcut("Ctrl+R", "visibility"),
-		styles.RenderShortcut("⏎", "submit"),
-		styles.RenderShortcut("Esc", "cancel"),
+		styles.RenderShortcut("Tab", i18n.T("shortcut.next")),
+		styles.RenderShortcut("Shift+Tab", i18n.T("shortcut.prev")),
+		styles.RenderShortcut("Ctrl+R", i18n.T("shortcut.visibility")),
+		styles.RenderShortcut("⏎", i18n.T("shortcut.submit")),
+		styles.RenderShortcut("Esc", i18n.T("shortcut.cancel")),
 	}
 
 	return layout.Shortcuts(shortcuts)
@@ -0,0 +1,300 @@
+package screens
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/i18n"
+	"github.com/buntime/cli/internal/tui/layout"
+	"github.com/buntime/cli/internal/tui/messages"
+	"github.com/buntime/cli/internal/tui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type workerPoolState int
+
+const (
+	workerPoolStateLoading workerPoolState = iota
+	workerPoolStateEdit
+	workerPoolStateConfirm
+	workerPoolStateApplying
+)
+
+// WorkerPoolModel scales the server's worker pool up or down
+type WorkerPoolModel struct {
+	api    *api.Client
+	server *db.Server
+	state  workerPoolState
+	pool   *api.PoolInfo
+	target int // Size the pool will be resized to
+	err    error
+	width  int
+	height int
+}
+
+// NewWorkerPoolModel creates the worker pool screen
+func NewWorkerPoolModel(client *api.Client, server *db.Server, width, height int) *WorkerPoolModel {
+	return &WorkerPoolModel{
+		api:    client,
+		server: server,
+		state:  workerPoolStateLoading,
+		width:  width,
+		height: height,
+	}
+}
+
+type workerPoolLoadedMsg struct {
+	pool *api.PoolInfo
+	err  error
+}
+
+type workerPoolResizedMsg struct {
+	requested int
+	pool      *api.PoolInfo
+	err       error
+}
+
+func (m *WorkerPoolModel) Init() tea.Cmd {
+	return m.loadPool()
+}
+
+func (m *WorkerPoolModel) loadPool() tea.Cmd {
+	m.state = workerPoolStateLoading
+	m.err = nil
+	return func() tea.Msg {
+		pool, err := m.api.GetWorkerPool()
+		return workerPoolLoadedMsg{pool: pool, err: err}
+	}
+}
+
+func (m *WorkerPoolModel) resize() tea.Cmd {
+	m.state = workerPoolStateApplying
+	m.err = nil
+	target := m.target
+	return func() tea.Msg {
+		pool, err := m.api.SetWorkerPoolSize(target)
+		return workerPoolResizedMsg{requested: target, pool: pool, err: err}
+	}
+}
+
+func (m *WorkerPoolModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case workerPoolLoadedMsg:
+		if canceled(msg.err) {
+			return m, nil
+		}
+		m.state = workerPoolStateEdit
+		m.err = msg.err
+		if msg.err == nil {
+			m.pool = msg.pool
+			m.target = msg.pool.Size
+		}
+		return m, nil
+
+	case workerPoolResizedMsg:
+		m.state = workerPoolStateEdit
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.pool = msg.pool
+		m.target = msg.pool.Size
+		if msg.pool.Size != msg.requested {
+			return m, func() tea.Msg {
+				return messages.ShowWarning(fmt.Sprintf("The server applied %d workers instead of %d", msg.pool.Size, msg.requested))
+			}
+		}
+		return m, func() tea.Msg {
+			return messages.ShowSuccess(fmt.Sprintf("Worker pool resized to %d", msg.pool.Size))
+		}
+
+	case KeySwitchedMsg:
+		if !permissionDenied(m.err) {
+			return m, nil
+		}
+		if m.pool == nil {
+			return m, m.loadPool()
+		}
+		return m, m.resize()
+
+	case tea.KeyMsg:
+		switch m.state {
+		case workerPoolStateEdit:
+			return m.updateEdit(msg)
+		case workerPoolStateConfirm:
+			return m.updateConfirm(msg)
+		case workerPoolStateLoading:
+			if msg.String() == "esc" {
+				return m, goBack()
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m *WorkerPoolModel) updateEdit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case switchKeyKey:
+		if permissionDenied(m.err) {
+			return m, switchKey(m.api, m.server)
+		}
+	case "left", "h", "-":
+		m.adjust(-1)
+	case "right", "l", "+":
+		m.adjust(1)
+	case "enter":
+		if m.pool == nil || m.target == m.pool.Size {
+			return m, nil
+		}
+		if m.err = m.pool.CheckSize(m.target); m.err != nil {
+			return m, nil
+		}
+		m.state = workerPoolStateConfirm
+	case "r":
+		return m, m.loadPool()
+	case "esc":
+		return m, goBack()
+	}
+	return m, nil
+}
+
+func (m *WorkerPoolModel) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y", "enter":
+		return m, m.resize()
+	case "n", "N", "esc":
+		m.state = workerPoolStateEdit
+	}
+	return m, nil
+}
+
+// adjust moves the target size by delta, within the bounds the server reports
+func (m *WorkerPoolModel) adjust(delta int) {
+	if m.pool == nil {
+		return
+	}
+	target := m.target + delta
+	if m.pool.CheckSize(target) != nil {
+		return
+	}
+	m.target = target
+	m.err = nil
+}
+
+func (m *WorkerPoolModel) View() string {
+	innerWidth := layout.InnerWidth(m.width)
+
+	var content strings.Builder
+	apiErr, _ := m.err.(*api.APIError)
+	switch {
+	case m.state == workerPoolStateLoading:
+		content.WriteString(styles.TextMuted.Render("Loading the worker pool...") + "\n")
+	case m.pool == nil && apiErr != nil && apiErr.Type == api.ErrorTypeUnsupported:
+		content.WriteString(layout.CenterText(styles.TextMuted.Render("This server does not support resizing its worker pool."), innerWidth) + "\n")
+	case m.pool == nil && m.err != nil:
+		content.WriteString(styles.TextError.Render("Error: "+m.err.Error()) + "\n")
+		if permissionDenied(m.err) {
+			content.WriteString(renderSwitchKeyHint())
+		}
+	case m.state == workerPoolStateConfirm:
+		content.WriteString(layout.ConfirmModal(layout.ConfirmModalConfig{
+			Width:   innerWidth - 4,
+			Title:   "Resize the worker pool?",
+			Warning: "The pool on " + m.server.Name + " will be resized:",
+			Items: []layout.ConfirmModalItem{
+				{Label: "Current size", Value: strconv.Itoa(m.pool.Size)},
+				{Label: "Target size", Value: strconv.Itoa(m.target)},
+			},
+			Simple: true,
+		}))
+	default:
+		content.WriteString(m.renderPool(innerWidth))
+	}
+
+	return layout.Page(layout.PageConfig{
+		Width:      m.width,
+		Height:     m.height,
+		Server:     m.server,
+		Breadcrumb: "Main › Settings › Worker Pool",
+		Title:      "WORKER POOL",
+		Content:    content.String(),
+		Shortcuts:  m.getShortcuts(),
+	})
+}
+
+func (m *WorkerPoolModel) renderPool(width int) string {
+	var card strings.Builder
+
+	card.WriteString(styles.TextMuted.Render("Current size: ") + strconv.Itoa(m.pool.Size) + "\n")
+
+	target := styles.TextPrimary.Render(fmt.Sprintf("◀ %d ▶", m.target))
+	switch {
+	case m.target > m.pool.Size:
+		target += styles.TextMuted.Render(fmt.Sprintf("  (+%d)", m.target-m.pool.Size))
+	case m.target < m.pool.Size:
+		target += styles.TextMuted.Render(fmt.Sprintf("  (-%d)", m.pool.Size-m.target))
+	}
+	card.WriteString(styles.TextMuted.Render("Target size:  ") + target + "\n")
+
+	bounds := fmt.Sprintf("at least %d", max(1, m.pool.Min))
+	if m.pool.Max > 0 {
+		bounds = fmt.Sprintf("%d to %d", max(1, m.pool.Min), m.pool.Max)
+	}
+	card.WriteString(styles.TextMuted.Render("Allowed:      " + bounds))
+
+	var b strings.Builder
+	b.WriteString(layout.Card(layout.CardConfig{
+		Width:   width - 4,
+		Variant: layout.CardDefault,
+		Content: card.String(),
+	}))
+	b.WriteString("\n")
+
+	if m.state == workerPoolStateApplying {
+		b.WriteString("\n" + styles.TextWarning.Render(fmt.Sprintf("Resizing the pool to %d...", m.target)) + "\n")
+	}
+	if m.err != nil {
+		b.WriteString("\n" + styles.TextError.Render("✗ "+m.err.Error()) + "\n")
+		if permissionDenied(m.err) {
+			b.WriteString(renderSwitchKeyHint())
+		}
+	}
+
+	return b.String()
+}
+
+func (m *WorkerPoolModel) getShortcuts() []string {
+	switch m.state {
+	case workerPoolStateConfirm:
+		return []string{
+			styles.RenderShortcut("y", i18n.T("shortcut.confirm")),
+			styles.RenderShortcut("n/Esc", i18n.T("shortcut.cancel")),
+		}
+	case workerPoolStateApplying:
+		return []string{}
+	}
+
+	var shortcuts []string
+	if m.pool != nil {
+		shortcuts = append(shortcuts,
+			styles.RenderShortcut("←→", i18n.T("shortcut.adjust")),
+			styles.RenderShortcut("⏎", i18n.T("shortcut.resize")),
+		)
+	}
+	if permissionDenied(m.err) {
+		shortcuts = append(shortcuts, switchKeyShortcut())
+	}
+	return append(shortcuts,
+		styles.RenderShortcut("r", i18n.T("shortcut.refresh")),
+		styles.RenderShortcut("Esc", i18n.T("shortcut.back")),
+	)
+}
@@ -0,0 +1,51 @@
+package screens
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/tui/components"
+	"github.com/buntime/cli/internal/tui/messages"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestWorkerPoolConfirmsBeforeResizing(t *testing.T) {
+	m := NewWorkerPoolModel(nil, &db.Server{Name: "prod"}, 100, 40)
+	m.Update(workerPoolLoadedMsg{pool: &api.PoolInfo{Size: 4, Min: 2, Max: 5}})
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m.Update(tea.KeyMsg{Type: tea.KeyRight}) // Beyond the maximum
+	if m.target != 5 {
+		t.Fatalf("expected the target to stop at the maximum, got %d", m.target)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.state != workerPoolStateConfirm {
+		t.Fatal("expected a confirmation before resizing")
+	}
+	view := m.View()
+	if !strings.Contains(view, "Current size: 4") || !strings.Contains(view, "Target size: 5") {
+		t.Fatal("expected the confirmation to show the current and target sizes")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if m.state != workerPoolStateEdit {
+		t.Fatal("expected n to cancel")
+	}
+}
+
+func TestWorkerPoolReportsTheAppliedSize(t *testing.T) {
+	m := NewWorkerPoolModel(nil, &db.Server{Name: "prod"}, 100, 40)
+	m.Update(workerPoolLoadedMsg{pool: &api.PoolInfo{Size: 4}})
+
+	_, cmd := m.Update(workerPoolResizedMsg{requested: 12, pool: &api.PoolInfo{Size: 8}})
+	if m.pool.Size != 8 || m.target != 8 {
+		t.Fatalf("expected the applied size, got %+v and target %d", m.pool, m.target)
+	}
+	toast, ok := cmd().(messages.ShowToastMsg)
+	if !ok || toast.Type != components.ToastWarning || !strings.Contains(toast.Message, "8 workers instead of 12") {
+		t.Fatalf("expected a warning about the clamped size, got %#v", toast)
+	}
+}
@@ -1,12 +1,15 @@
 package tui
 
 import (
+	"context"
+	"fmt"
 	"strings"
 	"time"
 
 	"github.com/buntime/cli/internal/api"
 	"github.com/buntime/cli/internal/db"
 	"github.com/buntime/cli/internal/tui/components"
+	"github.com/buntime/cli/internal/tui/layout"
 	"github.com/buntime/cli/internal/tui/messages"
 	"github.com/buntime/cli/internal/tui/screens"
 	tea "github.com/charmbracelet/bubbletea"
@@ -32,6 +35,18 @@ const (
 	ScreenKeys
 	ScreenKeyCreate
 	ScreenKeyRevoke
+	ScreenKeyEdit
+	ScreenKeyRotate
+	ScreenBatchInstall
+	ScreenConnectionError
+	ScreenActivity
+	ScreenPluginEnable
+	ScreenPluginConfig
+	ScreenServerConfig
+	ScreenWorkerPool
+	ScreenAppRollback
+	ScreenPluginDetail
+	ScreenAppDetail
 )
 
 // Model is the main TUI model
@@ -48,6 +63,12 @@ type Model struct {
 	currentServer *db.Server
 	connected     bool
 
+	// cancelScreen aborts the requests started by the current screen
+	cancelScreen context.CancelFunc
+
+	// serverInfo caches the connected server's health between screens
+	serverInfo *screens.ServerInfoCache
+
 	// Window size
 	width  int
 	height int
@@ -64,6 +85,12 @@ type Model struct {
 func NewModel(database *db.DB) *Model {
 	toast := components.NewToastModel()
 	toast.SetWidth(80)
+	if warning := database.Warning(); warning != "" {
+		toast.ShowWarning(warning)
+	}
+	layout.SetLegendVisible(database.GetConfigBool(db.ConfigShowLegend))
+	density, _ := database.GetConfig(db.ConfigDensity)
+	layout.SetDensity(layout.Density(density))
 
 	return &Model{
 		db:           database,
@@ -78,20 +105,32 @@ func NewModel(database *db.DB) *Model {
 // NewConnectedModel creates a TUI model already connected to a server.
 func NewConnectedModel(database *db.DB, client *api.Client, server *db.Server) *Model {
 	model := NewModel(database)
+	model.applyProvenance(client)
 	model.api = client
 	model.currentServer = server
 	model.connected = true
+	model.serverInfo = screens.NewServerInfoCache()
 	model.router = newRouter(ScreenMainMenu)
 	return model
 }
 
+// applyProvenance tags the client's changes with the CLI version and
+// user@host unless the user opted out in Settings
+func (m *Model) applyProvenance(client *api.Client) {
+	if client != nil && m.db.SendProvenance() {
+		client.SetProvenance(api.NewProvenance(layout.Version))
+	}
+}
+
 // Init initializes the model
 func (m *Model) Init() tea.Cmd {
 	if m.connected {
+		m.startScreenContext()
 		m.screenModels[ScreenMainMenu] = screens.NewMainMenuModel(m.api, m.currentServer, m.width, m.height)
 		return tea.Batch(
 			m.screenModels[ScreenMainMenu].Init(),
 			toastTick(),
+			m.startHeartbeat(),
 		)
 	}
 
@@ -103,6 +142,13 @@ func (m *Model) Init() tea.Cmd {
 	)
 }
 
+// typing reports whether the current screen has a text field focused, so
+// the keys handled here are left to it
+func (m *Model) typing() bool {
+	entry, ok := m.screenModels[m.router.Current()].(screens.TextEntry)
+	return ok && entry.Typing()
+}
+
 // toastTick returns a command that ticks every 100ms for toast updates
 func toastTick() tea.Cmd {
 	return tea.Tick(100*time.Millisecond, func(t time.Time) tea.Msg {
@@ -118,19 +164,27 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		m.toast.SetWidth(msg.Width)
 
-		// Update current screen size
-		if screenModel, ok := m.screenModels[m.router.Current()]; ok {
+		// Resize every cached screen, not just the current one, so going
+		// back doesn't render a screen laid out for the old terminal size
+		var cmds []tea.Cmd
+		for screen, screenModel := range m.screenModels {
 			newModel, cmd := screenModel.Update(msg)
-			m.screenModels[m.router.Current()] = newModel
-			return m, cmd
+			m.screenModels[screen] = newModel
+			cmds = append(cmds, cmd)
 		}
-		return m, nil
+		return m, tea.Batch(cmds...)
 
 	case tea.KeyMsg:
 		if msg.Type == tea.KeyCtrlC {
 			m.quitting = true
 			return m, tea.Quit
 		}
+		if msg.String() == layout.LegendKey && !m.typing() {
+			visible := !layout.LegendVisible()
+			layout.SetLegendVisible(visible)
+			m.db.SetConfigBool(db.ConfigShowLegend, visible)
+			return m, nil
+		}
 
 	// Toast messages
 	case messages.ShowToastMsg:
@@ -150,20 +204,23 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.toast.Update()
 		return m, toastTick()
 
+	case heartbeatTickMsg:
+		if msg.client != m.api {
+			return m, nil
+		}
+		return m, m.checkConnection(msg.client)
+
+	case heartbeatMsg:
+		if msg.client != m.api {
+			return m, nil
+		}
+		return m, m.updateConnection(msg)
+
 	// Navigation messages from screens
 	case screens.NavigateMsg:
 		// If navigating back to server select, reset connection state and history
 		if msg.Screen == screens.ScreenServerSelect {
-			m.connected = false
-			m.currentServer = nil
-			m.api = nil
-			// Reset router to clear history (ServerSelect is the root screen)
-			m.router.Reset(ScreenServerSelect)
-			m.initScreen(ScreenServerSelect, nil)
-			if screenModel, ok := m.screenModels[m.router.Current()]; ok {
-				return m, screenModel.Init()
-			}
-			return m, nil
+			return m.disconnect()
 		}
 		return m.handleNavigation(msg)
 
@@ -171,20 +228,29 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.goBack()
 
 	case screens.ConnectedMsg:
+		m.applyProvenance(msg.Client)
 		m.api = msg.Client
 		m.currentServer = msg.Server
 		m.connected = true
+		m.serverInfo = screens.NewServerInfoCache()
+		m.startScreenContext()
 		// Reset router and navigate to Main Menu
 		m.router.Reset(ScreenMainMenu)
 		m.initScreen(ScreenMainMenu, nil)
 		if screenModel, ok := m.screenModels[m.router.Current()]; ok {
-			return m, screenModel.Init()
+			return m, tea.Batch(screenModel.Init(), m.startHeartbeat())
 		}
-		return m, nil
+		return m, m.startHeartbeat()
 
 	case messages.ServerSavedMsg:
 		if msg.Err != nil {
 			m.toast.ShowError("Failed to save server: " + msg.Err.Error())
+			// Let the form clear its saving state so the user can retry
+			if screenModel, ok := m.screenModels[m.router.Current()]; ok {
+				newModel, cmd := screenModel.Update(msg)
+				m.screenModels[m.router.Current()] = newModel
+				return m, cmd
+			}
 			return m, nil
 		}
 		m.toast.ShowSuccess("Server saved successfully")
@@ -235,6 +301,30 @@ func (m *Model) handleNavigation(msg screens.NavigateMsg) (tea.Model, tea.Cmd) {
 		screen = ScreenKeyCreate
 	case screens.ScreenKeyRevoke:
 		screen = ScreenKeyRevoke
+	case screens.ScreenKeyEdit:
+		screen = ScreenKeyEdit
+	case screens.ScreenKeyRotate:
+		screen = ScreenKeyRotate
+	case screens.ScreenBatchInstall:
+		screen = ScreenBatchInstall
+	case screens.ScreenConnectionError:
+		screen = ScreenConnectionError
+	case screens.ScreenActivity:
+		screen = ScreenActivity
+	case screens.ScreenPluginEnable:
+		screen = ScreenPluginEnable
+	case screens.ScreenPluginConfig:
+		screen = ScreenPluginConfig
+	case screens.ScreenServerConfig:
+		screen = ScreenServerConfig
+	case screens.ScreenWorkerPool:
+		screen = ScreenWorkerPool
+	case screens.ScreenAppRollback:
+		screen = ScreenAppRollback
+	case screens.ScreenPluginDetail:
+		screen = ScreenPluginDetail
+	case screens.ScreenAppDetail:
+		screen = ScreenAppDetail
 	default:
 		return m, nil
 	}
@@ -248,6 +338,12 @@ func (m *Model) View() string {
 		return ""
 	}
 
+	// Below the minimum size the bordered layout garbles; show a notice that
+	// updates live as the terminal is resized
+	if layout.TooSmall(m.width, m.height) {
+		return layout.TooSmallMessage(m.width, m.height)
+	}
+
 	var screenView string
 	if screenModel, ok := m.screenModels[m.router.Current()]; ok {
 		screenView = screenModel.View()
@@ -313,49 +409,167 @@ func (m *Model) View() string {
 	return screenView
 }
 
-func (m *Model) initScreen(screen Screen, data interface{}) {
+// initScreen creates the model of screen from the data it was navigated
+// with. Data of the wrong type is reported instead of leaving the screen
+// without a model.
+func (m *Model) initScreen(screen Screen, data interface{}) error {
 	switch screen {
 	case ScreenServerSelect:
 		m.screenModels[screen] = screens.NewServerSelectModel(m.db, m.width, m.height)
 	case ScreenAddServer:
 		m.screenModels[screen] = screens.NewAddServerModel(m.db, m.width, m.height)
 	case ScreenEditServer:
-		if server, ok := data.(*db.Server); ok {
-			m.screenModels[screen] = screens.NewEditServerModel(m.db, server, m.width, m.height)
+		server, err := screenData[*db.Server](screen, data)
+		if err != nil {
+			return err
 		}
+		m.screenModels[screen] = screens.NewEditServerModel(m.db, server, m.width, m.height)
 	case ScreenTokenPrompt:
-		if server, ok := data.(*db.Server); ok {
-			m.screenModels[screen] = screens.NewTokenPromptModel(m.db, server, m.width, m.height)
+		switch data := data.(type) {
+		case *db.Server:
+			m.screenModels[screen] = screens.NewTokenPromptModel(m.db, data, m.width, m.height)
+		case *screens.SwitchKeyData:
+			m.screenModels[screen] = screens.NewSwitchKeyModel(m.db, data, m.width, m.height)
+		default:
+			return screenDataError(screen, (*db.Server)(nil), data)
 		}
 	case ScreenMainMenu:
 		m.screenModels[screen] = screens.NewMainMenuModel(m.api, m.currentServer, m.width, m.height)
 	case ScreenApps:
 		m.screenModels[screen] = screens.NewAppsModel(m.api, m.currentServer, m.width, m.height)
 	case ScreenPlugins:
-		m.screenModels[screen] = screens.NewPluginsModel(m.api, m.currentServer, m.width, m.height)
+		m.screenModels[screen] = screens.NewPluginsModel(m.api, m.db, m.currentServer, m.width, m.height)
 	case ScreenAppInstall:
-		m.screenModels[screen] = screens.NewInstallModel(m.api, m.currentServer, "app", m.width, m.height)
+		m.screenModels[screen] = screens.NewInstallModel(m.api, m.db, m.currentServer, "app", m.width, m.height)
 	case ScreenPluginInstall:
-		m.screenModels[screen] = screens.NewInstallModel(m.api, m.currentServer, "plugin", m.width, m.height)
+		m.screenModels[screen] = screens.NewInstallModel(m.api, m.db, m.currentServer, "plugin", m.width, m.height)
 	case ScreenAppRemove:
-		if app, ok := data.(*api.AppInfo); ok {
-			m.screenModels[screen] = screens.NewRemoveModel(m.api, m.currentServer, "app", app.Name, app.Versions, m.width, m.height)
+		app, err := screenData[*api.AppInfo](screen, data)
+		if err != nil {
+			return err
 		}
+		m.screenModels[screen] = screens.NewRemoveAppModel(m.api, m.db, m.currentServer, app, m.width, m.height)
 	case ScreenPluginRemove:
-		if plugin, ok := data.(*api.PluginInfo); ok {
-			m.screenModels[screen] = screens.NewRemovePluginModel(m.api, m.currentServer, plugin, m.width, m.height)
+		plugin, err := screenData[*api.PluginInfo](screen, data)
+		if err != nil {
+			return err
 		}
+		m.screenModels[screen] = screens.NewRemovePluginModel(m.api, m.db, m.currentServer, plugin, m.width, m.height)
 	case ScreenKeys:
 		m.screenModels[screen] = screens.NewKeysModel(m.api, m.currentServer, m.width, m.height)
 	case ScreenKeyCreate:
 		m.screenModels[screen] = screens.NewKeyCreateModel(m.api, m.currentServer, m.width, m.height)
 	case ScreenKeyRevoke:
-		if key, ok := data.(*api.ApiKeyInfo); ok {
-			m.screenModels[screen] = screens.NewKeyRevokeModel(m.api, m.currentServer, key, m.width, m.height)
+		key, err := screenData[*api.ApiKeyInfo](screen, data)
+		if err != nil {
+			return err
+		}
+		m.screenModels[screen] = screens.NewKeyRevokeModel(m.api, m.db, m.currentServer, key, m.width, m.height)
+	case ScreenKeyEdit:
+		key, err := screenData[*api.ApiKeyInfo](screen, data)
+		if err != nil {
+			return err
+		}
+		m.screenModels[screen] = screens.NewKeyEditModel(m.api, m.currentServer, key, m.width, m.height)
+	case ScreenKeyRotate:
+		key, err := screenData[*api.ApiKeyInfo](screen, data)
+		if err != nil {
+			return err
 		}
+		m.screenModels[screen] = screens.NewKeyRotateModel(m.api, m.db, m.currentServer, key, m.width, m.height)
 	case ScreenSettings:
-		m.screenModels[screen] = screens.NewSettingsModel(m.api, m.db, m.currentServer, m.width, m.height)
+		m.screenModels[screen] = screens.NewSettingsModel(m.api, m.serverInfo, m.db, m.currentServer, m.width, m.height)
+	case ScreenBatchInstall:
+		servers, err := screenData[[]db.Server](screen, data)
+		if err != nil {
+			return err
+		}
+		m.screenModels[screen] = screens.NewBatchInstallModel(m.db, servers, m.width, m.height)
+	case ScreenConnectionError:
+		failure, err := screenData[*screens.ConnectionFailure](screen, data)
+		if err != nil {
+			return err
+		}
+		m.screenModels[screen] = screens.NewConnectionErrorModel(m.db, failure, m.width, m.height)
+	case ScreenActivity:
+		m.screenModels[screen] = screens.NewActivityModel(m.api, m.currentServer, m.width, m.height)
+	case ScreenPluginEnable:
+		plugin, err := screenData[*api.PluginInfo](screen, data)
+		if err != nil {
+			return err
+		}
+		m.screenModels[screen] = screens.NewPluginEnableModel(m.api, m.db, m.currentServer, plugin, m.width, m.height)
+	case ScreenPluginConfig:
+		plugin, err := screenData[*api.PluginInfo](screen, data)
+		if err != nil {
+			return err
+		}
+		m.screenModels[screen] = screens.NewPluginConfigModel(m.api, m.currentServer, plugin, m.width, m.height)
+	case ScreenServerConfig:
+		m.screenModels[screen] = screens.NewServerConfigModel(m.api, m.currentServer, m.width, m.height)
+	case ScreenWorkerPool:
+		m.screenModels[screen] = screens.NewWorkerPoolModel(m.api, m.currentServer, m.width, m.height)
+	case ScreenAppRollback:
+		app, err := screenData[*api.AppInfo](screen, data)
+		if err != nil {
+			return err
+		}
+		m.screenModels[screen] = screens.NewAppRollbackModel(m.api, m.currentServer, app, m.width, m.height)
+	case ScreenPluginDetail:
+		plugin, err := screenData[*api.PluginInfo](screen, data)
+		if err != nil {
+			return err
+		}
+		m.screenModels[screen] = screens.NewPluginDetailModel(m.api, m.currentServer, plugin, m.width, m.height)
+	case ScreenAppDetail:
+		app, err := screenData[*api.AppInfo](screen, data)
+		if err != nil {
+			return err
+		}
+		m.screenModels[screen] = screens.NewAppDetailModel(m.currentServer, app, m.width, m.height)
+	}
+	return nil
+}
+
+// screenData returns the data a screen was navigated with as the type the
+// screen needs
+func screenData[T any](screen Screen, data interface{}) (T, error) {
+	value, ok := data.(T)
+	if !ok {
+		return value, screenDataError(screen, value, data)
+	}
+	return value, nil
+}
+
+func screenDataError(screen Screen, want, got interface{}) error {
+	return fmt.Errorf("can't open screen %d: expected %T data, got %T", screen, want, got)
+}
+
+// disconnect drops the connection and returns to the server list, the root
+// screen, clearing the history
+func (m *Model) disconnect() (*Model, tea.Cmd) {
+	m.connected = false
+	m.currentServer = nil
+	m.api = nil
+	m.serverInfo = nil
+	m.startScreenContext()
+	m.router.Reset(ScreenServerSelect)
+	m.initScreen(ScreenServerSelect, nil)
+	if screenModel, ok := m.screenModels[m.router.Current()]; ok {
+		return m, screenModel.Init()
 	}
+	return m, nil
+}
+
+// requiresConnection reports whether a screen talks to the server, so it
+// can't be opened without a client
+func requiresConnection(screen Screen) bool {
+	switch screen {
+	case ScreenServerSelect, ScreenAddServer, ScreenEditServer, ScreenTokenPrompt,
+		ScreenBatchInstall, ScreenConnectionError:
+		return false
+	}
+	return true
 }
 
 func (m *Model) navigateTo(screen Screen, data interface{}) (*Model, tea.Cmd) {
@@ -363,14 +577,32 @@ func (m *Model) navigateTo(screen Screen, data interface{}) (*Model, tea.Cmd) {
 }
 
 func (m *Model) navigateToWithOptions(screen Screen, data interface{}, replaceHistory bool) (*Model, tea.Cmd) {
+	// Screens that need a server would fail on their first request; send the
+	// user to connect instead
+	if m.api == nil && requiresConnection(screen) {
+		m.toast.ShowWarning("Connect to a server first")
+		return m.disconnect()
+	}
+
+	// A key pressed twice before the first navigation lands queues two
+	// identical pushes; drop the second so Esc doesn't return to the same screen
+	if !replaceHistory && m.router.Current() == screen {
+		return m, nil
+	}
+
+	// Build the screen first so bad data leaves the user where they are
+	if err := m.initScreen(screen, data); err != nil {
+		m.toast.ShowError(err.Error())
+		return m, nil
+	}
+
 	// Use router for navigation
 	if replaceHistory {
 		m.router.Replace(screen)
 	} else {
 		m.router.Push(screen)
 	}
-
-	m.initScreen(screen, data)
+	m.startScreenContext()
 
 	// Initialize the new screen
 	if screenModel, ok := m.screenModels[screen]; ok {
@@ -388,6 +620,7 @@ func (m *Model) goBack() (*Model, tea.Cmd) {
 
 	// Pop from history using router
 	screen, _ := m.router.Pop()
+	m.startScreenContext()
 
 	// Re-initialize the screen
 	if screenModel, ok := m.screenModels[screen]; ok {
@@ -397,3 +630,19 @@ func (m *Model) goBack() (*Model, tea.Cmd) {
 
 	return m, nil
 }
+
+// startScreenContext aborts the requests of the screen being left and gives
+// the next one a fresh context. Results of a screen only reach it while it is
+// current, so the old requests would finish unseen.
+func (m *Model) startScreenContext() {
+	if m.cancelScreen != nil {
+		m.cancelScreen()
+		m.cancelScreen = nil
+	}
+	if m.api == nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.api.SetContext(ctx)
+	m.cancelScreen = cancel
+}
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+var keyRoles = []api.KeyRole{api.KeyRoleAdmin, api.KeyRoleEditor, api.KeyRoleViewer, api.KeyRoleCustom}
+
+func runKeyList(cmd *cobra.Command, args []string) error {
+	if err := checkOutput(output); err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	keys, err := client.ListKeys()
+	if err != nil {
+		return err
+	}
+
+	if dataOutput(output) {
+		if keys == nil {
+			keys = []api.ApiKeyInfo{}
+		}
+		return printData(os.Stdout, keys)
+	}
+
+	printKeyTable(os.Stdout, keys, time.Now())
+	return nil
+}
+
+func printKeyTable(w io.Writer, keys []api.ApiKeyInfo, now time.Time) {
+	if len(keys) == 0 {
+		fmt.Fprintln(w, "No API keys.")
+		return
+	}
+
+	fmt.Fprintf(w, "%-24s %-16s %-8s %-18s %s\n", "NAME", "PREFIX", "ROLE", "EXPIRES", "LAST USED")
+	fmt.Fprintln(w, "--------------------------------------------------------------------------------")
+
+	for _, key := range keys {
+		expires := "never"
+		if key.ExpiresAt != nil {
+			expiresAt := time.Unix(*key.ExpiresAt, 0)
+			expires = humanize.RelTime(expiresAt, now, "ago", "from now")
+			if !expiresAt.After(now) {
+				expires = "expired"
+			}
+		}
+		lastUsed := "never"
+		if key.LastUsedAt != nil {
+			lastUsed = humanize.RelTime(time.Unix(*key.LastUsedAt, 0), now, "ago", "from now")
+		}
+		fmt.Fprintf(w, "%-24s %-16s %-8s %-18s %s\n", key.Name, key.KeyPrefix+"...", key.Role, expires, lastUsed)
+	}
+}
+
+func runKeyCreate(cmd *cobra.Command, args []string) error {
+	input, err := keyCreateInput()
+	if err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	// Servers that cap key lifetimes would refuse the request anyway; checking
+	// first gives a clearer message. Servers without key metadata skip this.
+	meta, err := client.GetKeyMeta()
+	if err != nil {
+		if apiErr, ok := err.(*api.APIError); !ok || apiErr.Type != api.ErrorTypeUnsupported {
+			return err
+		}
+	}
+	if err := api.CheckExpiration(input.ExpiresIn, meta.MaxExpirationDays()); err != nil {
+		return err
+	}
+	// The TUI only offers the permissions the server lists; do the same here
+	if err := checkKeyPermissions(input.Permissions, meta); err != nil {
+		return err
+	}
+
+	if input.Role == api.KeyRoleCustom {
+		for _, advice := range api.AdvisePermissions(input.Permissions) {
+			fmt.Fprintln(os.Stderr, "Warning: "+advice)
+		}
+	}
+
+	result, err := client.CreateKey(input)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Created key %s (%s)\n\n", result.Name, result.Role)
+	fmt.Println(result.Key)
+	fmt.Fprintln(os.Stderr, "\nWarning: Copy this key now. You won't be able to see it again!")
+	return nil
+}
+
+// keyCreateInput builds the create request from the flags
+func keyCreateInput() (api.CreateKeyInput, error) {
+	name := strings.TrimSpace(keyName)
+	if name == "" {
+		return api.CreateKeyInput{}, fmt.Errorf("--name is required")
+	}
+
+	role := api.KeyRole(strings.ToLower(strings.TrimSpace(keyRole)))
+	if !validKeyRole(role) {
+		return api.CreateKeyInput{}, fmt.Errorf("unknown role %q (use admin, editor, viewer or custom)", keyRole)
+	}
+
+	var perms []api.Permission
+	for _, perm := range keyPermissions {
+		if perm = strings.TrimSpace(perm); perm != "" {
+			perms = append(perms, api.Permission(perm))
+		}
+	}
+	if role == api.KeyRoleCustom && len(perms) == 0 {
+		return api.CreateKeyInput{}, fmt.Errorf("--role custom needs at least one --permission")
+	}
+	if role != api.KeyRoleCustom && len(perms) > 0 {
+		return api.CreateKeyInput{}, fmt.Errorf("--permission can only be used with --role custom")
+	}
+
+	expiresIn := api.ExpirationNever
+	if !strings.EqualFold(strings.TrimSpace(keyExpiresIn), api.ExpirationNever) {
+		normalized, err := api.NormalizeExpiration(keyExpiresIn)
+		if err != nil {
+			return api.CreateKeyInput{}, fmt.Errorf("invalid --expires-in: %w", err)
+		}
+		expiresIn = normalized
+	}
+
+	return api.CreateKeyInput{
+		Name:        name,
+		Role:        role,
+		ExpiresIn:   expiresIn,
+		Description: strings.TrimSpace(keyDescription),
+		Permissions: perms,
+	}, nil
+}
+
+// checkKeyPermissions rejects permissions the server doesn't list. Servers
+// without key metadata, or that list none, accept anything here.
+func checkKeyPermissions(perms []api.Permission, meta *api.KeyMetaInfo) error {
+	if meta == nil || len(meta.Permissions) == 0 {
+		return nil
+	}
+	for _, perm := range perms {
+		if !slices.Contains(meta.Permissions, perm) {
+			names := make([]string, len(meta.Permissions))
+			for i, p := range meta.Permissions {
+				names[i] = string(p)
+			}
+			return fmt.Errorf("unknown permission %q (the server has %s)", perm, strings.Join(names, ", "))
+		}
+	}
+	return nil
+}
+
+func validKeyRole(role api.KeyRole) bool {
+	for _, r := range keyRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func runKeyRevoke(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	keys, err := client.ListKeys()
+	if err != nil {
+		return err
+	}
+	key, err := findKey(keys, args[0])
+	if err != nil {
+		return err
+	}
+
+	if !keyYes {
+		if err := confirmKeyRevoke(os.Stdin, os.Stdout, key); err != nil {
+			return err
+		}
+	}
+
+	if err := client.RevokeKey(key.ID); err != nil {
+		return err
+	}
+
+	fmt.Printf("Revoked %s\n", key.Name)
+	return nil
+}
+
+// findKey resolves a key by exact name, or failing that by the start of its
+// prefix. Several matches are an error listing their prefixes, so the caller
+// can pick one instead of revoking the wrong key.
+func findKey(keys []api.ApiKeyInfo, ref string) (*api.ApiKeyInfo, error) {
+	ref = strings.TrimSuffix(strings.TrimSpace(ref), "...")
+	if ref == "" {
+		return nil, fmt.Errorf("key name or prefix required")
+	}
+
+	var matches []*api.ApiKeyInfo
+	for i := range keys {
+		if keys[i].Name == ref {
+			matches = append(matches, &keys[i])
+		}
+	}
+	if len(matches) == 0 {
+		for i := range keys {
+			if strings.HasPrefix(keys[i].KeyPrefix, ref) {
+				matches = append(matches, &keys[i])
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("key not found: %s", ref)
+	case 1:
+		return matches[0], nil
+	}
+
+	prefixes := make([]string, len(matches))
+	for i, key := range matches {
+		prefixes[i] = key.KeyPrefix
+	}
+	return nil, fmt.Errorf("%d keys match %q; use one of their prefixes instead: %s", len(matches), ref, strings.Join(prefixes, ", "))
+}
+
+// confirmKeyRevoke shows the key and has the user type its name, like the TUI
+// revoke screen
+func confirmKeyRevoke(in io.Reader, out io.Writer, key *api.ApiKeyInfo) error {
+	fmt.Fprintf(out, "Name:   %s\n", key.Name)
+	fmt.Fprintf(out, "Role:   %s\n", key.Role)
+	fmt.Fprintf(out, "Prefix: %s...\n\n", key.KeyPrefix)
+	fmt.Fprintf(out, "Revoking a key can't be undone. Type %s to confirm: ", key.Name)
+
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	if strings.TrimSpace(answer) != key.Name {
+		return fmt.Errorf("confirmation did not match; %s was not revoked", key.Name)
+	}
+	return nil
+}
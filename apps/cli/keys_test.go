@@ -0,0 +1,139 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/buntime/cli/internal/api"
+)
+
+func setKeyFlags(t *testing.T, name, role, expiresIn string, perms ...string) {
+	t.Helper()
+	keyName, keyRole, keyExpiresIn, keyDescription, keyPermissions = name, role, expiresIn, "", perms
+	t.Cleanup(func() {
+		keyName, keyRole, keyExpiresIn, keyDescription, keyPermissions = "", "", "", "", nil
+	})
+}
+
+func TestKeyCreateInput(t *testing.T) {
+	setKeyFlags(t, " ci ", "Custom", "1y 2m", "apps:read", "apps:install")
+
+	input, err := keyCreateInput()
+	if err != nil {
+		t.Fatalf("keyCreateInput() error = %v", err)
+	}
+	want := api.CreateKeyInput{
+		Name:        "ci",
+		Role:        api.KeyRoleCustom,
+		ExpiresIn:   "425d",
+		Permissions: []api.Permission{api.PermAppsRead, api.PermAppsInstall},
+	}
+	if !reflect.DeepEqual(input, want) {
+		t.Fatalf("keyCreateInput() = %+v, want %+v", input, want)
+	}
+
+	setKeyFlags(t, "ci", "viewer", "never")
+	if input, err := keyCreateInput(); err != nil || input.ExpiresIn != api.ExpirationNever {
+		t.Fatalf("never: got %+v, %v", input, err)
+	}
+}
+
+func TestKeyCreateInputRejects(t *testing.T) {
+	cases := map[string]func(){
+		"missing name":         func() { setKeyFlags(t, " ", "viewer", "never") },
+		"unknown role":         func() { setKeyFlags(t, "ci", "owner", "never") },
+		"custom without perms": func() { setKeyFlags(t, "ci", "custom", "never") },
+		"perms without custom": func() { setKeyFlags(t, "ci", "editor", "never", "apps:read") },
+		"bad expiration":       func() { setKeyFlags(t, "ci", "viewer", "soon") },
+	}
+	for name, set := range cases {
+		set()
+		if _, err := keyCreateInput(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestFindKey(t *testing.T) {
+	keys := []api.ApiKeyInfo{
+		{ID: 1, Name: "ci", KeyPrefix: "btk_aaaa1111"},
+		{ID: 2, Name: "deploy", KeyPrefix: "btk_bbbb2222"},
+		{ID: 3, Name: "deploy", KeyPrefix: "btk_bbcc3333"},
+	}
+
+	for ref, want := range map[string]int{"ci": 1, "btk_bbbb": 2, "btk_bbcc3333...": 3} {
+		key, err := findKey(keys, ref)
+		if err != nil || key.ID != want {
+			t.Errorf("findKey(%q) = %v, %v, want key %d", ref, key, err, want)
+		}
+	}
+
+	_, err := findKey(keys, "deploy")
+	if err == nil || !strings.Contains(err.Error(), "btk_bbbb2222, btk_bbcc3333") {
+		t.Fatalf("expected the ambiguous name to list both prefixes, got %v", err)
+	}
+	if _, err := findKey(keys, "btk_bb"); err == nil {
+		t.Fatal("expected an ambiguous prefix to be rejected")
+	}
+	if _, err := findKey(keys, "missing"); err == nil {
+		t.Fatal("expected an unknown key to be rejected")
+	}
+}
+
+func TestConfirmKeyRevoke(t *testing.T) {
+	key := &api.ApiKeyInfo{Name: "ci", Role: api.KeyRoleViewer, KeyPrefix: "btk_aaaa1111"}
+
+	var out strings.Builder
+	if err := confirmKeyRevoke(strings.NewReader("ci\n"), &out, key); err != nil {
+		t.Fatalf("confirmKeyRevoke() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "btk_aaaa1111") {
+		t.Fatalf("expected the prompt to show the prefix, got %q", out.String())
+	}
+
+	if err := confirmKeyRevoke(strings.NewReader("y\n"), &out, key); err == nil {
+		t.Fatal("expected a wrong name to cancel the revoke")
+	}
+}
+
+func TestCheckKeyPermissions(t *testing.T) {
+	meta := &api.KeyMetaInfo{Permissions: []api.Permission{api.PermAppsRead, api.PermAppsInstall}}
+
+	if err := checkKeyPermissions([]api.Permission{api.PermAppsRead}, meta); err != nil {
+		t.Fatalf("expected a listed permission to pass, got %v", err)
+	}
+	err := checkKeyPermissions([]api.Permission{"apps:raed"}, meta)
+	if err == nil || !strings.Contains(err.Error(), "apps:read, apps:install") {
+		t.Fatalf("expected the unknown permission to list the server's, got %v", err)
+	}
+	if err := checkKeyPermissions([]api.Permission{"apps:raed"}, nil); err != nil {
+		t.Fatalf("expected servers without metadata to accept anything, got %v", err)
+	}
+}
+
+func TestPrintKeyTable(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	past, future := now.Add(-time.Hour).Unix(), now.Add(72*time.Hour).Unix()
+
+	var b strings.Builder
+	printKeyTable(&b, []api.ApiKeyInfo{
+		{Name: "ci", KeyPrefix: "btk_aaaa", Role: api.KeyRoleEditor, ExpiresAt: &future, LastUsedAt: &past},
+		{Name: "old", KeyPrefix: "btk_bbbb", Role: api.KeyRoleViewer, ExpiresAt: &past},
+		{Name: "admin", KeyPrefix: "btk_cccc", Role: api.KeyRoleAdmin},
+	}, now)
+	out := b.String()
+
+	for _, want := range []string{"btk_aaaa...", "3 days from now", "1 hour ago", "expired", "never"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in:\n%s", want, out)
+		}
+	}
+
+	b.Reset()
+	printKeyTable(&b, nil, now)
+	if b.String() != "No API keys.\n" {
+		t.Fatalf("empty list printed %q", b.String())
+	}
+}
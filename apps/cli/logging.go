@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/buntime/cli/internal/api"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// logOutput is the file given with --log-file, nil when logging is off
+var logOutput *os.File
+
+// openLogFile sends the standard logger, and with it the request log, to
+// path. The file is created readable by the owner only; an existing file
+// that others can read is used as is, with a warning.
+func openLogFile(path string) error {
+	file, err := tea.LogToFile(path, "buntime")
+	if err != nil {
+		return err
+	}
+	logOutput = file
+	log.SetFlags(log.LstdFlags | log.Lmicroseconds)
+
+	if info, err := file.Stat(); err == nil && info.Mode().Perm()&0o077 != 0 {
+		fmt.Fprintf(os.Stderr, "Warning: log file %s can be read by other users (mode %s)\n", path, info.Mode().Perm())
+	}
+
+	api.LogRequests(true)
+	log.Printf("buntime %s started", version)
+	return nil
+}
+
+// closeLogFile flushes and closes the log file, if any
+func closeLogFile() {
+	if logOutput == nil {
+		return
+	}
+	api.LogRequests(false)
+	log.SetOutput(os.Stderr)
+	logOutput.Close()
+	logOutput = nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOpenLogFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "buntime.log")
+	if err := openLogFile(path); err != nil {
+		t.Fatalf("openLogFile() error = %v", err)
+	}
+	log.Printf("hello from the test")
+	closeLogFile()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Fatalf("log file mode = %v, want 0600", perm)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "hello from the test") {
+		t.Fatalf("log file = %q", data)
+	}
+	if logOutput != nil {
+		t.Fatal("expected the log file to be closed")
+	}
+}
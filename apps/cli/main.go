@@ -1,12 +1,21 @@
 package main
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"log"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/buntime/cli/internal/api"
 	"github.com/buntime/cli/internal/db"
+	"github.com/buntime/cli/internal/deploy"
+	"github.com/buntime/cli/internal/i18n"
 	"github.com/buntime/cli/internal/tui"
+	"github.com/buntime/cli/internal/tui/screens"
+	"github.com/buntime/cli/internal/tui/styles"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 )
@@ -18,6 +27,64 @@ var (
 	serverURL string
 	token     string
 	insecure  bool
+	lang      string
+	noBell    bool
+	timeout   time.Duration
+	logFile   string
+
+	// tokenFromFlag is set when the token came from --token rather than the
+	// environment; only those are saved by the TUI
+	tokenFromFlag bool
+
+	// Install flags
+	force    bool
+	verify   bool
+	rollback bool
+
+	// List flags
+	selector string
+	output   string // Global --output, e.g. table or json
+
+	// Apply flags
+	specFile string
+	prune    bool
+	dryRun   bool
+
+	// Diff flags
+	exitCode bool
+
+	// Enable flags
+	enableVersion string
+
+	// Activity flags
+	activityType   string
+	activityActor  string
+	activityLimit  int
+	activityCursor string
+
+	// Key create flags
+	keyName        string
+	keyRole        string
+	keyExpiresIn   string
+	keyDescription string
+	keyPermissions []string
+
+	// Key revoke flags
+	keyYes bool
+
+	// Server health flags
+	serverHealthAll  bool
+	serverHealthJSON bool
+
+	// Server add flags
+	serverAddName string
+
+	// Health flags
+	healthWait time.Duration
+
+	// Worker restart flags
+	workerDrain        bool
+	workerDrainTimeout time.Duration
 )
 
 func main() {
@@ -26,12 +93,29 @@ func main() {
 		Short:   "Buntime CLI - Runtime Worker Pool Manager",
 		Version: version,
 		RunE:    runTUI,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			applyEnvDefaults(cmd != cmd.Root())
+			if logFile != "" {
+				if err := openLogFile(logFile); err != nil {
+					return err
+				}
+			}
+			if lang == "" {
+				lang = i18n.Detect()
+			}
+			return i18n.SetLanguage(lang)
+		},
 	}
 
 	// Global flags
-	rootCmd.PersistentFlags().StringVarP(&serverURL, "url", "u", "", "Server URL")
-	rootCmd.PersistentFlags().StringVarP(&token, "token", "t", "", "Authentication token")
+	rootCmd.PersistentFlags().StringVarP(&serverURL, "url", "u", "", "Server URL (default $"+envURL+", except in the TUI)")
+	rootCmd.PersistentFlags().StringVarP(&token, "token", "t", "", "Authentication token; overrides $"+envToken+", which overrides the token saved for --url")
 	rootCmd.PersistentFlags().BoolVarP(&insecure, "insecure", "k", false, "Skip TLS certificate verification")
+	rootCmd.PersistentFlags().StringVar(&lang, "lang", "", "Interface language (en, pt); defaults to $LANG")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", api.DefaultTimeout, "How long a request may take; uploads get at least 10m (e.g. 90s, 30m)")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Write a debug log, including every request made, to this file")
+	rootCmd.PersistentFlags().StringVarP(&output, "output", "o", "table", "Output format: table, json or yaml; app and plugin list also take template=<go template> (e.g. 'template={{.Name}} {{.Version}}')")
+	rootCmd.Flags().BoolVar(&noBell, "no-bell", false, "Don't ring the bell or notify when long operations finish")
 
 	// Plugin commands
 	pluginCmd := &cobra.Command{
@@ -44,6 +128,7 @@ func main() {
 		Short: "List installed plugins",
 		RunE:  runPluginList,
 	}
+	pluginListCmd.Flags().StringVarP(&selector, "selector", "l", "", "Only list plugins whose labels match (e.g. env=prod,tier!=web)")
 
 	pluginInstallCmd := &cobra.Command{
 		Use:   "install <file>",
@@ -51,6 +136,9 @@ func main() {
 		Args:  cobra.ExactArgs(1),
 		RunE:  runPluginInstall,
 	}
+	pluginInstallCmd.Flags().BoolVarP(&force, "force", "f", false, "Overwrite the version if it is already installed")
+	pluginInstallCmd.Flags().BoolVar(&verify, "verify", false, "Re-check server health after installing")
+	pluginInstallCmd.Flags().BoolVar(&rollback, "rollback", false, "Remove the installed version if the server becomes unhealthy (implies --verify)")
 
 	pluginRemoveCmd := &cobra.Command{
 		Use:   "remove <name> [version]",
@@ -65,6 +153,8 @@ func main() {
 		Args:  cobra.ExactArgs(1),
 		RunE:  runPluginEnable,
 	}
+	pluginEnableCmd.Flags().BoolVar(&verify, "verify", false, "Re-check server health after enabling")
+	pluginEnableCmd.Flags().StringVar(&enableVersion, "version", "", "Installed version to run (defaults to the one that ran last)")
 
 	pluginDisableCmd := &cobra.Command{
 		Use:   "disable <name>",
@@ -72,6 +162,7 @@ func main() {
 		Args:  cobra.ExactArgs(1),
 		RunE:  runPluginDisable,
 	}
+	pluginDisableCmd.Flags().BoolVar(&verify, "verify", false, "Re-check server health after disabling")
 
 	pluginCmd.AddCommand(pluginListCmd, pluginInstallCmd, pluginRemoveCmd, pluginEnableCmd, pluginDisableCmd)
 
@@ -86,6 +177,7 @@ func main() {
 		Short: "List installed apps",
 		RunE:  runAppList,
 	}
+	appListCmd.Flags().StringVarP(&selector, "selector", "l", "", "Only list apps whose labels match (e.g. env=prod,tier!=web)")
 
 	appInstallCmd := &cobra.Command{
 		Use:   "install <file>",
@@ -93,6 +185,9 @@ func main() {
 		Args:  cobra.ExactArgs(1),
 		RunE:  runAppInstall,
 	}
+	appInstallCmd.Flags().BoolVarP(&force, "force", "f", false, "Overwrite the version if it is already installed")
+	appInstallCmd.Flags().BoolVar(&verify, "verify", false, "Re-check server health after installing")
+	appInstallCmd.Flags().BoolVar(&rollback, "rollback", false, "Remove the installed version if the server becomes unhealthy (implies --verify)")
 
 	appRemoveCmd := &cobra.Command{
 		Use:   "remove <name> [version]",
@@ -103,43 +198,255 @@ func main() {
 
 	appCmd.AddCommand(appListCmd, appInstallCmd, appRemoveCmd)
 
+	// Config commands
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage the local configuration",
+	}
+
+	configRepairCmd := &cobra.Command{
+		Use:   "repair",
+		Short: "Back up and recreate a corrupted config database",
+		Args:  cobra.NoArgs,
+		RunE:  runConfigRepair,
+	}
+
+	configCmd.AddCommand(configRepairCmd)
+
+	applyCmd := &cobra.Command{
+		Use:   "apply -f <spec>",
+		Short: "Reconcile the server with a YAML or JSON spec of apps and plugins",
+		Args:  cobra.NoArgs,
+		RunE:  runApply,
+	}
+	applyCmd.Flags().StringVarP(&specFile, "file", "f", "", "Spec file listing the apps and plugins to install")
+	applyCmd.Flags().BoolVar(&prune, "prune", false, "Remove apps and plugins that are not in the spec")
+	applyCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the plan without changing the server")
+	applyCmd.MarkFlagRequired("file")
+
+	diffCmd := &cobra.Command{
+		Use:   "diff -f <spec>",
+		Short: "Show what apply would change without changing anything",
+		Args:  cobra.NoArgs,
+		RunE:  runDiff,
+	}
+	diffCmd.Flags().StringVarP(&specFile, "file", "f", "", "Spec file listing the apps and plugins to install")
+	diffCmd.Flags().BoolVar(&prune, "prune", false, "Report apps and plugins that are not in the spec as removals")
+	diffCmd.Flags().BoolVar(&exitCode, "exit-code", false, "Exit with status 1 when the server differs from the spec")
+	diffCmd.MarkFlagRequired("file")
+
+	healthCmd := &cobra.Command{
+		Use:   "health",
+		Short: "Check that the server is healthy; exits 1 when unhealthy, 2 when unreachable",
+		Args:  cobra.NoArgs,
+		RunE:  runHealth,
+	}
+	healthCmd.Flags().DurationVar(&healthWait, "wait", 0, "Keep checking until the server is healthy or this much time has passed (e.g. 60s)")
+
+	doctorCmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the connection to a server and the local clock",
+		Args:  cobra.NoArgs,
+		RunE:  runDoctor,
+	}
+
+	activityCmd := &cobra.Command{
+		Use:   "activity",
+		Short: "Show recent changes on the server, newest first",
+		Args:  cobra.NoArgs,
+		RunE:  runActivity,
+	}
+	activityCmd.Flags().StringVar(&activityType, "type", "", "Only show entries of this type (e.g. app.install)")
+	activityCmd.Flags().StringVar(&activityActor, "actor", "", "Only show entries made by this key")
+	activityCmd.Flags().IntVar(&activityLimit, "limit", 50, "Maximum number of entries to fetch")
+	activityCmd.Flags().StringVar(&activityCursor, "cursor", "", "Continue from the cursor printed by a previous page")
+
+	// Key commands
+	keyCmd := &cobra.Command{
+		Use:   "keys",
+		Short: "Manage API keys",
+	}
+
+	keyListCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List API keys",
+		Args:  cobra.NoArgs,
+		RunE:  runKeyList,
+	}
+
+	keyCreateCmd := &cobra.Command{
+		Use:   "create --name <name>",
+		Short: "Create an API key and print it once",
+		Args:  cobra.NoArgs,
+		RunE:  runKeyCreate,
+	}
+	keyCreateCmd.Flags().StringVar(&keyName, "name", "", "Name of the key")
+	keyCreateCmd.Flags().StringVar(&keyRole, "role", string(api.KeyRoleViewer), "Role: admin, editor, viewer or custom")
+	keyCreateCmd.Flags().StringVar(&keyExpiresIn, "expires-in", api.ExpirationNever, "Lifetime such as 30d, 6m or \"1y 2m\", or never")
+	keyCreateCmd.Flags().StringVar(&keyDescription, "description", "", "What the key is used for")
+	keyCreateCmd.Flags().StringArrayVar(&keyPermissions, "permission", nil, "Permission of a custom role (repeatable, e.g. apps:read)")
+	keyCreateCmd.MarkFlagRequired("name")
+
+	keyRevokeCmd := &cobra.Command{
+		Use:   "revoke <name|prefix>",
+		Short: "Revoke an API key",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runKeyRevoke,
+	}
+	keyRevokeCmd.Flags().BoolVarP(&keyYes, "yes", "y", false, "Revoke without asking to type the key name")
+
+	keyCmd.AddCommand(keyListCmd, keyCreateCmd, keyRevokeCmd)
+
+	// Server commands
+	serverCmd := &cobra.Command{
+		Use:   "server",
+		Short: "Work with saved servers",
+	}
+
+	serverHealthCmd := &cobra.Command{
+		Use:   "health [name...]",
+		Short: "Check the health of saved servers; exits 1 when any is down",
+		RunE:  runServerHealth,
+	}
+	serverHealthCmd.Flags().BoolVar(&serverHealthAll, "all", false, "Check every saved server")
+	serverHealthCmd.Flags().BoolVar(&serverHealthJSON, "json", false, "Print the results as JSON, same as --output json")
+
+	serverListCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List saved servers",
+		Args:  cobra.NoArgs,
+		RunE:  runServerList,
+	}
+
+	serverAddCmd := &cobra.Command{
+		Use:   "add --url <url>",
+		Short: "Save a server; --token and --insecure are saved with it",
+		Args:  cobra.NoArgs,
+		RunE:  runServerAdd,
+	}
+	serverAddCmd.Flags().StringVar(&serverAddName, "name", "", "Server name; defaults to the URL's host")
+
+	serverRemoveCmd := &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove a saved server",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runServerRemove,
+	}
+
+	serverCmd.AddCommand(serverListCmd, serverAddCmd, serverRemoveCmd, serverHealthCmd)
+
+	// Worker commands
+	workerCmd := &cobra.Command{
+		Use:   "workers",
+		Short: "Inspect and restart the server's workers",
+	}
+
+	workerListCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the workers in the pool",
+		Args:  cobra.NoArgs,
+		RunE:  runWorkerList,
+	}
+
+	workerRestartCmd := &cobra.Command{
+		Use:   "restart <id>",
+		Short: "Restart a worker",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runWorkerRestart,
+	}
+	workerRestartCmd.Flags().BoolVar(&workerDrain, "drain", false, "Let the worker finish its in-flight requests before restarting it")
+	workerRestartCmd.Flags().DurationVar(&workerDrainTimeout, "drain-timeout", 30*time.Second, "How long to wait for the worker to drain")
+
+	workerCmd.AddCommand(workerListCmd, workerRestartCmd)
+
 	// Add subcommands
-	rootCmd.AddCommand(pluginCmd, appCmd)
+	rootCmd.AddCommand(pluginCmd, appCmd, configCmd, applyCmd, diffCmd, healthCmd, doctorCmd, activityCmd, keyCmd, serverCmd, workerCmd)
+
+	// Errors are printed here so --output json and yaml can report them too
+	rootCmd.SilenceErrors = true
+	err := rootCmd.Execute()
+	if err != nil && logOutput != nil {
+		log.Printf("error: %v", err)
+	}
+	closeLogFile()
+	if err != nil {
+		code := 1
+		var exit *exitError
+		if errors.As(err, &exit) {
+			code = exit.code
+		}
+		switch {
+		case exit != nil && exit.err == nil:
+			// The command already reported why
+		case dataOutput(output):
+			printErrorJSON(os.Stdout, err)
+		default:
+			fmt.Fprintln(os.Stderr, "Error:", err)
+		}
+		os.Exit(code)
+	}
+}
+
+// exitError makes the CLI exit with a specific status. A nil err exits
+// without printing anything more.
+type exitError struct {
+	code int
+	err  error
+}
 
-	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
+func (e *exitError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("exit status %d", e.code)
 	}
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
 }
 
 func runTUI(cmd *cobra.Command, args []string) error {
+	if noBell {
+		screens.MuteAlerts()
+	}
+
 	// Initialize database
-	database, err := db.New()
+	database, err := openDatabase()
 	if err != nil {
-		return fmt.Errorf("failed to initialize database: %w", err)
+		return err
 	}
 	defer database.Close()
 
 	// If URL provided via CLI, skip server selection
 	var model *tui.Model
 	if serverURL != "" {
-		client := api.New(serverURL, token, insecure)
+		useSavedToken(database)
+		client := newClient()
 		if err := client.Ping(); err != nil {
 			// Check if auth required
 			if apiErr, ok := err.(*api.APIError); ok && apiErr.Type == api.ErrorTypeAuthRequired {
-				return fmt.Errorf("authentication required. Use --token flag")
+				return fmt.Errorf("authentication required. Use --token flag or %s", envToken)
 			}
 			return fmt.Errorf("connection failed: %w", err)
 		}
 
-		// Save server if not exists
+		// Save server if not exists. A token from the environment isn't
+		// written to the database in plain text.
+		var savedToken *string
+		if tokenFromFlag {
+			savedToken = &token
+		}
 		existing, _ := database.GetServerByURL(serverURL)
 		if existing == nil {
-			existing, err = database.CreateServer("CLI", serverURL, &token, insecure)
+			existing, err = database.CreateServer("CLI", serverURL, savedToken, insecure)
 			if err != nil {
 				return fmt.Errorf("failed to save server: %w", err)
 			}
 		} else {
-			if err := database.UpdateServer(existing.ID, existing.Name, serverURL, &token, insecure); err != nil {
+			if !tokenFromFlag {
+				savedToken = existing.Token
+			}
+			if err := database.UpdateServer(existing.ID, existing.Name, serverURL, savedToken, insecure); err != nil {
 				return fmt.Errorf("failed to update server: %w", err)
 			}
 			existing, _ = database.GetServer(existing.ID)
@@ -150,24 +457,117 @@ func runTUI(cmd *cobra.Command, args []string) error {
 		model = tui.NewModel(database)
 	}
 
-	// Run Bubble Tea
-	p := tea.NewProgram(model, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	// Run Bubble Tea. Panics are handled by runProgram so the crash report
+	// includes the version and a crash log.
+	p := tea.NewProgram(crashGuard{model: model}, tea.WithAltScreen(), tea.WithoutCatchPanics())
+	return runProgram(p)
+}
+
+// openDatabase opens the config database, offering to repair it when it is
+// corrupted instead of leaving the CLI unusable
+func openDatabase() (*db.DB, error) {
+	database, err := db.New()
+	if err == nil {
+		if warning := database.Warning(); warning != "" {
+			fmt.Fprintln(os.Stderr, "Warning: "+warning)
+		}
+		return database, nil
+	}
+	if !errors.Is(err, db.ErrCorrupt) {
+		return nil, fmt.Errorf("failed to initialize database: %w", err)
+	}
+
+	path, _ := db.Path()
+	fmt.Fprintf(os.Stderr, "The config database at %s is corrupted (%v).\n", path, err)
+	fmt.Fprint(os.Stderr, "Back it up and create a fresh one? [y/N] ")
+
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	if strings.ToLower(strings.TrimSpace(answer)) != "y" {
+		return nil, fmt.Errorf("config database is corrupted. Run `buntime config repair` to recover")
+	}
+
+	if err := repairDatabase(); err != nil {
+		return nil, err
+	}
+
+	database, err = db.New()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize database: %w", err)
+	}
+	return database, nil
+}
+
+func repairDatabase() error {
+	result, err := db.Repair()
+	if err != nil {
 		return err
 	}
 
+	fmt.Printf("Backed up corrupted database to %s\n", result.BackupPath)
+	fmt.Printf("Recovered %d server(s) and %d setting(s)\n", result.ServersRecovered, result.ConfigRecovered)
 	return nil
 }
 
+// Environment variables used when the matching flag isn't passed
+const (
+	envURL   = "BUNTIME_URL"
+	envToken = "BUNTIME_TOKEN"
+)
+
+// applyEnvDefaults fills --token, and --url for subcommands, from the
+// environment when they weren't passed, so the token can stay out of shell
+// history and process listings. The TUI ignores BUNTIME_URL so an exported
+// URL doesn't skip its server selection.
+func applyEnvDefaults(withURL bool) {
+	tokenFromFlag = token != ""
+	if withURL && serverURL == "" {
+		serverURL = os.Getenv(envURL)
+	}
+	if token == "" {
+		token = os.Getenv(envToken)
+	}
+}
+
+// useSavedToken falls back to the token saved for --url when neither the flag
+// nor the environment gave one
+func useSavedToken(database *db.DB) {
+	if token != "" || database == nil {
+		return
+	}
+	server, _ := database.GetServerByURL(strings.TrimSpace(serverURL))
+	if server != nil && server.Token != nil {
+		token = *server.Token
+	}
+}
+
+// newClient creates a client for --url with the global flags
+func newClient() *api.Client {
+	return api.New(serverURL, token, insecure,
+		api.WithTimeout(timeout),
+		api.WithUploadRetry(api.DefaultRetryAttempts, api.DefaultUploadRetryDelay),
+	)
+}
+
 func getClient() (*api.Client, error) {
 	if serverURL == "" {
-		return nil, fmt.Errorf("server URL required. Use --url flag or run in TUI mode")
+		return nil, fmt.Errorf("server URL required. Use --url flag, %s or run in TUI mode", envURL)
+	}
+
+	// Provenance is on unless opted out in the TUI settings
+	sendProvenance := true
+	if database, err := db.New(); err == nil {
+		useSavedToken(database)
+		sendProvenance = database.SendProvenance()
+		database.Close()
 	}
 
-	client := api.New(serverURL, token, insecure)
+	client := newClient()
 	if err := client.Ping(); err != nil {
 		return nil, err
 	}
+	if sendProvenance {
+		client.SetProvenance(api.NewProvenance(version))
+	}
 
 	return client, nil
 }
@@ -175,17 +575,42 @@ func getClient() (*api.Client, error) {
 // Plugin commands
 
 func runPluginList(cmd *cobra.Command, args []string) error {
+	sample := pluginRow{PluginInfo: api.PluginInfo{Versions: []string{"1.0.0"}, Labels: api.Labels{}}, Version: "1.0.0"}
+	tmpl, err := parseListOutput(output, sample)
+	if err != nil {
+		return err
+	}
+
 	client, err := getClient()
 	if err != nil {
 		return err
 	}
 
-	plugins, err := client.ListPlugins()
+	sel, err := api.ParseSelector(selector)
+	if err != nil {
+		return err
+	}
+
+	plugins, err := client.ListPluginsMatching(sel)
 	if err != nil {
 		return err
 	}
 
+	if tmpl != nil {
+		return printTemplate(os.Stdout, tmpl, pluginRows(plugins))
+	}
+	if dataOutput(output) {
+		if plugins == nil {
+			plugins = []api.PluginInfo{}
+		}
+		return printData(os.Stdout, plugins)
+	}
+
 	if len(plugins) == 0 {
+		if !sel.Empty() {
+			fmt.Printf("No plugins match %s.\n", sel)
+			return nil
+		}
 		fmt.Println("No plugins installed.")
 		return nil
 	}
@@ -210,6 +635,9 @@ func runPluginList(cmd *cobra.Command, args []string) error {
 		}
 
 		fmt.Printf("%-8s %-30s %-15s %s\n", status, p.Name, version, base)
+		if len(p.Labels) > 0 {
+			fmt.Printf("  labels: %s\n", p.Labels)
+		}
 	}
 
 	return nil
@@ -221,29 +649,130 @@ func runPluginInstall(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	result, err := client.InstallPlugin(args[0])
+	undo, err := prepareRollback(client, "plugin")
 	if err != nil {
 		return err
 	}
 
+	result, err := client.InstallResumable("plugin", args[0], resumableOptions())
+	if err != nil {
+		return withForceHint(err)
+	}
+
 	fmt.Printf("Installed %s v%s at %s\n", result.Name, result.Version, result.Path)
+	printInstallWarnings(result)
+	printReproduceCommand("plugin", args[0])
+	if err := verifyHealth(client); err != nil {
+		return rollbackInstall(client, undo, result, err, "plugin "+result.Name)
+	}
 	return nil
 }
 
+// resumableOptions are the install options from the flags. Retried uploads
+// are reported on stderr so a slow install isn't mistaken for a hung one.
+func resumableOptions() api.ResumableOptions {
+	return api.ResumableOptions{
+		InstallOptions: api.InstallOptions{Force: force},
+		OnRetry: func(attempt, maxAttempts int, err error) {
+			fmt.Fprintf(os.Stderr, "Warning: upload interrupted (%v), retrying (attempt %d of %d)\n", err, attempt, maxAttempts)
+		},
+	}
+}
+
+// withForceHint points at --force when an install is refused because the
+// version is already installed
+func withForceHint(err error) error {
+	if apiErr, ok := err.(*api.APIError); ok && apiErr.Type == api.ErrorTypeVersionExists {
+		return fmt.Errorf("%w. Use --force to overwrite", err)
+	}
+	return err
+}
+
+// printReproduceCommand prints the command that repeats an install, with the
+// token replaced by a placeholder, for runbooks
+func printReproduceCommand(itemType, path string) {
+	command := deploy.InstallCommand{
+		ItemType:  itemType,
+		Path:      path,
+		ServerURL: serverURL,
+		Insecure:  insecure,
+		Force:     force,
+		Verify:    verify,
+		Rollback:  rollback,
+	}
+	fmt.Printf("Reproduce with: %s\n", command)
+}
+
+// printInstallWarnings lists what the server flagged about a successful
+// install, on stderr so it isn't mistaken for the install output
+func printInstallWarnings(result *api.InstallResult) {
+	for _, warning := range result.Warnings {
+		fmt.Fprintln(os.Stderr, "Warning: "+warning)
+	}
+}
+
+// verifyHealth re-checks server health when --verify or --rollback is set
+func verifyHealth(client *api.Client) error {
+	if !verify && !rollback {
+		return nil
+	}
+
+	fmt.Printf("Verifying server health for %s...\n", api.DefaultVerifyWindow)
+	if err := client.VerifyHealth(api.DefaultVerifyWindow, api.DefaultVerifyInterval); err != nil {
+		return fmt.Errorf("server became unhealthy: %w", err)
+	}
+
+	fmt.Println("Server healthy")
+	return nil
+}
+
+// prepareRollback records what is installed before an upload, when
+// --rollback is set
+func prepareRollback(client *api.Client, itemType string) (*api.InstallRollback, error) {
+	if !rollback {
+		return nil, nil
+	}
+	return client.PrepareRollback(itemType)
+}
+
+// rollbackInstall removes a just-installed version after a failed health
+// verification when --rollback is set
+func rollbackInstall(client *api.Client, undo *api.InstallRollback, result *api.InstallResult, verifyErr error, target string) error {
+	if undo == nil {
+		return verifyErr
+	}
+
+	fmt.Printf("Rolling back %s...\n", target)
+	if err := client.Rollback(undo, result); err != nil {
+		return fmt.Errorf("%w; rollback of %s failed: %v", verifyErr, target, err)
+	}
+
+	fmt.Printf("Rolled back %s\n", target)
+	return fmt.Errorf("%w (rolled back %s)", verifyErr, target)
+}
+
 // findPluginByName looks up a plugin by name and returns its ID
 func findPluginByName(client *api.Client, name string) (int, error) {
-	plugins, err := client.ListPlugins()
+	plugin, err := findPlugin(client, name)
 	if err != nil {
 		return 0, err
 	}
+	return plugin.ID, nil
+}
 
-	for _, p := range plugins {
-		if p.Name == name {
-			return p.ID, nil
+func findPlugin(client *api.Client, name string) (*api.PluginInfo, error) {
+	plugins, err := client.ListPlugins()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range plugins {
+		if plugins[i].Name == name {
+			return &plugins[i], nil
 		}
 	}
 
-	return 0, fmt.Errorf("plugin not found: %s", name)
+	return nil, fmt.Errorf("plugin not found: %s", name)
 }
 
 func runPluginRemove(cmd *cobra.Command, args []string) error {
@@ -268,17 +797,47 @@ func runPluginEnable(cmd *cobra.Command, args []string) error {
 	}
 
 	name := args[0]
-	id, err := findPluginByName(client, name)
+	plugin, err := findPlugin(client, name)
 	if err != nil {
 		return err
 	}
 
-	if err := client.EnablePlugin(id); err != nil {
+	version, err := enableVersionFor(plugin, enableVersion)
+	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Enabled %s\n", name)
-	return nil
+	if err := client.EnablePluginVersion(plugin.ID, version); err != nil {
+		return err
+	}
+
+	if version != "" {
+		fmt.Printf("Enabled %s v%s\n", name, version)
+	} else {
+		fmt.Printf("Enabled %s\n", name)
+	}
+	if len(plugin.Versions) > 1 && enableVersion == "" {
+		fmt.Printf("Installed versions: %s. Pass --version to run another.\n", strings.Join(plugin.Versions, ", "))
+	}
+	return verifyHealth(client)
+}
+
+// enableVersionFor picks the version to enable: the requested one, which must
+// be installed, or the one that ran last. It is empty when the server should
+// choose.
+func enableVersionFor(plugin *api.PluginInfo, requested string) (string, error) {
+	if requested == "" {
+		if plugin.ActiveVersion != "" && len(plugin.Versions) > 1 {
+			return plugin.DefaultEnableVersion(), nil
+		}
+		return "", nil
+	}
+	for _, version := range plugin.Versions {
+		if version == requested {
+			return version, nil
+		}
+	}
+	return "", fmt.Errorf("plugin %s has no version %s (installed: %s)", plugin.Name, requested, strings.Join(plugin.Versions, ", "))
 }
 
 func runPluginDisable(cmd *cobra.Command, args []string) error {
@@ -298,23 +857,48 @@ func runPluginDisable(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("Disabled %s\n", name)
-	return nil
+	return verifyHealth(client)
 }
 
 // App commands
 
 func runAppList(cmd *cobra.Command, args []string) error {
+	sample := appRow{AppInfo: api.AppInfo{Versions: []string{"1.0.0"}, Labels: api.Labels{}}, Version: "1.0.0"}
+	tmpl, err := parseListOutput(output, sample)
+	if err != nil {
+		return err
+	}
+
 	client, err := getClient()
 	if err != nil {
 		return err
 	}
 
-	apps, err := client.ListApps()
+	sel, err := api.ParseSelector(selector)
+	if err != nil {
+		return err
+	}
+
+	apps, err := client.ListAppsMatching(sel)
 	if err != nil {
 		return err
 	}
 
+	if tmpl != nil {
+		return printTemplate(os.Stdout, tmpl, appRows(apps))
+	}
+	if dataOutput(output) {
+		if apps == nil {
+			apps = []api.AppInfo{}
+		}
+		return printData(os.Stdout, apps)
+	}
+
 	if len(apps) == 0 {
+		if !sel.Empty() {
+			fmt.Printf("No apps match %s.\n", sel)
+			return nil
+		}
 		fmt.Println("No apps installed.")
 		return nil
 	}
@@ -325,10 +909,13 @@ func runAppList(cmd *cobra.Command, args []string) error {
 	for _, a := range apps {
 		version := "-"
 		if len(a.Versions) > 0 {
-			version = a.Versions[0]
+			version = a.CurrentVersion()
 		}
 
 		fmt.Printf("%-30s %-15s %s\n", a.Name, version, a.Path)
+		if len(a.Labels) > 0 {
+			fmt.Printf("  labels: %s\n", a.Labels)
+		}
 	}
 
 	return nil
@@ -340,12 +927,22 @@ func runAppInstall(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	result, err := client.InstallApp(args[0])
+	undo, err := prepareRollback(client, "app")
 	if err != nil {
 		return err
 	}
 
+	result, err := client.InstallResumable("app", args[0], resumableOptions())
+	if err != nil {
+		return withForceHint(err)
+	}
+
 	fmt.Printf("Installed %s v%s at %s\n", result.Name, result.Version, result.Path)
+	printInstallWarnings(result)
+	printReproduceCommand("app", args[0])
+	if err := verifyHealth(client); err != nil {
+		return rollbackInstall(client, undo, result, err, fmt.Sprintf("app %s v%s", result.Name, result.Version))
+	}
 	return nil
 }
 
@@ -368,3 +965,148 @@ func runAppRemove(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Removed %s v%s\n", name, version)
 	return nil
 }
+
+// Apply command
+
+func runApply(cmd *cobra.Command, args []string) error {
+	client, plan, err := planFromSpec()
+	if err != nil {
+		return err
+	}
+
+	if plan.Empty() {
+		fmt.Println("Server already matches the spec.")
+		return nil
+	}
+
+	fmt.Println("Plan:")
+	for _, step := range plan.Steps {
+		fmt.Println("  " + step.String())
+	}
+
+	if dryRun {
+		fmt.Printf("\n%d change(s) planned. Run without --dry-run to apply.\n", len(plan.Steps))
+		return nil
+	}
+
+	fmt.Println()
+	for i, step := range plan.Steps {
+		if err := deploy.Execute(client, step); err != nil {
+			return fmt.Errorf("failed to %s %s %s (%d of %d change(s) applied): %w", step.Action, step.ItemType, step.Name, i, len(plan.Steps), err)
+		}
+		fmt.Println("Applied " + step.String())
+	}
+
+	fmt.Printf("Applied %d change(s)\n", len(plan.Steps))
+	return nil
+}
+
+// errDrift makes `diff --exit-code` fail after a table that shows the server
+// differs from the spec
+var errDrift = errors.New("server differs from the spec")
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	_, plan, err := planFromSpec()
+	if err != nil {
+		return err
+	}
+
+	switch output {
+	case "json", "yaml":
+		steps := plan.Steps
+		if steps == nil {
+			steps = []deploy.Step{}
+		}
+		if err := printData(os.Stdout, steps); err != nil {
+			return err
+		}
+	case "table":
+		printPlanTable(plan)
+	default:
+		return fmt.Errorf("unknown output format %q (use table, json or yaml)", output)
+	}
+
+	if exitCode && !plan.Empty() {
+		cmd.SilenceUsage = true
+		if dataOutput(output) {
+			// The steps are the report; a second document would break parsing
+			return &exitError{code: 1}
+		}
+		return errDrift
+	}
+	return nil
+}
+
+func printPlanTable(plan *deploy.Plan) {
+	if plan.Empty() {
+		fmt.Println("No differences: the server matches the spec.")
+		return
+	}
+
+	fmt.Printf("%-8s %-7s %-30s %-15s %s\n", "ACTION", "TYPE", "NAME", "VERSION", "SOURCE")
+	fmt.Println("--------------------------------------------------------------")
+
+	for _, step := range plan.Steps {
+		style := styles.TextWarning
+		switch step.Action {
+		case deploy.ActionInstall:
+			style = styles.TextSuccess
+		case deploy.ActionRemove:
+			style = styles.TextError
+		}
+
+		version := "-"
+		if step.Version != "" {
+			version = step.Version
+		}
+		source := "-"
+		if step.Source != "" {
+			source = step.Source
+		}
+
+		fmt.Printf("%s %-7s %-30s %-15s %s\n", style.Render(fmt.Sprintf("%-8s", step.Action)), step.ItemType, step.Name, version, source)
+	}
+
+	fmt.Printf("\n%d change(s) needed\n", len(plan.Steps))
+}
+
+// planFromSpec loads --file and works out what it would take to bring the
+// server in line with it
+func planFromSpec() (*api.Client, *deploy.Plan, error) {
+	spec, err := deploy.LoadSpec(specFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client, err := getClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	state, err := deploy.FetchState(client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plan, err := deploy.BuildPlan(spec, state, prune)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, plan, nil
+}
+
+// Config commands
+
+func runConfigRepair(cmd *cobra.Command, args []string) error {
+	database, err := db.New()
+	if err == nil {
+		database.Close()
+		fmt.Println("Config database is healthy, nothing to repair.")
+		return nil
+	}
+	if !errors.Is(err, db.ErrCorrupt) {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+
+	return repairDatabase()
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/buntime/cli/internal/db"
+	"github.com/spf13/cobra"
+)
+
+// withFlags sets the connection flags for one test
+func withFlags(t *testing.T, url, tok string) {
+	savedURL, savedToken, savedFromFlag := serverURL, token, tokenFromFlag
+	serverURL, token = url, tok
+	t.Cleanup(func() { serverURL, token, tokenFromFlag = savedURL, savedToken, savedFromFlag })
+}
+
+// captureStdout returns what fn prints to stdout
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	fn()
+	w.Close()
+	out, _ := io.ReadAll(r)
+	return string(out)
+}
+
+func TestDiffExitCodePrintsOneJSONDocument(t *testing.T) {
+	t.Setenv("BUNTIME_CONFIG_DIR", db.MemoryDir)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/apps":
+			w.Write([]byte(`[{"name":"blog","versions":["1.0.0"]}]`))
+		case "/api/plugins":
+			w.Write([]byte(`[]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	withFlags(t, server.URL, "")
+
+	spec := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := os.WriteFile(spec, []byte("apps: []\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	savedFile, savedPrune, savedExitCode, savedOutput := specFile, prune, exitCode, output
+	t.Cleanup(func() { specFile, prune, exitCode, output = savedFile, savedPrune, savedExitCode, savedOutput })
+	specFile, prune, exitCode, output = spec, true, true, "json"
+
+	var err error
+	out := captureStdout(t, func() { err = runDiff(&cobra.Command{}, nil) })
+
+	// main prints nothing more for an exitError without an error
+	var exit *exitError
+	if !errors.As(err, &exit) || exit.code != 1 || exit.err != nil {
+		t.Fatalf("runDiff() = %v, want a silent exit status 1", err)
+	}
+	decoder := json.NewDecoder(strings.NewReader(out))
+	var steps []map[string]any
+	if err := decoder.Decode(&steps); err != nil || len(steps) != 1 {
+		t.Fatalf("expected the plan's one step, got %q (err %v)", out, err)
+	}
+	if decoder.More() {
+		t.Fatalf("expected a single JSON document, got %q", out)
+	}
+}
+
+func TestTokenPrecedence(t *testing.T) {
+	t.Setenv("BUNTIME_CONFIG_DIR", db.MemoryDir)
+	database, err := db.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer database.Close()
+	saved := "saved-token"
+	if _, err := database.CreateServer("Prod", "https://prod.home", &saved, false); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		flag    string
+		env     string
+		want    string
+		flagURL string
+		envURL  string
+		wantURL string
+		tui     bool
+	}{
+		{name: "flag wins", flag: "flag-token", env: "env-token", want: "flag-token", flagURL: "https://prod.home", wantURL: "https://prod.home"},
+		{name: "env over saved", env: "env-token", want: "env-token", envURL: "https://prod.home", wantURL: "https://prod.home"},
+		{name: "saved last", want: "saved-token", envURL: "https://prod.home", wantURL: "https://prod.home"},
+		{name: "url flag over env", want: "", flagURL: "https://other.home", envURL: "https://prod.home", wantURL: "https://other.home"},
+		{name: "tui ignores env url", env: "env-token", want: "env-token", envURL: "https://prod.home", wantURL: "", tui: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withFlags(t, tt.flagURL, tt.flag)
+			t.Setenv(envToken, tt.env)
+			t.Setenv(envURL, tt.envURL)
+
+			applyEnvDefaults(!tt.tui)
+			useSavedToken(database)
+			if serverURL != tt.wantURL || token != tt.want {
+				t.Fatalf("got url %q token %q, want %q %q", serverURL, token, tt.wantURL, tt.want)
+			}
+			if tokenFromFlag != (tt.flag != "") {
+				t.Fatalf("tokenFromFlag = %v, want it set only for --token", tokenFromFlag)
+			}
+		})
+	}
+}
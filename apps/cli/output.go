@@ -0,0 +1,170 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/buntime/cli/internal/api"
+	"gopkg.in/yaml.v3"
+)
+
+const templatePrefix = "template="
+
+// templateFuncs are available in --output templates
+var templateFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// appRow is what an --output template sees for each app: the API struct plus
+// Version, the latest installed version
+type appRow struct {
+	api.AppInfo
+	Version string
+}
+
+// pluginRow is what an --output template sees for each plugin
+type pluginRow struct {
+	api.PluginInfo
+	Version string
+}
+
+// checkOutput validates --output for commands that print a table, JSON or
+// YAML
+func checkOutput(value string) error {
+	if value != "table" && !dataOutput(value) {
+		return fmt.Errorf("unknown output format %q (use table, json or yaml)", value)
+	}
+	return nil
+}
+
+// dataOutput reports whether value asks for machine-readable output instead
+// of a table
+func dataOutput(value string) bool {
+	return value == "json" || value == "yaml"
+}
+
+// parseListOutput validates a list command's --output value. It returns nil
+// for the table, json and yaml formats and the parsed template for
+// "template=...".
+// The template is tried against a sample row so mistakes such as unknown
+// fields are reported before anything is printed.
+func parseListOutput(value string, sample any) (*template.Template, error) {
+	if value == "table" || dataOutput(value) {
+		return nil, nil
+	}
+
+	text, ok := strings.CutPrefix(value, templatePrefix)
+	if !ok {
+		return nil, fmt.Errorf("unknown output format %q (use table, json, yaml or template=<go template>)", value)
+	}
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("--output template is empty")
+	}
+
+	tmpl, err := template.New("output").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --output template: %w", err)
+	}
+	if err := tmpl.Execute(io.Discard, sample); err != nil {
+		return nil, fmt.Errorf("invalid --output template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// printTemplate executes tmpl once per row, ending each with a newline like
+// docker and kubectl do
+func printTemplate[T any](w io.Writer, tmpl *template.Template, rows []T) error {
+	for _, row := range rows {
+		if err := tmpl.Execute(w, row); err != nil {
+			return fmt.Errorf("failed to render --output template: %w", err)
+		}
+		if _, err := io.WriteString(w, "\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func appRows(apps []api.AppInfo) []appRow {
+	rows := make([]appRow, len(apps))
+	for i, app := range apps {
+		rows[i] = appRow{AppInfo: app}
+		if len(app.Versions) > 0 {
+			rows[i].Version = app.CurrentVersion()
+		}
+	}
+	return rows
+}
+
+func pluginRows(plugins []api.PluginInfo) []pluginRow {
+	rows := make([]pluginRow, len(plugins))
+	for i, plugin := range plugins {
+		rows[i] = pluginRow{PluginInfo: plugin}
+		if len(plugin.Versions) > 0 {
+			rows[i].Version = plugin.Versions[0]
+		}
+	}
+	return rows
+}
+
+// printJSON writes v as indented JSON
+func printJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// printData writes v in the machine-readable format of --output, JSON unless
+// YAML was asked for
+func printData(w io.Writer, v any) error {
+	if output == "yaml" {
+		return printYAML(w, v)
+	}
+	return printJSON(w, v)
+}
+
+// printYAML writes v as YAML with the same keys and order as its JSON, so
+// the API structs don't need yaml tags
+func printYAML(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	// JSON is valid YAML, so decoding it keeps keys in order
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	blockStyle(&doc)
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// blockStyle drops the flow style and quoting decoded from JSON. Empty
+// collections stay in flow style so they print as [] and {}.
+func blockStyle(node *yaml.Node) {
+	if len(node.Content) > 0 || node.Kind == yaml.ScalarNode {
+		node.Style = 0
+	}
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+// printErrorJSON reports a failed command as {"error": "..."} so scripts
+// using --output json or yaml can parse failures like any other result
+func printErrorJSON(w io.Writer, err error) error {
+	return printData(w, struct {
+		Error string `json:"error"`
+	}{Error: err.Error()})
+}
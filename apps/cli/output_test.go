@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/buntime/cli/internal/api"
+)
+
+func TestParseListOutput(t *testing.T) {
+	sample := appRow{AppInfo: api.AppInfo{Versions: []string{"1.0.0"}}, Version: "1.0.0"}
+
+	tmpl, err := parseListOutput("table", sample)
+	if err != nil || tmpl != nil {
+		t.Fatalf("table: got %v, %v", tmpl, err)
+	}
+
+	invalid := map[string]string{
+		"unknown format": "xml",
+		"empty template": "template=",
+		"parse error":    "template={{.Name",
+		"unknown field":  "template={{.Nmae}}",
+	}
+	for name, value := range invalid {
+		if _, err := parseListOutput(value, sample); err == nil {
+			t.Errorf("%s: expected an error for %q", name, value)
+		}
+	}
+}
+
+func TestPrintTemplate(t *testing.T) {
+	apps := []api.AppInfo{
+		{Name: "front", Versions: []string{"2.0.0", "1.0.0"}},
+		{Name: "empty"},
+	}
+
+	tmpl, err := parseListOutput(`template={{.Name}} {{.Version}} [{{join .Versions ","}}]`, appRow{})
+	if err != nil {
+		t.Fatalf("parseListOutput() error = %v", err)
+	}
+
+	var b strings.Builder
+	if err := printTemplate(&b, tmpl, appRows(apps)); err != nil {
+		t.Fatalf("printTemplate() error = %v", err)
+	}
+	want := "front 2.0.0 [2.0.0,1.0.0]\nempty  []\n"
+	if b.String() != want {
+		t.Fatalf("got %q, want %q", b.String(), want)
+	}
+}
+
+func TestParseListOutputAcceptsJSON(t *testing.T) {
+	tmpl, err := parseListOutput("json", appRow{})
+	if err != nil || tmpl != nil {
+		t.Fatalf("json: got %v, %v", tmpl, err)
+	}
+	if err := checkOutput("template={{.Name}}"); err == nil {
+		t.Fatal("checkOutput() accepted a template")
+	}
+}
+
+func TestPrintErrorJSON(t *testing.T) {
+	var b strings.Builder
+	if err := printErrorJSON(&b, errors.New(`app "front" not found`)); err != nil {
+		t.Fatalf("printErrorJSON() error = %v", err)
+	}
+	want := "{\n  \"error\": \"app \\\"front\\\" not found\"\n}\n"
+	if b.String() != want {
+		t.Fatalf("got %q, want %q", b.String(), want)
+	}
+}
+
+func TestPrintDataAsYAML(t *testing.T) {
+	defer func(previous string) { output = previous }(output)
+	output = "yaml"
+
+	var b strings.Builder
+	apps := []api.AppInfo{{Name: "front", Path: "true", Versions: []string{"1.0", "2.0.0"}, Enabled: true}}
+	if err := printData(&b, apps); err != nil {
+		t.Fatalf("printData() error = %v", err)
+	}
+	// Keys match the JSON tags and strings that YAML would read as other
+	// types stay quoted
+	want := "- name: front\n  path: \"true\"\n  versions:\n    - \"1.0\"\n    - 2.0.0\n  enabled: true\n"
+	if b.String() != want {
+		t.Fatalf("got %q, want %q", b.String(), want)
+	}
+
+	b.Reset()
+	if err := printData(&b, []api.AppInfo{}); err != nil || b.String() != "[]\n" {
+		t.Fatalf("expected an empty list to print [], got %q, %v", b.String(), err)
+	}
+}
@@ -0,0 +1,333 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/db"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+func runServerList(cmd *cobra.Command, args []string) error {
+	if err := checkOutput(output); err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+
+	database, err := openDatabase()
+	if err != nil {
+		return err
+	}
+	servers, err := database.ListServers()
+	database.Close()
+	if err != nil {
+		return fmt.Errorf("failed to list servers: %w", err)
+	}
+
+	if dataOutput(output) {
+		return printData(os.Stdout, serverListRows(servers))
+	}
+
+	printServerTable(os.Stdout, servers, time.Now())
+	return nil
+}
+
+func runServerAdd(cmd *cobra.Command, args []string) error {
+	if err := db.ValidateServerURL(serverURL); err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+
+	database, err := openDatabase()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	url := strings.TrimSpace(serverURL)
+	if err := database.CheckServerURLFree(url, 0); err != nil {
+		return err
+	}
+
+	name := strings.TrimSpace(serverAddName)
+	if name == "" {
+		name = db.DefaultServerName(url)
+	}
+	var serverToken *string
+	if token != "" {
+		serverToken = &token
+	}
+	// Only an explicit --timeout is saved; the flag's default is the client's
+	var timeoutSeconds int
+	if cmd.Flags().Changed("timeout") {
+		timeoutSeconds = int(timeout / time.Second)
+		if timeoutSeconds == 0 || db.ValidateServerTimeout(timeoutSeconds) != nil {
+			return fmt.Errorf("--timeout must be between 1 and %d seconds to be saved with a server", int(db.MaxServerTimeout/time.Second))
+		}
+	}
+
+	server, err := database.CreateServer(name, url, serverToken, insecure)
+	if err != nil {
+		return fmt.Errorf("failed to save server: %w", err)
+	}
+	if timeoutSeconds > 0 {
+		if err := database.UpdateServerTimeout(server.ID, timeoutSeconds); err != nil {
+			return fmt.Errorf("failed to save server: %w", err)
+		}
+	}
+
+	fmt.Printf("Saved server %s (%s)\n", server.Name, server.URL)
+	return nil
+}
+
+func runServerRemove(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	database, err := openDatabase()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	servers, err := database.ListServers()
+	if err != nil {
+		return fmt.Errorf("failed to list servers: %w", err)
+	}
+	server, err := findServer(servers, args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := database.DeleteServer(server.ID); err != nil {
+		return fmt.Errorf("failed to remove server: %w", err)
+	}
+
+	fmt.Printf("Removed server %s (%s)\n", server.Name, server.URL)
+	return nil
+}
+
+// findServer resolves a saved server by name. Names aren't unique, so a name
+// shared by several servers is an error rather than a guess.
+func findServer(servers []db.Server, name string) (*db.Server, error) {
+	matches, err := selectServers(servers, []string{name})
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) > 1 {
+		urls := make([]string, len(matches))
+		for i, server := range matches {
+			urls[i] = server.URL
+		}
+		return nil, fmt.Errorf("%d saved servers are named %s (%s); rename one in the TUI first", len(matches), name, strings.Join(urls, ", "))
+	}
+	return &matches[0], nil
+}
+
+func printServerTable(w io.Writer, servers []db.Server, now time.Time) {
+	if len(servers) == 0 {
+		fmt.Fprintln(w, "No saved servers.")
+		return
+	}
+
+	fmt.Fprintf(w, "%-20s %-40s %-9s %s\n", "NAME", "URL", "INSECURE", "LAST USED")
+	fmt.Fprintln(w, "--------------------------------------------------------------------------------")
+
+	for _, server := range servers {
+		insecure := "no"
+		if server.Insecure {
+			insecure = "yes"
+		}
+		lastUsed := "never"
+		if server.LastUsedAt != nil {
+			lastUsed = humanize.RelTime(*server.LastUsedAt, now, "ago", "from now")
+		}
+		fmt.Fprintf(w, "%-20s %-40s %-9s %s\n", server.Name, server.URL, insecure, lastUsed)
+	}
+}
+
+// serverListRow is a saved server as `server list -o json` prints it. The
+// token itself is left out; HasToken says whether one is saved.
+type serverListRow struct {
+	Name       string     `json:"name"`
+	URL        string     `json:"url"`
+	Insecure   bool       `json:"insecure"`
+	Pinned     bool       `json:"pinned"`
+	HasToken   bool       `json:"hasToken"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+}
+
+// serverTimeout is the request timeout for a saved server: --timeout when
+// given, otherwise the server's own, otherwise the default
+func serverTimeout(cmd *cobra.Command, server db.Server) time.Duration {
+	if !cmd.Flags().Changed("timeout") && server.TimeoutSeconds > 0 {
+		return server.Timeout()
+	}
+	return timeout
+}
+
+func serverListRows(servers []db.Server) []serverListRow {
+	rows := make([]serverListRow, len(servers))
+	for i, server := range servers {
+		rows[i] = serverListRow{
+			Name:       server.Name,
+			URL:        server.URL,
+			Insecure:   server.Insecure,
+			Pinned:     server.Pinned,
+			HasToken:   server.Token != nil && *server.Token != "",
+			LastUsedAt: server.LastUsedAt,
+		}
+	}
+	return rows
+}
+
+// serverHealthRow is one server in the health report
+type serverHealthRow struct {
+	Name      string `json:"name"`
+	URL       string `json:"url"`
+	Online    bool   `json:"online"`
+	Healthy   bool   `json:"healthy"`
+	Status    string `json:"status,omitempty"`
+	Version   string `json:"version,omitempty"`
+	LatencyMs int64  `json:"latencyMs"`
+	Error     string `json:"error,omitempty"`
+}
+
+func runServerHealth(cmd *cobra.Command, args []string) error {
+	if serverHealthJSON {
+		output = "json"
+	}
+	if err := checkOutput(output); err != nil {
+		return err
+	}
+	if !serverHealthAll && len(args) == 0 {
+		return fmt.Errorf("name the servers to check or pass --all")
+	}
+	if serverHealthAll && len(args) > 0 {
+		return fmt.Errorf("--all can't be combined with server names")
+	}
+	cmd.SilenceUsage = true
+
+	database, err := openDatabase()
+	if err != nil {
+		return err
+	}
+	servers, err := database.ListServers()
+	database.Close()
+	if err != nil {
+		return fmt.Errorf("failed to list servers: %w", err)
+	}
+
+	if !serverHealthAll {
+		if servers, err = selectServers(servers, args); err != nil {
+			return err
+		}
+	}
+	if len(servers) == 0 {
+		if dataOutput(output) {
+			return printData(os.Stdout, []serverHealthRow{})
+		}
+		fmt.Println("No saved servers.")
+		return nil
+	}
+
+	clients := make([]*api.Client, len(servers))
+	for i, server := range servers {
+		var serverToken string
+		if server.Token != nil {
+			serverToken = *server.Token
+		}
+		clients[i] = api.New(server.URL, serverToken, server.Insecure, api.WithTimeout(serverTimeout(cmd, server)))
+	}
+	checks := api.CheckHealthAll(context.Background(), clients, api.DefaultHealthConcurrency)
+
+	rows := make([]serverHealthRow, len(servers))
+	down := 0
+	for i, check := range checks {
+		rows[i] = newServerHealthRow(servers[i], check)
+		if !rows[i].Healthy {
+			down++
+		}
+	}
+
+	if dataOutput(output) {
+		if err := printData(os.Stdout, rows); err != nil {
+			return err
+		}
+	} else {
+		printServerHealthTable(os.Stdout, rows)
+	}
+
+	if down > 0 {
+		return fmt.Errorf("%d of %d servers are down", down, len(rows))
+	}
+	return nil
+}
+
+// selectServers picks saved servers by name, in the order given
+func selectServers(servers []db.Server, names []string) ([]db.Server, error) {
+	var selected []db.Server
+	var missing []string
+	for _, name := range names {
+		found := false
+		for _, server := range servers {
+			if server.Name == name {
+				selected = append(selected, server)
+				found = true
+			}
+		}
+		if !found {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("no saved server named %s", strings.Join(missing, ", "))
+	}
+	return selected, nil
+}
+
+func newServerHealthRow(server db.Server, check api.HealthCheck) serverHealthRow {
+	row := serverHealthRow{
+		Name:      server.Name,
+		URL:       server.URL,
+		Online:    check.Online,
+		Healthy:   check.Up(),
+		LatencyMs: check.Latency.Milliseconds(),
+	}
+	if check.Health != nil {
+		row.Status = check.Health.Status
+		row.Version = check.Health.Version
+	}
+	if check.Err != nil {
+		row.Error = check.Err.Error()
+	}
+	return row
+}
+
+func printServerHealthTable(w io.Writer, rows []serverHealthRow) {
+	fmt.Fprintf(w, "%-20s %-32s %-10s %-10s %s\n", "NAME", "URL", "STATUS", "VERSION", "LATENCY")
+	fmt.Fprintln(w, "--------------------------------------------------------------------------------")
+
+	for _, row := range rows {
+		status := "online"
+		switch {
+		case !row.Online:
+			status = "offline"
+		case !row.Healthy:
+			status = "unhealthy"
+		}
+		latency := "-"
+		if row.Online {
+			latency = fmt.Sprintf("%dms", row.LatencyMs)
+		}
+		fmt.Fprintf(w, "%-20s %-32s %-10s %-10s %s\n", row.Name, row.URL, status, dashIfEmpty(row.Version), latency)
+		if row.Error != "" {
+			fmt.Fprintf(w, "  %s\n", row.Error)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/buntime/cli/internal/db"
+	"github.com/spf13/cobra"
+)
+
+func TestSelectServers(t *testing.T) {
+	servers := []db.Server{{ID: 1, Name: "prod"}, {ID: 2, Name: "staging"}}
+
+	selected, err := selectServers(servers, []string{"staging", "prod"})
+	if err != nil || len(selected) != 2 || selected[0].ID != 2 {
+		t.Fatalf("selectServers() = %v, %v", selected, err)
+	}
+	if _, err := selectServers(servers, []string{"prod", "qa"}); err == nil || !strings.Contains(err.Error(), "qa") {
+		t.Fatalf("expected an error naming the unknown server, got %v", err)
+	}
+}
+
+func TestServerHealthJSONFlagSetsTheOutput(t *testing.T) {
+	t.Setenv("BUNTIME_CONFIG_DIR", db.MemoryDir)
+	savedOutput, savedAll, savedJSON := output, serverHealthAll, serverHealthJSON
+	t.Cleanup(func() { output, serverHealthAll, serverHealthJSON = savedOutput, savedAll, savedJSON })
+	output, serverHealthAll, serverHealthJSON = "table", true, true
+
+	var err error
+	out := captureStdout(t, func() { err = runServerHealth(&cobra.Command{}, nil) })
+	if err != nil || strings.TrimSpace(out) != "[]" || output != "json" {
+		t.Fatalf("runServerHealth() printed %q with output %q, err %v; want an empty JSON list", out, output, err)
+	}
+}
+
+func TestPrintServerHealthTable(t *testing.T) {
+	server := db.Server{Name: "prod", URL: "https://prod.home"}
+	rows := []serverHealthRow{
+		newServerHealthRow(server, api.HealthCheck{Online: true, Health: &api.HealthInfo{OK: true, Version: "1.2.0"}, Latency: 42 * time.Millisecond}),
+		newServerHealthRow(server, api.HealthCheck{Online: true, Health: &api.HealthInfo{OK: false, Status: "degraded"}}),
+		newServerHealthRow(server, api.HealthCheck{Err: errors.New("Connection refused")}),
+	}
+
+	var b strings.Builder
+	printServerHealthTable(&b, rows)
+	lines := strings.Split(b.String(), "\n")
+	for i, want := range []string{"online     1.2.0      42ms", "unhealthy", "offline"} {
+		if !strings.Contains(lines[i+2], want) {
+			t.Errorf("row %d = %q, want it to contain %q", i, lines[i+2], want)
+		}
+	}
+	if !strings.Contains(b.String(), "  Connection refused") {
+		t.Error("expected the error of the offline server")
+	}
+}
+
+func TestFindServerRefusesSharedNames(t *testing.T) {
+	servers := []db.Server{
+		{ID: 1, Name: "prod", URL: "https://a.home"},
+		{ID: 2, Name: "prod", URL: "https://b.home"},
+		{ID: 3, Name: "staging", URL: "https://c.home"},
+	}
+
+	if server, err := findServer(servers, "staging"); err != nil || server.ID != 3 {
+		t.Fatalf("findServer() = %v, %v", server, err)
+	}
+	if _, err := findServer(servers, "prod"); err == nil || !strings.Contains(err.Error(), "https://b.home") {
+		t.Fatalf("expected an error listing both servers, got %v", err)
+	}
+	if _, err := findServer(servers, "qa"); err == nil {
+		t.Fatal("expected an error for an unknown name")
+	}
+}
+
+func TestPrintServerTable(t *testing.T) {
+	now := time.Now()
+	used := now.Add(-2 * time.Hour)
+	servers := []db.Server{
+		{Name: "prod", URL: "https://prod.home", LastUsedAt: &used},
+		{Name: "lab", URL: "https://lab.home", Insecure: true},
+	}
+
+	var b strings.Builder
+	printServerTable(&b, servers, now)
+	lines := strings.Split(b.String(), "\n")
+	if !strings.Contains(lines[2], "no") || !strings.Contains(lines[2], "2 hours ago") {
+		t.Errorf("row 0 = %q", lines[2])
+	}
+	if !strings.Contains(lines[3], "yes") || !strings.Contains(lines[3], "never") {
+		t.Errorf("row 1 = %q", lines[3])
+	}
+}
+
+func TestServerListRowsLeaveOutTheToken(t *testing.T) {
+	secret := "s3cret"
+	rows := serverListRows([]db.Server{
+		{Name: "prod", URL: "https://prod.home", Token: &secret, Pinned: true},
+		{Name: "lab", URL: "https://lab.home"},
+	})
+
+	var b strings.Builder
+	if err := printJSON(&b, rows); err != nil {
+		t.Fatalf("printJSON() error = %v", err)
+	}
+	if strings.Contains(b.String(), secret) {
+		t.Fatalf("token leaked into %s", b.String())
+	}
+	if !rows[0].HasToken || !rows[0].Pinned || rows[1].HasToken {
+		t.Fatalf("rows = %+v", rows)
+	}
+}
+
+func TestServerTimeoutPrefersTheFlag(t *testing.T) {
+	saved := timeout
+	defer func() { timeout = saved }()
+
+	cmd := &cobra.Command{}
+	cmd.Flags().DurationVar(&timeout, "timeout", api.DefaultTimeout, "")
+
+	if got := serverTimeout(cmd, db.Server{}); got != api.DefaultTimeout {
+		t.Fatalf("without a saved timeout got %v", got)
+	}
+	if got := serverTimeout(cmd, db.Server{TimeoutSeconds: 90}); got != 90*time.Second {
+		t.Fatalf("with a saved timeout got %v", got)
+	}
+	if err := cmd.Flags().Set("timeout", "5s"); err != nil {
+		t.Fatal(err)
+	}
+	if got := serverTimeout(cmd, db.Server{TimeoutSeconds: 90}); got != 5*time.Second {
+		t.Fatalf("with --timeout got %v", got)
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/buntime/cli/internal/api"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+func runWorkerList(cmd *cobra.Command, args []string) error {
+	if err := checkOutput(output); err != nil {
+		return err
+	}
+
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	workers, err := client.ListWorkers()
+	if err != nil {
+		return err
+	}
+
+	if dataOutput(output) {
+		if workers == nil {
+			workers = []api.WorkerInfo{}
+		}
+		return printData(os.Stdout, workers)
+	}
+
+	printWorkerTable(os.Stdout, workers)
+	return nil
+}
+
+func runWorkerRestart(cmd *cobra.Command, args []string) error {
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	if workerDrain {
+		if err := client.DrainWorker(args[0]); err != nil {
+			return err
+		}
+		if err := waitForDrain(client, args[0], workerDrainTimeout, workerDrainInterval, os.Stderr); err != nil {
+			return err
+		}
+	}
+
+	worker, err := client.RestartWorker(args[0])
+	if err != nil {
+		return err
+	}
+
+	if worker != nil {
+		fmt.Printf("Restarted worker %s (new PID %d)\n", args[0], worker.PID)
+	} else {
+		fmt.Printf("Restarted worker %s\n", args[0])
+	}
+	return nil
+}
+
+// workerDrainInterval is how often a draining worker is checked on
+const workerDrainInterval = 500 * time.Millisecond
+
+// waitForDrain waits until worker id has finished its in-flight requests,
+// printing the count to w whenever it changes. A worker that has left the
+// pool counts as drained.
+func waitForDrain(client *api.Client, id string, timeout, interval time.Duration, w io.Writer) error {
+	deadline := time.Now().Add(timeout)
+	reported := -1
+	for {
+		workers, err := client.ListWorkers()
+		if err != nil {
+			return err
+		}
+
+		idx := slices.IndexFunc(workers, func(worker api.WorkerInfo) bool { return worker.ID == id })
+		if idx < 0 {
+			return nil
+		}
+		worker := workers[idx]
+		if worker.Status == api.WorkerDrained || worker.InFlight == 0 {
+			fmt.Fprintf(w, "Drained worker %s\n", id)
+			return nil
+		}
+
+		if worker.InFlight != reported {
+			fmt.Fprintf(w, "Draining worker %s: %d request(s) in flight\n", id, worker.InFlight)
+			reported = worker.InFlight
+		}
+		if !time.Now().Before(deadline) {
+			return fmt.Errorf("worker %s still has %d request(s) in flight after %s; raise --drain-timeout or restart without --drain", id, worker.InFlight, timeout)
+		}
+		time.Sleep(min(interval, time.Until(deadline)))
+	}
+}
+
+func printWorkerTable(w io.Writer, workers []api.WorkerInfo) {
+	if len(workers) == 0 {
+		fmt.Fprintln(w, "No workers running.")
+		return
+	}
+
+	fmt.Fprintf(w, "%-12s %-24s %-10s %-8s %-10s %-10s %s\n", "ID", "APP", "STATUS", "PID", "UPTIME", "MEMORY", "REQUESTS")
+	fmt.Fprintln(w, "--------------------------------------------------------------------------------------")
+
+	for _, worker := range workers {
+		memory := "-"
+		if worker.MemoryBytes > 0 {
+			memory = humanize.IBytes(uint64(worker.MemoryBytes))
+		}
+		fmt.Fprintf(w, "%-12s %-24s %-10s %-8d %-10s %-10s %d\n",
+			worker.ID, dashIfEmpty(worker.App), worker.Status, worker.PID,
+			formatUptime(worker.Uptime()), memory, worker.RequestsHandled)
+	}
+}
+
+// formatUptime shows the two largest units of d, e.g. "3d 4h" or "5m 12s"
+func formatUptime(d time.Duration) string {
+	d = d.Round(time.Second)
+	days := int(d / (24 * time.Hour))
+	hours := int(d/time.Hour) % 24
+	minutes := int(d/time.Minute) % 60
+	seconds := int(d/time.Second) % 60
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case minutes > 0:
+		return fmt.Sprintf("%dm %ds", minutes, seconds)
+	}
+	return fmt.Sprintf("%ds", seconds)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/buntime/cli/internal/api"
+)
+
+func TestPrintWorkerTable(t *testing.T) {
+	var b strings.Builder
+	printWorkerTable(&b, nil)
+	if b.String() != "No workers running.\n" {
+		t.Fatalf("empty pool: got %q", b.String())
+	}
+
+	b.Reset()
+	printWorkerTable(&b, []api.WorkerInfo{
+		{ID: "w1", App: "front@1.0.0", Status: "active", PID: 42, UptimeMs: 26 * 3600 * 1000, MemoryBytes: 64 << 20, RequestsHandled: 7},
+		{ID: "w2", Status: "idle", UptimeMs: 90 * 1000},
+	})
+	lines := strings.Split(b.String(), "\n")
+	if !strings.Contains(lines[2], "1d 2h") || !strings.Contains(lines[2], "64 MiB") {
+		t.Errorf("row 1 = %q", lines[2])
+	}
+	if !strings.Contains(lines[3], "1m 30s") || strings.Count(lines[3], " - ") != 2 {
+		t.Errorf("row 2 = %q, want dashes for the missing app and memory", lines[3])
+	}
+}
+
+func TestFormatUptime(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		0:                          "0s",
+		59 * time.Second:           "59s",
+		2*time.Hour + time.Minute:  "2h 1m",
+		50*time.Hour + time.Minute: "2d 2h",
+	} {
+		if got := formatUptime(d); got != want {
+			t.Errorf("formatUptime(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestWaitForDrainReportsProgress(t *testing.T) {
+	inFlight := []int{3, 3, 1, 0}
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/.well-known/buntime" {
+			http.NotFound(w, r)
+			return
+		}
+		n := inFlight[min(int(polls.Add(1))-1, len(inFlight)-1)]
+		fmt.Fprintf(w, `{"workers":[{"id":"w-1","status":"draining","inFlight":%d}]}`, n)
+	}))
+	defer server.Close()
+
+	var b strings.Builder
+	if err := waitForDrain(api.New(server.URL, "", false), "w-1", 5*time.Second, time.Millisecond, &b); err != nil {
+		t.Fatalf("waitForDrain() error = %v", err)
+	}
+	want := "Draining worker w-1: 3 request(s) in flight\nDraining worker w-1: 1 request(s) in flight\nDrained worker w-1\n"
+	if b.String() != want {
+		t.Fatalf("got %q, want %q", b.String(), want)
+	}
+}
+
+func TestWaitForDrainGivesUp(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/.well-known/buntime" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"workers":[{"id":"w-1","status":"draining","inFlight":2}]}`))
+	}))
+	defer server.Close()
+
+	err := waitForDrain(api.New(server.URL, "", false), "w-1", 10*time.Millisecond, time.Millisecond, io.Discard)
+	if err == nil || !strings.Contains(err.Error(), "2 request(s) in flight") {
+		t.Fatalf("expected a timeout naming the requests left, got %v", err)
+	}
+}